@@ -0,0 +1,112 @@
+package main
+
+import (
+	"archive/zip"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/usememos/memos/store/backup"
+)
+
+var (
+	backupCmd = &cobra.Command{
+		Use:   "backup <file>",
+		Short: "Back up the database and the local files of the instance to a ZIP archive",
+		Long: `Back up the database and the local files of the instance to a ZIP archive.
+The database is read in one transaction, so the backup is consistent while the server keeps running.
+Attachments stored in S3 are not included.`,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			instanceProfile := newInstanceProfile()
+			if err := instanceProfile.Validate(); err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+			storeInstance, err := openStore(ctx, instanceProfile)
+			if err != nil {
+				return err
+			}
+			defer storeInstance.Close()
+
+			// Write to a temporary file first so an interrupted backup never looks complete.
+			file, err := os.CreateTemp(filepath.Dir(args[0]), filepath.Base(args[0])+".*.tmp")
+			if err != nil {
+				return errors.Wrap(err, "failed to create backup file")
+			}
+			defer os.Remove(file.Name())
+			manifest, err := backup.Create(ctx, storeInstance, instanceProfile, file)
+			if err != nil {
+				file.Close()
+				return err
+			}
+			if err := file.Close(); err != nil {
+				return errors.Wrap(err, "failed to write backup file")
+			}
+			if err := os.Rename(file.Name(), args[0]); err != nil {
+				return errors.Wrap(err, "failed to write backup file")
+			}
+			printManifest(manifest)
+			return nil
+		},
+	}
+	restoreCmd = &cobra.Command{
+		Use:   "restore <file>",
+		Short: "Restore the database and the local files of the instance from a backup",
+		Long: `Restore the database and the local files of the instance from a backup.
+The backup must come from the same database driver and schema version. Stop the server before restoring.`,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			force, err := cmd.Flags().GetBool("force")
+			if err != nil {
+				return err
+			}
+			reader, err := zip.OpenReader(args[0])
+			if err != nil {
+				return errors.Wrap(err, "failed to open backup file")
+			}
+			defer reader.Close()
+
+			instanceProfile := newInstanceProfile()
+			if err := instanceProfile.Validate(); err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+			storeInstance, err := openStore(ctx, instanceProfile)
+			if err != nil {
+				return err
+			}
+			defer storeInstance.Close()
+
+			manifest, err := backup.Restore(ctx, storeInstance, instanceProfile, &reader.Reader, backup.RestoreOptions{Force: force})
+			if errors.Is(err, backup.ErrDatabaseNotEmpty) {
+				return errors.Wrap(err, "use --force to replace the existing data")
+			}
+			if err != nil {
+				return errors.Wrap(err, "failed to restore")
+			}
+			printManifest(manifest)
+			return nil
+		},
+	}
+)
+
+func init() {
+	restoreCmd.Flags().Bool("force", false, "replace the data of a database that is not empty")
+	rootCmd.AddCommand(backupCmd, restoreCmd)
+}
+
+func printManifest(manifest *backup.Manifest) {
+	fmt.Printf("Version: %s (schema %s)\n", manifest.Version, manifest.SchemaVersion)
+	fmt.Printf("Driver: %s\n", manifest.Driver)
+	fmt.Printf("Created: %s\n", manifest.CreateTime.Format("2006-01-02 15:04:05 MST"))
+	for _, table := range backup.Tables {
+		fmt.Printf("  %s: %d rows\n", table, manifest.Tables[table])
+	}
+	fmt.Printf("Files: %d\n", len(manifest.Entries)-len(backup.Tables))
+}
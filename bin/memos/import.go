@@ -0,0 +1,111 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	apiv1 "github.com/usememos/memos/server/router/api/v1"
+	"github.com/usememos/memos/store"
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Import memos from a Markdown archive, an Obsidian vault or a flomo, Notion or Evernote export",
+	Args:  cobra.ExactArgs(1),
+	// Errors of the import are not usage errors.
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		username, err := cmd.Flags().GetString("user")
+		if err != nil {
+			return err
+		}
+		formatName, err := cmd.Flags().GetString("format")
+		if err != nil {
+			return err
+		}
+		dryRun, err := cmd.Flags().GetBool("dry-run")
+		if err != nil {
+			return err
+		}
+		format := v1pb.ImportMemosRequest_FORMAT_UNSPECIFIED
+		if formatName != "" {
+			value, ok := v1pb.ImportMemosRequest_Format_value[strings.ToUpper(formatName)]
+			if !ok || value == 0 {
+				return errors.Errorf("unsupported format %q", formatName)
+			}
+			format = v1pb.ImportMemosRequest_Format(value)
+		}
+		data, err := os.ReadFile(args[0])
+		if err != nil {
+			return errors.Wrap(err, "failed to read import file")
+		}
+
+		instanceProfile := newInstanceProfile()
+		if err := instanceProfile.Validate(); err != nil {
+			return err
+		}
+		ctx := cmd.Context()
+		storeInstance, err := openStore(ctx, instanceProfile)
+		if err != nil {
+			return err
+		}
+		defer storeInstance.Close()
+		user, err := storeInstance.GetUser(ctx, &store.FindUser{Username: &username})
+		if err != nil {
+			return errors.Wrap(err, "failed to get user")
+		}
+		if user == nil {
+			return errors.Errorf("user %s not found", username)
+		}
+
+		service := &apiv1.APIV1Service{Profile: instanceProfile, Store: storeInstance}
+		response, err := service.ImportUserMemos(ctx, user.ID, &v1pb.ImportMemosRequest{
+			Data:   data,
+			Format: format,
+			DryRun: dryRun,
+		})
+		if err != nil {
+			return err
+		}
+		printImportReport(response, dryRun)
+		return nil
+	},
+}
+
+func init() {
+	importCmd.Flags().String("user", "", "username of the owner of the imported memos")
+	importCmd.Flags().String("format", "", `format of the file, one of "markdown", "obsidian", "flomo", "notion" or "evernote", detected when empty`)
+	importCmd.Flags().Bool("dry-run", false, "report the memos to import without creating them")
+	if err := importCmd.MarkFlagRequired("user"); err != nil {
+		panic(err)
+	}
+	rootCmd.AddCommand(importCmd)
+}
+
+func printImportReport(response *v1pb.ImportMemosResponse, dryRun bool) {
+	fmt.Printf("Format: %s\n", response.Format)
+	for _, item := range response.Items {
+		target := item.Memo
+		if target == "" {
+			target = "(dry run)"
+		}
+		fmt.Printf("%s -> %s, created %s, %d attachments, %d relations\n",
+			item.Source, target, item.CreateTime.AsTime().Format("2006-01-02 15:04"), item.AttachmentCount, item.RelationCount)
+	}
+	if dryRun {
+		fmt.Printf("%d memos would be imported.\n", len(response.Items))
+	} else {
+		fmt.Printf("%d memos imported.\n", len(response.Items))
+	}
+	if len(response.Warnings) > 0 {
+		fmt.Fprintf(os.Stderr, "\nWarnings:\n")
+		for _, warning := range response.Warnings {
+			fmt.Fprintf(os.Stderr, "  %s\n", warning)
+		}
+	}
+}
@@ -8,7 +8,10 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	// Embed the IANA time zone database so user and workspace timezones resolve on minimal images.
+	_ "time/tzdata"
 
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
@@ -24,17 +27,7 @@ var (
 		Use:   "memos",
 		Short: `An open source, lightweight note-taking service. Easily capture and share your great thoughts.`,
 		Run: func(_ *cobra.Command, _ []string) {
-			instanceProfile := &profile.Profile{
-				Mode:        viper.GetString("mode"),
-				Addr:        viper.GetString("addr"),
-				Port:        viper.GetInt("port"),
-				UNIXSock:    viper.GetString("unix-sock"),
-				Data:        viper.GetString("data"),
-				Driver:      viper.GetString("driver"),
-				DSN:         viper.GetString("dsn"),
-				InstanceURL: viper.GetString("instance-url"),
-				Version:     version.GetCurrentVersion(viper.GetString("mode")),
-			}
+			instanceProfile := newInstanceProfile()
 			if err := instanceProfile.Validate(); err != nil {
 				panic(err)
 			}
@@ -134,6 +127,35 @@ func init() {
 	}
 }
 
+// newInstanceProfile returns the instance profile from the flags and the environment.
+func newInstanceProfile() *profile.Profile {
+	return &profile.Profile{
+		Mode:        viper.GetString("mode"),
+		Addr:        viper.GetString("addr"),
+		Port:        viper.GetInt("port"),
+		UNIXSock:    viper.GetString("unix-sock"),
+		Data:        viper.GetString("data"),
+		Driver:      viper.GetString("driver"),
+		DSN:         viper.GetString("dsn"),
+		InstanceURL: viper.GetString("instance-url"),
+		Version:     version.GetCurrentVersion(viper.GetString("mode")),
+	}
+}
+
+// openStore opens the database of the instance and migrates it to the current schema.
+func openStore(ctx context.Context, instanceProfile *profile.Profile) (*store.Store, error) {
+	dbDriver, err := db.NewDBDriver(instanceProfile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create db driver")
+	}
+	storeInstance := store.New(dbDriver, instanceProfile)
+	if err := storeInstance.Migrate(ctx); err != nil {
+		storeInstance.Close()
+		return nil, errors.Wrap(err, "failed to migrate")
+	}
+	return storeInstance, nil
+}
+
 func printGreetings(profile *profile.Profile) {
 	fmt.Printf("Memos %s started successfully!\n", profile.Version)
 
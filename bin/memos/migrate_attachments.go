@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/usememos/memos/store"
+)
+
+var migrateAttachmentsCmd = &cobra.Command{
+	Use:   "migrate-attachments",
+	Short: "Move the attachments to the storage of the workspace storage setting",
+	Long: `Move the attachments to the storage of the workspace storage setting, e.g. from the database to S3.
+Every attachment is verified with its checksum before its old content is deleted. An interrupted migration
+continues with the remaining attachments when it runs again. Attachments stored at external links are skipped.`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		batchSize, err := cmd.Flags().GetInt("batch-size")
+		if err != nil {
+			return err
+		}
+		instanceProfile := newInstanceProfile()
+		if err := instanceProfile.Validate(); err != nil {
+			return err
+		}
+		ctx := cmd.Context()
+		storeInstance, err := openStore(ctx, instanceProfile)
+		if err != nil {
+			return err
+		}
+		defer storeInstance.Close()
+
+		progress, err := storeInstance.MigrateAttachments(ctx, store.MigrateAttachmentsOptions{
+			BatchSize: batchSize,
+			OnProgress: func(progress *store.AttachmentMigrationProgress) {
+				fmt.Printf("\rMigrated %d/%d attachments, %d failed", progress.Migrated, progress.Total, progress.Failed)
+			},
+		})
+		if progress != nil && progress.Migrated+progress.Failed > 0 {
+			fmt.Println()
+		}
+		if err != nil {
+			return errors.Wrap(err, "failed to migrate attachments")
+		}
+		fmt.Printf("Migrated %d of %d attachments\n", progress.Migrated, progress.Total)
+		for _, failure := range progress.Failures {
+			fmt.Printf("  %s\n", failure)
+		}
+		if progress.Failed > 0 {
+			return errors.Errorf("%d attachments failed to migrate", progress.Failed)
+		}
+		return nil
+	},
+}
+
+func init() {
+	migrateAttachmentsCmd.Flags().Int("batch-size", 100, "number of attachments listed at once")
+	rootCmd.AddCommand(migrateAttachmentsCmd)
+}
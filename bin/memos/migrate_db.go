@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/usememos/memos/internal/profile"
+	"github.com/usememos/memos/store/backup"
+)
+
+var migrateDBCmd = &cobra.Command{
+	Use:   "migrate-db",
+	Short: "Copy the database of the instance to a database of another driver",
+	Long: `Copy the database of the instance to a database of another driver, e.g. from SQLite to Postgres.
+Every table is copied with its ids and the row counts of the target are checked before the copy is committed.
+Both databases are migrated to the current schema first. Local attachment files stay in the data directory.
+Stop the server before migrating and start it with the new driver and DSN afterwards.`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		flags := cmd.Flags()
+		fromDriver, err := flags.GetString("from-driver")
+		if err != nil {
+			return err
+		}
+		fromDSN, err := flags.GetString("from-dsn")
+		if err != nil {
+			return err
+		}
+		toDriver, err := flags.GetString("to-driver")
+		if err != nil {
+			return err
+		}
+		toDSN, err := flags.GetString("to-dsn")
+		if err != nil {
+			return err
+		}
+		force, err := flags.GetBool("force")
+		if err != nil {
+			return err
+		}
+
+		sourceProfile, err := newDatabaseProfile(fromDriver, fromDSN)
+		if err != nil {
+			return err
+		}
+		targetProfile, err := newDatabaseProfile(toDriver, toDSN)
+		if err != nil {
+			return err
+		}
+		if sourceProfile.Driver == targetProfile.Driver && sourceProfile.DSN == targetProfile.DSN {
+			return errors.New("the source and target databases are the same")
+		}
+
+		ctx := cmd.Context()
+		source, err := openStore(ctx, sourceProfile)
+		if err != nil {
+			return errors.Wrap(err, "failed to open source database")
+		}
+		defer source.Close()
+		target, err := openStore(ctx, targetProfile)
+		if err != nil {
+			return errors.Wrap(err, "failed to open target database")
+		}
+		defer target.Close()
+
+		counts, err := backup.Copy(ctx, source, sourceProfile, target, targetProfile, backup.CopyOptions{Force: force})
+		if errors.Is(err, backup.ErrDatabaseNotEmpty) {
+			return errors.Wrap(err, "use --force to replace the data of the target database")
+		}
+		if err != nil {
+			return errors.Wrap(err, "failed to migrate database")
+		}
+		fmt.Printf("Copied the %s database to the %s database:\n", sourceProfile.Driver, targetProfile.Driver)
+		for _, table := range backup.Tables {
+			fmt.Printf("  %s: %d rows\n", table, counts[table])
+		}
+		return nil
+	},
+}
+
+func init() {
+	migrateDBCmd.Flags().String("from-driver", "", "database driver of the source database")
+	migrateDBCmd.Flags().String("from-dsn", "", "database source name of the source database, defaults to the database of the data directory for SQLite")
+	migrateDBCmd.Flags().String("to-driver", "", "database driver of the target database")
+	migrateDBCmd.Flags().String("to-dsn", "", "database source name of the target database")
+	migrateDBCmd.Flags().Bool("force", false, "replace the data of a target database that is not empty")
+	for _, name := range []string{"from-driver", "to-driver", "to-dsn"} {
+		if err := migrateDBCmd.MarkFlagRequired(name); err != nil {
+			panic(err)
+		}
+	}
+	rootCmd.AddCommand(migrateDBCmd)
+}
+
+// newDatabaseProfile returns the instance profile with another database.
+func newDatabaseProfile(driver, dsn string) (*profile.Profile, error) {
+	instanceProfile := newInstanceProfile()
+	instanceProfile.Driver = driver
+	instanceProfile.DSN = dsn
+	if err := instanceProfile.Validate(); err != nil {
+		return nil, err
+	}
+	return instanceProfile, nil
+}
@@ -3,9 +3,11 @@ package util //nolint:revive // util namespace is intentional for shared helpers
 import (
 	"crypto/rand"
 	"math/big"
+	"net/http"
 	"net/mail"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -71,3 +73,30 @@ func ReplaceString(slice []string, old, new string) []string {
 	}
 	return slice
 }
+
+// IsNotModified reports whether a conditional GET request with the If-None-Match and
+// If-Modified-Since header values can be answered with 304 Not Modified for a response
+// with the etag and last modified time. If-None-Match takes precedence as in RFC 9110.
+func IsNotModified(ifNoneMatch, ifModifiedSince, etag string, lastModified time.Time) bool {
+	if ifNoneMatch != "" {
+		if etag == "" {
+			return false
+		}
+		for _, candidate := range strings.Split(ifNoneMatch, ",") {
+			candidate = strings.TrimSpace(candidate)
+			if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+				return true
+			}
+		}
+		return false
+	}
+	if ifModifiedSince == "" || lastModified.IsZero() {
+		return false
+	}
+	since, err := http.ParseTime(ifModifiedSince)
+	if err != nil {
+		return false
+	}
+	// HTTP dates have a precision of seconds.
+	return !lastModified.Truncate(time.Second).After(since)
+}
@@ -2,6 +2,7 @@ package util //nolint:revive // util is an appropriate package name for utility
 
 import (
 	"testing"
+	"time"
 )
 
 func TestValidateEmail(t *testing.T) {
@@ -29,3 +30,30 @@ func TestValidateEmail(t *testing.T) {
 		}
 	}
 }
+
+func TestIsNotModified(t *testing.T) {
+	lastModified := time.Date(2024, 3, 1, 12, 0, 0, 500, time.UTC)
+	tests := []struct {
+		ifNoneMatch     string
+		ifModifiedSince string
+		want            bool
+	}{
+		{ifNoneMatch: `"abc"`, want: true},
+		{ifNoneMatch: `"xyz", W/"abc"`, want: true},
+		{ifNoneMatch: "*", want: true},
+		{ifNoneMatch: `"xyz"`, want: false},
+		// If-None-Match takes precedence over If-Modified-Since.
+		{ifNoneMatch: `"xyz"`, ifModifiedSince: "Fri, 01 Mar 2024 12:00:00 GMT", want: false},
+		{ifModifiedSince: "Fri, 01 Mar 2024 12:00:00 GMT", want: true},
+		{ifModifiedSince: "Sat, 02 Mar 2024 12:00:00 GMT", want: true},
+		{ifModifiedSince: "Fri, 01 Mar 2024 11:59:59 GMT", want: false},
+		{ifModifiedSince: "invalid", want: false},
+		{want: false},
+	}
+	for _, test := range tests {
+		result := IsNotModified(test.ifNoneMatch, test.ifModifiedSince, `"abc"`, lastModified)
+		if result != test.want {
+			t.Errorf("IsNotModified(%q, %q): got result %v, want %v.", test.ifNoneMatch, test.ifModifiedSince, result, test.want)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package archive
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"mime"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/net/html"
+)
+
+// enexExport is an Evernote export file.
+type enexExport struct {
+	Notes []*enexNote `xml:"note"`
+}
+
+type enexNote struct {
+	Title string `xml:"title"`
+	// Content is the ENML document of the note.
+	Content   string          `xml:"content"`
+	Created   string          `xml:"created"`
+	Updated   string          `xml:"updated"`
+	Tags      []string        `xml:"tag"`
+	Latitude  float64         `xml:"note-attributes>latitude"`
+	Longitude float64         `xml:"note-attributes>longitude"`
+	Resources []*enexResource `xml:"resource"`
+}
+
+type enexResource struct {
+	// Data is the base64 encoded content of the resource.
+	Data     string `xml:"data"`
+	Mime     string `xml:"mime"`
+	Filename string `xml:"resource-attributes>file-name"`
+}
+
+func isEnex(data []byte) bool {
+	head := data[:min(len(data), 1024)]
+	return bytes.Contains(head, []byte("<en-export"))
+}
+
+// readEnex reads the notes of an Evernote export file.
+func readEnex(p string, data []byte) ([]*Note, error) {
+	export := &enexExport{}
+	if err := xml.Unmarshal(data, export); err != nil {
+		return nil, errors.Wrap(err, "invalid ENEX file")
+	}
+
+	notes := []*Note{}
+	for i, enex := range export.Notes {
+		note := &Note{
+			Path:        path.Join(p, fmt.Sprintf("%d", i+1)),
+			FrontMatter: &FrontMatter{},
+		}
+		if t, ok := parseTime(enex.Created, time.UTC); ok {
+			note.FrontMatter.Created = t
+		}
+		if t, ok := parseTime(enex.Updated, time.UTC); ok {
+			note.FrontMatter.Updated = t
+		}
+		if enex.Latitude != 0 || enex.Longitude != 0 {
+			note.FrontMatter.Location = &Location{
+				Latitude:  enex.Latitude,
+				Longitude: enex.Longitude,
+			}
+		}
+
+		content := ""
+		if enex.Content != "" {
+			document, err := html.Parse(strings.NewReader(enex.Content))
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to parse note %q", enex.Title)
+			}
+			content = htmlToMarkdown(document)
+		}
+		if title := strings.TrimSpace(enex.Title); title != "" {
+			content = strings.TrimSpace("# " + title + "\n\n" + content)
+		}
+		note.Content = appendTags(content, enex.Tags)
+
+		for j, resource := range enex.Resources {
+			blob, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(resource.Data), ""))
+			if err != nil {
+				note.Warnings = append(note.Warnings, fmt.Sprintf("invalid data of resource %q", resource.Filename))
+				continue
+			}
+			filename := resource.Filename
+			if filename == "" {
+				filename = fmt.Sprintf("attachment-%d", j+1)
+				if extensions, _ := mime.ExtensionsByType(resource.Mime); len(extensions) > 0 {
+					filename += extensions[0]
+				}
+			}
+			attachment := newAttachment(path.Join(note.Path, filename), filename, resource.Mime, func() []byte {
+				return blob
+			})
+			attachment.Size = int64(len(blob))
+			attachment.blob = blob
+			note.Attachments = append(note.Attachments, attachment)
+		}
+		notes = append(notes, note)
+	}
+	return notes, nil
+}
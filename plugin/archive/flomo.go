@@ -0,0 +1,102 @@
+package archive
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/net/html"
+)
+
+// flomoMemoRegex matches the memos of a flomo HTML export.
+var flomoMemoRegex = regexp.MustCompile(`<div class="memo"`)
+
+// readFlomoNotes reads the memos of the HTML pages of a flomo export.
+// Each memo is a div with its time, its content and its files:
+//
+//	<div class="memo">
+//	  <div class="time">2023-05-01 10:00:00</div>
+//	  <div class="content"><p>Hello #tag</p></div>
+//	  <div class="files"><img src="file/2023-05-01/1/image.png" /></div>
+//	</div>
+func readFlomoNotes(source *zipSource, location *time.Location) ([]*Note, error) {
+	notes := []*Note{}
+	for _, p := range source.paths {
+		if strings.ToLower(path.Ext(p)) != ".html" {
+			continue
+		}
+		data, err := source.read(p)
+		if err != nil {
+			return nil, err
+		}
+		document, err := html.Parse(bytes.NewReader(data))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse %s", p)
+		}
+		for i, memo := range findHTMLElements(document, "memo") {
+			note := &Note{
+				Path:        fmt.Sprintf("%s#%d", p, i+1),
+				FrontMatter: &FrontMatter{},
+			}
+			for _, element := range findHTMLElements(memo, "time") {
+				if t, ok := parseTime(htmlText(element), location); ok {
+					note.FrontMatter.Created = t
+				}
+			}
+			for _, element := range findHTMLElements(memo, "content") {
+				note.Content = htmlToMarkdown(element)
+			}
+			for _, element := range findHTMLElements(memo, "files") {
+				for _, src := range htmlSources(element) {
+					filePath := path.Join(path.Dir(p), src)
+					if err := addAttachment(source, note, filePath); errors.Is(err, errFileNotFound) {
+						note.Warnings = append(note.Warnings, "link to missing file "+src)
+					} else if err != nil {
+						return nil, errors.Wrapf(err, "failed to read %s", p)
+					}
+				}
+			}
+			notes = append(notes, note)
+		}
+	}
+	return notes, nil
+}
+
+// findHTMLElements returns the elements with the given class under a node, excluding nested matches.
+func findHTMLElements(node *html.Node, class string) []*html.Node {
+	elements := []*html.Node{}
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type == html.ElementNode && slices.Contains(strings.Fields(htmlAttribute(child, "class")), class) {
+			elements = append(elements, child)
+			continue
+		}
+		elements = append(elements, findHTMLElements(child, class)...)
+	}
+	return elements
+}
+
+// htmlSources returns the sources of the images, media and links under a node.
+func htmlSources(node *html.Node) []string {
+	sources := []string{}
+	if node.Type == html.ElementNode {
+		for _, key := range []string{"src", "href"} {
+			if value := htmlAttribute(node, key); value != "" && !schemeRegex.MatchString(value) {
+				sources = append(sources, value)
+				break
+			}
+		}
+	}
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		for _, source := range htmlSources(child) {
+			if !slices.Contains(sources, source) {
+				sources = append(sources, source)
+			}
+		}
+	}
+	return sources
+}
@@ -0,0 +1,201 @@
+package archive
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// blankLinesRegex matches the runs of blank lines to collapse in converted HTML.
+var blankLinesRegex = regexp.MustCompile(`\n[ \t]*(?:\n[ \t]*)+\n`)
+
+// htmlToMarkdown converts the HTML of a note to Markdown.
+// Only the common formatting of notes is kept: headings, paragraphs, lists, links, emphasis and code.
+func htmlToMarkdown(node *html.Node) string {
+	converter := &htmlConverter{}
+	converter.convert(node)
+	text := strings.ReplaceAll(converter.builder.String(), "\u00a0", " ")
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	return strings.TrimSpace(blankLinesRegex.ReplaceAllString(strings.Join(lines, "\n"), "\n\n"))
+}
+
+func htmlText(node *html.Node) string {
+	if node.Type == html.TextNode {
+		return node.Data
+	}
+	var builder strings.Builder
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		builder.WriteString(htmlText(child))
+	}
+	return strings.TrimSpace(builder.String())
+}
+
+func htmlAttribute(node *html.Node, key string) string {
+	for _, attribute := range node.Attr {
+		if attribute.Key == key {
+			return attribute.Val
+		}
+	}
+	return ""
+}
+
+type htmlConverter struct {
+	builder strings.Builder
+	// lists holds the item counters of the open lists, -1 for unordered lists.
+	lists []int
+	inPre bool
+}
+
+// newline ends the current line, or adds a blank line for paragraph breaks.
+func (c *htmlConverter) newline(blank bool) {
+	text := c.builder.String()
+	if text == "" {
+		return
+	}
+	if !strings.HasSuffix(text, "\n") {
+		c.builder.WriteString("\n")
+	}
+	if blank && !strings.HasSuffix(text, "\n\n") {
+		c.builder.WriteString("\n")
+	}
+}
+
+func (c *htmlConverter) write(s string) {
+	if strings.HasSuffix(c.builder.String(), "\n") || c.builder.Len() == 0 {
+		s = strings.TrimLeft(s, " ")
+	}
+	c.builder.WriteString(s)
+}
+
+func (c *htmlConverter) convert(node *html.Node) {
+	switch node.Type {
+	case html.TextNode:
+		if c.inPre {
+			c.builder.WriteString(node.Data)
+		} else if text := strings.Join(strings.Fields(node.Data), " "); text != "" {
+			if strings.TrimLeft(node.Data, " \t\r\n") != node.Data {
+				text = " " + text
+			}
+			if strings.TrimRight(node.Data, " \t\r\n") != node.Data {
+				text += " "
+			}
+			c.write(text)
+		} else if node.Data != "" {
+			c.write(" ")
+		}
+		return
+	case html.ElementNode:
+	default:
+		c.convertChildren(node)
+		return
+	}
+
+	switch node.Data {
+	case "head", "script", "style", "title", "img", "en-media", "audio", "video":
+	case "br":
+		c.builder.WriteString("\n")
+	case "hr":
+		c.newline(true)
+		c.builder.WriteString("---")
+		c.newline(true)
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		c.newline(true)
+		c.builder.WriteString(strings.Repeat("#", int(node.Data[1]-'0')) + " ")
+		c.convertChildren(node)
+		c.newline(true)
+	case "p", "blockquote", "table":
+		c.newline(true)
+		if node.Data == "blockquote" {
+			c.builder.WriteString("> ")
+		}
+		c.convertChildren(node)
+		c.newline(true)
+	case "div", "tr", "section", "article":
+		c.newline(false)
+		c.convertChildren(node)
+		c.newline(false)
+	case "ul", "ol":
+		c.newline(len(c.lists) == 0)
+		counter := -1
+		if node.Data == "ol" {
+			counter = 0
+		}
+		c.lists = append(c.lists, counter)
+		c.convertChildren(node)
+		c.lists = c.lists[:len(c.lists)-1]
+		c.newline(len(c.lists) == 0)
+	case "li":
+		c.newline(false)
+		marker := "- "
+		if depth := len(c.lists); depth > 0 {
+			c.builder.WriteString(strings.Repeat("  ", depth-1))
+			if c.lists[depth-1] >= 0 {
+				c.lists[depth-1]++
+				marker = fmt.Sprintf("%d. ", c.lists[depth-1])
+			}
+		}
+		c.builder.WriteString(marker)
+		c.convertChildren(node)
+		c.newline(false)
+	case "en-todo":
+		if htmlAttribute(node, "checked") == "true" {
+			c.write("- [x] ")
+		} else {
+			c.write("- [ ] ")
+		}
+		// The HTML parser does not know en-todo is a void element and nests the following text in it.
+		c.convertChildren(node)
+	case "pre":
+		c.newline(true)
+		c.builder.WriteString("```\n")
+		c.inPre = true
+		c.convertChildren(node)
+		c.inPre = false
+		c.newline(false)
+		c.builder.WriteString("```")
+		c.newline(true)
+	case "strong", "b":
+		c.wrap(node, "**")
+	case "em", "i":
+		c.wrap(node, "*")
+	case "s", "del", "strike":
+		c.wrap(node, "~~")
+	case "code":
+		if c.inPre {
+			c.convertChildren(node)
+		} else {
+			c.wrap(node, "`")
+		}
+	case "a":
+		href := htmlAttribute(node, "href")
+		text := htmlText(node)
+		if href == "" || text == "" || text == href {
+			c.convertChildren(node)
+		} else {
+			c.write(fmt.Sprintf("[%s](%s)", text, href))
+		}
+	default:
+		c.convertChildren(node)
+	}
+}
+
+func (c *htmlConverter) convertChildren(node *html.Node) {
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		c.convert(child)
+	}
+}
+
+// wrap writes the text of an inline element between markers, e.g. **bold**.
+func (c *htmlConverter) wrap(node *html.Node, marker string) {
+	text := htmlText(node)
+	if strings.TrimSpace(text) == "" {
+		c.convertChildren(node)
+		return
+	}
+	c.write(marker + strings.TrimSpace(strings.Join(strings.Fields(text), " ")) + marker)
+}
@@ -0,0 +1,615 @@
+package archive
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// Format is the format of an import source.
+type Format string
+
+const (
+	// FormatMarkdown is a ZIP archive of Markdown files with optional front matter, e.g. a memos export.
+	FormatMarkdown Format = "MARKDOWN"
+	// FormatObsidian is a ZIP archive of an Obsidian vault.
+	FormatObsidian Format = "OBSIDIAN"
+	// FormatFlomo is a ZIP archive of a flomo HTML export.
+	FormatFlomo Format = "FLOMO"
+	// FormatNotion is a ZIP archive of a Notion Markdown export.
+	FormatNotion Format = "NOTION"
+	// FormatEvernote is an Evernote ENEX file or a ZIP archive of ENEX files.
+	FormatEvernote Format = "EVERNOTE"
+)
+
+// Note is a note read from an import source.
+type Note struct {
+	// Path is the path of the note in the import source.
+	Path        string
+	FrontMatter *FrontMatter
+	Content     string
+	Attachments []*Attachment
+	// References lists the paths of the notes the note links to.
+	References []string
+	// Warnings lists the problems found while reading the note, e.g. links to missing files.
+	Warnings []string
+}
+
+// Attachment is a file attached to a note of an import source.
+type Attachment struct {
+	// Path is the path of the file in the import source.
+	Path     string
+	Filename string
+	Type     string
+	// Size is the size of the file in bytes.
+	Size int64
+
+	// blob is the content of the file. The files of ZIP archives are read from the source when
+	// their blob is read, so that only the attachment being imported is held in memory.
+	blob   []byte
+	source *zipSource
+}
+
+// ReadBlob returns the content of the attachment.
+func (a *Attachment) ReadBlob() ([]byte, error) {
+	if a.source == nil {
+		return a.blob, nil
+	}
+	file, ok := a.source.files[a.Path]
+	if !ok {
+		return nil, errors.Wrap(errFileNotFound, a.Path)
+	}
+	return a.source.open(a.Path, file)
+}
+
+var (
+	zipSignature = []byte("PK\x03\x04")
+	// emptyZipSignature starts the end of central directory record of an empty archive.
+	emptyZipSignature = []byte("PK\x05\x06")
+	// notionFilenameRegex matches the files of a Notion export, which end with the id of the page.
+	notionFilenameRegex = regexp.MustCompile(`^(.+) [0-9a-f]{32}\.md$`)
+	// wikiLinkRegex matches wiki links and embeds, e.g. [[Note]], [[Note#Heading|Alias]] or ![[image.png]].
+	wikiLinkRegex = regexp.MustCompile(`(!?)\[\[([^\[\]|#^]*)(?:[#^][^\[\]|]*)?(?:\|([^\[\]]*))?\]\]`)
+	// markdownLinkRegex matches Markdown links and images, e.g. [text](path) or ![alt](<path> "title").
+	markdownLinkRegex = regexp.MustCompile(`(!?)\[([^\[\]]*)\]\(\s*(?:<([^<>]+)>|([^\s()]+))(?:\s+"[^"]*")?\s*\)`)
+	// schemeRegex matches the scheme of an absolute URL.
+	schemeRegex = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9+.-]*:`)
+	// attachmentTextRegex matches the text left by the links to attachments.
+	attachmentTextRegex = regexp.MustCompile(`\x00[^\x00]*\x00`)
+)
+
+// attachmentTextMark delimits the text left by a link to an attachment while resolving the links of a line.
+const attachmentTextMark = "\x00"
+
+const (
+	// maxZipFileCount is the maximum number of files of a ZIP archive.
+	maxZipFileCount = 100000
+	// maxZipReadSize is the maximum number of bytes read from the files of a ZIP archive, which may
+	// decompress to much more than their size, counting the attachments once per note.
+	maxZipReadSize = 1 << 30
+)
+
+var (
+	// ErrFileTooLarge is returned for the files of an import source that exceed the maximum file size.
+	ErrFileTooLarge = errors.New("file exceeds the size limit")
+	// ErrSourceTooLarge is returned for the import sources with too many files, or files that
+	// decompress to more than the maximum read size.
+	ErrSourceTooLarge = errors.New("import source exceeds the size limit")
+	// errFileNotFound is returned for the files linked from notes that are missing from a source.
+	errFileNotFound = errors.New("file not found")
+)
+
+// DetectFormat returns the format of an import source.
+// Files of the source larger than the maximum file size are not read.
+func DetectFormat(data []byte, maxFileSize int64) (Format, error) {
+	if !isZip(data) {
+		if isEnex(data) {
+			return FormatEvernote, nil
+		}
+		return "", errors.New("unsupported import source, expected a ZIP archive or an ENEX file")
+	}
+
+	source, err := openZip(data, maxFileSize)
+	if err != nil {
+		return "", err
+	}
+	format := FormatMarkdown
+	for _, p := range source.paths {
+		if slices.Contains(strings.Split(p, "/"), ".obsidian") {
+			return FormatObsidian, nil
+		}
+		switch strings.ToLower(path.Ext(p)) {
+		case ".enex":
+			return FormatEvernote, nil
+		case ".html":
+			data, err := source.read(p)
+			if err != nil {
+				return "", err
+			}
+			if flomoMemoRegex.Match(data) {
+				return FormatFlomo, nil
+			}
+		case ".md":
+			if notionFilenameRegex.MatchString(path.Base(p)) {
+				format = FormatNotion
+			}
+		}
+	}
+	return format, nil
+}
+
+// ReadNotes reads the notes of an import source.
+// Times without a time zone in the source are interpreted in the given location. Attachments larger
+// than the maximum file size are skipped with a warning, and other files fail with ErrFileTooLarge.
+// The attachments of ZIP archives are read with Attachment.ReadBlob, and are counted against the
+// maximum read size of the source here so that reading them cannot fail with ErrSourceTooLarge.
+func ReadNotes(data []byte, format Format, location *time.Location, maxFileSize int64) ([]*Note, error) {
+	if format == FormatEvernote && !isZip(data) {
+		return readEnex("", data)
+	}
+	source, err := openZip(data, maxFileSize)
+	if err != nil {
+		return nil, err
+	}
+	switch format {
+	case FormatMarkdown, FormatObsidian, FormatNotion:
+		return readMarkdownNotes(source, format, location)
+	case FormatFlomo:
+		return readFlomoNotes(source, location)
+	case FormatEvernote:
+		notes := []*Note{}
+		for _, p := range source.paths {
+			if strings.ToLower(path.Ext(p)) != ".enex" {
+				continue
+			}
+			data, err := source.read(p)
+			if err != nil {
+				return nil, err
+			}
+			enexNotes, err := readEnex(p, data)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to read %s", p)
+			}
+			notes = append(notes, enexNotes...)
+		}
+		return notes, nil
+	default:
+		return nil, errors.Errorf("unsupported format %q", format)
+	}
+}
+
+func isZip(data []byte) bool {
+	return bytes.HasPrefix(data, zipSignature) || bytes.HasPrefix(data, emptyZipSignature)
+}
+
+// zipSource is a ZIP archive opened as an import source.
+type zipSource struct {
+	files map[string]*zip.File
+	// paths lists the paths of the files in lexical order.
+	paths []string
+	// maxFileSize is the maximum size of the files that are read.
+	maxFileSize int64
+	// readSize is the size of the files read from the archive and of the attachments to be read.
+	readSize int64
+}
+
+func openZip(data []byte, maxFileSize int64) (*zipSource, error) {
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid ZIP archive")
+	}
+	if len(reader.File) > maxZipFileCount {
+		return nil, errors.Wrapf(ErrSourceTooLarge, "more than %d files", maxZipFileCount)
+	}
+	source := &zipSource{files: map[string]*zip.File{}, maxFileSize: maxFileSize}
+	for _, file := range reader.File {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+		p := path.Clean(strings.ReplaceAll(file.Name, "\\", "/"))
+		if strings.HasPrefix(p, "__MACOSX/") || path.Base(p) == ".DS_Store" {
+			continue
+		}
+		source.files[p] = file
+		source.paths = append(source.paths, p)
+	}
+	slices.Sort(source.paths)
+	return source, nil
+}
+
+func (s *zipSource) read(p string) ([]byte, error) {
+	file, err := s.reserve(p)
+	if err != nil {
+		return nil, err
+	}
+	return s.open(p, file)
+}
+
+// reserve counts the size of a file against the maximum read size before it is read.
+// Files are rejected before they are decompressed, and while they are read since the size in
+// the header may be wrong.
+func (s *zipSource) reserve(p string) (*zip.File, error) {
+	file, ok := s.files[p]
+	if !ok {
+		return nil, errors.Wrap(errFileNotFound, p)
+	}
+	if file.UncompressedSize64 > uint64(s.maxFileSize) {
+		return nil, errors.Wrapf(ErrFileTooLarge, "failed to read %s", p)
+	}
+	s.readSize += int64(file.UncompressedSize64)
+	if s.readSize > maxZipReadSize {
+		return nil, errors.Wrapf(ErrSourceTooLarge, "failed to read %s", p)
+	}
+	return file, nil
+}
+
+// open reads a file of the archive.
+func (s *zipSource) open(p string, file *zip.File) ([]byte, error) {
+	reader, err := file.Open()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open %s", p)
+	}
+	defer reader.Close()
+	data, err := io.ReadAll(io.LimitReader(reader, s.maxFileSize+1))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %s", p)
+	}
+	if int64(len(data)) > s.maxFileSize {
+		return nil, errors.Wrapf(ErrFileTooLarge, "failed to read %s", p)
+	}
+	return data, nil
+}
+
+// resolve returns the path of a file linked from a note in the given directory.
+// Wiki links are resolved like Obsidian does: relative to the vault root, relative to the note,
+// and finally by the shortest path ending with the target.
+func (s *zipSource) resolve(dir, target string, wiki bool) (string, bool) {
+	candidates := []string{path.Join(dir, target)}
+	if wiki {
+		if path.Ext(target) == "" {
+			target += ".md"
+		}
+		candidates = []string{target, path.Join(dir, target)}
+	}
+	for _, candidate := range candidates {
+		if _, ok := s.files[candidate]; ok {
+			return candidate, true
+		}
+	}
+	if !wiki {
+		return "", false
+	}
+	resolved := ""
+	for _, p := range s.paths {
+		if !strings.EqualFold(p, target) && !strings.HasSuffix(strings.ToLower(p), "/"+strings.ToLower(target)) {
+			continue
+		}
+		if resolved == "" || len(p) < len(resolved) {
+			resolved = p
+		}
+	}
+	return resolved, resolved != ""
+}
+
+func isMarkdownFile(p string) bool {
+	ext := strings.ToLower(path.Ext(p))
+	return ext == ".md" || ext == ".markdown"
+}
+
+// isHiddenPath reports whether a path is in a hidden folder, e.g. .obsidian or .trash.
+func isHiddenPath(p string) bool {
+	for _, segment := range strings.Split(p, "/") {
+		if strings.HasPrefix(segment, ".") {
+			return true
+		}
+	}
+	return false
+}
+
+func readMarkdownNotes(source *zipSource, format Format, location *time.Location) ([]*Note, error) {
+	notes := []*Note{}
+	for _, p := range source.paths {
+		if !isMarkdownFile(p) || isHiddenPath(p) {
+			continue
+		}
+		data, err := source.read(p)
+		if err != nil {
+			return nil, err
+		}
+		frontMatter, content, err := readFrontMatter(data, location)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read %s", p)
+		}
+		note := &Note{
+			Path:        p,
+			FrontMatter: frontMatter,
+			Content:     content,
+		}
+		switch format {
+		case FormatObsidian:
+			title := strings.TrimSuffix(path.Base(p), path.Ext(p))
+			if !strings.HasPrefix(note.Content, "# ") {
+				note.Content = strings.TrimSpace("# " + title + "\n\n" + note.Content)
+			}
+		case FormatNotion:
+			readNotionProperties(note, location)
+		}
+		if err := resolveLinks(source, note); err != nil {
+			return nil, errors.Wrapf(err, "failed to read %s", p)
+		}
+		for _, attachmentPath := range frontMatter.Attachments {
+			if err := addAttachment(source, note, attachmentPath); errors.Is(err, errFileNotFound) {
+				note.Warnings = append(note.Warnings, "missing attachment "+attachmentPath)
+			} else if err != nil {
+				return nil, errors.Wrapf(err, "failed to read %s", p)
+			}
+		}
+		note.Content = appendTags(note.Content, frontMatter.Tags)
+		if frontMatter.Created.IsZero() {
+			frontMatter.Created = source.files[p].Modified
+		}
+		notes = append(notes, note)
+	}
+	return notes, nil
+}
+
+// resolveLinks turns the links of a note to other notes of the source into references,
+// and the links to other files into attachments.
+// Links to attachments are removed from the content since memos show their attachments,
+// and the lines left empty by the removal are dropped.
+func resolveLinks(source *zipSource, note *Note) error {
+	dir := path.Dir(note.Path)
+	var resolveErr error
+	resolveFile := func(p, text string, embed bool) string {
+		if isMarkdownFile(p) {
+			if !slices.Contains(note.References, p) {
+				note.References = append(note.References, p)
+			}
+			return text
+		}
+		if err := addAttachment(source, note, p); err != nil {
+			resolveErr = err
+		}
+		if embed {
+			return ""
+		}
+		return attachmentTextMark + text + attachmentTextMark
+	}
+
+	lines := strings.Split(note.Content, "\n")
+	kept := make([]string, 0, len(lines))
+	inCodeBlock := false
+	for _, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inCodeBlock = !inCodeBlock
+		}
+		if inCodeBlock {
+			kept = append(kept, line)
+			continue
+		}
+		resolved := wikiLinkRegex.ReplaceAllStringFunc(line, func(link string) string {
+			matches := wikiLinkRegex.FindStringSubmatch(link)
+			embed, target, alias := matches[1] == "!", strings.TrimSpace(matches[2]), matches[3]
+			text := alias
+			if text == "" {
+				text = strings.TrimSuffix(path.Base(target), path.Ext(target))
+			}
+			if target == "" {
+				return text
+			}
+			p, ok := source.resolve(dir, target, true)
+			if !ok {
+				note.Warnings = append(note.Warnings, "link to missing file "+target)
+				return text
+			}
+			if !isMarkdownFile(p) && alias == "" {
+				text = path.Base(p)
+			}
+			return resolveFile(p, text, embed)
+		})
+		resolved = markdownLinkRegex.ReplaceAllStringFunc(resolved, func(link string) string {
+			matches := markdownLinkRegex.FindStringSubmatch(link)
+			embed, text, target := matches[1] == "!", matches[2], matches[3]+matches[4]
+			if schemeRegex.MatchString(target) || strings.HasPrefix(target, "/") || strings.HasPrefix(target, "#") {
+				return link
+			}
+			if i := strings.IndexAny(target, "?#"); i >= 0 {
+				target = target[:i]
+			}
+			if unescaped, err := url.PathUnescape(target); err == nil {
+				target = unescaped
+			}
+			p, ok := source.resolve(dir, target, false)
+			if !ok {
+				note.Warnings = append(note.Warnings, "link to missing file "+target)
+				return link
+			}
+			return resolveFile(p, text, embed)
+		})
+		// Drop the lines holding nothing but links to attachments.
+		if strings.TrimSpace(line) != "" && strings.TrimSpace(attachmentTextRegex.ReplaceAllString(resolved, "")) == "" {
+			continue
+		}
+		kept = append(kept, strings.ReplaceAll(resolved, attachmentTextMark, ""))
+	}
+	note.Content = strings.TrimSpace(strings.Join(kept, "\n"))
+	return resolveErr
+}
+
+// addAttachment attaches a file of the source to a note once. The file is not read, but its size
+// is counted against the maximum read size of the source.
+func addAttachment(source *zipSource, note *Note, p string) error {
+	for _, attachment := range note.Attachments {
+		if attachment.Path == p {
+			return nil
+		}
+	}
+	file, err := source.reserve(p)
+	if errors.Is(err, ErrFileTooLarge) {
+		note.Warnings = append(note.Warnings, fmt.Sprintf("attachment %s exceeds the size limit and is skipped", p))
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	attachment := newAttachment(p, path.Base(p), "", func() []byte {
+		return readZipHead(file)
+	})
+	attachment.Size = int64(file.UncompressedSize64)
+	attachment.source = source
+	note.Attachments = append(note.Attachments, attachment)
+	return nil
+}
+
+// newAttachment returns an attachment whose type is read from the filename, or else detected
+// from the head of its content.
+func newAttachment(p, filename, mimeType string, head func() []byte) *Attachment {
+	if mimeType == "" {
+		mimeType = mime.TypeByExtension(strings.ToLower(path.Ext(filename)))
+	}
+	if mimeType == "" {
+		mimeType = http.DetectContentType(head())
+	}
+	if mediaType, _, err := mime.ParseMediaType(mimeType); err == nil {
+		mimeType = mediaType
+	}
+	return &Attachment{
+		Path:     p,
+		Filename: filename,
+		Type:     mimeType,
+	}
+}
+
+// readZipHead returns the bytes of a file of a ZIP archive that are used to detect its type.
+func readZipHead(file *zip.File) []byte {
+	reader, err := file.Open()
+	if err != nil {
+		return nil
+	}
+	defer reader.Close()
+	// DetectContentType considers at most the first 512 bytes.
+	head, _ := io.ReadAll(io.LimitReader(reader, 512))
+	return head
+}
+
+// appendTags appends the tags missing from the content as a line of hashtags,
+// since the tags of a memo are read from its content.
+func appendTags(content string, tags []string) string {
+	missing := []string{}
+	for _, tag := range tags {
+		tag = strings.Join(strings.Fields(strings.TrimPrefix(tag, "#")), "_")
+		if tag == "" || hasTag(content, tag) || slices.Contains(missing, "#"+tag) {
+			continue
+		}
+		missing = append(missing, "#"+tag)
+	}
+	if len(missing) == 0 {
+		return content
+	}
+	if content == "" {
+		return strings.Join(missing, " ")
+	}
+	return content + "\n\n" + strings.Join(missing, " ")
+}
+
+func hasTag(content, tag string) bool {
+	for _, field := range strings.Fields(content) {
+		if field == "#"+tag || strings.HasPrefix(field, "#"+tag+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// timeLayouts lists the layouts of the times found in import sources.
+var timeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"2006/01/02 15:04",
+	"January 2, 2006 3:04 PM",
+	"January 2, 2006",
+	"20060102T150405Z",
+}
+
+// parseTime parses a time of an import source, in the given location when it has no time zone.
+func parseTime(value string, location *time.Location) (time.Time, bool) {
+	value = strings.TrimSpace(value)
+	for _, layout := range timeLayouts {
+		if t, err := time.ParseInLocation(layout, value, location); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// readFrontMatter splits a Markdown document into its front matter and content.
+// Front matter not written by memos, e.g. with a comma separated tags string or dates
+// without a time zone, is read leniently.
+func readFrontMatter(data []byte, location *time.Location) (*FrontMatter, string, error) {
+	if frontMatter, content, err := UnmarshalMarkdown(data); err == nil {
+		return frontMatter, content, nil
+	}
+
+	header, content := splitFrontMatter(data)
+	fields := map[string]any{}
+	if err := yaml.Unmarshal([]byte(header), &fields); err != nil {
+		return nil, "", errors.Wrap(err, "invalid front matter")
+	}
+	frontMatter := &FrontMatter{}
+	for key, value := range fields {
+		switch strings.ToLower(key) {
+		case "tags", "tag":
+			frontMatter.Tags = append(frontMatter.Tags, readStrings(value)...)
+		case "created", "date", "created_at":
+			frontMatter.Created = readTime(value, location)
+		case "updated", "modified", "updated_at":
+			frontMatter.Updated = readTime(value, location)
+		}
+	}
+	return frontMatter, content, nil
+}
+
+// readStrings reads a list of strings from a YAML list or a comma separated string.
+func readStrings(value any) []string {
+	values := []string{}
+	switch value := value.(type) {
+	case string:
+		for _, item := range strings.Split(value, ",") {
+			values = append(values, strings.Fields(item)...)
+		}
+	case []any:
+		for _, item := range value {
+			if s, ok := item.(string); ok && strings.TrimSpace(s) != "" {
+				values = append(values, strings.TrimSpace(s))
+			}
+		}
+	}
+	return values
+}
+
+func readTime(value any, location *time.Location) time.Time {
+	switch value := value.(type) {
+	case time.Time:
+		return value
+	case string:
+		t, _ := parseTime(value, location)
+		return t
+	}
+	return time.Time{}
+}
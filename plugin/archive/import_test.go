@@ -0,0 +1,344 @@
+package archive
+
+import (
+	"archive/zip"
+	"bytes"
+	"hash/crc32"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// testMaxFileSize is the maximum size of the files read from the test sources.
+const testMaxFileSize = 1 << 20
+
+func newTestZip(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+	for name, content := range files {
+		fileWriter, err := writer.CreateHeader(&zip.FileHeader{
+			Name:     name,
+			Modified: time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC),
+		})
+		require.NoError(t, err)
+		_, err = fileWriter.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+	return buf.Bytes()
+}
+
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		files  map[string]string
+		format Format
+	}{
+		{
+			files:  map[string]string{"memo-1.md": "Hello"},
+			format: FormatMarkdown,
+		},
+		{
+			files:  map[string]string{"vault/.obsidian/app.json": "{}", "vault/Note.md": "Hello"},
+			format: FormatObsidian,
+		},
+		{
+			files:  map[string]string{"flomo/index.html": `<div class="memo"><div class="time">2023-05-01 10:00:00</div></div>`},
+			format: FormatFlomo,
+		},
+		{
+			files:  map[string]string{"Export/Page 0123456789abcdef0123456789abcdef.md": "# Page"},
+			format: FormatNotion,
+		},
+		{
+			files:  map[string]string{"notes.enex": "<en-export></en-export>"},
+			format: FormatEvernote,
+		},
+	}
+	for _, test := range tests {
+		format, err := DetectFormat(newTestZip(t, test.files), testMaxFileSize)
+		require.NoError(t, err)
+		require.Equal(t, test.format, format)
+	}
+
+	format, err := DetectFormat([]byte(`<?xml version="1.0" encoding="UTF-8"?><en-export></en-export>`), testMaxFileSize)
+	require.NoError(t, err)
+	require.Equal(t, FormatEvernote, format)
+	_, err = DetectFormat([]byte("plain text"), testMaxFileSize)
+	require.Error(t, err)
+}
+
+func TestReadMarkdownNotes(t *testing.T) {
+	data := newTestZip(t, map[string]string{
+		"memo-1.md": `---
+uid: memo-1
+visibility: PUBLIC
+tags:
+    - work
+created: 2025-01-10T12:00:00Z
+relations:
+    - type: REFERENCE
+      memo: memo-2
+attachments:
+    - attachments/a/image.png
+    - attachments/b/my report.pdf
+---
+
+Hello #work
+
+![image.png](attachments/a/image.png)
+[my report.pdf](attachments/b/my%20report.pdf)
+`,
+		"attachments/a/image.png":       "png",
+		"attachments/b/my report.pdf":   "pdf",
+		"notes/plain.md":                "No front matter, see [memo](../memo-1.md) and [site](https://usememos.com).",
+		"notes/.hidden/ignored.md":      "Ignored",
+		"attachments/c/unreferenced.md": "",
+	})
+
+	notes, err := ReadNotes(data, FormatMarkdown, time.UTC, testMaxFileSize)
+	require.NoError(t, err)
+	require.Len(t, notes, 3)
+
+	memo := notes[1]
+	require.Equal(t, "memo-1.md", memo.Path)
+	require.Equal(t, "memo-1", memo.FrontMatter.UID)
+	require.Equal(t, time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC), memo.FrontMatter.Created)
+	require.Equal(t, "Hello #work", memo.Content)
+	require.Len(t, memo.Attachments, 2)
+	require.Equal(t, "image.png", memo.Attachments[0].Filename)
+	require.Equal(t, "image/png", memo.Attachments[0].Type)
+	require.Equal(t, int64(3), memo.Attachments[0].Size)
+	blob, err := memo.Attachments[0].ReadBlob()
+	require.NoError(t, err)
+	require.Equal(t, []byte("png"), blob)
+	require.Equal(t, "my report.pdf", memo.Attachments[1].Filename)
+	require.Equal(t, "application/pdf", memo.Attachments[1].Type)
+	require.Empty(t, memo.Warnings)
+
+	plain := notes[2]
+	require.Equal(t, "notes/plain.md", plain.Path)
+	require.Equal(t, "No front matter, see memo and [site](https://usememos.com).", plain.Content)
+	require.Equal(t, []string{"memo-1.md"}, plain.References)
+	require.True(t, time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC).Equal(plain.FrontMatter.Created))
+}
+
+func TestReadObsidianNotes(t *testing.T) {
+	location := time.FixedZone("UTC+8", 8*60*60)
+	data := newTestZip(t, map[string]string{
+		"vault/.obsidian/app.json": "{}",
+		"vault/Daily/Ideas.md": `---
+tags: project, idea
+created: 2023-05-01 10:00
+aliases: [Thoughts]
+---
+Talk to [[People/Alice|Alice]] about [[Plans#Q3]] and [[Missing]].
+
+![[chart.png]]
+See ![[report.pdf]] too.
+
+` + "```\n[[Not a link]]\n```",
+		"vault/People/Alice.md":   "# Alice",
+		"vault/Plans.md":          "Plans #project",
+		"vault/assets/chart.png":  "png",
+		"vault/assets/report.pdf": "pdf",
+		"vault/.trash/Old.md":     "Deleted",
+	})
+
+	notes, err := ReadNotes(data, FormatObsidian, location, testMaxFileSize)
+	require.NoError(t, err)
+	require.Len(t, notes, 3)
+
+	ideas := notes[0]
+	require.Equal(t, "vault/Daily/Ideas.md", ideas.Path)
+	require.Equal(t, time.Date(2023, 5, 1, 10, 0, 0, 0, location), ideas.FrontMatter.Created)
+	require.Equal(t, []string{"project", "idea"}, ideas.FrontMatter.Tags)
+	require.Equal(t, "# Ideas\n\nTalk to Alice about Plans and Missing.\n\nSee  too.\n\n```\n[[Not a link]]\n```\n\n#project #idea", ideas.Content)
+	require.Equal(t, []string{"vault/People/Alice.md", "vault/Plans.md"}, ideas.References)
+	require.Len(t, ideas.Attachments, 2)
+	require.Equal(t, "vault/assets/chart.png", ideas.Attachments[0].Path)
+	require.Equal(t, "vault/assets/report.pdf", ideas.Attachments[1].Path)
+	require.Equal(t, []string{"link to missing file Missing"}, ideas.Warnings)
+
+	require.Equal(t, "# Alice", notes[1].Content)
+	require.Equal(t, "# Plans\n\nPlans #project", notes[2].Content)
+}
+
+func TestReadNotionNotes(t *testing.T) {
+	data := newTestZip(t, map[string]string{
+		"Export/Trip 0123456789abcdef0123456789abcdef.md": `# Trip
+
+Created: May 1, 2023 10:00 AM
+Tags: travel, family
+
+Packing list, see [Gear](Gear%20fedcba9876543210fedcba9876543210.md).
+
+![photo.jpg](Trip%200123456789abcdef0123456789abcdef/photo.jpg)`,
+		"Export/Trip 0123456789abcdef0123456789abcdef/photo.jpg": "jpg",
+		"Export/Gear fedcba9876543210fedcba9876543210.md":        "Note: bring a tent.",
+	})
+
+	notes, err := ReadNotes(data, FormatNotion, time.UTC, testMaxFileSize)
+	require.NoError(t, err)
+	require.Len(t, notes, 2)
+
+	gear := notes[0]
+	require.Equal(t, "# Gear\n\nNote: bring a tent.", gear.Content)
+
+	trip := notes[1]
+	require.Equal(t, time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC), trip.FrontMatter.Created)
+	require.Equal(t, "# Trip\n\nPacking list, see Gear.\n\n#travel #family", trip.Content)
+	require.Equal(t, []string{"Export/Gear fedcba9876543210fedcba9876543210.md"}, trip.References)
+	require.Len(t, trip.Attachments, 1)
+	require.Equal(t, "image/jpeg", trip.Attachments[0].Type)
+}
+
+func TestReadFlomoNotes(t *testing.T) {
+	data := newTestZip(t, map[string]string{
+		"flomo/index.html": `<html><body><div class="memos">
+<div class="memo">
+  <div class="time">2023-05-01 10:00:00</div>
+  <div class="content"><p>Hello <strong>world</strong> #inbox</p><ul><li>one</li><li>two</li></ul></div>
+  <div class="files"><img src="file/2023-05-01/1/image.png" /></div>
+</div>
+<div class="memo">
+  <div class="time">2023-05-02 08:30:00</div>
+  <div class="content"><p>Second</p><ol><li>a</li><li>b</li></ol><p>See <a href="https://flomoapp.com">flomo</a></p></div>
+  <div class="files"></div>
+</div>
+</div></body></html>`,
+		"flomo/file/2023-05-01/1/image.png": "png",
+	})
+
+	notes, err := ReadNotes(data, FormatFlomo, time.UTC, testMaxFileSize)
+	require.NoError(t, err)
+	require.Len(t, notes, 2)
+
+	require.Equal(t, "flomo/index.html#1", notes[0].Path)
+	require.Equal(t, time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC), notes[0].FrontMatter.Created)
+	require.Equal(t, "Hello **world** #inbox\n\n- one\n- two", notes[0].Content)
+	require.Len(t, notes[0].Attachments, 1)
+	require.Equal(t, "image.png", notes[0].Attachments[0].Filename)
+
+	require.Equal(t, "Second\n\n1. a\n2. b\n\nSee [flomo](https://flomoapp.com)", notes[1].Content)
+	require.Empty(t, notes[1].Attachments)
+}
+
+func TestReadEvernoteNotes(t *testing.T) {
+	data := []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE en-export SYSTEM "http://xml.evernote.com/pub/evernote-export3.dtd">
+<en-export export-date="20240101T000000Z" application="Evernote">
+  <note>
+    <title>Groceries</title>
+    <content><![CDATA[<?xml version="1.0" encoding="UTF-8" standalone="no"?>
+<!DOCTYPE en-note SYSTEM "http://xml.evernote.com/pub/enml2.dtd">
+<en-note><div>Buy:</div><div><en-todo checked="true"/>milk</div><div><en-todo/>eggs</div><en-media hash="abc" type="image/png"/></en-note>]]></content>
+    <created>20230501T100000Z</created>
+    <updated>20230502T100000Z</updated>
+    <tag>shopping</tag>
+    <tag>home list</tag>
+    <note-attributes>
+      <latitude>40.7128</latitude>
+      <longitude>-74.006</longitude>
+    </note-attributes>
+    <resource>
+      <data encoding="base64">
+cG5n
+      </data>
+      <mime>image/png</mime>
+      <resource-attributes><file-name>receipt.png</file-name></resource-attributes>
+    </resource>
+  </note>
+</en-export>`)
+
+	notes, err := ReadNotes(data, FormatEvernote, time.Local, testMaxFileSize)
+	require.NoError(t, err)
+	require.Len(t, notes, 1)
+
+	note := notes[0]
+	require.Equal(t, time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC), note.FrontMatter.Created.UTC())
+	require.Equal(t, time.Date(2023, 5, 2, 10, 0, 0, 0, time.UTC), note.FrontMatter.Updated.UTC())
+	require.Equal(t, &Location{Latitude: 40.7128, Longitude: -74.006}, note.FrontMatter.Location)
+	require.Equal(t, "# Groceries\n\nBuy:\n- [x] milk\n- [ ] eggs\n\n#shopping #home_list", note.Content)
+	require.Len(t, note.Attachments, 1)
+	require.Equal(t, "receipt.png", note.Attachments[0].Filename)
+	blob, err := note.Attachments[0].ReadBlob()
+	require.NoError(t, err)
+	require.Equal(t, []byte("png"), blob)
+}
+
+func TestReadNotesFileSizeLimit(t *testing.T) {
+	// A compressed file that is much larger than its archive.
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+	for _, name := range []string{"bomb.md", "memo.md", "large.bin"} {
+		fileWriter, err := writer.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate})
+		require.NoError(t, err)
+		switch name {
+		case "memo.md":
+			_, err = fileWriter.Write([]byte("Memo\n\n![large](large.bin)"))
+		default:
+			_, err = fileWriter.Write(make([]byte, 4*testMaxFileSize))
+		}
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+	require.Less(t, buf.Len(), testMaxFileSize/16)
+
+	_, err := ReadNotes(buf.Bytes(), FormatMarkdown, time.UTC, testMaxFileSize)
+	require.ErrorIs(t, err, ErrFileTooLarge)
+
+	// Attachments that exceed the limit are skipped with a warning.
+	data := newTestZip(t, map[string]string{
+		"memo.md":   "Memo\n\n![large](large.bin)",
+		"large.bin": strings.Repeat("large content ", 8),
+	})
+	notes, err := ReadNotes(data, FormatMarkdown, time.UTC, int64(len("Memo\n\n![large](large.bin)")))
+	require.NoError(t, err)
+	require.Len(t, notes, 1)
+	require.Empty(t, notes[0].Attachments)
+	require.Equal(t, []string{"attachment large.bin exceeds the size limit and is skipped"}, notes[0].Warnings)
+
+	// The size in the header of a file is not trusted.
+	buf.Reset()
+	writer = zip.NewWriter(&buf)
+	fileWriter, err := writer.CreateRaw(&zip.FileHeader{
+		Name:               "lying.md",
+		Method:             zip.Store,
+		CRC32:              crc32.ChecksumIEEE(make([]byte, 64)),
+		CompressedSize64:   64,
+		UncompressedSize64: 1,
+	})
+	require.NoError(t, err)
+	_, err = fileWriter.Write(make([]byte, 64))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+	_, err = ReadNotes(buf.Bytes(), FormatMarkdown, time.UTC, 16)
+	require.Error(t, err)
+}
+
+func TestReadNotesReadSizeLimit(t *testing.T) {
+	files := map[string]string{
+		"a.md":      "![image](image.png)",
+		"b.md":      "![image](image.png)",
+		"image.png": "png",
+	}
+	data := newTestZip(t, files)
+
+	// The attachments are counted once per note before they are read.
+	source, err := openZip(data, testMaxFileSize)
+	require.NoError(t, err)
+	notes, err := readMarkdownNotes(source, FormatMarkdown, time.UTC)
+	require.NoError(t, err)
+	require.Len(t, notes, 2)
+	readSize := int64(len(files["a.md"]) + len(files["b.md"]) + 2*len(files["image.png"]))
+	require.Equal(t, readSize, source.readSize)
+
+	source, err = openZip(data, testMaxFileSize)
+	require.NoError(t, err)
+	source.readSize = maxZipReadSize - readSize + 1
+	_, err = readMarkdownNotes(source, FormatMarkdown, time.UTC)
+	require.ErrorIs(t, err, ErrSourceTooLarge)
+}
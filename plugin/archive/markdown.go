@@ -0,0 +1,97 @@
+package archive
+
+import (
+	"bytes"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+const frontMatterDelimiter = "---"
+
+// FrontMatter is the YAML front matter of an exported memo.
+type FrontMatter struct {
+	UID        string    `yaml:"uid,omitempty"`
+	Visibility string    `yaml:"visibility,omitempty"`
+	State      string    `yaml:"state,omitempty"`
+	Pinned     bool      `yaml:"pinned,omitempty"`
+	Tags       []string  `yaml:"tags,omitempty"`
+	Created    time.Time `yaml:"created,omitempty"`
+	Updated    time.Time `yaml:"updated,omitempty"`
+	Location   *Location `yaml:"location,omitempty"`
+	// Relations lists the memos related to the memo.
+	Relations []*Relation `yaml:"relations,omitempty"`
+	// Attachments lists the paths of the attachment files relative to the archive root.
+	Attachments []string `yaml:"attachments,omitempty"`
+}
+
+type Location struct {
+	Placeholder string  `yaml:"placeholder,omitempty"`
+	Latitude    float64 `yaml:"latitude"`
+	Longitude   float64 `yaml:"longitude"`
+}
+
+type Relation struct {
+	// Type is the relation type, e.g. REFERENCE or COMMENT.
+	Type string `yaml:"type"`
+	// Memo is the uid of the related memo.
+	Memo string `yaml:"memo"`
+}
+
+// MarshalMarkdown returns the Markdown document of a memo with its front matter.
+func MarshalMarkdown(frontMatter *FrontMatter, content string) ([]byte, error) {
+	data, err := yaml.Marshal(frontMatter)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal front matter")
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(frontMatterDelimiter + "\n")
+	buf.Write(data)
+	buf.WriteString(frontMatterDelimiter + "\n\n")
+	buf.WriteString(content)
+	if !strings.HasSuffix(content, "\n") {
+		buf.WriteString("\n")
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalMarkdown splits a Markdown document into its front matter and content.
+// Documents without front matter return an empty front matter and the whole document as content.
+func UnmarshalMarkdown(data []byte) (*FrontMatter, string, error) {
+	header, content := splitFrontMatter(data)
+	frontMatter := &FrontMatter{}
+	if err := yaml.Unmarshal([]byte(header), frontMatter); err != nil {
+		return nil, "", errors.Wrap(err, "invalid front matter")
+	}
+	return frontMatter, content, nil
+}
+
+// splitFrontMatter splits a Markdown document into its YAML front matter and content.
+func splitFrontMatter(data []byte) (string, string) {
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+	text = strings.TrimPrefix(text, "\ufeff")
+	if !strings.HasPrefix(text, frontMatterDelimiter+"\n") {
+		return "", text
+	}
+
+	rest := text[len(frontMatterDelimiter)+1:]
+	var header, content string
+	if strings.HasPrefix(rest, frontMatterDelimiter+"\n") || rest == frontMatterDelimiter {
+		header, content = "", strings.TrimPrefix(rest, frontMatterDelimiter)
+	} else {
+		end := strings.Index(rest, "\n"+frontMatterDelimiter+"\n")
+		if end < 0 {
+			if !strings.HasSuffix(rest, "\n"+frontMatterDelimiter) {
+				return "", text
+			}
+			end = len(rest) - len(frontMatterDelimiter) - 1
+		}
+		header = rest[:end]
+		content = rest[min(end+len(frontMatterDelimiter)+2, len(rest)):]
+	}
+	content = strings.TrimPrefix(content, "\n")
+	return header, strings.TrimSuffix(content, "\n")
+}
@@ -0,0 +1,71 @@
+package archive
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarkdownRoundTrip(t *testing.T) {
+	frontMatter := &FrontMatter{
+		UID:        "memo-1",
+		Visibility: "PUBLIC",
+		State:      "NORMAL",
+		Pinned:     true,
+		Tags:       []string{"work", "project"},
+		Created:    time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC),
+		Updated:    time.Date(2025, 1, 11, 8, 30, 0, 0, time.UTC),
+		Location:   &Location{Placeholder: "New York", Latitude: 40.7128, Longitude: -74.006},
+		Relations:  []*Relation{{Type: "REFERENCE", Memo: "memo-2"}},
+		Attachments: []string{
+			"attachments/a/image.png",
+		},
+	}
+	content := "# Title\n\n---\n\nSome text after a rule."
+	data, err := MarshalMarkdown(frontMatter, content)
+	require.NoError(t, err)
+
+	parsed, parsedContent, err := UnmarshalMarkdown(data)
+	require.NoError(t, err)
+	require.Equal(t, frontMatter, parsed)
+	require.Equal(t, content, parsedContent)
+}
+
+func TestUnmarshalMarkdown(t *testing.T) {
+	tests := []struct {
+		data        string
+		frontMatter *FrontMatter
+		content     string
+	}{
+		{
+			data:        "Just content",
+			frontMatter: &FrontMatter{},
+			content:     "Just content",
+		},
+		{
+			data:        "---\r\nuid: abc\r\ntags: [a]\r\n---\r\nHello\r\n",
+			frontMatter: &FrontMatter{UID: "abc", Tags: []string{"a"}},
+			content:     "Hello",
+		},
+		{
+			data:        "---\n---\nHello",
+			frontMatter: &FrontMatter{},
+			content:     "Hello",
+		},
+		{
+			data:        "---\nnot closed",
+			frontMatter: &FrontMatter{},
+			content:     "---\nnot closed",
+		},
+	}
+	for _, test := range tests {
+		frontMatter, content, err := UnmarshalMarkdown([]byte(test.data))
+		require.NoError(t, err, test.data)
+		require.Equal(t, test.frontMatter, frontMatter, test.data)
+		require.Equal(t, test.content, content, test.data)
+	}
+
+	_, _, err := UnmarshalMarkdown([]byte("---\nuid: [\n---\n"))
+	require.Error(t, err)
+}
@@ -0,0 +1,53 @@
+package archive
+
+import (
+	"path"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// notionPropertyRegex matches a property line of a Notion page, e.g. "Created: May 1, 2023 10:00 AM".
+var notionPropertyRegex = regexp.MustCompile(`^([A-Z][\w ]*): (.+)$`)
+
+// readNotionProperties reads the properties listed under the title of a Notion page
+// into the front matter of the note and removes them from the content.
+func readNotionProperties(note *Note, location *time.Location) {
+	if !strings.HasPrefix(note.Content, "# ") {
+		if matches := notionFilenameRegex.FindStringSubmatch(path.Base(note.Path)); matches != nil {
+			note.Content = strings.TrimSpace("# " + matches[1] + "\n\n" + note.Content)
+		}
+	}
+
+	lines := strings.Split(note.Content, "\n")
+	start := 1
+	for start < len(lines) && strings.TrimSpace(lines[start]) == "" {
+		start++
+	}
+	end := start
+	known := false
+	for ; end < len(lines) && strings.TrimSpace(lines[end]) != ""; end++ {
+		matches := notionPropertyRegex.FindStringSubmatch(lines[end])
+		if matches == nil {
+			return
+		}
+		switch strings.ToLower(matches[1]) {
+		case "created", "created time":
+			if t, ok := parseTime(matches[2], location); ok {
+				note.FrontMatter.Created, known = t, true
+			}
+		case "last edited time", "updated":
+			if t, ok := parseTime(matches[2], location); ok {
+				note.FrontMatter.Updated, known = t, true
+			}
+		case "tags":
+			note.FrontMatter.Tags, known = append(note.FrontMatter.Tags, readStrings(matches[2])...), true
+		}
+	}
+	if !known {
+		return
+	}
+	title := strings.TrimSpace(strings.Join(lines[:start], "\n"))
+	body := strings.TrimSpace(strings.Join(lines[end:], "\n"))
+	note.Content = strings.TrimSpace(title + "\n\n" + body)
+}
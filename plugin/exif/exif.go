@@ -0,0 +1,515 @@
+// Package exif reads the metadata of JPEG and PNG images while they are streamed, and strips the
+// metadata that may leak private information, like the GPS location of photos.
+package exif
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"io"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Strip is the metadata that is removed from images.
+type Strip int
+
+const (
+	// StripNone keeps all metadata.
+	StripNone Strip = iota
+	// StripGPS removes the GPS location from the EXIF metadata, and the XMP metadata that may repeat it.
+	StripGPS
+	// StripAll removes the EXIF, XMP and IPTC metadata and the comments, but keeps the orientation.
+	StripAll
+)
+
+// maxHeaderSize is the maximum size of the metadata before the image data, and of a metadata
+// chunk. Images with more metadata are passed through unchanged when nothing is stripped, and
+// rejected otherwise.
+const maxHeaderSize = 4 << 20
+
+var (
+	// ErrUnsupported is returned for images whose metadata cannot be stripped, like images of other
+	// formats than JPEG, PNG and WebP, or malformed images.
+	ErrUnsupported = errors.New("image metadata cannot be stripped")
+	// errMalformed is returned for images whose metadata cannot be parsed.
+	errMalformed = errors.New("malformed image")
+)
+
+// metadataFreeTypes are the image types without EXIF or XMP metadata, which are kept unchanged
+// while stripping.
+var metadataFreeTypes = []string{"image/gif", "image/bmp", "image/x-icon", "image/vnd.microsoft.icon", "image/svg+xml"}
+
+// isoImageBrands are the brands of the ISO base media file format of HEIF and AVIF images.
+var isoImageBrands = []string{"heic", "heix", "heim", "heis", "hevc", "hevx", "mif1", "msf1", "avif", "avis"}
+
+// Location is a GPS location.
+type Location struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// Metadata is the metadata of an image.
+type Metadata struct {
+	// Width and Height are the dimensions of the image as displayed, after the orientation is applied.
+	Width  int
+	Height int
+	// Orientation is the EXIF orientation, from 1 to 8, or 0 if unknown.
+	Orientation int
+	// CaptureTime is the time the photo was taken, or zero if unknown.
+	CaptureTime time.Time
+	CameraMake  string
+	CameraModel string
+	// Location is the GPS location of the photo, or nil if unknown.
+	Location *Location
+}
+
+// Process reads the metadata at the start of a JPEG, PNG or WebP image, and returns the image with
+// the metadata stripped. The format is detected from the content, since the MIME type is chosen by
+// the uploader. The metadata that follows the image data, like the EXIF metadata of WebP images,
+// is stripped while the content is streamed, so the metadata is only complete once the returned
+// reader is read to the end. Capture times without offset are in the location.
+//
+// When nothing is stripped, other content and images whose metadata cannot be parsed are returned
+// unchanged with nil metadata. Otherwise, images that cannot be stripped fail with ErrUnsupported,
+// either here or while the returned reader is read.
+func Process(r io.Reader, mimeType string, strip Strip, location *time.Location) (io.Reader, *Metadata, error) {
+	if location == nil {
+		location = time.UTC
+	}
+	signature := make([]byte, 12)
+	n, err := io.ReadFull(r, signature)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return nil, nil, err
+	}
+	signature = signature[:n]
+	r = io.MultiReader(bytes.NewReader(signature), r)
+
+	var process func(io.Reader, Strip, *time.Location) ([]byte, io.Reader, *Metadata, error)
+	switch {
+	case bytes.HasPrefix(signature, []byte{0xFF, 0xD8, 0xFF}):
+		process = processJPEG
+	case bytes.HasPrefix(signature, pngSignature):
+		process = processPNG
+	case len(signature) == 12 && bytes.HasPrefix(signature, riffSignature) && bytes.Equal(signature[8:], webpSignature):
+		process = processWebP
+	default:
+		if strip != StripNone && mayHoldMetadata(mimeType, signature) {
+			return nil, nil, errors.Wrapf(ErrUnsupported, "unsupported image type %s", mimeType)
+		}
+		return r, nil, nil
+	}
+
+	// The consumed bytes are replayed when the image is malformed.
+	replay := &replayReader{r: r, recorded: &bytes.Buffer{}}
+	header, rest, metadata, err := process(replay, strip, location)
+	if err != nil {
+		if errors.Is(err, errMalformed) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			if strip != StripNone {
+				return nil, nil, errors.Wrap(ErrUnsupported, err.Error())
+			}
+			return io.MultiReader(replay.recorded, r), nil, nil
+		}
+		return nil, nil, err
+	}
+	replay.recorded = nil
+	return io.MultiReader(bytes.NewReader(header), rest), metadata, nil
+}
+
+// mayHoldMetadata reports whether content that is not a JPEG, PNG or WebP image may hold metadata
+// that cannot be stripped, like TIFF, HEIF and AVIF images.
+func mayHoldMetadata(mimeType string, signature []byte) bool {
+	mediaType, _, _ := strings.Cut(strings.ToLower(mimeType), ";")
+	mediaType = strings.TrimSpace(mediaType)
+	if strings.HasPrefix(mediaType, "image/") {
+		return !slices.Contains(metadataFreeTypes, mediaType)
+	}
+	if bytes.HasPrefix(signature, []byte("II*\x00")) || bytes.HasPrefix(signature, []byte("MM\x00*")) {
+		return true
+	}
+	return len(signature) == 12 && string(signature[4:8]) == "ftyp" && slices.Contains(isoImageBrands, string(signature[8:12]))
+}
+
+// replayReader records the bytes read from a reader until the recording is stopped.
+type replayReader struct {
+	r        io.Reader
+	recorded *bytes.Buffer
+}
+
+func (r *replayReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	if r.recorded != nil {
+		r.recorded.Write(p[:n])
+	}
+	return n, err
+}
+
+// headerReader reads the header of an image up to the maximum header size.
+type headerReader struct {
+	r    io.Reader
+	read int
+}
+
+func (h *headerReader) next(n int) ([]byte, error) {
+	h.read += n
+	if h.read > maxHeaderSize {
+		return nil, errors.Wrap(errMalformed, "header too large")
+	}
+	data := make([]byte, n)
+	if _, err := io.ReadFull(h.r, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// chunkReader streams the chunks of a PNG or WebP image, whose metadata may follow the image data.
+// The next function of the format reads a chunk, and sets the bytes to return and the number of
+// bytes of the chunk to copy.
+type chunkReader struct {
+	r     io.Reader
+	strip Strip
+	next  func() error
+	// out is the bytes to return before the bytes to copy.
+	out []byte
+	// copying is the number of bytes to copy unchanged, or to clear if clearing is set.
+	copying  int64
+	clearing bool
+	// consumed is the bytes of the current chunk read by the next function, which are returned
+	// unchanged when the chunk is malformed and nothing is stripped.
+	consumed []byte
+	// ended is set at the end of the image.
+	ended bool
+	err   error
+}
+
+// read reads bytes of the current chunk.
+func (c *chunkReader) read(n int64) ([]byte, error) {
+	if n < 0 || n > maxHeaderSize {
+		return nil, errors.Wrap(errMalformed, "chunk too large")
+	}
+	data := make([]byte, n)
+	read, err := io.ReadFull(c.r, data)
+	c.consumed = append(c.consumed, data[:read]...)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// readHeader reads the chunks up to the image data, and returns them.
+func (c *chunkReader) readHeader(imageData func() bool) ([]byte, error) {
+	header := []byte{}
+	for !imageData() {
+		if c.ended || len(header) > maxHeaderSize {
+			return nil, errors.Wrap(errMalformed, "missing image data")
+		}
+		c.clearing = false
+		if err := c.next(); err != nil {
+			return nil, err
+		}
+		header = append(header, c.out...)
+		c.out = nil
+		if !imageData() && c.copying > 0 {
+			data, err := c.read(c.copying)
+			if err != nil {
+				return nil, err
+			}
+			if c.clearing {
+				clear(data)
+			}
+			header = append(header, data...)
+			c.copying = 0
+		}
+	}
+	return header, nil
+}
+
+func (c *chunkReader) Read(p []byte) (int, error) {
+	for len(c.out) == 0 && c.copying == 0 {
+		if c.err != nil {
+			return 0, c.err
+		}
+		if c.ended {
+			// Data after the end of the image is dropped while stripping, since it may hold anything.
+			if c.strip == StripNone {
+				c.copying = math.MaxInt64
+			} else {
+				c.err = io.EOF
+			}
+			continue
+		}
+		c.consumed = c.consumed[:0]
+		c.clearing = false
+		if err := c.next(); err != nil {
+			c.fail(err)
+		}
+	}
+	if len(c.out) > 0 {
+		n := copy(p, c.out)
+		c.out = c.out[n:]
+		return n, nil
+	}
+	n, err := c.r.Read(p[:min(int64(len(p)), c.copying)])
+	c.copying -= int64(n)
+	if c.clearing {
+		clear(p[:n])
+	}
+	if err != nil {
+		c.copying, c.err = 0, err
+	}
+	return n, err
+}
+
+// fail ends the stream on an error of the next function. When nothing is stripped, the rest of
+// malformed images is returned unchanged.
+func (c *chunkReader) fail(err error) {
+	malformed := errors.Is(err, errMalformed) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+	switch {
+	case err == io.EOF && len(c.consumed) == 0:
+		// The image ends between two chunks.
+		c.err = io.EOF
+	case malformed && c.strip == StripNone:
+		c.out = append([]byte{}, c.consumed...)
+		c.copying = math.MaxInt64
+	case malformed:
+		c.err = errors.Wrap(ErrUnsupported, err.Error())
+	default:
+		c.err = err
+	}
+}
+
+var exifPrefix = []byte("Exif\x00\x00")
+
+// processJPEG reads the segments of a JPEG image up to the start of the scan, which begins the
+// image data, and returns them with the metadata stripped.
+func processJPEG(r io.Reader, strip Strip, location *time.Location) ([]byte, io.Reader, *Metadata, error) {
+	h := &headerReader{r: r}
+	soi, err := h.next(2)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if soi[0] != 0xFF || soi[1] != 0xD8 {
+		return nil, nil, nil, errors.Wrap(errMalformed, "missing start of image")
+	}
+
+	metadata := &Metadata{}
+	segments := [][]byte{}
+	exifIndex := 0
+	parsedExif := false
+	for {
+		marker, err := h.next(2)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if marker[0] != 0xFF {
+			return nil, nil, nil, errors.Wrap(errMalformed, "invalid marker")
+		}
+		// Markers may be preceded by fill bytes.
+		for marker[1] == 0xFF {
+			fill, err := h.next(1)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			marker[1] = fill[0]
+		}
+		switch {
+		case marker[1] == 0xDA:
+			// The start of scan is followed by the image data, which is streamed unchanged.
+			segments = append(segments, []byte{0xFF, 0xDA})
+			if strip == StripAll && metadata.Orientation > 1 {
+				segments = insertSegment(segments, exifIndex, newJPEGSegment(0xE1, append(append([]byte{}, exifPrefix...), newOrientationTIFF(metadata.Orientation)...)))
+			}
+			applyOrientation(metadata)
+			header := append([]byte{0xFF, 0xD8}, bytes.Join(segments, nil)...)
+			return header, r, metadata, nil
+		case marker[1] == 0x01 || (marker[1] >= 0xD0 && marker[1] <= 0xD7):
+			segments = append(segments, []byte{0xFF, marker[1]})
+			continue
+		case marker[1] == 0xD8 || marker[1] == 0xD9:
+			return nil, nil, nil, errors.Wrap(errMalformed, "unexpected marker")
+		}
+
+		length, err := h.next(2)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		size := int(binary.BigEndian.Uint16(length))
+		if size < 2 {
+			return nil, nil, nil, errors.Wrap(errMalformed, "invalid segment length")
+		}
+		data, err := h.next(size - 2)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		keep := true
+		switch {
+		case marker[1] >= 0xC0 && marker[1] <= 0xCF && marker[1] != 0xC4 && marker[1] != 0xC8 && marker[1] != 0xCC:
+			// The frame header holds the precision, the height and the width.
+			if len(data) >= 5 {
+				metadata.Height = int(binary.BigEndian.Uint16(data[1:3]))
+				metadata.Width = int(binary.BigEndian.Uint16(data[3:5]))
+			}
+		case marker[1] == 0xE0:
+			// The EXIF metadata follows the JFIF header.
+			exifIndex = len(segments) + 1
+		case marker[1] == 0xE1 && bytes.HasPrefix(data, exifPrefix) && !parsedExif:
+			parsedExif = true
+			tiff := data[len(exifPrefix):]
+			readTIFFMetadata(tiff, metadata, location)
+			switch strip {
+			case StripGPS:
+				blankGPS(tiff)
+			case StripAll:
+				keep = false
+			default:
+			}
+		case marker[1] == 0xE1:
+			// Other APP1 segments hold XMP metadata.
+			keep = strip == StripNone
+		case marker[1] == 0xED || marker[1] == 0xFE:
+			// APP13 segments hold IPTC metadata.
+			keep = strip != StripAll
+		default:
+		}
+		if keep {
+			segments = append(segments, newJPEGSegment(marker[1], data))
+		}
+	}
+}
+
+func newJPEGSegment(marker byte, data []byte) []byte {
+	segment := []byte{0xFF, marker, 0, 0}
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(data)+2))
+	return append(segment, data...)
+}
+
+func insertSegment(segments [][]byte, index int, segment []byte) [][]byte {
+	index = min(index, len(segments))
+	return append(segments[:index], append([][]byte{segment}, segments[index:]...)...)
+}
+
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+// pngReader strips the metadata of the chunks of a PNG image.
+type pngReader struct {
+	chunkReader
+	location *time.Location
+	metadata *Metadata
+	// imageData is set once the first image data chunk is read.
+	imageData bool
+}
+
+// processPNG reads the chunks of a PNG image up to the first image data chunk, and returns them
+// with the metadata stripped, followed by a reader of the rest of the chunks.
+func processPNG(r io.Reader, strip Strip, location *time.Location) ([]byte, io.Reader, *Metadata, error) {
+	signature := make([]byte, len(pngSignature))
+	if _, err := io.ReadFull(r, signature); err != nil {
+		return nil, nil, nil, err
+	}
+	if !bytes.Equal(signature, pngSignature) {
+		return nil, nil, nil, errors.Wrap(errMalformed, "missing signature")
+	}
+	p := &pngReader{
+		chunkReader: chunkReader{r: r, strip: strip},
+		location:    location,
+		metadata:    &Metadata{},
+	}
+	p.next = p.nextChunk
+	header, err := p.readHeader(func() bool { return p.imageData })
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return append(signature, header...), p, p.metadata, nil
+}
+
+func (p *pngReader) nextChunk() error {
+	chunkHeader, err := p.read(8)
+	if err != nil {
+		return err
+	}
+	length := int64(binary.BigEndian.Uint32(chunkHeader[:4]))
+	chunkType := string(chunkHeader[4:8])
+	switch chunkType {
+	case "IHDR", "eXIf", "tEXt", "zTXt", "iTXt":
+		data, err := p.read(length + 4)
+		if err != nil {
+			return err
+		}
+		orientation := p.metadata.Orientation
+		data = data[:length]
+		keep := true
+		switch chunkType {
+		case "IHDR":
+			if len(data) >= 8 {
+				p.metadata.Width = int(binary.BigEndian.Uint32(data[0:4]))
+				p.metadata.Height = int(binary.BigEndian.Uint32(data[4:8]))
+			}
+		case "eXIf":
+			readTIFFMetadata(data, p.metadata, p.location)
+			switch p.strip {
+			case StripGPS:
+				blankGPS(data)
+			case StripAll:
+				keep = false
+			default:
+			}
+		default:
+			// Text chunks may hold XMP metadata, or EXIF metadata as raw profiles.
+			keyword, _, _ := bytes.Cut(data, []byte{0})
+			private := strings.HasPrefix(string(keyword), "XML:com.adobe.xmp") || strings.HasPrefix(string(keyword), "Raw profile type")
+			keep = p.strip == StripNone || (p.strip == StripGPS && !private)
+		}
+		if keep {
+			p.out = newPNGChunk(chunkType, data)
+		}
+		// The orientation of metadata that follows the image data is applied once it is read.
+		if p.imageData && orientation == 0 && p.metadata.Orientation > 1 {
+			if p.strip == StripAll {
+				p.out = newPNGChunk("eXIf", newOrientationTIFF(p.metadata.Orientation))
+			}
+			applyOrientation(p.metadata)
+		}
+		return nil
+	case "IDAT":
+		if !p.imageData {
+			p.imageData = true
+			if p.strip == StripAll && p.metadata.Orientation > 1 {
+				p.out = newPNGChunk("eXIf", newOrientationTIFF(p.metadata.Orientation))
+			}
+			applyOrientation(p.metadata)
+		}
+	case "IEND":
+		p.ended = true
+	default:
+	}
+	// The image data and the other chunks are copied unchanged.
+	p.out = append(p.out, chunkHeader...)
+	p.copying = length + 4
+	return nil
+}
+
+func newPNGChunk(chunkType string, data []byte) []byte {
+	chunk := binary.BigEndian.AppendUint32(nil, uint32(len(data)))
+	chunk = append(chunk, chunkType...)
+	chunk = append(chunk, data...)
+	return append(chunk, newPNGChecksum(chunkType, data)...)
+}
+
+func newPNGChecksum(chunkType string, data []byte) []byte {
+	checksum := crc32.NewIEEE()
+	checksum.Write([]byte(chunkType))
+	checksum.Write(data)
+	return binary.BigEndian.AppendUint32(nil, checksum.Sum32())
+}
+
+// applyOrientation swaps the dimensions of images that are displayed rotated by 90 degrees.
+func applyOrientation(metadata *Metadata) {
+	if metadata.Orientation >= 5 && metadata.Orientation <= 8 {
+		metadata.Width, metadata.Height = metadata.Height, metadata.Width
+	}
+}
@@ -0,0 +1,318 @@
+package exif
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/HugoSmits86/nativewebp"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/image/webp"
+)
+
+type testEntry struct {
+	tag   uint16
+	typ   uint16
+	count uint32
+	value []byte
+}
+
+// buildTIFF returns big-endian EXIF metadata of the directories. The EXIF and GPS pointers of the
+// first directory point to the second and third directories.
+func buildTIFF(ifds ...[]testEntry) []byte {
+	offsets := []uint32{8}
+	for _, ifd := range ifds {
+		size := 2 + 12*len(ifd) + 4
+		for _, entry := range ifd {
+			if len(entry.value) > 4 {
+				size += len(entry.value)
+			}
+		}
+		offsets = append(offsets, offsets[len(offsets)-1]+uint32(size))
+	}
+	data := []byte("MM\x00\x2a\x00\x00\x00\x08")
+	for i, ifd := range ifds {
+		external := []byte{}
+		externalOffset := offsets[i] + uint32(2+12*len(ifd)+4)
+		data = binary.BigEndian.AppendUint16(data, uint16(len(ifd)))
+		for _, entry := range ifd {
+			value := entry.value
+			switch entry.tag {
+			case tagExifIFD:
+				value = binary.BigEndian.AppendUint32(nil, offsets[1])
+			case tagGPSIFD:
+				value = binary.BigEndian.AppendUint32(nil, offsets[2])
+			default:
+			}
+			data = binary.BigEndian.AppendUint16(data, entry.tag)
+			data = binary.BigEndian.AppendUint16(data, entry.typ)
+			data = binary.BigEndian.AppendUint32(data, entry.count)
+			if len(value) > 4 {
+				data = binary.BigEndian.AppendUint32(data, externalOffset+uint32(len(external)))
+				external = append(external, value...)
+			} else {
+				data = append(data, append(value, make([]byte, 4-len(value))...)...)
+			}
+		}
+		data = binary.BigEndian.AppendUint32(data, 0)
+		data = append(data, external...)
+	}
+	return data
+}
+
+func ascii(value string) testEntry {
+	return testEntry{typ: 2, count: uint32(len(value) + 1), value: append([]byte(value), 0)}
+}
+
+func rationals(values ...uint32) testEntry {
+	data := []byte{}
+	for _, value := range values {
+		data = binary.BigEndian.AppendUint32(data, value)
+		data = binary.BigEndian.AppendUint32(data, 1)
+	}
+	return testEntry{typ: 5, count: uint32(len(values)), value: data}
+}
+
+func withTag(tag uint16, entry testEntry) testEntry {
+	entry.tag = tag
+	return entry
+}
+
+func newTestEXIF() []byte {
+	return buildTIFF(
+		[]testEntry{
+			withTag(tagMake, ascii("Apple")),
+			withTag(tagModel, ascii("iPhone 15")),
+			{tag: tagOrientation, typ: 3, count: 1, value: []byte{0, 6}},
+			{tag: tagExifIFD, typ: 4, count: 1},
+			{tag: tagGPSIFD, typ: 4, count: 1},
+		},
+		[]testEntry{
+			withTag(tagDateTimeOriginal, ascii("2024:05:01 12:30:00")),
+			withTag(tagOffsetTimeOriginal, ascii("+08:00")),
+		},
+		[]testEntry{
+			withTag(tagGPSLatitudeRef, ascii("N")),
+			withTag(tagGPSLatitude, rationals(31, 12, 36)),
+			withTag(tagGPSLongitudeRef, ascii("W")),
+			withTag(tagGPSLongitude, rationals(121, 30, 0)),
+		},
+	)
+}
+
+func newTestJPEG(t *testing.T) []byte {
+	buffer := &bytes.Buffer{}
+	require.NoError(t, jpeg.Encode(buffer, image.NewGray(image.Rect(0, 0, 40, 20)), nil))
+	data := buffer.Bytes()
+	xmp := append([]byte("http://ns.adobe.com/xap/1.0/\x00"), []byte("<exif:GPSLatitude>31,12.6N</exif:GPSLatitude>")...)
+	segments := append(newJPEGSegment(0xE1, append(append([]byte{}, exifPrefix...), newTestEXIF()...)), newJPEGSegment(0xE1, xmp)...)
+	return append(append(append([]byte{}, data[:2]...), segments...), data[2:]...)
+}
+
+func newTestPNG(t *testing.T) []byte {
+	buffer := &bytes.Buffer{}
+	require.NoError(t, png.Encode(buffer, image.NewGray(image.Rect(0, 0, 40, 20))))
+	data := buffer.Bytes()
+	// The eXIf chunk follows the IHDR chunk of 25 bytes after the signature.
+	position := len(pngSignature) + 25
+	return append(append(append([]byte{}, data[:position]...), newPNGChunk("eXIf", newTestEXIF())...), data[position:]...)
+}
+
+func newTestWebP(t *testing.T) []byte {
+	buffer := &bytes.Buffer{}
+	require.NoError(t, nativewebp.Encode(buffer, image.NewGray(image.Rect(0, 0, 40, 20)), nil))
+	// The extended format header with the EXIF and XMP flags and the canvas size minus one.
+	header := []byte{0x08 | webpFlagXMP, 0, 0, 0, 39, 0, 0, 19, 0, 0}
+	xmp := []byte("<x:xmpmeta><exif:GPSLatitude>31,12.6N</exif:GPSLatitude></x:xmpmeta>")
+	chunks := append(newWebPChunk("VP8X", header), buffer.Bytes()[12:]...)
+	chunks = append(chunks, newWebPChunk("EXIF", newTestEXIF())...)
+	chunks = append(chunks, newWebPChunk("XMP ", xmp)...)
+	data := binary.LittleEndian.AppendUint32(append([]byte{}, riffSignature...), uint32(len(chunks)+4))
+	return append(append(data, webpSignature...), chunks...)
+}
+
+func newWebPChunk(fourCC string, data []byte) []byte {
+	chunk := binary.LittleEndian.AppendUint32([]byte(fourCC), uint32(len(data)))
+	chunk = append(chunk, data...)
+	if len(data)%2 == 1 {
+		chunk = append(chunk, 0)
+	}
+	return chunk
+}
+
+func process(t *testing.T, data []byte, mimeType string, strip Strip) ([]byte, *Metadata) {
+	reader, metadata, err := Process(bytes.NewReader(data), mimeType, strip, time.UTC)
+	require.NoError(t, err)
+	processed, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	return processed, metadata
+}
+
+// processError returns the error of processing content, either from Process or while reading.
+func processError(data []byte, mimeType string, strip Strip) error {
+	reader, _, err := Process(bytes.NewReader(data), mimeType, strip, time.UTC)
+	if err != nil {
+		return err
+	}
+	_, err = io.ReadAll(reader)
+	return err
+}
+
+func TestProcess(t *testing.T) {
+	tests := []struct {
+		name     string
+		mimeType string
+		data     []byte
+		decode   func(io.Reader) (image.Image, error)
+	}{
+		{name: "JPEG", mimeType: "image/jpeg", data: newTestJPEG(t), decode: jpeg.Decode},
+		{name: "PNG", mimeType: "image/png", data: newTestPNG(t), decode: png.Decode},
+		{name: "WebP", mimeType: "image/webp", data: newTestWebP(t), decode: webp.Decode},
+		// The format is detected from the content, whatever the MIME type chosen by the uploader.
+		{name: "JPEG as octet stream", mimeType: "application/octet-stream", data: newTestJPEG(t), decode: jpeg.Decode},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			processed, metadata := process(t, test.data, test.mimeType, StripNone)
+			require.Equal(t, test.data, processed)
+			// The image is displayed rotated by 90 degrees.
+			require.Equal(t, 20, metadata.Width)
+			require.Equal(t, 40, metadata.Height)
+			require.Equal(t, 6, metadata.Orientation)
+			require.Equal(t, "Apple", metadata.CameraMake)
+			require.Equal(t, "iPhone 15", metadata.CameraModel)
+			require.True(t, metadata.CaptureTime.Equal(time.Date(2024, 5, 1, 4, 30, 0, 0, time.UTC)))
+			require.NotNil(t, metadata.Location)
+			require.InDelta(t, 31.21, metadata.Location.Latitude, 0.0001)
+			require.InDelta(t, -121.5, metadata.Location.Longitude, 0.0001)
+
+			processed, _ = process(t, test.data, test.mimeType, StripGPS)
+			_, err := test.decode(bytes.NewReader(processed))
+			require.NoError(t, err)
+			require.NotContains(t, string(processed), "GPSLatitude")
+			_, metadata = process(t, processed, test.mimeType, StripNone)
+			require.Nil(t, metadata.Location)
+			require.Equal(t, "Apple", metadata.CameraMake)
+			require.False(t, metadata.CaptureTime.IsZero())
+
+			processed, _ = process(t, test.data, test.mimeType, StripAll)
+			_, err = test.decode(bytes.NewReader(processed))
+			require.NoError(t, err)
+			_, metadata = process(t, processed, test.mimeType, StripNone)
+			require.Nil(t, metadata.Location)
+			require.Empty(t, metadata.CameraMake)
+			require.True(t, metadata.CaptureTime.IsZero())
+			// The orientation is kept, so that the image is still displayed upright.
+			require.Equal(t, 6, metadata.Orientation)
+		})
+	}
+}
+
+func TestProcessUnchanged(t *testing.T) {
+	for _, test := range []struct {
+		mimeType string
+		data     []byte
+	}{
+		{mimeType: "image/jpeg", data: []byte("not a jpeg")},
+		{mimeType: "image/png", data: []byte("\x89PNG\r\n\x1a\n\x00\x00")},
+		{mimeType: "image/heic", data: []byte("\x00\x00\x00\x18ftypheic\x00\x00\x00\x00")},
+		{mimeType: "text/plain", data: []byte("text")},
+	} {
+		processed, metadata := process(t, test.data, test.mimeType, StripNone)
+		require.Equal(t, test.data, processed, test.mimeType)
+		require.Nil(t, metadata, test.mimeType)
+	}
+
+	// Content without image metadata is kept while stripping.
+	for _, test := range []struct {
+		mimeType string
+		data     []byte
+	}{
+		{mimeType: "text/plain", data: []byte("text")},
+		{mimeType: "image/gif", data: []byte("GIF89a")},
+	} {
+		processed, metadata := process(t, test.data, test.mimeType, StripAll)
+		require.Equal(t, test.data, processed, test.mimeType)
+		require.Nil(t, metadata, test.mimeType)
+	}
+}
+
+func TestProcessUnsupported(t *testing.T) {
+	// A JPEG image whose metadata exceeds the maximum header size.
+	comment := newJPEGSegment(0xFE, make([]byte, 60000))
+	oversized := []byte{0xFF, 0xD8}
+	for len(oversized) <= maxHeaderSize {
+		oversized = append(oversized, comment...)
+	}
+	oversized = append(oversized, newTestJPEG(t)[2:]...)
+	// A PNG image with GPS metadata after the image data, in a chunk exceeding the maximum size.
+	data := newTestPNG(t)
+	end := len(data) - 12
+	oversizedPNG := append(append(append([]byte{}, data[:end]...), newPNGChunk("tEXt", make([]byte, maxHeaderSize+1))...), data[end:]...)
+
+	for _, test := range []struct {
+		name     string
+		mimeType string
+		data     []byte
+	}{
+		{name: "malformed JPEG", mimeType: "image/jpeg", data: []byte("not a jpeg")},
+		{name: "truncated PNG", mimeType: "image/png", data: []byte("\x89PNG\r\n\x1a\n\x00\x00")},
+		{name: "HEIC", mimeType: "image/heic", data: []byte("\x00\x00\x00\x18ftypheic\x00\x00\x00\x00")},
+		{name: "HEIC as octet stream", mimeType: "application/octet-stream", data: []byte("\x00\x00\x00\x18ftypheic\x00\x00\x00\x00")},
+		{name: "TIFF", mimeType: "image/tiff", data: []byte("II*\x00\x08\x00\x00\x00")},
+		{name: "oversized JPEG header", mimeType: "image/jpeg", data: oversized},
+		{name: "oversized PNG chunk", mimeType: "image/png", data: oversizedPNG},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			for _, strip := range []Strip{StripGPS, StripAll} {
+				require.ErrorIs(t, processError(test.data, test.mimeType, strip), ErrUnsupported)
+			}
+			// Images are kept unchanged when nothing is stripped.
+			processed, _ := process(t, test.data, test.mimeType, StripNone)
+			require.Equal(t, test.data, processed)
+		})
+	}
+}
+
+func TestProcessPNGMetadataAfterImageData(t *testing.T) {
+	// The eXIf and XMP chunks follow the image data, before the IEND chunk of 12 bytes.
+	data := newTestPNG(t)
+	position := len(pngSignature) + 25
+	exif := data[position : position+12+len(newTestEXIF())]
+	data = append(append([]byte{}, data[:position]...), data[position+len(exif):]...)
+	end := len(data) - 12
+	xmp := newPNGChunk("iTXt", []byte("XML:com.adobe.xmp\x00\x00\x00\x00\x00<exif:GPSLatitude>31,12.6N</exif:GPSLatitude>"))
+	data = append(append(append(append([]byte{}, data[:end]...), exif...), xmp...), data[end:]...)
+
+	processed, metadata := process(t, data, "image/png", StripNone)
+	require.Equal(t, data, processed)
+	require.NotNil(t, metadata.Location)
+	require.Equal(t, 20, metadata.Width)
+
+	processed, _ = process(t, data, "image/png", StripGPS)
+	_, err := png.Decode(bytes.NewReader(processed))
+	require.NoError(t, err)
+	require.NotContains(t, string(processed), "GPSLatitude")
+	_, metadata = process(t, processed, "image/png", StripNone)
+	require.Nil(t, metadata.Location)
+	require.Equal(t, "Apple", metadata.CameraMake)
+
+	processed, _ = process(t, data, "image/png", StripAll)
+	_, metadata = process(t, processed, "image/png", StripNone)
+	require.Empty(t, metadata.CameraMake)
+	require.Equal(t, 6, metadata.Orientation)
+}
+
+func TestParseDateTime(t *testing.T) {
+	location := time.FixedZone("UTC+2", 2*60*60)
+	captureTime, ok := parseDateTime("2024:05:01 12:30:00", "", location)
+	require.True(t, ok)
+	require.True(t, captureTime.Equal(time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC)))
+	_, ok = parseDateTime("0000:00:00 00:00:00", "", location)
+	require.False(t, ok)
+}
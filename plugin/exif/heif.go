@@ -0,0 +1,292 @@
+package exif
+
+import (
+	"bytes"
+	"encoding/binary"
+	"slices"
+	"time"
+)
+
+// heifItem is an item of a HEIF image, like a coded image or its EXIF metadata.
+type heifItem struct {
+	id  uint32
+	typ string
+}
+
+// heifExtent is a part of the data of a HEIF item.
+type heifExtent struct {
+	offset, length uint64
+}
+
+// heifLocation is the location of the data of a HEIF item.
+type heifLocation struct {
+	// inIDAT reports whether the offsets are in the idat box of the meta box instead of the file.
+	inIDAT  bool
+	extents []heifExtent
+}
+
+// HEIFPreview returns the largest JPEG preview embedded in a HEIF image, like a HEIC image, which
+// is either an item coded as JPEG or the thumbnail of the EXIF metadata. The previews are stored
+// like the coded image, so the orientation of the image is written into the returned preview.
+// It returns false when the image has no JPEG preview.
+func HEIFPreview(data []byte) ([]byte, bool) {
+	if len(data) < 12 || string(data[4:8]) != "ftyp" || !slices.Contains(isoImageBrands, string(data[8:12])) {
+		return nil, false
+	}
+	meta, ok := findBox(data, "meta")
+	// The meta box is a full box with a version and flags.
+	if !ok || len(meta) < 4 {
+		return nil, false
+	}
+	meta = meta[4:]
+	iinf, _ := findBox(meta, "iinf")
+	iloc, _ := findBox(meta, "iloc")
+	idat, _ := findBox(meta, "idat")
+	locations := readHEIFLocations(iloc)
+	// Items of several extents are copied together, and the copies are limited to the size of the
+	// image, since extents may repeat the same data.
+	budget := uint64(len(data))
+	readItem := func(id uint32) []byte {
+		location, ok := locations[id]
+		if !ok {
+			return nil
+		}
+		source := data
+		if location.inIDAT {
+			source = idat
+		}
+		parts := [][]byte{}
+		size := uint64(0)
+		for _, extent := range location.extents {
+			length := extent.length
+			// An extent without length spans the rest of the source.
+			if length == 0 && extent.offset <= uint64(len(source)) {
+				length = uint64(len(source)) - extent.offset
+			}
+			if extent.offset > uint64(len(source)) || length > uint64(len(source))-extent.offset {
+				return nil
+			}
+			parts = append(parts, source[extent.offset:extent.offset+length])
+			size += length
+		}
+		if len(parts) == 1 {
+			return parts[0]
+		}
+		if size > budget {
+			return nil
+		}
+		budget -= size
+		return bytes.Join(parts, nil)
+	}
+
+	var preview []byte
+	metadata := &Metadata{}
+	for _, item := range readHEIFItems(iinf) {
+		switch item.typ {
+		case "jpeg":
+			if content := readItem(item.id); len(content) > len(preview) && bytes.HasPrefix(content, []byte{0xFF, 0xD8}) {
+				preview = content
+			}
+		case "Exif":
+			// The EXIF metadata starts with the offset of its TIFF header.
+			content := readItem(item.id)
+			if len(content) < 4 || uint64(binary.BigEndian.Uint32(content)) > uint64(len(content)-4) {
+				continue
+			}
+			tiffData := content[4+binary.BigEndian.Uint32(content):]
+			readTIFFMetadata(tiffData, metadata, time.UTC)
+			if thumbnail := readTIFFThumbnail(tiffData); len(thumbnail) > len(preview) {
+				preview = thumbnail
+			}
+		default:
+		}
+	}
+	if preview == nil {
+		return nil, false
+	}
+	if metadata.Orientation <= 1 {
+		return preview, true
+	}
+	segment := newJPEGSegment(0xE1, append(slices.Clone(exifPrefix), newOrientationTIFF(metadata.Orientation)...))
+	return append(append([]byte{0xFF, 0xD8}, segment...), preview[2:]...), true
+}
+
+// readTIFFThumbnail returns the JPEG thumbnail of EXIF metadata, which is described by the second
+// image file directory.
+func readTIFFThumbnail(data []byte) []byte {
+	t, ok := newTIFF(data)
+	if !ok {
+		return nil
+	}
+	// The offset of the next directory follows the entries of the first one.
+	ifd := t.firstIFD()
+	if int64(ifd)+2 > int64(len(data)) {
+		return nil
+	}
+	next := int64(ifd) + 2 + 12*int64(t.order.Uint16(data[ifd:]))
+	if next+4 > int64(len(data)) {
+		return nil
+	}
+	thumbnailIFD := t.order.Uint32(data[next:])
+	if thumbnailIFD == 0 {
+		return nil
+	}
+	offsetEntry, ok := t.find(thumbnailIFD, tagJPEGInterchangeFormat)
+	if !ok {
+		return nil
+	}
+	lengthEntry, ok := t.find(thumbnailIFD, tagJPEGInterchangeFormatLength)
+	if !ok {
+		return nil
+	}
+	offset, length := uint64(t.uint(offsetEntry)), uint64(t.uint(lengthEntry))
+	if offset+length > uint64(len(data)) || !bytes.HasPrefix(data[offset:], []byte{0xFF, 0xD8}) {
+		return nil
+	}
+	return data[offset : offset+length]
+}
+
+// nextBox returns the type and the content of the first box of the ISO base media file format in
+// the data, and the data after the box.
+func nextBox(data []byte) (string, []byte, []byte, bool) {
+	if len(data) < 8 {
+		return "", nil, nil, false
+	}
+	size, header := uint64(binary.BigEndian.Uint32(data)), uint64(8)
+	switch size {
+	case 0:
+		// The last box extends to the end of the data.
+		size = uint64(len(data))
+	case 1:
+		if len(data) < 16 {
+			return "", nil, nil, false
+		}
+		size, header = binary.BigEndian.Uint64(data[8:]), 16
+	default:
+	}
+	if size < header || size > uint64(len(data)) {
+		return "", nil, nil, false
+	}
+	return string(data[4:8]), data[header:size], data[size:], true
+}
+
+// findBox returns the content of the first box of the type in the boxes.
+func findBox(data []byte, typ string) ([]byte, bool) {
+	for {
+		boxType, content, rest, ok := nextBox(data)
+		if !ok {
+			return nil, false
+		}
+		if boxType == typ {
+			return content, true
+		}
+		data = rest
+	}
+}
+
+// boxReader reads the big endian fields of a box, and stops at the end of the box.
+type boxReader struct {
+	data   []byte
+	failed bool
+}
+
+// uint reads an unsigned integer of the size in bytes, which may be zero.
+func (r *boxReader) uint(size int) uint64 {
+	if r.failed || size > 8 || size > len(r.data) {
+		r.failed = true
+		return 0
+	}
+	value := uint64(0)
+	for _, b := range r.data[:size] {
+		value = value<<8 | uint64(b)
+	}
+	r.data = r.data[size:]
+	return value
+}
+
+// readHEIFItems reads the items of the item information box.
+func readHEIFItems(iinf []byte) []heifItem {
+	r := &boxReader{data: iinf}
+	entryCountSize := 4
+	if r.uint(4)>>24 == 0 {
+		entryCountSize = 2
+	}
+	r.uint(entryCountSize)
+	if r.failed {
+		return nil
+	}
+	items := []heifItem{}
+	for boxes := r.data; ; {
+		typ, infe, rest, ok := nextBox(boxes)
+		if !ok {
+			return items
+		}
+		boxes = rest
+		if typ != "infe" {
+			continue
+		}
+		// Only the item information entries of version 2 and 3 have an item type.
+		entry := &boxReader{data: infe}
+		version := entry.uint(4) >> 24
+		if version != 2 && version != 3 {
+			continue
+		}
+		idSize := 2
+		if version == 3 {
+			idSize = 4
+		}
+		id := entry.uint(idSize)
+		entry.uint(2)
+		if !entry.failed && len(entry.data) >= 4 {
+			items = append(items, heifItem{id: uint32(id), typ: string(entry.data[:4])})
+		}
+	}
+}
+
+// readHEIFLocations reads the locations of the items of the item location box.
+func readHEIFLocations(iloc []byte) map[uint32]heifLocation {
+	r := &boxReader{data: iloc}
+	version := r.uint(4) >> 24
+	sizes := r.uint(1)
+	offsetSize, lengthSize := int(sizes>>4), int(sizes&0x0F)
+	sizes = r.uint(1)
+	baseOffsetSize, indexSize := int(sizes>>4), 0
+	if version == 1 || version == 2 {
+		indexSize = int(sizes & 0x0F)
+	}
+	idSize := 2
+	if version == 2 {
+		idSize = 4
+	}
+	count := r.uint(idSize)
+	locations := map[uint32]heifLocation{}
+	for i := uint64(0); i < count && !r.failed; i++ {
+		id := uint32(r.uint(idSize))
+		location := heifLocation{}
+		if version == 1 || version == 2 {
+			// The construction method is 0 for offsets in the file and 1 for offsets in the idat box.
+			switch r.uint(2) & 0x0F {
+			case 0:
+			case 1:
+				location.inIDAT = true
+			default:
+				return locations
+			}
+		}
+		// The data reference index is 0 for the data in the same file.
+		if r.uint(2) != 0 {
+			return locations
+		}
+		baseOffset := r.uint(baseOffsetSize)
+		extentCount := r.uint(2)
+		for j := uint64(0); j < extentCount && !r.failed; j++ {
+			r.uint(indexSize)
+			offset := r.uint(offsetSize)
+			location.extents = append(location.extents, heifExtent{offset: baseOffset + offset, length: r.uint(lengthSize)})
+		}
+		if !r.failed {
+			locations[id] = location
+		}
+	}
+	return locations
+}
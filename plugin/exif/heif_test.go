@@ -0,0 +1,117 @@
+package exif
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/jpeg"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testHEIFItem struct {
+	typ  string
+	data []byte
+}
+
+func newTestBox(typ string, content []byte) []byte {
+	box := binary.BigEndian.AppendUint32(nil, uint32(8+len(content)))
+	return append(append(box, typ...), content...)
+}
+
+func newTestFullBox(typ string, version byte, content []byte) []byte {
+	return newTestBox(typ, append([]byte{version, 0, 0, 0}, content...))
+}
+
+// newTestHEIF returns a HEIC image with the items, whose data is in the mdat box.
+func newTestHEIF(items ...testHEIFItem) []byte {
+	ftyp := newTestBox("ftyp", []byte("heic\x00\x00\x00\x00mif1heic"))
+	newMeta := func(dataOffset uint32) []byte {
+		iinf := binary.BigEndian.AppendUint16(nil, uint16(len(items)))
+		// The offsets and lengths of the item locations have 4 bytes, and there is no base offset.
+		iloc := binary.BigEndian.AppendUint16([]byte{0x44, 0x00}, uint16(len(items)))
+		for i, item := range items {
+			id := uint16(i + 1)
+			infe := append(binary.BigEndian.AppendUint16(nil, id), 0, 0)
+			infe = append(append(infe, item.typ...), 0)
+			iinf = append(iinf, newTestFullBox("infe", 2, infe)...)
+			iloc = binary.BigEndian.AppendUint16(iloc, id)
+			iloc = binary.BigEndian.AppendUint16(iloc, 0)
+			iloc = binary.BigEndian.AppendUint16(iloc, 1)
+			iloc = binary.BigEndian.AppendUint32(iloc, dataOffset)
+			iloc = binary.BigEndian.AppendUint32(iloc, uint32(len(item.data)))
+			dataOffset += uint32(len(item.data))
+		}
+		return newTestFullBox("meta", 0, append(newTestFullBox("iinf", 0, iinf), newTestFullBox("iloc", 0, iloc)...))
+	}
+	meta := newMeta(0)
+	meta = newMeta(uint32(len(ftyp) + len(meta) + 8))
+	mdat := []byte{}
+	for _, item := range items {
+		mdat = append(mdat, item.data...)
+	}
+	return append(append(ftyp, meta...), newTestBox("mdat", mdat)...)
+}
+
+// newTestHEIFExif returns the EXIF item of a HEIC image with the orientation and the thumbnail.
+func newTestHEIFExif(orientation uint16, thumbnail []byte) []byte {
+	data := []byte("MM\x00\x2a\x00\x00\x00\x08")
+	entry := func(tag, typ uint16, value uint32) {
+		data = binary.BigEndian.AppendUint16(data, tag)
+		data = binary.BigEndian.AppendUint16(data, typ)
+		data = binary.BigEndian.AppendUint32(data, 1)
+		data = binary.BigEndian.AppendUint32(data, value)
+	}
+	// The first directory at 8 points to the thumbnail directory at 26, and the thumbnail at 56.
+	data = binary.BigEndian.AppendUint16(data, 1)
+	entry(tagOrientation, 3, uint32(orientation)<<16)
+	data = binary.BigEndian.AppendUint32(data, 26)
+	data = binary.BigEndian.AppendUint16(data, 2)
+	entry(tagJPEGInterchangeFormat, 4, 56)
+	entry(tagJPEGInterchangeFormatLength, 4, uint32(len(thumbnail)))
+	data = append(binary.BigEndian.AppendUint32(data, 0), thumbnail...)
+	// The TIFF header follows the EXIF prefix.
+	return append(binary.BigEndian.AppendUint32(nil, uint32(len(exifPrefix))), append(append([]byte{}, exifPrefix...), data...)...)
+}
+
+func newTestGrayJPEG(t *testing.T, width, height int) []byte {
+	buffer := &bytes.Buffer{}
+	require.NoError(t, jpeg.Encode(buffer, image.NewGray(image.Rect(0, 0, width, height)), nil))
+	return buffer.Bytes()
+}
+
+func TestHEIFPreview(t *testing.T) {
+	hevc := testHEIFItem{typ: "hvc1", data: []byte("coded image")}
+	decodePreview := func(data []byte) (image.Config, int) {
+		preview, ok := HEIFPreview(data)
+		require.True(t, ok)
+		config, err := jpeg.DecodeConfig(bytes.NewReader(preview))
+		require.NoError(t, err)
+		_, metadata := process(t, preview, "image/jpeg", StripNone)
+		return config, metadata.Orientation
+	}
+
+	// The EXIF thumbnail is returned with the orientation of the image.
+	config, orientation := decodePreview(newTestHEIF(hevc, testHEIFItem{typ: "Exif", data: newTestHEIFExif(6, newTestGrayJPEG(t, 16, 8))}))
+	require.Equal(t, 16, config.Width)
+	require.Equal(t, 8, config.Height)
+	require.Equal(t, 6, orientation)
+
+	// The largest preview is returned.
+	config, _ = decodePreview(newTestHEIF(
+		hevc,
+		testHEIFItem{typ: "jpeg", data: newTestGrayJPEG(t, 40, 20)},
+		testHEIFItem{typ: "Exif", data: newTestHEIFExif(1, newTestGrayJPEG(t, 16, 8))},
+	))
+	require.Equal(t, 40, config.Width)
+	require.Equal(t, 20, config.Height)
+
+	// Images without a JPEG preview, and other images, have none.
+	_, ok := HEIFPreview(newTestHEIF(hevc, testHEIFItem{typ: "Exif", data: newTestHEIFExif(6, nil)}))
+	require.False(t, ok)
+	_, ok = HEIFPreview(newTestGrayJPEG(t, 16, 8))
+	require.False(t, ok)
+	_, ok = HEIFPreview(newTestHEIF(hevc)[:40])
+	require.False(t, ok)
+}
@@ -0,0 +1,275 @@
+package exif
+
+import (
+	"encoding/binary"
+	"math"
+	"strings"
+	"time"
+)
+
+// The EXIF tags that are read.
+const (
+	tagMake        = 0x010F
+	tagModel       = 0x0110
+	tagOrientation = 0x0112
+	tagDateTime    = 0x0132
+	// The offset and the length of the JPEG thumbnail in the second image file directory.
+	tagJPEGInterchangeFormat       = 0x0201
+	tagJPEGInterchangeFormatLength = 0x0202
+	tagExifIFD                     = 0x8769
+	tagGPSIFD                      = 0x8825
+	tagDateTimeOriginal            = 0x9003
+	tagOffsetTimeOriginal          = 0x9011
+	tagGPSLatitudeRef              = 0x0001
+	tagGPSLatitude                 = 0x0002
+	tagGPSLongitudeRef             = 0x0003
+	tagGPSLongitude                = 0x0004
+)
+
+// typeSizes are the sizes in bytes of the values of the TIFF field types.
+var typeSizes = map[uint16]int{
+	1: 1, 2: 1, 3: 2, 4: 4, 5: 8, 6: 1, 7: 1, 8: 2, 9: 4, 10: 8, 11: 4, 12: 8,
+}
+
+// tiff is the TIFF structure of EXIF metadata.
+type tiff struct {
+	data  []byte
+	order binary.ByteOrder
+}
+
+// tiffEntry is an entry of an image file directory.
+type tiffEntry struct {
+	tag   uint16
+	typ   uint16
+	count int
+	// offset is the position of the value in the data.
+	offset int
+	size   int
+}
+
+func newTIFF(data []byte) (*tiff, bool) {
+	if len(data) < 8 {
+		return nil, false
+	}
+	t := &tiff{data: data}
+	switch string(data[:2]) {
+	case "II":
+		t.order = binary.LittleEndian
+	case "MM":
+		t.order = binary.BigEndian
+	default:
+		return nil, false
+	}
+	if t.order.Uint16(data[2:4]) != 42 {
+		return nil, false
+	}
+	return t, true
+}
+
+func (t *tiff) firstIFD() uint32 {
+	return t.order.Uint32(t.data[4:8])
+}
+
+// entries returns the entries of the image file directory at the offset. Entries with values
+// outside of the data are skipped.
+func (t *tiff) entries(ifd uint32) []tiffEntry {
+	if int64(ifd)+2 > int64(len(t.data)) {
+		return nil
+	}
+	count := int(t.order.Uint16(t.data[ifd:]))
+	entries := []tiffEntry{}
+	for i := 0; i < count; i++ {
+		position := int(ifd) + 2 + i*12
+		if position+12 > len(t.data) {
+			break
+		}
+		entry := tiffEntry{
+			tag:    t.order.Uint16(t.data[position:]),
+			typ:    t.order.Uint16(t.data[position+2:]),
+			count:  int(t.order.Uint32(t.data[position+4:])),
+			offset: position + 8,
+		}
+		typeSize, ok := typeSizes[entry.typ]
+		if !ok || entry.count < 0 || entry.count > len(t.data) {
+			continue
+		}
+		entry.size = typeSize * entry.count
+		// Values of more than 4 bytes are stored at an offset.
+		if entry.size > 4 {
+			entry.offset = int(t.order.Uint32(t.data[position+8:]))
+		}
+		if entry.offset < 0 || entry.offset+entry.size > len(t.data) {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func (t *tiff) find(ifd uint32, tag uint16) (tiffEntry, bool) {
+	for _, entry := range t.entries(ifd) {
+		if entry.tag == tag {
+			return entry, true
+		}
+	}
+	return tiffEntry{}, false
+}
+
+func (t *tiff) string(entry tiffEntry) string {
+	value := string(t.data[entry.offset : entry.offset+entry.size])
+	value, _, _ = strings.Cut(value, "\x00")
+	return strings.TrimSpace(value)
+}
+
+func (t *tiff) uint(entry tiffEntry) uint32 {
+	switch entry.typ {
+	case 3:
+		return uint32(t.order.Uint16(t.data[entry.offset:]))
+	case 4:
+		return t.order.Uint32(t.data[entry.offset:])
+	default:
+		return 0
+	}
+}
+
+func (t *tiff) rationals(entry tiffEntry) []float64 {
+	if entry.typ != 5 {
+		return nil
+	}
+	values := []float64{}
+	for i := 0; i < entry.count; i++ {
+		numerator := t.order.Uint32(t.data[entry.offset+i*8:])
+		denominator := t.order.Uint32(t.data[entry.offset+i*8+4:])
+		if denominator == 0 {
+			return nil
+		}
+		values = append(values, float64(numerator)/float64(denominator))
+	}
+	return values
+}
+
+// readTIFFMetadata reads the orientation, the camera, the capture time and the GPS location of
+// EXIF metadata. Invalid values are ignored.
+func readTIFFMetadata(data []byte, metadata *Metadata, location *time.Location) {
+	t, ok := newTIFF(data)
+	if !ok {
+		return
+	}
+	ifd := t.firstIFD()
+	var exifIFD, gpsIFD uint32
+	var dateTime string
+	for _, entry := range t.entries(ifd) {
+		switch entry.tag {
+		case tagMake:
+			metadata.CameraMake = t.string(entry)
+		case tagModel:
+			metadata.CameraModel = t.string(entry)
+		case tagOrientation:
+			if orientation := int(t.uint(entry)); orientation >= 1 && orientation <= 8 {
+				metadata.Orientation = orientation
+			}
+		case tagDateTime:
+			dateTime = t.string(entry)
+		case tagExifIFD:
+			exifIFD = t.uint(entry)
+		case tagGPSIFD:
+			gpsIFD = t.uint(entry)
+		default:
+		}
+	}
+
+	offset := ""
+	if exifIFD != 0 {
+		if entry, ok := t.find(exifIFD, tagDateTimeOriginal); ok {
+			dateTime = t.string(entry)
+		}
+		if entry, ok := t.find(exifIFD, tagOffsetTimeOriginal); ok {
+			offset = t.string(entry)
+		}
+	}
+	if captureTime, ok := parseDateTime(dateTime, offset, location); ok {
+		metadata.CaptureTime = captureTime
+	}
+
+	if gpsIFD != 0 {
+		values := map[uint16]tiffEntry{}
+		for _, entry := range t.entries(gpsIFD) {
+			values[entry.tag] = entry
+		}
+		latitude, latitudeOK := parseCoordinate(t, values[tagGPSLatitude], t.string(values[tagGPSLatitudeRef]), "S", 90)
+		longitude, longitudeOK := parseCoordinate(t, values[tagGPSLongitude], t.string(values[tagGPSLongitudeRef]), "W", 180)
+		if latitudeOK && longitudeOK {
+			metadata.Location = &Location{Latitude: latitude, Longitude: longitude}
+		}
+	}
+}
+
+// parseDateTime parses an EXIF date time with an optional offset, like +08:00.
+func parseDateTime(value, offset string, location *time.Location) (time.Time, bool) {
+	if offset != "" {
+		if t, err := time.Parse("2006:01:02 15:04:05-07:00", value+offset); err == nil {
+			return t, true
+		}
+	}
+	t, err := time.ParseInLocation("2006:01:02 15:04:05", value, location)
+	if err != nil || t.Year() < 1900 {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// parseCoordinate parses a coordinate of degrees, minutes and seconds that is negative on the
+// negative reference, e.g. S for latitudes.
+func parseCoordinate(t *tiff, entry tiffEntry, ref, negativeRef string, limit float64) (float64, bool) {
+	if entry.size == 0 {
+		return 0, false
+	}
+	values := t.rationals(entry)
+	if len(values) != 3 {
+		return 0, false
+	}
+	coordinate := values[0] + values[1]/60 + values[2]/3600
+	if math.IsNaN(coordinate) || coordinate > limit {
+		return 0, false
+	}
+	if strings.EqualFold(ref, negativeRef) {
+		coordinate = -coordinate
+	}
+	return coordinate, true
+}
+
+// blankGPS zeroes the values and the entries of the GPS directory of EXIF metadata in place, so
+// that the directory is empty without changing the layout of the metadata. It returns whether
+// the metadata was changed.
+func blankGPS(data []byte) bool {
+	t, ok := newTIFF(data)
+	if !ok {
+		return false
+	}
+	entry, ok := t.find(t.firstIFD(), tagGPSIFD)
+	if !ok {
+		return false
+	}
+	gpsIFD := int(t.uint(entry))
+	if gpsIFD <= 0 || gpsIFD+2 > len(data) {
+		return false
+	}
+	for _, entry := range t.entries(uint32(gpsIFD)) {
+		clear(data[entry.offset : entry.offset+entry.size])
+	}
+	count := int(t.order.Uint16(data[gpsIFD:]))
+	clear(data[gpsIFD:min(gpsIFD+2+count*12, len(data))])
+	return true
+}
+
+// newOrientationTIFF returns EXIF metadata with only the orientation.
+func newOrientationTIFF(orientation int) []byte {
+	data := []byte("MM\x00\x2a\x00\x00\x00\x08")
+	data = binary.BigEndian.AppendUint16(data, 1)
+	data = binary.BigEndian.AppendUint16(data, tagOrientation)
+	data = binary.BigEndian.AppendUint16(data, 3)
+	data = binary.BigEndian.AppendUint32(data, 1)
+	data = binary.BigEndian.AppendUint16(data, uint16(orientation))
+	data = binary.BigEndian.AppendUint16(data, 0)
+	return binary.BigEndian.AppendUint32(data, 0)
+}
@@ -0,0 +1,163 @@
+package exif
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	riffSignature = []byte("RIFF")
+	webpSignature = []byte("WEBP")
+)
+
+// webpFlagXMP is the flag of the extended format header of WebP images with XMP metadata.
+const webpFlagXMP = 0x04
+
+// webpReader strips the metadata of the chunks of a WebP image. The metadata chunks follow the
+// image data, and the size of the image is written before them, so the chunks are stripped in
+// place: the GPS location and the other EXIF metadata are cleared without changing the size of
+// the EXIF chunk, and the XMP chunk is cleared and renamed to a JUNK chunk, which readers skip.
+type webpReader struct {
+	chunkReader
+	location *time.Location
+	metadata *Metadata
+	// remaining is the number of bytes of the RIFF data that are not read yet.
+	remaining int64
+	// imageData is set once the first image data chunk is read.
+	imageData bool
+}
+
+// processWebP reads the chunks of a WebP image up to the first image data chunk, and returns them
+// with the metadata stripped, followed by a reader of the rest of the chunks.
+func processWebP(r io.Reader, strip Strip, location *time.Location) ([]byte, io.Reader, *Metadata, error) {
+	header := make([]byte, 12)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, nil, nil, err
+	}
+	if !bytes.HasPrefix(header, riffSignature) || !bytes.Equal(header[8:], webpSignature) {
+		return nil, nil, nil, errors.Wrap(errMalformed, "missing signature")
+	}
+	w := &webpReader{
+		chunkReader: chunkReader{r: r, strip: strip},
+		location:    location,
+		metadata:    &Metadata{},
+		// The size of the RIFF data includes the WEBP signature.
+		remaining: int64(binary.LittleEndian.Uint32(header[4:8])) - 4,
+	}
+	w.next = w.nextChunk
+	chunks, err := w.readHeader(func() bool { return w.imageData })
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return append(header, chunks...), w, w.metadata, nil
+}
+
+func (w *webpReader) nextChunk() error {
+	if w.remaining <= 0 {
+		w.ended = true
+		return nil
+	}
+	chunkHeader, err := w.read(8)
+	if err != nil {
+		return err
+	}
+	size := int64(binary.LittleEndian.Uint32(chunkHeader[4:8]))
+	// Chunks are padded to an even size.
+	padded := size + size&1
+	w.remaining -= 8 + padded
+	if w.remaining < 0 {
+		return errors.Wrap(errMalformed, "chunk exceeds the image")
+	}
+
+	switch fourCC := string(chunkHeader[:4]); fourCC {
+	case "VP8X":
+		data, err := w.read(padded)
+		if err != nil {
+			return err
+		}
+		if size < 10 {
+			return errors.Wrap(errMalformed, "invalid extended format header")
+		}
+		// The extended format header holds the flags and the canvas size minus one.
+		w.metadata.Width = 1 + int(uint32(data[4])|uint32(data[5])<<8|uint32(data[6])<<16)
+		w.metadata.Height = 1 + int(uint32(data[7])|uint32(data[8])<<8|uint32(data[9])<<16)
+		if w.strip != StripNone {
+			data[0] &^= webpFlagXMP
+		}
+		w.out = append(chunkHeader, data...)
+	case "VP8 ", "VP8L":
+		w.imageData = true
+		// The dimensions of simple images are read from the start of their image data.
+		data, err := w.read(min(padded, 10))
+		if err != nil {
+			return err
+		}
+		if w.metadata.Width == 0 {
+			readWebPDimensions(fourCC, data, w.metadata)
+		}
+		w.out = append(chunkHeader, data...)
+		w.copying = padded - int64(len(data))
+	case "ALPH", "ANMF":
+		w.imageData = true
+		w.out = chunkHeader
+		w.copying = padded
+	case "EXIF":
+		data, err := w.read(padded)
+		if err != nil {
+			return err
+		}
+		// Some encoders write the EXIF prefix of JPEG images before the metadata.
+		tiff := bytes.TrimPrefix(data[:size], exifPrefix)
+		readTIFFMetadata(tiff, w.metadata, w.location)
+		switch w.strip {
+		case StripGPS:
+			blankGPS(tiff)
+		case StripAll:
+			// The metadata is replaced with the orientation, so that the image is still displayed upright.
+			orientation := newOrientationTIFF(max(w.metadata.Orientation, 1))
+			clear(tiff)
+			if len(tiff) >= len(orientation) {
+				copy(tiff, orientation)
+			}
+		default:
+		}
+		applyOrientation(w.metadata)
+		w.out = append(chunkHeader, data...)
+	case "XMP ":
+		w.out = chunkHeader
+		w.copying = padded
+		if w.strip != StripNone {
+			w.out = append([]byte("JUNK"), chunkHeader[4:]...)
+			w.clearing = true
+		}
+	default:
+		w.out = chunkHeader
+		w.copying = padded
+	}
+	return nil
+}
+
+// readWebPDimensions reads the dimensions of a simple WebP image from the start of its lossy or
+// lossless image data.
+func readWebPDimensions(fourCC string, data []byte, metadata *Metadata) {
+	switch fourCC {
+	case "VP8 ":
+		// The frame tag of 3 bytes is followed by a start code and the 14 bits dimensions.
+		if len(data) >= 10 && bytes.Equal(data[3:6], []byte{0x9D, 0x01, 0x2A}) {
+			metadata.Width = int(binary.LittleEndian.Uint16(data[6:8]) & 0x3FFF)
+			metadata.Height = int(binary.LittleEndian.Uint16(data[8:10]) & 0x3FFF)
+		}
+	case "VP8L":
+		// The signature is followed by the 14 bits dimensions minus one.
+		if len(data) >= 5 && data[0] == 0x2F {
+			bits := binary.LittleEndian.Uint32(data[1:5])
+			metadata.Width = 1 + int(bits&0x3FFF)
+			metadata.Height = 1 + int(bits>>14&0x3FFF)
+		}
+	default:
+	}
+}
@@ -15,6 +15,7 @@ type CommonSQLConverter struct {
 	paramIndex    int
 	allowedFields []string
 	entityType    string
+	timeContext   *TimeContext
 }
 
 // NewCommonSQLConverter creates a new converter with the specified dialect for memo filters.
@@ -22,8 +23,9 @@ func NewCommonSQLConverter(dialect SQLDialect) *CommonSQLConverter {
 	return &CommonSQLConverter{
 		dialect:       dialect,
 		paramIndex:    1,
-		allowedFields: []string{"creator_id", "created_ts", "updated_ts", "visibility", "content", "pinned", "has_task_list", "has_link", "has_code", "has_incomplete_tasks"},
+		allowedFields: []string{"creator_id", "created_ts", "updated_ts", "visibility", "content", "pinned", "has_task_list", "has_link", "has_code", "has_incomplete_tasks", "has_attachment", "attachment_type", "comment_count", "reaction_count", "has_location", "is_comment"},
 		entityType:    "memo",
+		timeContext:   defaultTimeContext(),
 	}
 }
 
@@ -32,8 +34,9 @@ func NewCommonSQLConverterWithOffset(dialect SQLDialect, offset int) *CommonSQLC
 	return &CommonSQLConverter{
 		dialect:       dialect,
 		paramIndex:    offset + 1,
-		allowedFields: []string{"creator_id", "created_ts", "updated_ts", "visibility", "content", "pinned", "has_task_list", "has_link", "has_code", "has_incomplete_tasks"},
+		allowedFields: []string{"creator_id", "created_ts", "updated_ts", "visibility", "content", "pinned", "has_task_list", "has_link", "has_code", "has_incomplete_tasks", "has_attachment", "attachment_type", "comment_count", "reaction_count", "has_location", "is_comment"},
 		entityType:    "memo",
+		timeContext:   defaultTimeContext(),
 	}
 }
 
@@ -44,11 +47,32 @@ func NewUserSQLConverter(dialect SQLDialect) *CommonSQLConverter {
 		paramIndex:    1,
 		allowedFields: []string{"username"},
 		entityType:    "user",
+		timeContext:   defaultTimeContext(),
 	}
 }
 
+// WithTimeContext sets the time zone and week start used to evaluate date functions.
+func (c *CommonSQLConverter) WithTimeContext(timeContext *TimeContext) *CommonSQLConverter {
+	if timeContext != nil {
+		c.timeContext = timeContext
+	}
+	return c
+}
+
 // ConvertExprToSQL converts a CEL expression to SQL using the configured dialect.
 func (c *CommonSQLConverter) ConvertExprToSQL(ctx *ConvertContext, expr *exprv1.Expr) error {
+	if err := c.convertExprToSQL(ctx, expr); err != nil {
+		// Keep the innermost expression that failed so validation can report its position.
+		var exprErr *exprError
+		if errors.As(err, &exprErr) {
+			return err
+		}
+		return &exprError{exprID: expr.GetId(), err: err}
+	}
+	return nil
+}
+
+func (c *CommonSQLConverter) convertExprToSQL(ctx *ConvertContext, expr *exprv1.Expr) error {
 	if v, ok := expr.ExprKind.(*exprv1.Expr_CallExpr); ok {
 		switch v.CallExpr.Function {
 		case "_||_", "_&&_":
@@ -61,6 +85,12 @@ func (c *CommonSQLConverter) ConvertExprToSQL(ctx *ConvertContext, expr *exprv1.
 			return c.handleInOperator(ctx, v.CallExpr)
 		case "contains":
 			return c.handleContainsOperator(ctx, v.CallExpr)
+		case "startsWith":
+			return c.handleStartsWithOperator(ctx, v.CallExpr)
+		case "within_radius":
+			return c.handleWithinRadius(ctx, v.CallExpr)
+		case "within_bbox":
+			return c.handleWithinBBox(ctx, v.CallExpr)
 		default:
 			return errors.Errorf("unsupported call expression function: %s", v.CallExpr.Function)
 		}
@@ -133,6 +163,9 @@ func (c *CommonSQLConverter) handleComparisonOperator(ctx *ConvertContext, callE
 		if leftCallExpr.CallExpr.Function == "size" {
 			return c.handleSizeComparison(ctx, callExpr, leftCallExpr.CallExpr)
 		}
+		if leftCallExpr.CallExpr.Function == "date" {
+			return c.handleDateComparison(ctx, callExpr, leftCallExpr.CallExpr)
+		}
 	}
 
 	identifier, err := GetIdentExprName(callExpr.Args[0])
@@ -144,7 +177,7 @@ func (c *CommonSQLConverter) handleComparisonOperator(ctx *ConvertContext, callE
 		return errors.Errorf("invalid identifier for %s", callExpr.Function)
 	}
 
-	value, err := GetExprValue(callExpr.Args[1])
+	value, err := getExprValue(callExpr.Args[1], c.timeContext)
 	if err != nil {
 		return err
 	}
@@ -164,6 +197,12 @@ func (c *CommonSQLConverter) handleComparisonOperator(ctx *ConvertContext, callE
 			return c.handlePinnedComparison(ctx, operator, value)
 		case "has_task_list", "has_link", "has_code", "has_incomplete_tasks":
 			return c.handleBooleanComparison(ctx, identifier, operator, value)
+		case "has_attachment", "has_location", "is_comment":
+			return c.handleSubqueryBooleanComparison(ctx, identifier, operator, value)
+		case "comment_count", "reaction_count":
+			return c.handleCountComparison(ctx, identifier, operator, value)
+		case "attachment_type":
+			return c.handleAttachmentTypeComparison(ctx, operator, value)
 		default:
 			return errors.Errorf("unsupported identifier in comparison: %s", identifier)
 		}
@@ -192,11 +231,11 @@ func (c *CommonSQLConverter) handleSizeComparison(ctx *ConvertContext, callExpr
 		return err
 	}
 
-	if identifier != "tags" {
-		return errors.Errorf("size function only supports 'tags' identifier, got: %s", identifier)
+	if identifier != "tags" && identifier != "references" {
+		return errors.Errorf("size function only supports 'tags' and 'references' identifiers, got: %s", identifier)
 	}
 
-	value, err := GetExprValue(callExpr.Args[1])
+	value, err := getExprValue(callExpr.Args[1], c.timeContext)
 	if err != nil {
 		return err
 	}
@@ -208,8 +247,13 @@ func (c *CommonSQLConverter) handleSizeComparison(ctx *ConvertContext, callExpr
 
 	operator := c.getComparisonOperator(callExpr.Function)
 
+	sizeExpr := c.dialect.GetJSONArrayLength("$.tags")
+	if identifier == "references" {
+		sizeExpr = c.getSQLTemplate("references_count")
+	}
+
 	if _, err := ctx.Buffer.WriteString(fmt.Sprintf("%s %s %s",
-		c.dialect.GetJSONArrayLength("$.tags"),
+		sizeExpr,
 		operator,
 		c.dialect.GetParameterPlaceholder(c.paramIndex))); err != nil {
 		return err
@@ -221,6 +265,68 @@ func (c *CommonSQLConverter) handleSizeComparison(ctx *ConvertContext, callExpr
 	return nil
 }
 
+func (c *CommonSQLConverter) handleDateComparison(ctx *ConvertContext, callExpr *exprv1.Expr_Call, dateCall *exprv1.Expr_Call) error {
+	if len(dateCall.Args) != 1 {
+		return errors.New("date function requires exactly one argument")
+	}
+
+	identifier, err := GetIdentExprName(dateCall.Args[0])
+	if err != nil {
+		return err
+	}
+
+	if c.entityType != "memo" || (identifier != "created_ts" && identifier != "updated_ts") {
+		return errors.Errorf("date function only supports 'created_ts' and 'updated_ts' identifiers, got: %s", identifier)
+	}
+
+	value, err := getExprValue(callExpr.Args[1], c.timeContext)
+	if err != nil {
+		return err
+	}
+
+	valueInt, ok := value.(int64)
+	if !ok {
+		return errors.New("date comparison value must be an integer timestamp")
+	}
+
+	// Compare against the bounds of the calendar day so the timestamp column can be used as is.
+	start, end := c.timeContext.dayRange(valueInt)
+	timestampField := c.dialect.GetTimestampComparison(identifier)
+	var sqlExpr string
+	var args []any
+	switch callExpr.Function {
+	case "_==_":
+		sqlExpr = fmt.Sprintf("(%s >= %s AND %s < %s)", timestampField, c.dialect.GetParameterPlaceholder(c.paramIndex), timestampField, c.dialect.GetParameterPlaceholder(c.paramIndex+1))
+		args = []any{start, end}
+	case "_!=_":
+		sqlExpr = fmt.Sprintf("(%s < %s OR %s >= %s)", timestampField, c.dialect.GetParameterPlaceholder(c.paramIndex), timestampField, c.dialect.GetParameterPlaceholder(c.paramIndex+1))
+		args = []any{start, end}
+	case "_<_":
+		sqlExpr = fmt.Sprintf("%s < %s", timestampField, c.dialect.GetParameterPlaceholder(c.paramIndex))
+		args = []any{start}
+	case "_<=_":
+		sqlExpr = fmt.Sprintf("%s < %s", timestampField, c.dialect.GetParameterPlaceholder(c.paramIndex))
+		args = []any{end}
+	case "_>_":
+		sqlExpr = fmt.Sprintf("%s >= %s", timestampField, c.dialect.GetParameterPlaceholder(c.paramIndex))
+		args = []any{end}
+	case "_>=_":
+		sqlExpr = fmt.Sprintf("%s >= %s", timestampField, c.dialect.GetParameterPlaceholder(c.paramIndex))
+		args = []any{start}
+	default:
+		return errors.Errorf("unsupported operator for date comparison: %s", callExpr.Function)
+	}
+
+	if _, err := ctx.Buffer.WriteString(sqlExpr); err != nil {
+		return err
+	}
+
+	ctx.Args = append(ctx.Args, args...)
+	c.paramIndex += len(args)
+
+	return nil
+}
+
 func (c *CommonSQLConverter) handleInOperator(ctx *ConvertContext, callExpr *exprv1.Expr_Call) error {
 	if len(callExpr.Args) != 2 {
 		return errors.Errorf("invalid number of arguments for %s", callExpr.Function)
@@ -231,6 +337,9 @@ func (c *CommonSQLConverter) handleInOperator(ctx *ConvertContext, callExpr *exp
 		if identifier == "tags" {
 			return c.handleElementInTags(ctx, callExpr.Args[0])
 		}
+		if identifier == "references" && c.entityType == "memo" {
+			return c.handleElementInReferences(ctx, callExpr.Args[0])
+		}
 		return errors.Errorf("invalid collection identifier for %s: %s", callExpr.Function, identifier)
 	}
 
@@ -240,7 +349,7 @@ func (c *CommonSQLConverter) handleInOperator(ctx *ConvertContext, callExpr *exp
 		return err
 	}
 
-	if !slices.Contains([]string{"tag", "visibility", "content_id", "memo_id"}, identifier) {
+	if !slices.Contains([]string{"tag", "visibility", "content_id", "memo_id", "attachment_type"}, identifier) {
 		return errors.Errorf("invalid identifier for %s", callExpr.Function)
 	}
 
@@ -261,6 +370,8 @@ func (c *CommonSQLConverter) handleInOperator(ctx *ConvertContext, callExpr *exp
 		return c.handleContentIDInList(ctx, values)
 	} else if identifier == "memo_id" {
 		return c.handleMemoIDInList(ctx, values)
+	} else if identifier == "attachment_type" {
+		return c.handleAttachmentTypeInList(ctx, values)
 	}
 
 	return nil
@@ -428,6 +539,73 @@ func (c *CommonSQLConverter) handleContainsOperator(ctx *ConvertContext, callExp
 	return nil
 }
 
+// likeEscaper escapes the wildcards of LIKE patterns with the escape character '!', which is
+// quoted the same in every dialect, unlike the backslash.
+var likeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
+func (c *CommonSQLConverter) handleStartsWithOperator(ctx *ConvertContext, callExpr *exprv1.Expr_Call) error {
+	if len(callExpr.Args) != 1 {
+		return errors.Errorf("invalid number of arguments for %s", callExpr.Function)
+	}
+
+	identifier, err := GetIdentExprName(callExpr.Target)
+	if err != nil {
+		return err
+	}
+
+	if c.entityType != "memo" || identifier != "attachment_type" {
+		return errors.Errorf("invalid identifier for %s", callExpr.Function)
+	}
+
+	arg, err := GetConstValue(callExpr.Args[0])
+	if err != nil {
+		return err
+	}
+
+	// The wildcards of the prefix are escaped, so that it matches like the evaluator.
+	condition := fmt.Sprintf("LIKE %s ESCAPE '!'", c.dialect.GetParameterPlaceholder(c.paramIndex))
+	if _, err := ctx.Buffer.WriteString(fmt.Sprintf(c.getSQLTemplate("attachment_type_match"), condition)); err != nil {
+		return err
+	}
+
+	ctx.Args = append(ctx.Args, fmt.Sprintf("%s%%", likeEscaper.Replace(fmt.Sprint(arg))))
+	c.paramIndex++
+
+	return nil
+}
+
+func (c *CommonSQLConverter) handleElementInReferences(ctx *ConvertContext, elementExpr *exprv1.Expr) error {
+	element, err := GetConstValue(elementExpr)
+	if err != nil {
+		return errors.Errorf("first argument must be a constant value for 'element in references': %v", err)
+	}
+
+	if _, err := ctx.Buffer.WriteString(fmt.Sprintf(c.getSQLTemplate("references_contains"), c.dialect.GetParameterPlaceholder(c.paramIndex))); err != nil {
+		return err
+	}
+
+	ctx.Args = append(ctx.Args, element)
+	c.paramIndex++
+
+	return nil
+}
+
+func (c *CommonSQLConverter) handleAttachmentTypeInList(ctx *ConvertContext, values []any) error {
+	placeholders := []string{}
+	for range values {
+		placeholders = append(placeholders, c.dialect.GetParameterPlaceholder(c.paramIndex))
+		c.paramIndex++
+	}
+
+	condition := fmt.Sprintf("IN (%s)", strings.Join(placeholders, ","))
+	if _, err := ctx.Buffer.WriteString(fmt.Sprintf(c.getSQLTemplate("attachment_type_match"), condition)); err != nil {
+		return err
+	}
+
+	ctx.Args = append(ctx.Args, values...)
+	return nil
+}
+
 func (c *CommonSQLConverter) handleIdentifier(ctx *ConvertContext, identExpr *exprv1.Expr_Ident) error {
 	identifier := identExpr.GetName()
 
@@ -436,10 +614,18 @@ func (c *CommonSQLConverter) handleIdentifier(ctx *ConvertContext, identExpr *ex
 		return errors.Errorf("invalid identifier %s for entity type %s", identifier, c.entityType)
 	}
 
-	if !slices.Contains([]string{"pinned", "has_task_list", "has_link", "has_code", "has_incomplete_tasks"}, identifier) {
+	if !slices.Contains([]string{"pinned", "has_task_list", "has_link", "has_code", "has_incomplete_tasks", "has_attachment", "has_location", "is_comment"}, identifier) {
 		return errors.Errorf("invalid identifier %s", identifier)
 	}
 
+	// Relation based flags are resolved with subqueries.
+	if slices.Contains([]string{"has_attachment", "has_location", "is_comment"}, identifier) {
+		if _, err := ctx.Buffer.WriteString(c.getSQLTemplate(identifier)); err != nil {
+			return err
+		}
+		return nil
+	}
+
 	if identifier == "pinned" {
 		tablePrefix := c.dialect.GetTablePrefix("memo")
 		if _, ok := c.dialect.(*PostgreSQLDialect); ok {
@@ -726,6 +912,80 @@ func (c *CommonSQLConverter) handleBooleanComparison(ctx *ConvertContext, field,
 	return nil
 }
 
+func (c *CommonSQLConverter) handleSubqueryBooleanComparison(ctx *ConvertContext, field, operator string, value interface{}) error {
+	if operator != "=" && operator != "!=" {
+		return errors.Errorf("invalid operator for %s", field)
+	}
+
+	valueBool, ok := value.(bool)
+	if !ok {
+		return errors.Errorf("invalid boolean value for %s", field)
+	}
+
+	sqlExpr := c.getSQLTemplate(field)
+	if (operator == "=") != valueBool {
+		sqlExpr = fmt.Sprintf("NOT (%s)", sqlExpr)
+	}
+	if _, err := ctx.Buffer.WriteString(sqlExpr); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *CommonSQLConverter) handleCountComparison(ctx *ConvertContext, field, operator string, value interface{}) error {
+	valueInt, ok := value.(int64)
+	if !ok {
+		return errors.Errorf("invalid integer value for %s", field)
+	}
+
+	if _, err := ctx.Buffer.WriteString(fmt.Sprintf("%s %s %s", c.getSQLTemplate(field), operator, c.dialect.GetParameterPlaceholder(c.paramIndex))); err != nil {
+		return err
+	}
+
+	ctx.Args = append(ctx.Args, valueInt)
+	c.paramIndex++
+
+	return nil
+}
+
+func (c *CommonSQLConverter) handleAttachmentTypeComparison(ctx *ConvertContext, operator string, value interface{}) error {
+	if operator != "=" && operator != "!=" {
+		return errors.New("invalid operator for attachment_type")
+	}
+
+	valueStr, ok := value.(string)
+	if !ok {
+		return errors.New("invalid string value for attachment_type")
+	}
+
+	// attachment_type != "x" matches memos without any attachment of that type.
+	sqlExpr := fmt.Sprintf(c.getSQLTemplate("attachment_type_match"), "= "+c.dialect.GetParameterPlaceholder(c.paramIndex))
+	if operator == "!=" {
+		sqlExpr = fmt.Sprintf("NOT (%s)", sqlExpr)
+	}
+	if _, err := ctx.Buffer.WriteString(sqlExpr); err != nil {
+		return err
+	}
+
+	ctx.Args = append(ctx.Args, valueStr)
+	c.paramIndex++
+
+	return nil
+}
+
+// getSQLTemplate returns the SQL template for the converter dialect.
+func (c *CommonSQLConverter) getSQLTemplate(templateName string) string {
+	switch c.dialect.(type) {
+	case *MySQLDialect:
+		return GetSQL(templateName, MySQLTemplate)
+	case *PostgreSQLDialect:
+		return GetSQL(templateName, PostgreSQLTemplate)
+	default:
+		return GetSQL(templateName, SQLiteTemplate)
+	}
+}
+
 func (*CommonSQLConverter) getComparisonOperator(function string) string {
 	switch function {
 	case "_==_":
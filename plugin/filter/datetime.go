@@ -0,0 +1,136 @@
+package filter
+
+import (
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// TimeContext holds the settings used to evaluate date functions in filters,
+// such as today() and start_of_week().
+type TimeContext struct {
+	// Location is the time zone used for calendar calculations.
+	Location *time.Location
+	// WeekStart is the first day of the week used by start_of_week().
+	WeekStart time.Weekday
+	// Now returns the current time. It defaults to time.Now.
+	Now func() time.Time
+}
+
+// NewTimeContext creates a time context with the given location and week start.
+// A nil location falls back to the server local time zone.
+func NewTimeContext(location *time.Location, weekStart time.Weekday) *TimeContext {
+	if location == nil {
+		location = time.Local
+	}
+	return &TimeContext{
+		Location:  location,
+		WeekStart: weekStart,
+		Now:       time.Now,
+	}
+}
+
+func defaultTimeContext() *TimeContext {
+	return NewTimeContext(time.Local, time.Sunday)
+}
+
+func (tc *TimeContext) now() time.Time {
+	if tc.Now == nil {
+		return time.Now().In(tc.Location)
+	}
+	return tc.Now().In(tc.Location)
+}
+
+// startOfDay returns the midnight starting the calendar day of ts.
+func (tc *TimeContext) startOfDay(ts int64) time.Time {
+	t := time.Unix(ts, 0).In(tc.Location)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, tc.Location)
+}
+
+func (tc *TimeContext) today() int64 {
+	return tc.startOfDay(tc.now().Unix()).Unix()
+}
+
+func (tc *TimeContext) startOfWeek() int64 {
+	day := tc.startOfDay(tc.now().Unix())
+	offset := (int(day.Weekday()) - int(tc.WeekStart) + 7) % 7
+	return day.AddDate(0, 0, -offset).Unix()
+}
+
+func (tc *TimeContext) startOfMonth() int64 {
+	t := tc.now()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, tc.Location).Unix()
+}
+
+// dayRange returns the bounds [start, end) of the calendar day containing ts.
+func (tc *TimeContext) dayRange(ts int64) (int64, int64) {
+	start := tc.startOfDay(ts)
+	return start.Unix(), start.AddDate(0, 0, 1).Unix()
+}
+
+var timestampLayouts = []string{
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+	time.DateOnly,
+}
+
+// parseTimestamp parses an RFC 3339 timestamp or a local date/time like "2025-01-01"
+// and returns its unix timestamp. Values without a zone are read in the context location.
+func (tc *TimeContext) parseTimestamp(value string) (int64, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.Unix(), nil
+	}
+	for _, layout := range timestampLayouts {
+		if t, err := time.ParseInLocation(layout, value, tc.Location); err == nil {
+			return t.Unix(), nil
+		}
+	}
+	return 0, errors.Errorf("invalid timestamp %q", value)
+}
+
+var durationPartRegex = regexp.MustCompile(`(\d+)(w|d|h|m|s)`)
+
+// parseDuration parses durations like "7d", "2w" or "1h30m" and returns the number of seconds.
+func parseDuration(value string) (int64, error) {
+	raw := value
+	sign := int64(1)
+	if len(raw) > 0 && raw[0] == '-' {
+		sign, raw = -1, raw[1:]
+	}
+	if raw == "" {
+		return 0, errors.Errorf("invalid duration %q", value)
+	}
+
+	matches := durationPartRegex.FindAllStringSubmatchIndex(raw, -1)
+	var seconds int64
+	consumed := 0
+	for _, match := range matches {
+		if match[0] != consumed {
+			return 0, errors.Errorf("invalid duration %q", value)
+		}
+		consumed = match[1]
+		n, err := strconv.ParseInt(raw[match[2]:match[3]], 10, 64)
+		if err != nil {
+			return 0, errors.Errorf("invalid duration %q", value)
+		}
+		switch raw[match[4]:match[5]] {
+		case "w":
+			n *= 7 * 24 * 60 * 60
+		case "d":
+			n *= 24 * 60 * 60
+		case "h":
+			n *= 60 * 60
+		case "m":
+			n *= 60
+		}
+		seconds += n
+	}
+	if consumed != len(raw) {
+		return 0, errors.Errorf("invalid duration %q", value)
+	}
+	return sign * seconds, nil
+}
@@ -0,0 +1,530 @@
+package filter
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+	exprv1 "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
+)
+
+// Memo is the in-memory view of a memo that memo filters are evaluated against.
+type Memo struct {
+	Content            string
+	CreatorID          int32
+	CreatedTs          int64
+	UpdatedTs          int64
+	Pinned             bool
+	Visibility         string
+	Tags               []string
+	HasTaskList        bool
+	HasLink            bool
+	HasCode            bool
+	HasIncompleteTasks bool
+	// AttachmentTypes holds the MIME type of every attachment of the memo.
+	AttachmentTypes []string
+	CommentCount    int64
+	ReactionCount   int64
+	// Location is nil if the memo has no location.
+	Location *MemoLocation
+	// IsComment is true if the memo is a comment of another memo.
+	IsComment bool
+	// References holds the UIDs of the memos referenced by the memo.
+	References []string
+}
+
+// MemoLocation is the location of a memo in degrees.
+type MemoLocation struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// MemoEvaluator evaluates a memo filter against in-memory memos.
+// It follows the semantics of the SQL converter on SQLite, so a memo matches the filter
+// exactly when the SQL condition generated for the filter would select it. This includes
+// the NULL results of conditions on absent tags and task list properties.
+type MemoEvaluator struct {
+	expr        *exprv1.Expr
+	timeContext *TimeContext
+}
+
+// NewMemoEvaluator compiles the filter with the memo attributes.
+func NewMemoEvaluator(filter string) (*MemoEvaluator, error) {
+	parsedExpr, err := Parse(filter, MemoFilterCELAttributes...)
+	if err != nil {
+		return nil, err
+	}
+	return &MemoEvaluator{
+		expr:        parsedExpr.GetExpr(),
+		timeContext: defaultTimeContext(),
+	}, nil
+}
+
+// WithTimeContext sets the time zone and week start used to evaluate date functions.
+func (e *MemoEvaluator) WithTimeContext(timeContext *TimeContext) *MemoEvaluator {
+	if timeContext != nil {
+		e.timeContext = timeContext
+	}
+	return e
+}
+
+// sqlBool is a boolean of the SQL three-valued logic.
+type sqlBool int8
+
+const (
+	sqlFalse sqlBool = iota
+	sqlTrue
+	sqlNull
+)
+
+func toSQLBool(value bool) sqlBool {
+	if value {
+		return sqlTrue
+	}
+	return sqlFalse
+}
+
+func (b sqlBool) and(other sqlBool) sqlBool {
+	if b == sqlFalse || other == sqlFalse {
+		return sqlFalse
+	}
+	if b == sqlNull || other == sqlNull {
+		return sqlNull
+	}
+	return sqlTrue
+}
+
+func (b sqlBool) or(other sqlBool) sqlBool {
+	if b == sqlTrue || other == sqlTrue {
+		return sqlTrue
+	}
+	if b == sqlNull || other == sqlNull {
+		return sqlNull
+	}
+	return sqlFalse
+}
+
+func (b sqlBool) not() sqlBool {
+	switch b {
+	case sqlTrue:
+		return sqlFalse
+	case sqlFalse:
+		return sqlTrue
+	default:
+		return sqlNull
+	}
+}
+
+// Evaluate returns whether the memo matches the filter.
+func (e *MemoEvaluator) Evaluate(memo *Memo) (bool, error) {
+	result, err := e.evaluate(e.expr, memo)
+	if err != nil {
+		return false, err
+	}
+	return result == sqlTrue, nil
+}
+
+func (e *MemoEvaluator) evaluate(expr *exprv1.Expr, memo *Memo) (sqlBool, error) {
+	switch v := expr.ExprKind.(type) {
+	case *exprv1.Expr_CallExpr:
+		callExpr := v.CallExpr
+		switch callExpr.Function {
+		case "_||_", "_&&_":
+			if len(callExpr.Args) != 2 {
+				return sqlFalse, errors.Errorf("invalid number of arguments for %s", callExpr.Function)
+			}
+			// Both sides are evaluated so unsupported expressions fail like in the SQL converter.
+			left, err := e.evaluate(callExpr.Args[0], memo)
+			if err != nil {
+				return sqlFalse, err
+			}
+			right, err := e.evaluate(callExpr.Args[1], memo)
+			if err != nil {
+				return sqlFalse, err
+			}
+			if callExpr.Function == "_||_" {
+				return left.or(right), nil
+			}
+			return left.and(right), nil
+		case "!_":
+			if len(callExpr.Args) != 1 {
+				return sqlFalse, errors.Errorf("invalid number of arguments for %s", callExpr.Function)
+			}
+			result, err := e.evaluate(callExpr.Args[0], memo)
+			return result.not(), err
+		case "_==_", "_!=_", "_<_", "_>_", "_<=_", "_>=_":
+			return e.evaluateComparison(callExpr, memo)
+		case "@in":
+			return e.evaluateIn(callExpr, memo)
+		case "contains":
+			return wrapSQLBool(e.evaluateContains(callExpr, memo))
+		case "startsWith":
+			return wrapSQLBool(e.evaluateStartsWith(callExpr, memo))
+		case "within_radius":
+			return wrapSQLBool(e.evaluateWithinRadius(callExpr, memo))
+		case "within_bbox":
+			return wrapSQLBool(e.evaluateWithinBBox(callExpr, memo))
+		default:
+			return sqlFalse, errors.Errorf("unsupported call expression function: %s", callExpr.Function)
+		}
+	case *exprv1.Expr_IdentExpr:
+		return wrapSQLBool(e.evaluateIdentifier(v.IdentExpr.GetName(), memo))
+	case *exprv1.Expr_ConstExpr:
+		value, ok := v.ConstExpr.ConstantKind.(*exprv1.Constant_BoolValue)
+		if !ok {
+			return sqlFalse, errors.New("unsupported constant expression")
+		}
+		return toSQLBool(value.BoolValue), nil
+	default:
+		return sqlFalse, errors.New("unsupported expression")
+	}
+}
+
+func wrapSQLBool(value bool, err error) (sqlBool, error) {
+	return toSQLBool(value), err
+}
+
+func (*MemoEvaluator) evaluateIdentifier(identifier string, memo *Memo) (bool, error) {
+	switch identifier {
+	case "pinned":
+		return memo.Pinned, nil
+	case "has_task_list":
+		return memo.HasTaskList, nil
+	case "has_link":
+		return memo.HasLink, nil
+	case "has_code":
+		return memo.HasCode, nil
+	case "has_incomplete_tasks":
+		return memo.HasIncompleteTasks, nil
+	case "has_attachment":
+		return len(memo.AttachmentTypes) > 0, nil
+	case "has_location":
+		return memo.Location != nil, nil
+	case "is_comment":
+		return memo.IsComment, nil
+	default:
+		return false, errors.Errorf("invalid identifier %s", identifier)
+	}
+}
+
+func (e *MemoEvaluator) evaluateComparison(callExpr *exprv1.Expr_Call, memo *Memo) (sqlBool, error) {
+	if len(callExpr.Args) != 2 {
+		return sqlFalse, errors.Errorf("invalid number of arguments for %s", callExpr.Function)
+	}
+
+	if leftCallExpr, ok := callExpr.Args[0].ExprKind.(*exprv1.Expr_CallExpr); ok {
+		switch leftCallExpr.CallExpr.Function {
+		case "size":
+			return wrapSQLBool(e.evaluateSizeComparison(callExpr, leftCallExpr.CallExpr, memo))
+		case "date":
+			return wrapSQLBool(e.evaluateDateComparison(callExpr, leftCallExpr.CallExpr, memo))
+		}
+	}
+
+	identifier, err := GetIdentExprName(callExpr.Args[0])
+	if err != nil {
+		return sqlFalse, err
+	}
+	value, err := getExprValue(callExpr.Args[1], e.timeContext)
+	if err != nil {
+		return sqlFalse, err
+	}
+
+	switch identifier {
+	case "created_ts", "updated_ts":
+		valueInt, ok := value.(int64)
+		if !ok {
+			return sqlFalse, errors.New("invalid integer timestamp value")
+		}
+		field := memo.CreatedTs
+		if identifier == "updated_ts" {
+			field = memo.UpdatedTs
+		}
+		return toSQLBool(compareInt(callExpr.Function, field, valueInt)), nil
+	case "comment_count", "reaction_count":
+		valueInt, ok := value.(int64)
+		if !ok {
+			return sqlFalse, errors.Errorf("invalid integer value for %s", identifier)
+		}
+		field := memo.CommentCount
+		if identifier == "reaction_count" {
+			field = memo.ReactionCount
+		}
+		return toSQLBool(compareInt(callExpr.Function, field, valueInt)), nil
+	}
+
+	if callExpr.Function != "_==_" && callExpr.Function != "_!=_" {
+		return sqlFalse, errors.Errorf("invalid operator for %s", identifier)
+	}
+	var equal bool
+	switch identifier {
+	case "visibility", "content":
+		valueStr, ok := value.(string)
+		if !ok {
+			return sqlFalse, errors.New("invalid string value")
+		}
+		field := memo.Visibility
+		if identifier == "content" {
+			field = memo.Content
+		}
+		equal = field == valueStr
+	case "creator_id":
+		valueInt, ok := value.(int64)
+		if !ok {
+			return sqlFalse, errors.New("invalid int value")
+		}
+		equal = int64(memo.CreatorID) == valueInt
+	case "pinned", "has_task_list", "has_link", "has_code", "has_incomplete_tasks", "has_attachment", "has_location", "is_comment":
+		valueBool, ok := value.(bool)
+		if !ok {
+			return sqlFalse, errors.Errorf("invalid boolean value for %s", identifier)
+		}
+		field, err := e.evaluateIdentifier(identifier, memo)
+		if err != nil {
+			return sqlFalse, err
+		}
+		// On SQLite has_task_list is compared with the JSON value, which is NULL when
+		// the property is false because false values are omitted from the payload.
+		if identifier == "has_task_list" && !field {
+			return sqlNull, nil
+		}
+		equal = field == valueBool
+	case "attachment_type":
+		valueStr, ok := value.(string)
+		if !ok {
+			return sqlFalse, errors.New("invalid string value for attachment_type")
+		}
+		// attachment_type != "x" matches memos without any attachment of that type.
+		equal = slices.Contains(memo.AttachmentTypes, valueStr)
+	default:
+		return sqlFalse, errors.Errorf("invalid identifier for %s", callExpr.Function)
+	}
+	if callExpr.Function == "_!=_" {
+		return toSQLBool(!equal), nil
+	}
+	return toSQLBool(equal), nil
+}
+
+func (e *MemoEvaluator) evaluateSizeComparison(callExpr *exprv1.Expr_Call, sizeCall *exprv1.Expr_Call, memo *Memo) (bool, error) {
+	if len(sizeCall.Args) != 1 {
+		return false, errors.New("size function requires exactly one argument")
+	}
+	identifier, err := GetIdentExprName(sizeCall.Args[0])
+	if err != nil {
+		return false, err
+	}
+	var size int
+	switch identifier {
+	case "tags":
+		size = len(memo.Tags)
+	case "references":
+		size = len(memo.References)
+	default:
+		return false, errors.Errorf("size function only supports 'tags' and 'references' identifiers, got: %s", identifier)
+	}
+	value, err := getExprValue(callExpr.Args[1], e.timeContext)
+	if err != nil {
+		return false, err
+	}
+	valueInt, ok := value.(int64)
+	if !ok {
+		return false, errors.New("size comparison value must be an integer")
+	}
+	return compareInt(callExpr.Function, int64(size), valueInt), nil
+}
+
+func (e *MemoEvaluator) evaluateDateComparison(callExpr *exprv1.Expr_Call, dateCall *exprv1.Expr_Call, memo *Memo) (bool, error) {
+	if len(dateCall.Args) != 1 {
+		return false, errors.New("date function requires exactly one argument")
+	}
+	identifier, err := GetIdentExprName(dateCall.Args[0])
+	if err != nil {
+		return false, err
+	}
+	var field int64
+	switch identifier {
+	case "created_ts":
+		field = memo.CreatedTs
+	case "updated_ts":
+		field = memo.UpdatedTs
+	default:
+		return false, errors.Errorf("date function only supports 'created_ts' and 'updated_ts' identifiers, got: %s", identifier)
+	}
+	value, err := getExprValue(callExpr.Args[1], e.timeContext)
+	if err != nil {
+		return false, err
+	}
+	valueInt, ok := value.(int64)
+	if !ok {
+		return false, errors.New("date comparison value must be an integer timestamp")
+	}
+
+	start, end := e.timeContext.dayRange(valueInt)
+	switch callExpr.Function {
+	case "_==_":
+		return field >= start && field < end, nil
+	case "_!=_":
+		return field < start || field >= end, nil
+	case "_<_":
+		return field < start, nil
+	case "_<=_":
+		return field < end, nil
+	case "_>_":
+		return field >= end, nil
+	case "_>=_":
+		return field >= start, nil
+	default:
+		return false, errors.Errorf("unsupported operator for date comparison: %s", callExpr.Function)
+	}
+}
+
+func (*MemoEvaluator) evaluateIn(callExpr *exprv1.Expr_Call, memo *Memo) (sqlBool, error) {
+	if len(callExpr.Args) != 2 {
+		return sqlFalse, errors.Errorf("invalid number of arguments for %s", callExpr.Function)
+	}
+
+	// Handle "element in collection" syntax.
+	if identifier, err := GetIdentExprName(callExpr.Args[1]); err == nil {
+		element, err := GetConstValue(callExpr.Args[0])
+		if err != nil {
+			return sqlFalse, errors.Errorf("first argument must be a constant value for 'element in %s': %v", identifier, err)
+		}
+		switch identifier {
+		case "tags":
+			return matchTags(memo, func(tag string) bool { return tag == toString(element) }), nil
+		case "references":
+			return toSQLBool(slices.Contains(memo.References, toString(element))), nil
+		default:
+			return sqlFalse, errors.Errorf("invalid collection identifier for %s: %s", callExpr.Function, identifier)
+		}
+	}
+
+	identifier, err := GetIdentExprName(callExpr.Args[0])
+	if err != nil {
+		return sqlFalse, err
+	}
+	values := []string{}
+	for _, element := range callExpr.Args[1].GetListExpr().GetElements() {
+		value, err := GetConstValue(element)
+		if err != nil {
+			return sqlFalse, err
+		}
+		values = append(values, toString(value))
+	}
+
+	switch identifier {
+	case "tag":
+		return matchTags(memo, func(tag string) bool { return slices.Contains(values, tag) }), nil
+	case "visibility":
+		return toSQLBool(slices.Contains(values, memo.Visibility)), nil
+	case "attachment_type":
+		return toSQLBool(slices.ContainsFunc(memo.AttachmentTypes, func(t string) bool { return slices.Contains(values, t) })), nil
+	default:
+		return sqlFalse, errors.Errorf("invalid identifier for %s", callExpr.Function)
+	}
+}
+
+// matchTags matches the tags of the memo. The tags are NULL in SQL when the memo has none,
+// as empty lists are omitted from the payload.
+func matchTags(memo *Memo, match func(tag string) bool) sqlBool {
+	if len(memo.Tags) == 0 {
+		return sqlNull
+	}
+	return toSQLBool(slices.ContainsFunc(memo.Tags, match))
+}
+
+func (*MemoEvaluator) evaluateContains(callExpr *exprv1.Expr_Call, memo *Memo) (bool, error) {
+	if len(callExpr.Args) != 1 {
+		return false, errors.Errorf("invalid number of arguments for %s", callExpr.Function)
+	}
+	identifier, err := GetIdentExprName(callExpr.Target)
+	if err != nil {
+		return false, err
+	}
+	if identifier != "content" {
+		return false, errors.Errorf("invalid identifier for %s", callExpr.Function)
+	}
+	arg, err := GetConstValue(callExpr.Args[0])
+	if err != nil {
+		return false, err
+	}
+	// LIKE and ILIKE match case-insensitively.
+	return strings.Contains(strings.ToLower(memo.Content), strings.ToLower(toString(arg))), nil
+}
+
+func (*MemoEvaluator) evaluateStartsWith(callExpr *exprv1.Expr_Call, memo *Memo) (bool, error) {
+	if len(callExpr.Args) != 1 {
+		return false, errors.Errorf("invalid number of arguments for %s", callExpr.Function)
+	}
+	identifier, err := GetIdentExprName(callExpr.Target)
+	if err != nil {
+		return false, err
+	}
+	if identifier != "attachment_type" {
+		return false, errors.Errorf("invalid identifier for %s", callExpr.Function)
+	}
+	arg, err := GetConstValue(callExpr.Args[0])
+	if err != nil {
+		return false, err
+	}
+	prefix := strings.ToLower(toString(arg))
+	return slices.ContainsFunc(memo.AttachmentTypes, func(t string) bool {
+		return strings.HasPrefix(strings.ToLower(t), prefix)
+	}), nil
+}
+
+func (e *MemoEvaluator) evaluateWithinRadius(callExpr *exprv1.Expr_Call, memo *Memo) (bool, error) {
+	values, err := getFloatArgs(callExpr, 3, e.timeContext)
+	if err != nil {
+		return false, err
+	}
+	area, err := newRadiusArea(values[0], values[1], values[2])
+	if err != nil {
+		return false, err
+	}
+	if memo.Location == nil {
+		return false, nil
+	}
+	return area.contains(memo.Location.Latitude, memo.Location.Longitude), nil
+}
+
+func (e *MemoEvaluator) evaluateWithinBBox(callExpr *exprv1.Expr_Call, memo *Memo) (bool, error) {
+	values, err := getFloatArgs(callExpr, 4, e.timeContext)
+	if err != nil {
+		return false, err
+	}
+	if err := validateBBox(values[0], values[2]); err != nil {
+		return false, err
+	}
+	if memo.Location == nil {
+		return false, nil
+	}
+	return bboxContains(values[0], values[1], values[2], values[3], memo.Location.Latitude, memo.Location.Longitude), nil
+}
+
+func compareInt(function string, left, right int64) bool {
+	switch function {
+	case "_==_":
+		return left == right
+	case "_!=_":
+		return left != right
+	case "_<_":
+		return left < right
+	case "_>_":
+		return left > right
+	case "_<=_":
+		return left <= right
+	case "_>=_":
+		return left >= right
+	default:
+		return false
+	}
+}
+
+func toString(value any) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	return fmt.Sprint(value)
+}
@@ -2,7 +2,6 @@ package filter
 
 import (
 	"errors"
-	"time"
 
 	exprv1 "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
 )
@@ -42,6 +41,10 @@ func GetIdentExprName(expr *exprv1.Expr) (string, error) {
 // GetFunctionValue evaluates CEL function calls and returns their value.
 // This is specifically for time functions like now().
 func GetFunctionValue(expr *exprv1.Expr) (any, error) {
+	return getFunctionValue(expr, defaultTimeContext())
+}
+
+func getFunctionValue(expr *exprv1.Expr, tc *TimeContext) (any, error) {
 	callExpr, ok := expr.ExprKind.(*exprv1.Expr_CallExpr)
 	if !ok {
 		return nil, errors.New("invalid function call expression")
@@ -52,17 +55,74 @@ func GetFunctionValue(expr *exprv1.Expr) (any, error) {
 		if len(callExpr.CallExpr.Args) != 0 {
 			return nil, errors.New("now() function takes no arguments")
 		}
-		return time.Now().Unix(), nil
+		return tc.now().Unix(), nil
+	case "today":
+		if len(callExpr.CallExpr.Args) != 0 {
+			return nil, errors.New("today() function takes no arguments")
+		}
+		return tc.today(), nil
+	case "start_of_week":
+		if len(callExpr.CallExpr.Args) != 0 {
+			return nil, errors.New("start_of_week() function takes no arguments")
+		}
+		return tc.startOfWeek(), nil
+	case "start_of_month":
+		if len(callExpr.CallExpr.Args) != 0 {
+			return nil, errors.New("start_of_month() function takes no arguments")
+		}
+		return tc.startOfMonth(), nil
+	case "timestamp":
+		// Handle timestamp("2025-01-01") and timestamp("2025-01-01T08:00:00Z")
+		if len(callExpr.CallExpr.Args) != 1 {
+			return nil, errors.New("timestamp() function requires exactly one argument")
+		}
+		value, err := GetConstValue(callExpr.CallExpr.Args[0])
+		if err != nil {
+			return nil, err
+		}
+		valueStr, ok := value.(string)
+		if !ok {
+			return nil, errors.New("timestamp() argument must be a string")
+		}
+		return tc.parseTimestamp(valueStr)
+	case "duration":
+		// Handle duration("7d"), returned in seconds
+		if len(callExpr.CallExpr.Args) != 1 {
+			return nil, errors.New("duration() function requires exactly one argument")
+		}
+		value, err := GetConstValue(callExpr.CallExpr.Args[0])
+		if err != nil {
+			return nil, err
+		}
+		valueStr, ok := value.(string)
+		if !ok {
+			return nil, errors.New("duration() argument must be a string")
+		}
+		return parseDuration(valueStr)
+	case "date":
+		// Handle date(timestamp) on constant values, truncating to the start of the day
+		if len(callExpr.CallExpr.Args) != 1 {
+			return nil, errors.New("date() function requires exactly one argument")
+		}
+		value, err := getExprValue(callExpr.CallExpr.Args[0], tc)
+		if err != nil {
+			return nil, err
+		}
+		valueInt, ok := value.(int64)
+		if !ok {
+			return nil, errors.New("date() argument must be an integer timestamp")
+		}
+		return tc.startOfDay(valueInt).Unix(), nil
 	case "_-_":
 		// Handle subtraction for expressions like "now() - 60 * 60 * 24"
 		if len(callExpr.CallExpr.Args) != 2 {
 			return nil, errors.New("subtraction requires exactly two arguments")
 		}
-		left, err := GetExprValue(callExpr.CallExpr.Args[0])
+		left, err := getExprValue(callExpr.CallExpr.Args[0], tc)
 		if err != nil {
 			return nil, err
 		}
-		right, err := GetExprValue(callExpr.CallExpr.Args[1])
+		right, err := getExprValue(callExpr.CallExpr.Args[1], tc)
 		if err != nil {
 			return nil, err
 		}
@@ -77,11 +137,11 @@ func GetFunctionValue(expr *exprv1.Expr) (any, error) {
 		if len(callExpr.CallExpr.Args) != 2 {
 			return nil, errors.New("multiplication requires exactly two arguments")
 		}
-		left, err := GetExprValue(callExpr.CallExpr.Args[0])
+		left, err := getExprValue(callExpr.CallExpr.Args[0], tc)
 		if err != nil {
 			return nil, err
 		}
-		right, err := GetExprValue(callExpr.CallExpr.Args[1])
+		right, err := getExprValue(callExpr.CallExpr.Args[1], tc)
 		if err != nil {
 			return nil, err
 		}
@@ -96,11 +156,11 @@ func GetFunctionValue(expr *exprv1.Expr) (any, error) {
 		if len(callExpr.CallExpr.Args) != 2 {
 			return nil, errors.New("addition requires exactly two arguments")
 		}
-		left, err := GetExprValue(callExpr.CallExpr.Args[0])
+		left, err := getExprValue(callExpr.CallExpr.Args[0], tc)
 		if err != nil {
 			return nil, err
 		}
-		right, err := GetExprValue(callExpr.CallExpr.Args[1])
+		right, err := getExprValue(callExpr.CallExpr.Args[1], tc)
 		if err != nil {
 			return nil, err
 		}
@@ -117,11 +177,15 @@ func GetFunctionValue(expr *exprv1.Expr) (any, error) {
 
 // GetExprValue attempts to get a value from an expression, trying constants first, then functions.
 func GetExprValue(expr *exprv1.Expr) (any, error) {
+	return getExprValue(expr, defaultTimeContext())
+}
+
+func getExprValue(expr *exprv1.Expr, tc *TimeContext) (any, error) {
 	// Try to get constant value first
 	if constValue, err := GetConstValue(expr); err == nil {
 		return constValue, nil
 	}
 
 	// If not a constant, try to evaluate as a function
-	return GetFunctionValue(expr)
+	return getFunctionValue(expr, tc)
 }
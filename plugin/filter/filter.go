@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/env"
 	"github.com/google/cel-go/common/types"
 	"github.com/google/cel-go/common/types/ref"
 	"github.com/pkg/errors"
@@ -24,6 +25,13 @@ var MemoFilterCELAttributes = []cel.EnvOption{
 	cel.Variable("has_link", cel.BoolType),
 	cel.Variable("has_code", cel.BoolType),
 	cel.Variable("has_incomplete_tasks", cel.BoolType),
+	cel.Variable("has_attachment", cel.BoolType),
+	cel.Variable("attachment_type", cel.StringType),
+	cel.Variable("comment_count", cel.IntType),
+	cel.Variable("reaction_count", cel.IntType),
+	cel.Variable("has_location", cel.BoolType),
+	cel.Variable("is_comment", cel.BoolType),
+	cel.Variable("references", cel.ListType(cel.StringType)),
 	// Current timestamp function.
 	cel.Function("now",
 		cel.Overload("now",
@@ -34,6 +42,57 @@ var MemoFilterCELAttributes = []cel.EnvOption{
 			}),
 		),
 	),
+	// Date functions. They are evaluated when converting the filter to SQL,
+	// using the time zone and week start of the request.
+	cel.Function("timestamp",
+		cel.Overload("timestamp_string",
+			[]*cel.Type{cel.StringType},
+			cel.IntType,
+		),
+	),
+	cel.Function("duration",
+		cel.Overload("duration_string",
+			[]*cel.Type{cel.StringType},
+			cel.IntType,
+		),
+	),
+	cel.Function("today",
+		cel.Overload("today",
+			[]*cel.Type{},
+			cel.IntType,
+		),
+	),
+	cel.Function("start_of_week",
+		cel.Overload("start_of_week",
+			[]*cel.Type{},
+			cel.IntType,
+		),
+	),
+	cel.Function("start_of_month",
+		cel.Overload("start_of_month",
+			[]*cel.Type{},
+			cel.IntType,
+		),
+	),
+	cel.Function("date",
+		cel.Overload("date_int",
+			[]*cel.Type{cel.IntType},
+			cel.IntType,
+		),
+	),
+	// Geo functions on the memo location. Arguments are in degrees and kilometers.
+	cel.Function("within_radius",
+		cel.Overload("within_radius",
+			[]*cel.Type{cel.DynType, cel.DynType, cel.DynType},
+			cel.BoolType,
+		),
+	),
+	cel.Function("within_bbox",
+		cel.Overload("within_bbox",
+			[]*cel.Type{cel.DynType, cel.DynType, cel.DynType, cel.DynType},
+			cel.BoolType,
+		),
+	),
 }
 
 // ReactionFilterCELAttributes are the CEL attributes for reaction.
@@ -54,7 +113,7 @@ var AttachmentFilterCELAttributes = []cel.EnvOption{
 // Parse parses the filter string and returns the parsed expression.
 // The filter string should be a CEL expression.
 func Parse(filter string, opts ...cel.EnvOption) (expr *exprv1.ParsedExpr, err error) {
-	e, err := cel.NewEnv(opts...)
+	e, err := newEnv(opts...)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create CEL environment")
 	}
@@ -64,3 +123,11 @@ func Parse(filter string, opts ...cel.EnvOption) (expr *exprv1.ParsedExpr, err e
 	}
 	return cel.AstToParsedExpr(ast)
 }
+
+// newEnv creates the CEL environment for filters with the given attributes.
+func newEnv(opts ...cel.EnvOption) (*cel.Env, error) {
+	// The builtin timestamp and duration conversions are replaced by the integer based
+	// date functions declared in the attributes, so they are excluded from the standard library.
+	stdLibSubset := env.NewLibrarySubset().AddExcludedFunctions(env.NewFunction("timestamp"), env.NewFunction("duration"))
+	return cel.NewCustomEnv(append([]cel.EnvOption{cel.StdLib(cel.StdLibSubset(stdLibSubset))}, opts...)...)
+}
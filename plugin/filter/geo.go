@@ -0,0 +1,204 @@
+package filter
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/pkg/errors"
+	exprv1 "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
+)
+
+const (
+	// kilometersPerLatitudeDegree is the approximate length of one degree of latitude.
+	kilometersPerLatitudeDegree = 110.574
+	// kilometersPerLongitudeDegree is the length of one degree of longitude at the equator.
+	kilometersPerLongitudeDegree = 111.320
+)
+
+// handleWithinRadius converts within_radius(lat, lng, km) to SQL.
+// The distance uses an equirectangular projection around the center, which only needs
+// arithmetic and so works on every dialect. It is accurate enough for city scale radii.
+func (c *CommonSQLConverter) handleWithinRadius(ctx *ConvertContext, callExpr *exprv1.Expr_Call) error {
+	if c.entityType != "memo" {
+		return errors.Errorf("within_radius is not supported for entity type %s", c.entityType)
+	}
+	values, err := getFloatArgs(callExpr, 3, c.timeContext)
+	if err != nil {
+		return err
+	}
+	lat, lng, km := values[0], values[1], values[2]
+	area, err := newRadiusArea(lat, lng, km)
+	if err != nil {
+		return err
+	}
+	latField, lngField := c.getSQLTemplate("location_latitude"), c.getSQLTemplate("location_longitude")
+	conditions := []string{
+		c.getSQLTemplate("has_location"),
+		// Bounding box prefilter.
+		fmt.Sprintf("%s BETWEEN %s AND %s", latField, c.addArg(ctx, lat-area.latDelta), c.addArg(ctx, lat+area.latDelta)),
+	}
+	// The area is split at the antimeridian, and each part is matched against its own center.
+	parts := []string{}
+	for _, center := range area.centers() {
+		parts = append(parts, strings.Join([]string{
+			fmt.Sprintf("%s BETWEEN %s AND %s", lngField, c.addArg(ctx, center-area.lngDelta), c.addArg(ctx, center+area.lngDelta)),
+			fmt.Sprintf("((%s - %s) * %s) * ((%s - %s) * %s) + ((%s - %s) * %s) * ((%s - %s) * %s) <= %s",
+				latField, c.addArg(ctx, lat), c.addArg(ctx, area.latScale),
+				latField, c.addArg(ctx, lat), c.addArg(ctx, area.latScale),
+				lngField, c.addArg(ctx, center), c.addArg(ctx, area.lngScale),
+				lngField, c.addArg(ctx, center), c.addArg(ctx, area.lngScale),
+				c.addArg(ctx, km*km)),
+		}, " AND "))
+	}
+	if len(parts) == 1 {
+		conditions = append(conditions, parts[0])
+	} else {
+		conditions = append(conditions, fmt.Sprintf("((%s))", strings.Join(parts, ") OR (")))
+	}
+	if _, err := ctx.Buffer.WriteString(fmt.Sprintf("(%s)", strings.Join(conditions, " AND "))); err != nil {
+		return err
+	}
+	return nil
+}
+
+// handleWithinBBox converts within_bbox(min_lat, min_lng, max_lat, max_lng) to SQL.
+// A box with min_lng greater than max_lng crosses the antimeridian.
+func (c *CommonSQLConverter) handleWithinBBox(ctx *ConvertContext, callExpr *exprv1.Expr_Call) error {
+	if c.entityType != "memo" {
+		return errors.Errorf("within_bbox is not supported for entity type %s", c.entityType)
+	}
+	values, err := getFloatArgs(callExpr, 4, c.timeContext)
+	if err != nil {
+		return err
+	}
+	minLat, minLng, maxLat, maxLng := values[0], values[1], values[2], values[3]
+	if err := validateBBox(minLat, maxLat); err != nil {
+		return err
+	}
+
+	latField, lngField := c.getSQLTemplate("location_latitude"), c.getSQLTemplate("location_longitude")
+	conditions := []string{
+		c.getSQLTemplate("has_location"),
+		fmt.Sprintf("%s BETWEEN %s AND %s", latField, c.addArg(ctx, minLat), c.addArg(ctx, maxLat)),
+	}
+	if minLng <= maxLng {
+		conditions = append(conditions, fmt.Sprintf("%s BETWEEN %s AND %s", lngField, c.addArg(ctx, minLng), c.addArg(ctx, maxLng)))
+	} else {
+		conditions = append(conditions, fmt.Sprintf("(%s >= %s OR %s <= %s)", lngField, c.addArg(ctx, minLng), lngField, c.addArg(ctx, maxLng)))
+	}
+	if _, err := ctx.Buffer.WriteString(fmt.Sprintf("(%s)", strings.Join(conditions, " AND "))); err != nil {
+		return err
+	}
+	return nil
+}
+
+// radiusArea holds the projection of a within_radius area.
+type radiusArea struct {
+	lat, lng, km       float64
+	latScale, lngScale float64
+	latDelta, lngDelta float64
+}
+
+func newRadiusArea(lat, lng, km float64) (*radiusArea, error) {
+	if lat < -90 || lat > 90 || lng < -180 || lng > 180 {
+		return nil, errors.New("within_radius center is out of range")
+	}
+	if km < 0 {
+		return nil, errors.New("within_radius distance must not be negative")
+	}
+	area := &radiusArea{
+		lat:      lat,
+		lng:      lng,
+		km:       km,
+		latScale: kilometersPerLatitudeDegree,
+		lngScale: kilometersPerLongitudeDegree * math.Cos(lat*math.Pi/180),
+		latDelta: km / kilometersPerLatitudeDegree,
+		lngDelta: 180,
+	}
+	if area.lngScale > 0 {
+		area.lngDelta = math.Min(km/area.lngScale, 180)
+	}
+	return area, nil
+}
+
+// centers returns the longitudes of the center of the area and of its copies shifted by a full turn,
+// whose bounding boxes overlap the valid longitudes. The area crosses the antimeridian when there
+// is more than one.
+func (a *radiusArea) centers() []float64 {
+	centers := []float64{a.lng}
+	for _, center := range []float64{a.lng - 360, a.lng + 360} {
+		if center+a.lngDelta >= -180 && center-a.lngDelta <= 180 {
+			centers = append(centers, center)
+		}
+	}
+	return centers
+}
+
+// contains reports whether the point is in the area, using the same computation as the SQL condition.
+func (a *radiusArea) contains(lat, lng float64) bool {
+	if lat < a.lat-a.latDelta || lat > a.lat+a.latDelta {
+		return false
+	}
+	for _, center := range a.centers() {
+		if lng < center-a.lngDelta || lng > center+a.lngDelta {
+			continue
+		}
+		dy := (lat - a.lat) * a.latScale
+		dx := (lng - center) * a.lngScale
+		if dy*dy+dx*dx <= a.km*a.km {
+			return true
+		}
+	}
+	return false
+}
+
+func validateBBox(minLat, maxLat float64) error {
+	if minLat > maxLat {
+		return errors.New("within_bbox min_lat must not be greater than max_lat")
+	}
+	return nil
+}
+
+// bboxContains reports whether the point is in the box, which crosses the antimeridian when minLng > maxLng.
+func bboxContains(minLat, minLng, maxLat, maxLng, lat, lng float64) bool {
+	if lat < minLat || lat > maxLat {
+		return false
+	}
+	if minLng <= maxLng {
+		return lng >= minLng && lng <= maxLng
+	}
+	return lng >= minLng || lng <= maxLng
+}
+
+func getFloatArgs(callExpr *exprv1.Expr_Call, count int, tc *TimeContext) ([]float64, error) {
+	if len(callExpr.Args) != count {
+		return nil, errors.Errorf("%s requires exactly %d arguments", callExpr.Function, count)
+	}
+	values := make([]float64, 0, count)
+	for _, arg := range callExpr.Args {
+		value, err := getExprValue(arg, tc)
+		if err != nil {
+			return nil, err
+		}
+		switch v := value.(type) {
+		case float64:
+			values = append(values, v)
+		case int64:
+			values = append(values, float64(v))
+		case uint64:
+			values = append(values, float64(v))
+		default:
+			return nil, errors.Errorf("%s arguments must be numbers", callExpr.Function)
+		}
+	}
+	return values, nil
+}
+
+// addArg appends the value to the arguments and returns its placeholder.
+func (c *CommonSQLConverter) addArg(ctx *ConvertContext, value any) string {
+	placeholder := c.dialect.GetParameterPlaceholder(c.paramIndex)
+	ctx.Args = append(ctx.Args, value)
+	c.paramIndex++
+	return placeholder
+}
@@ -92,6 +92,56 @@ var SQLTemplates = map[string]SQLTemplate{
 		MySQL:      "`memo`.`visibility` IN (%s)",
 		PostgreSQL: "memo.visibility IN (%s)",
 	},
+	"has_attachment": {
+		SQLite:     "EXISTS (SELECT 1 FROM `resource` WHERE `resource`.`memo_id` = `memo`.`id`)",
+		MySQL:      "EXISTS (SELECT 1 FROM `resource` WHERE `resource`.`memo_id` = `memo`.`id`)",
+		PostgreSQL: "EXISTS (SELECT 1 FROM resource WHERE resource.memo_id = memo.id)",
+	},
+	"attachment_type_match": {
+		SQLite:     "EXISTS (SELECT 1 FROM `resource` WHERE `resource`.`memo_id` = `memo`.`id` AND `resource`.`type` %s)",
+		MySQL:      "EXISTS (SELECT 1 FROM `resource` WHERE `resource`.`memo_id` = `memo`.`id` AND `resource`.`type` %s)",
+		PostgreSQL: "EXISTS (SELECT 1 FROM resource WHERE resource.memo_id = memo.id AND resource.type %s)",
+	},
+	"comment_count": {
+		SQLite:     "(SELECT COUNT(*) FROM `memo_relation` AS `mr` WHERE `mr`.`related_memo_id` = `memo`.`id` AND `mr`.`type` = 'COMMENT')",
+		MySQL:      "(SELECT COUNT(*) FROM `memo_relation` AS `mr` WHERE `mr`.`related_memo_id` = `memo`.`id` AND `mr`.`type` = 'COMMENT')",
+		PostgreSQL: "(SELECT COUNT(*) FROM memo_relation AS mr WHERE mr.related_memo_id = memo.id AND mr.type = 'COMMENT')",
+	},
+	"reaction_count": {
+		SQLite:     "(SELECT COUNT(*) FROM `reaction` WHERE `reaction`.`content_id` = 'memos/' || `memo`.`uid`)",
+		MySQL:      "(SELECT COUNT(*) FROM `reaction` WHERE `reaction`.`content_id` = CONCAT('memos/', `memo`.`uid`))",
+		PostgreSQL: "(SELECT COUNT(*) FROM reaction WHERE reaction.content_id = 'memos/' || memo.uid)",
+	},
+	"has_location": {
+		SQLite:     "JSON_EXTRACT(`memo`.`payload`, '$.location') IS NOT NULL",
+		MySQL:      "JSON_EXTRACT(`memo`.`payload`, '$.location') IS NOT NULL",
+		PostgreSQL: "memo.payload->'location' IS NOT NULL",
+	},
+	"location_latitude": {
+		SQLite:     "COALESCE(JSON_EXTRACT(`memo`.`payload`, '$.location.latitude'), 0)",
+		MySQL:      "COALESCE(CAST(JSON_EXTRACT(`memo`.`payload`, '$.location.latitude') AS DECIMAL(10,7)), 0)",
+		PostgreSQL: "COALESCE((memo.payload->'location'->>'latitude')::double precision, 0)",
+	},
+	"location_longitude": {
+		SQLite:     "COALESCE(JSON_EXTRACT(`memo`.`payload`, '$.location.longitude'), 0)",
+		MySQL:      "COALESCE(CAST(JSON_EXTRACT(`memo`.`payload`, '$.location.longitude') AS DECIMAL(10,7)), 0)",
+		PostgreSQL: "COALESCE((memo.payload->'location'->>'longitude')::double precision, 0)",
+	},
+	"is_comment": {
+		SQLite:     "EXISTS (SELECT 1 FROM `memo_relation` AS `mr` WHERE `mr`.`memo_id` = `memo`.`id` AND `mr`.`type` = 'COMMENT')",
+		MySQL:      "EXISTS (SELECT 1 FROM `memo_relation` AS `mr` WHERE `mr`.`memo_id` = `memo`.`id` AND `mr`.`type` = 'COMMENT')",
+		PostgreSQL: "EXISTS (SELECT 1 FROM memo_relation AS mr WHERE mr.memo_id = memo.id AND mr.type = 'COMMENT')",
+	},
+	"references_count": {
+		SQLite:     "(SELECT COUNT(*) FROM `memo_relation` AS `mr` WHERE `mr`.`memo_id` = `memo`.`id` AND `mr`.`type` = 'REFERENCE')",
+		MySQL:      "(SELECT COUNT(*) FROM `memo_relation` AS `mr` WHERE `mr`.`memo_id` = `memo`.`id` AND `mr`.`type` = 'REFERENCE')",
+		PostgreSQL: "(SELECT COUNT(*) FROM memo_relation AS mr WHERE mr.memo_id = memo.id AND mr.type = 'REFERENCE')",
+	},
+	"references_contains": {
+		SQLite:     "EXISTS (SELECT 1 FROM `memo_relation` AS `mr` JOIN `memo` AS `referenced_memo` ON `referenced_memo`.`id` = `mr`.`related_memo_id` WHERE `mr`.`memo_id` = `memo`.`id` AND `mr`.`type` = 'REFERENCE' AND `referenced_memo`.`uid` = %s)",
+		MySQL:      "EXISTS (SELECT 1 FROM `memo_relation` AS `mr` JOIN `memo` AS `referenced_memo` ON `referenced_memo`.`id` = `mr`.`related_memo_id` WHERE `mr`.`memo_id` = `memo`.`id` AND `mr`.`type` = 'REFERENCE' AND `referenced_memo`.`uid` = %s)",
+		PostgreSQL: "EXISTS (SELECT 1 FROM memo_relation AS mr JOIN memo AS referenced_memo ON referenced_memo.id = mr.related_memo_id WHERE mr.memo_id = memo.id AND mr.type = 'REFERENCE' AND referenced_memo.uid = %s)",
+	},
 }
 
 // GetSQL returns the appropriate SQL for the given template and database type.
@@ -0,0 +1,260 @@
+package filter
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common"
+	exprv1 "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
+)
+
+// Kind is the kind of entity a filter applies to.
+type Kind string
+
+const (
+	MemoFilter       Kind = "memo"
+	UserFilter       Kind = "user"
+	AttachmentFilter Kind = "attachment"
+	ReactionFilter   Kind = "reaction"
+)
+
+// Error is a filter validation error with its position in the filter.
+type Error struct {
+	Message string
+	// Line is the 1-based line of the error.
+	Line int
+	// Column is the 0-based column of the error within the line.
+	Column int
+	// Offset is the 0-based character offset of the error within the filter.
+	Offset int
+	// Suggestions are the known names close to an unknown one.
+	Suggestions []string
+}
+
+func (e *Error) Error() string {
+	message := fmt.Sprintf("%s (line %d, column %d)", e.Message, e.Line, e.Column+1)
+	if len(e.Suggestions) > 0 {
+		message += fmt.Sprintf(", did you mean %s?", strings.Join(e.Suggestions, " or "))
+	}
+	return message
+}
+
+// exprError is a conversion error of the expression with the given ID.
+type exprError struct {
+	exprID int64
+	err    error
+}
+
+func (e *exprError) Error() string {
+	return e.err.Error()
+}
+
+func (e *exprError) Unwrap() error {
+	return e.err
+}
+
+// GetCELAttributes returns the CEL attributes of the filter kind.
+func GetCELAttributes(kind Kind) ([]cel.EnvOption, bool) {
+	switch kind {
+	case MemoFilter:
+		return MemoFilterCELAttributes, true
+	case UserFilter:
+		return UserFilterCELAttributes, true
+	case AttachmentFilter:
+		return AttachmentFilterCELAttributes, true
+	case ReactionFilter:
+		return ReactionFilterCELAttributes, true
+	default:
+		return nil, false
+	}
+}
+
+func newSQLConverter(kind Kind, dialect SQLDialect) *CommonSQLConverter {
+	if kind == UserFilter {
+		return NewUserSQLConverter(dialect)
+	}
+	return NewCommonSQLConverter(dialect)
+}
+
+var undeclaredReferenceRegex = regexp.MustCompile(`undeclared reference to '([^']+)'`)
+
+// Validate type-checks the filter against the attributes of the kind and checks that
+// it can be converted to SQL with the dialect. It returns no errors for a valid filter.
+func Validate(filter string, kind Kind, dialect SQLDialect) []*Error {
+	source := common.NewTextSource(filter)
+	if strings.TrimSpace(filter) == "" {
+		return []*Error{newError(source, 0, "filter cannot be empty")}
+	}
+	attributes, ok := GetCELAttributes(kind)
+	if !ok {
+		return []*Error{newError(source, 0, fmt.Sprintf("unknown filter kind %q", kind))}
+	}
+	e, err := newEnv(attributes...)
+	if err != nil {
+		return []*Error{newError(source, 0, err.Error())}
+	}
+
+	ast, issues := e.Compile(filter)
+	if issues != nil && issues.Err() != nil {
+		errs := []*Error{}
+		for _, issue := range issues.Errors() {
+			offset, _ := source.LocationOffset(issue.Location)
+			validationErr := newError(source, int(offset), issue.Message)
+			if matches := undeclaredReferenceRegex.FindStringSubmatch(issue.Message); len(matches) == 2 {
+				validationErr.Message = fmt.Sprintf("unknown name '%s'", matches[1])
+				validationErr.Suggestions = suggestNames(matches[1], getDeclaredNames(e))
+			}
+			errs = append(errs, validationErr)
+		}
+		return errs
+	}
+	parsedExpr, err := cel.AstToParsedExpr(ast)
+	if err != nil {
+		return []*Error{newError(source, 0, err.Error())}
+	}
+
+	// The type checker accepts every declared attribute, but the SQL converter only
+	// supports some usages of them, e.g. no ordering on strings.
+	if err := newSQLConverter(kind, dialect).ConvertExprToSQL(NewConvertContext(), parsedExpr.GetExpr()); err != nil {
+		offset := 0
+		if exprErr, ok := err.(*exprError); ok {
+			if expr := findExpr(parsedExpr.GetExpr(), exprErr.exprID); expr != nil {
+				if start := getStartOffset(expr, parsedExpr.GetSourceInfo()); start != math.MaxInt32 {
+					offset = int(start)
+				}
+			}
+		}
+		return []*Error{newError(source, offset, err.Error())}
+	}
+	return nil
+}
+
+func newError(source common.Source, offset int, message string) *Error {
+	validationErr := &Error{
+		Message: message,
+		Line:    1,
+		Offset:  offset,
+	}
+	if location, ok := source.OffsetLocation(int32(offset)); ok {
+		validationErr.Line = location.Line()
+		validationErr.Column = location.Column()
+	}
+	return validationErr
+}
+
+// getDeclaredNames returns the variable and function names of the environment.
+func getDeclaredNames(e *cel.Env) []string {
+	names := []string{}
+	for _, variable := range e.Variables() {
+		names = append(names, variable.Name())
+	}
+	for name := range e.Functions() {
+		// Skip operators such as _==_ and @in.
+		if strings.HasPrefix(name, "_") || strings.HasPrefix(name, "@") || strings.Contains(name, ".") {
+			continue
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// suggestNames returns up to three names closest to the unknown name.
+func suggestNames(unknown string, names []string) []string {
+	maxDistance := max(2, len(unknown)/3)
+	type candidate struct {
+		name     string
+		distance int
+	}
+	candidates := []candidate{}
+	for _, name := range names {
+		distance := levenshtein(strings.ToLower(unknown), strings.ToLower(name))
+		if distance <= maxDistance || strings.Contains(name, unknown) {
+			candidates = append(candidates, candidate{name: name, distance: distance})
+		}
+	}
+	slices.SortFunc(candidates, func(a, b candidate) int {
+		if a.distance != b.distance {
+			return a.distance - b.distance
+		}
+		return strings.Compare(a.name, b.name)
+	})
+	suggestions := []string{}
+	for _, c := range candidates {
+		if len(suggestions) == 3 {
+			break
+		}
+		suggestions = append(suggestions, c.name)
+	}
+	return suggestions
+}
+
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
+
+// getChildExprs returns the direct sub-expressions of the expression.
+func getChildExprs(expr *exprv1.Expr) []*exprv1.Expr {
+	switch v := expr.ExprKind.(type) {
+	case *exprv1.Expr_CallExpr:
+		children := []*exprv1.Expr{}
+		if v.CallExpr.Target != nil {
+			children = append(children, v.CallExpr.Target)
+		}
+		return append(children, v.CallExpr.Args...)
+	case *exprv1.Expr_ListExpr:
+		return v.ListExpr.Elements
+	case *exprv1.Expr_SelectExpr:
+		return []*exprv1.Expr{v.SelectExpr.Operand}
+	default:
+		return nil
+	}
+}
+
+func findExpr(expr *exprv1.Expr, id int64) *exprv1.Expr {
+	if expr == nil {
+		return nil
+	}
+	if expr.GetId() == id {
+		return expr
+	}
+	for _, child := range getChildExprs(expr) {
+		if found := findExpr(child, id); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// getStartOffset returns the smallest character offset of the expression and its children,
+// which is where the expression starts in the filter.
+func getStartOffset(expr *exprv1.Expr, sourceInfo *exprv1.SourceInfo) int32 {
+	offset, ok := sourceInfo.GetPositions()[expr.GetId()]
+	if !ok {
+		offset = math.MaxInt32
+	}
+	for _, child := range getChildExprs(expr) {
+		offset = min(offset, getStartOffset(child, sourceInfo))
+	}
+	return offset
+}
@@ -0,0 +1,127 @@
+package local
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/plugin/storage"
+)
+
+// Backend stores objects as files in a directory of the local file system.
+// Absolute keys are used as they are, for filepath templates with an absolute path.
+type Backend struct {
+	root string
+}
+
+// New returns a backend that stores objects in the root directory.
+func New(root string) *Backend {
+	return &Backend{root: root}
+}
+
+func (b *Backend) path(key string) (string, error) {
+	p := filepath.FromSlash(key)
+	if filepath.IsAbs(p) {
+		return filepath.Clean(p), nil
+	}
+	p = filepath.Join(b.root, p)
+	if rel, err := filepath.Rel(b.root, p); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", errors.Errorf("invalid key %q", key)
+	}
+	return p, nil
+}
+
+func (b *Backend) Put(_ context.Context, key string, _ string, content io.Reader) (int64, error) {
+	p, err := b.path(key)
+	if err != nil {
+		return 0, err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), os.ModePerm); err != nil {
+		return 0, errors.Wrap(err, "failed to create directory")
+	}
+	// The content is written to a temporary file first, so that a failed write leaves no partial file.
+	file, err := os.CreateTemp(filepath.Dir(p), ".upload-*")
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to create file")
+	}
+	size, err := io.Copy(file, content)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(file.Name(), p)
+	}
+	if err != nil {
+		os.Remove(file.Name())
+		return 0, errors.Wrap(err, "failed to write file")
+	}
+	return size, nil
+}
+
+func (b *Backend) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	return b.Range(ctx, key, 0, -1)
+}
+
+func (b *Backend) Range(_ context.Context, key string, offset, length int64) (io.ReadCloser, error) {
+	p, err := b.path(key)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(p)
+	if err != nil {
+		return nil, convertError(err, "failed to open file")
+	}
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		file.Close()
+		return nil, errors.Wrap(err, "failed to seek file")
+	}
+	if length < 0 {
+		return file, nil
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{io.LimitReader(file, length), file}, nil
+}
+
+func (b *Backend) Delete(_ context.Context, key string) error {
+	p, err := b.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(p); err != nil {
+		return convertError(err, "failed to delete file")
+	}
+	return nil
+}
+
+func (*Backend) Presign(context.Context, string, time.Duration) (string, error) {
+	return "", storage.ErrNotSupported
+}
+
+func (b *Backend) Stat(_ context.Context, key string) (*storage.ObjectInfo, error) {
+	p, err := b.path(key)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(p)
+	if err != nil {
+		return nil, convertError(err, "failed to stat file")
+	}
+	return &storage.ObjectInfo{
+		Size:    info.Size(),
+		ModTime: info.ModTime(),
+	}, nil
+}
+
+func convertError(err error, message string) error {
+	if os.IsNotExist(err) {
+		return errors.Wrap(storage.ErrNotFound, message)
+	}
+	return errors.Wrap(err, message)
+}
@@ -0,0 +1,40 @@
+package local
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/plugin/storage/storagetest"
+)
+
+func TestBackend(t *testing.T) {
+	storagetest.TestBackend(t, New(t.TempDir()))
+}
+
+func TestBackendPaths(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	backend := New(root)
+
+	_, err := backend.Put(ctx, "assets/file.txt", "text/plain", bytes.NewReader([]byte("content")))
+	require.NoError(t, err)
+	data, err := os.ReadFile(filepath.Join(root, "assets", "file.txt"))
+	require.NoError(t, err)
+	require.Equal(t, "content", string(data))
+
+	// Absolute keys are outside of the root.
+	absolute := filepath.Join(t.TempDir(), "file.txt")
+	_, err = backend.Put(ctx, filepath.ToSlash(absolute), "text/plain", bytes.NewReader([]byte("content")))
+	require.NoError(t, err)
+	_, err = os.Stat(absolute)
+	require.NoError(t, err)
+
+	// Relative keys must not escape the root.
+	_, err = backend.Put(ctx, "../file.txt", "text/plain", bytes.NewReader([]byte("content")))
+	require.Error(t, err)
+}
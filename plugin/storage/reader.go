@@ -0,0 +1,86 @@
+package storage
+
+import (
+	"context"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// Reader reads an object of a backend with seeking. The first read after a seek opens the
+// rest of the object from the new offset with Range, so that only the read bytes are
+// transferred, e.g. for the range requests of a video player.
+type Reader struct {
+	ctx     context.Context
+	backend Backend
+	key     string
+	size    int64
+
+	offset int64
+	body   io.ReadCloser
+}
+
+// NewReader opens the object at the key for reading with seeking.
+func NewReader(ctx context.Context, backend Backend, key string) (*Reader, error) {
+	info, err := backend.Stat(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	return &Reader{
+		ctx:     ctx,
+		backend: backend,
+		key:     key,
+		size:    info.Size,
+	}, nil
+}
+
+// Size returns the size of the object.
+func (r *Reader) Size() int64 {
+	return r.size
+}
+
+func (r *Reader) Read(p []byte) (int, error) {
+	if r.offset >= r.size {
+		return 0, io.EOF
+	}
+	if r.body == nil {
+		body, err := r.backend.Range(r.ctx, r.key, r.offset, -1)
+		if err != nil {
+			return 0, err
+		}
+		r.body = body
+	}
+	n, err := r.body.Read(p)
+	r.offset += int64(n)
+	return n, err
+}
+
+func (r *Reader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += r.offset
+	case io.SeekEnd:
+		offset += r.size
+	default:
+		return 0, errors.New("invalid whence")
+	}
+	if offset < 0 {
+		return 0, errors.New("negative position")
+	}
+	if offset != r.offset && r.body != nil {
+		r.body.Close()
+		r.body = nil
+	}
+	r.offset = offset
+	return offset, nil
+}
+
+func (r *Reader) Close() error {
+	if r.body == nil {
+		return nil
+	}
+	err := r.body.Close()
+	r.body = nil
+	return err
+}
@@ -0,0 +1,104 @@
+package s3
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/plugin/storage"
+)
+
+var _ storage.Backend = (*Client)(nil)
+
+func (c *Client) Put(ctx context.Context, key string, contentType string, content io.Reader) (int64, error) {
+	// The uploader sends large content in parts with a multipart upload.
+	counter := &countingReader{Reader: content}
+	if _, err := c.UploadObject(ctx, key, contentType, counter); err != nil {
+		return 0, errors.Wrap(err, "failed to upload object")
+	}
+	return counter.n, nil
+}
+
+func (c *Client) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	return c.Range(ctx, key, 0, -1)
+}
+
+func (c *Client) Range(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error) {
+	if length == 0 {
+		return io.NopCloser(bytes.NewReader(nil)), nil
+	}
+	input := &s3.GetObjectInput{
+		Bucket: c.Bucket,
+		Key:    aws.String(key),
+	}
+	if length > 0 {
+		input.Range = aws.String(fmt.Sprintf("bytes=%d-%d", offset, offset+length-1))
+	} else if offset > 0 {
+		input.Range = aws.String(fmt.Sprintf("bytes=%d-", offset))
+	}
+	output, err := c.Client.GetObject(ctx, input)
+	if err != nil {
+		return nil, convertError(err, "failed to get object")
+	}
+	return output.Body, nil
+}
+
+func (c *Client) Delete(ctx context.Context, key string) error {
+	return c.DeleteObject(ctx, key)
+}
+
+func (c *Client) Presign(ctx context.Context, key string, expires time.Duration) (string, error) {
+	presignClient := s3.NewPresignClient(c.Client)
+	presignResult, err := presignClient.PresignGetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(*c.Bucket),
+		Key:    aws.String(key),
+	}, func(opts *s3.PresignOptions) {
+		opts.Expires = expires
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to presign get object")
+	}
+	return presignResult.URL, nil
+}
+
+func (c *Client) Stat(ctx context.Context, key string) (*storage.ObjectInfo, error) {
+	output, err := c.Client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: c.Bucket,
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, convertError(err, "failed to head object")
+	}
+	return &storage.ObjectInfo{
+		Size:    aws.ToInt64(output.ContentLength),
+		ModTime: aws.ToTime(output.LastModified),
+	}, nil
+}
+
+func convertError(err error, message string) error {
+	var noSuchKey *types.NoSuchKey
+	var notFound *types.NotFound
+	if errors.As(err, &noSuchKey) || errors.As(err, &notFound) {
+		return errors.Wrap(storage.ErrNotFound, message)
+	}
+	return errors.Wrap(err, message)
+}
+
+// countingReader counts the bytes read from the reader.
+type countingReader struct {
+	io.Reader
+	n int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	r.n += int64(n)
+	return n, err
+}
@@ -3,6 +3,7 @@ package s3
 import (
 	"context"
 	"io"
+	"net/http"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -10,11 +11,16 @@ import (
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/pkg/errors"
 
 	storepb "github.com/usememos/memos/proto/gen/store"
 )
 
+// PresignExpiration is the expiration time of the presigned URLs of objects.
+// Reference: https://docs.aws.amazon.com/AmazonS3/latest/API/sigv4-query-string-auth.html
+const PresignExpiration = 5 * 24 * time.Hour
+
 type Client struct {
 	Client *s3.Client
 	Bucket *string
@@ -62,21 +68,56 @@ func (c *Client) UploadObject(ctx context.Context, key string, fileType string,
 	return *resultKey, nil
 }
 
-// PresignGetObject presigns an object in S3.
+// PresignGetObject presigns an object in S3 for PresignExpiration.
 func (c *Client) PresignGetObject(ctx context.Context, key string) (string, error) {
+	return c.Presign(ctx, key, PresignExpiration)
+}
+
+// PresignedRequest is a presigned request that is sent to S3 without the credentials.
+type PresignedRequest struct {
+	URL    string
+	Method string
+	// Header is the signed headers that must be sent with the request.
+	Header http.Header
+}
+
+// PresignPutObject presigns an upload of an object in S3 for the expiration. The content type
+// and the content length are signed, so that S3 rejects uploads of other content.
+func (c *Client) PresignPutObject(ctx context.Context, key string, contentType string, contentLength int64, expires time.Duration) (*PresignedRequest, error) {
 	presignClient := s3.NewPresignClient(c.Client)
-	presignResult, err := presignClient.PresignGetObject(ctx, &s3.GetObjectInput{
-		Bucket: aws.String(*c.Bucket),
-		Key:    aws.String(key),
+	presignResult, err := presignClient.PresignPutObject(ctx, &s3.PutObjectInput{
+		Bucket:        c.Bucket,
+		Key:           aws.String(key),
+		ContentType:   aws.String(contentType),
+		ContentLength: aws.Int64(contentLength),
 	}, func(opts *s3.PresignOptions) {
-		// Set the expiration time of the presigned URL to 5 days.
-		// Reference: https://docs.aws.amazon.com/AmazonS3/latest/API/sigv4-query-string-auth.html
-		opts.Expires = time.Duration(5 * 24 * time.Hour)
+		opts.Expires = expires
 	})
 	if err != nil {
-		return "", errors.Wrap(err, "failed to presign put object")
+		return nil, errors.Wrap(err, "failed to presign put object")
 	}
-	return presignResult.URL, nil
+	return &PresignedRequest{
+		URL:    presignResult.URL,
+		Method: presignResult.Method,
+		Header: presignResult.SignedHeader,
+	}, nil
+}
+
+// GetObject downloads an object from S3.
+func (c *Client) GetObject(ctx context.Context, key string) ([]byte, error) {
+	output, err := c.Client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: c.Bucket,
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get object")
+	}
+	defer output.Body.Close()
+	data, err := io.ReadAll(output.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read object")
+	}
+	return data, nil
 }
 
 // DeleteObject deletes an object in S3.
@@ -90,3 +131,20 @@ func (c *Client) DeleteObject(ctx context.Context, key string) error {
 	}
 	return nil
 }
+
+// ListObjects lists the objects in S3 with a key prefix.
+func (c *Client) ListObjects(ctx context.Context, prefix string) ([]types.Object, error) {
+	objects := []types.Object{}
+	paginator := s3.NewListObjectsV2Paginator(c.Client, &s3.ListObjectsV2Input{
+		Bucket: c.Bucket,
+		Prefix: aws.String(prefix),
+	})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list objects")
+		}
+		objects = append(objects, output.Contents...)
+	}
+	return objects, nil
+}
@@ -0,0 +1,53 @@
+package s3
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/plugin/storage/storagetest"
+	storepb "github.com/usememos/memos/proto/gen/store"
+)
+
+func TestBackend(t *testing.T) {
+	server := httptest.NewServer(storagetest.NewFakeS3())
+	defer server.Close()
+
+	client, err := NewClient(context.Background(), &storepb.StorageS3Config{
+		AccessKeyId:     "access-key",
+		AccessKeySecret: "secret-key",
+		Endpoint:        server.URL,
+		Region:          "us-east-1",
+		Bucket:          "memos",
+		UsePathStyle:    true,
+	})
+	require.NoError(t, err)
+	storagetest.TestBackend(t, client)
+
+	url, err := client.Presign(context.Background(), "assets/image.png", time.Hour)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(url, server.URL+"/memos/assets/image.png?"))
+	require.Contains(t, url, "X-Amz-Expires=3600")
+
+	request, err := client.PresignPutObject(context.Background(), "assets/upload.txt", "text/plain", 7, time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, http.MethodPut, request.Method)
+	require.True(t, strings.HasPrefix(request.URL, server.URL+"/memos/assets/upload.txt?"))
+	require.Equal(t, "text/plain", request.Header.Get("Content-Type"))
+	require.Contains(t, request.URL, "content-length")
+	upload, err := http.NewRequest(request.Method, request.URL, strings.NewReader("content"))
+	require.NoError(t, err)
+	upload.Header.Set("Content-Type", "text/plain")
+	response, err := http.DefaultClient.Do(upload)
+	require.NoError(t, err)
+	response.Body.Close()
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	info, err := client.Stat(context.Background(), "assets/upload.txt")
+	require.NoError(t, err)
+	require.Equal(t, int64(7), info.Size)
+}
@@ -0,0 +1,224 @@
+package sftp
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"net"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/usememos/memos/plugin/storage"
+	storepb "github.com/usememos/memos/proto/gen/store"
+)
+
+const dialTimeout = 30 * time.Second
+
+// Client stores objects as files of an SFTP server. Every operation connects to the server,
+// and the readers of Get and Range keep their connection until they are closed.
+type Client struct {
+	address string
+	config  *ssh.ClientConfig
+}
+
+var _ storage.Backend = (*Client)(nil)
+
+func NewClient(config *storepb.StorageSFTPConfig) (*Client, error) {
+	if config.HostPublicKey == "" {
+		return nil, errors.New("host public key is required")
+	}
+	hostPublicKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(config.HostPublicKey))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid host public key")
+	}
+	auth := []ssh.AuthMethod{}
+	if config.PrivateKey != "" {
+		signer, err := ssh.ParsePrivateKey([]byte(config.PrivateKey))
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid private key")
+		}
+		auth = append(auth, ssh.PublicKeys(signer))
+	}
+	if config.Password != "" {
+		auth = append(auth, ssh.Password(config.Password))
+	}
+	if len(auth) == 0 {
+		return nil, errors.New("password or private key is required")
+	}
+
+	address := config.Address
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		address = net.JoinHostPort(address, "22")
+	}
+	return &Client{
+		address: address,
+		config: &ssh.ClientConfig{
+			User:            config.Username,
+			Auth:            auth,
+			HostKeyCallback: ssh.FixedHostKey(hostPublicKey),
+			Timeout:         dialTimeout,
+		},
+	}, nil
+}
+
+// connection is an SFTP session over its own SSH connection.
+type connection struct {
+	*sftp.Client
+	ssh *ssh.Client
+}
+
+func (c *connection) Close() error {
+	err := c.Client.Close()
+	if sshErr := c.ssh.Close(); err == nil {
+		err = sshErr
+	}
+	return err
+}
+
+func (c *Client) connect(ctx context.Context) (*connection, error) {
+	dialer := &net.Dialer{Timeout: dialTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", c.address)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to connect to the server")
+	}
+	sshConn, channels, requests, err := ssh.NewClientConn(conn, c.address, c.config)
+	if err != nil {
+		conn.Close()
+		return nil, errors.Wrap(err, "failed to establish ssh connection")
+	}
+	sshClient := ssh.NewClient(sshConn, channels, requests)
+	sftpClient, err := sftp.NewClient(sshClient)
+	if err != nil {
+		sshClient.Close()
+		return nil, errors.Wrap(err, "failed to start sftp session")
+	}
+	return &connection{Client: sftpClient, ssh: sshClient}, nil
+}
+
+func (c *Client) Put(ctx context.Context, key string, _ string, content io.Reader) (int64, error) {
+	conn, err := c.connect(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	dir := path.Dir(key)
+	if err := conn.MkdirAll(dir); err != nil {
+		return 0, errors.Wrap(err, "failed to create directory")
+	}
+	// The content is written to a temporary file first, so that a failed write leaves no partial file.
+	tempPath := path.Join(dir, ".upload-"+strconv.FormatInt(time.Now().UnixNano(), 36))
+	file, err := conn.Create(tempPath)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to create file")
+	}
+	size, err := io.Copy(file, content)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = rename(conn, tempPath, key)
+	}
+	if err != nil {
+		conn.Remove(tempPath)
+		return 0, errors.Wrap(err, "failed to write file")
+	}
+	return size, nil
+}
+
+// rename replaces the file at newPath with the file at oldPath.
+func rename(conn *connection, oldPath, newPath string) error {
+	if err := conn.PosixRename(oldPath, newPath); err == nil {
+		return nil
+	}
+	// Servers without the posix-rename extension do not rename over existing files.
+	if err := conn.Remove(newPath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return conn.Rename(oldPath, newPath)
+}
+
+func (c *Client) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	return c.Range(ctx, key, 0, -1)
+}
+
+func (c *Client) Range(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error) {
+	conn, err := c.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	file, err := conn.Open(key)
+	if err != nil {
+		conn.Close()
+		return nil, convertError(err, "failed to open file")
+	}
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		file.Close()
+		conn.Close()
+		return nil, errors.Wrap(err, "failed to seek file")
+	}
+	var reader io.Reader = file
+	if length >= 0 {
+		reader = io.LimitReader(file, length)
+	}
+	return &fileReader{Reader: reader, file: file, conn: conn}, nil
+}
+
+// fileReader closes the connection of the file with the file.
+type fileReader struct {
+	io.Reader
+	file *sftp.File
+	conn *connection
+}
+
+func (r *fileReader) Close() error {
+	err := r.file.Close()
+	if connErr := r.conn.Close(); err == nil {
+		err = connErr
+	}
+	return err
+}
+
+func (c *Client) Delete(ctx context.Context, key string) error {
+	conn, err := c.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if err := conn.Remove(key); err != nil {
+		return convertError(err, "failed to delete file")
+	}
+	return nil
+}
+
+func (*Client) Presign(context.Context, string, time.Duration) (string, error) {
+	return "", storage.ErrNotSupported
+}
+
+func (c *Client) Stat(ctx context.Context, key string) (*storage.ObjectInfo, error) {
+	conn, err := c.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	info, err := conn.Stat(key)
+	if err != nil {
+		return nil, convertError(err, "failed to stat file")
+	}
+	return &storage.ObjectInfo{
+		Size:    info.Size(),
+		ModTime: info.ModTime(),
+	}, nil
+}
+
+func convertError(err error, message string) error {
+	if errors.Is(err, fs.ErrNotExist) {
+		return errors.Wrap(storage.ErrNotFound, message)
+	}
+	return errors.Wrap(err, message)
+}
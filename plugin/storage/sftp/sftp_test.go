@@ -0,0 +1,138 @@
+package sftp
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"net"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/pkg/sftp"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/usememos/memos/plugin/storage/storagetest"
+	storepb "github.com/usememos/memos/proto/gen/store"
+)
+
+// newTestingServer starts an SSH server with an in-memory SFTP subsystem, and returns its
+// address and its public key in the authorized_keys format.
+func newTestingServer(t *testing.T, userPublicKey ssh.PublicKey) (string, string) {
+	_, hostPrivateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	hostSigner, err := ssh.NewSignerFromKey(hostPrivateKey)
+	require.NoError(t, err)
+	config := &ssh.ServerConfig{
+		PasswordCallback: func(conn ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+			if conn.User() == "memos" && string(password) == "secret" {
+				return nil, nil
+			}
+			return nil, errors.New("invalid password")
+		},
+		PublicKeyCallback: func(conn ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if conn.User() == "memos" && userPublicKey != nil && bytes.Equal(key.Marshal(), userPublicKey.Marshal()) {
+				return nil, nil
+			}
+			return nil, errors.New("invalid public key")
+		},
+	}
+	config.AddHostKey(hostSigner)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+	handlers := sftp.InMemHandler()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveSSH(conn, config, handlers)
+		}
+	}()
+	return listener.Addr().String(), string(ssh.MarshalAuthorizedKey(hostSigner.PublicKey()))
+}
+
+func serveSSH(conn net.Conn, config *ssh.ServerConfig, handlers sftp.Handlers) {
+	_, channels, requests, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		conn.Close()
+		return
+	}
+	go ssh.DiscardRequests(requests)
+	for newChannel := range channels {
+		if newChannel.ChannelType() != "session" {
+			newChannel.Reject(ssh.UnknownChannelType, "unknown channel type")
+			continue
+		}
+		channel, channelRequests, err := newChannel.Accept()
+		if err != nil {
+			continue
+		}
+		go func() {
+			for req := range channelRequests {
+				req.Reply(req.Type == "subsystem" && len(req.Payload) > 4 && string(req.Payload[4:]) == "sftp", nil)
+			}
+		}()
+		go func() {
+			server := sftp.NewRequestServer(channel, handlers)
+			server.Serve()
+			server.Close()
+		}()
+	}
+}
+
+func TestBackend(t *testing.T) {
+	address, hostPublicKey := newTestingServer(t, nil)
+	client, err := NewClient(&storepb.StorageSFTPConfig{
+		Address:       address,
+		Username:      "memos",
+		Password:      "secret",
+		HostPublicKey: hostPublicKey,
+	})
+	require.NoError(t, err)
+	storagetest.TestBackend(t, client)
+}
+
+func TestPrivateKeyAuthentication(t *testing.T) {
+	userPublicKey, userPrivateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	sshPublicKey, err := ssh.NewPublicKey(userPublicKey)
+	require.NoError(t, err)
+	block, err := ssh.MarshalPrivateKey(userPrivateKey, "")
+	require.NoError(t, err)
+	address, hostPublicKey := newTestingServer(t, sshPublicKey)
+
+	client, err := NewClient(&storepb.StorageSFTPConfig{
+		Address:       address,
+		Username:      "memos",
+		PrivateKey:    string(pem.EncodeToMemory(block)),
+		HostPublicKey: hostPublicKey,
+	})
+	require.NoError(t, err)
+	size, err := client.Put(context.Background(), "file.txt", "text/plain", bytes.NewReader([]byte("content")))
+	require.NoError(t, err)
+	require.Equal(t, int64(7), size)
+}
+
+func TestHostKeyVerification(t *testing.T) {
+	address, _ := newTestingServer(t, nil)
+	_, otherHostPublicKey := newTestingServer(t, nil)
+
+	client, err := NewClient(&storepb.StorageSFTPConfig{
+		Address:       address,
+		Username:      "memos",
+		Password:      "secret",
+		HostPublicKey: otherHostPublicKey,
+	})
+	require.NoError(t, err)
+	_, err = client.Stat(context.Background(), "file.txt")
+	require.ErrorContains(t, err, "host key mismatch")
+
+	_, err = NewClient(&storepb.StorageSFTPConfig{Address: address, Username: "memos", Password: "secret"})
+	require.ErrorContains(t, err, "host public key is required")
+}
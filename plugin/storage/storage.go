@@ -0,0 +1,39 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrNotFound is returned when no object exists at a key.
+	ErrNotFound = errors.New("object not found")
+	// ErrNotSupported is returned by the operations a backend does not support, e.g. Presign.
+	ErrNotSupported = errors.New("operation not supported by the storage backend")
+)
+
+// Backend stores objects at slash-separated keys, e.g. assets/1700000000_image.png.
+type Backend interface {
+	// Put writes the content to the key, replacing any object at the key, and returns its size.
+	Put(ctx context.Context, key string, contentType string, content io.Reader) (int64, error)
+	// Get opens the content of the object at the key.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Range opens length bytes of the content of the object at the key from the offset.
+	// A negative length reads to the end of the object.
+	Range(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error)
+	// Delete deletes the object at the key.
+	Delete(ctx context.Context, key string) error
+	// Presign returns a URL to get the object at the key without credentials until it expires.
+	Presign(ctx context.Context, key string, expires time.Duration) (string, error)
+	// Stat returns the info of the object at the key.
+	Stat(ctx context.Context, key string) (*ObjectInfo, error)
+}
+
+// ObjectInfo is the info of an object in a backend.
+type ObjectInfo struct {
+	Size    int64
+	ModTime time.Time
+}
@@ -0,0 +1,80 @@
+package storagetest
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FakeS3 is an in-memory stand-in of the S3 API for single part uploads with path-style requests.
+type FakeS3 struct {
+	mutex   sync.Mutex
+	objects map[string][]byte
+}
+
+func (f *FakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	key := r.URL.Path
+	switch r.Method {
+	case http.MethodPut:
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		f.objects[key] = data
+		w.Header().Set("ETag", strconv.Quote(strconv.Itoa(len(data))))
+	case http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodGet, http.MethodHead:
+		data, ok := f.objects[key]
+		if !ok {
+			w.Header().Set("Content-Type", "application/xml")
+			w.WriteHeader(http.StatusNotFound)
+			if r.Method == http.MethodGet {
+				fmt.Fprint(w, `<?xml version="1.0" encoding="UTF-8"?><Error><Code>NoSuchKey</Code><Message>The specified key does not exist.</Message></Error>`)
+			}
+			return
+		}
+		w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+		status := http.StatusOK
+		if value := r.Header.Get("Range"); value != "" {
+			start, end, _ := strings.Cut(strings.TrimPrefix(value, "bytes="), "-")
+			first, _ := strconv.Atoi(start)
+			last := len(data) - 1
+			if end != "" {
+				last, _ = strconv.Atoi(end)
+			}
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", first, last, len(data)))
+			data = data[first : last+1]
+			status = http.StatusPartialContent
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+		w.WriteHeader(status)
+		if r.Method == http.MethodGet {
+			w.Write(data)
+		}
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// NewFakeS3 returns an empty fake S3.
+func NewFakeS3() *FakeS3 {
+	return &FakeS3{objects: map[string][]byte{}}
+}
+
+// Object returns the content of the object at the path, which starts with the bucket.
+func (f *FakeS3) Object(path string) ([]byte, bool) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	data, ok := f.objects[path]
+	return data, ok
+}
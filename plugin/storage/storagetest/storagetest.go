@@ -0,0 +1,114 @@
+// Package storagetest checks that storage backends behave the same, for the tests of the backends.
+package storagetest
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/plugin/storage"
+)
+
+// TestBackend runs the common tests of a backend. The backend must be empty.
+func TestBackend(t *testing.T, backend storage.Backend) {
+	ctx := context.Background()
+	content := []byte(strings.Repeat("0123456789", 100))
+	key := "assets/2025/01/image.png"
+
+	t.Run("Put and Get", func(t *testing.T) {
+		// The content is streamed from a reader of unknown size.
+		size, err := backend.Put(ctx, key, "image/png", io.MultiReader(bytes.NewReader(content)))
+		require.NoError(t, err)
+		require.Equal(t, int64(len(content)), size)
+
+		body, err := backend.Get(ctx, key)
+		require.NoError(t, err)
+		defer body.Close()
+		data, err := io.ReadAll(body)
+		require.NoError(t, err)
+		require.Equal(t, content, data)
+	})
+
+	t.Run("Put replaces the object", func(t *testing.T) {
+		other := []byte("replaced")
+		_, err := backend.Put(ctx, "replaced.txt", "text/plain", bytes.NewReader(content))
+		require.NoError(t, err)
+		_, err = backend.Put(ctx, "replaced.txt", "text/plain", bytes.NewReader(other))
+		require.NoError(t, err)
+		require.Equal(t, other, readAll(t, backend, "replaced.txt"))
+		require.NoError(t, backend.Delete(ctx, "replaced.txt"))
+	})
+
+	t.Run("Stat", func(t *testing.T) {
+		info, err := backend.Stat(ctx, key)
+		require.NoError(t, err)
+		require.Equal(t, int64(len(content)), info.Size)
+		require.False(t, info.ModTime.IsZero())
+	})
+
+	t.Run("Range", func(t *testing.T) {
+		body, err := backend.Range(ctx, key, 500, 10)
+		require.NoError(t, err)
+		data, err := io.ReadAll(body)
+		require.NoError(t, err)
+		require.NoError(t, body.Close())
+		require.Equal(t, content[500:510], data)
+
+		body, err = backend.Range(ctx, key, 990, -1)
+		require.NoError(t, err)
+		data, err = io.ReadAll(body)
+		require.NoError(t, err)
+		require.NoError(t, body.Close())
+		require.Equal(t, content[990:], data)
+	})
+
+	t.Run("Reader", func(t *testing.T) {
+		reader, err := storage.NewReader(ctx, backend, key)
+		require.NoError(t, err)
+		defer reader.Close()
+		require.Equal(t, int64(len(content)), reader.Size())
+
+		offset, err := reader.Seek(-100, io.SeekEnd)
+		require.NoError(t, err)
+		require.Equal(t, int64(900), offset)
+		data := make([]byte, 10)
+		_, err = io.ReadFull(reader, data)
+		require.NoError(t, err)
+		require.Equal(t, content[900:910], data)
+
+		_, err = reader.Seek(0, io.SeekStart)
+		require.NoError(t, err)
+		data, err = io.ReadAll(reader)
+		require.NoError(t, err)
+		require.Equal(t, content, data)
+	})
+
+	t.Run("Missing objects", func(t *testing.T) {
+		_, err := backend.Stat(ctx, "missing.txt")
+		require.True(t, errors.Is(err, storage.ErrNotFound), "unexpected error: %v", err)
+		_, err = backend.Get(ctx, "missing.txt")
+		require.True(t, errors.Is(err, storage.ErrNotFound), "unexpected error: %v", err)
+		_, err = storage.NewReader(ctx, backend, "missing.txt")
+		require.True(t, errors.Is(err, storage.ErrNotFound), "unexpected error: %v", err)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		require.NoError(t, backend.Delete(ctx, key))
+		_, err := backend.Stat(ctx, key)
+		require.True(t, errors.Is(err, storage.ErrNotFound), "unexpected error: %v", err)
+	})
+}
+
+func readAll(t *testing.T, backend storage.Backend, key string) []byte {
+	body, err := backend.Get(context.Background(), key)
+	require.NoError(t, err)
+	defer body.Close()
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+	return data
+}
@@ -0,0 +1,195 @@
+package webdav
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/plugin/storage"
+	storepb "github.com/usememos/memos/proto/gen/store"
+)
+
+// Client stores objects as files in a directory of a WebDAV server.
+type Client struct {
+	endpoint   *url.URL
+	username   string
+	password   string
+	httpClient *http.Client
+}
+
+var _ storage.Backend = (*Client)(nil)
+
+func NewClient(config *storepb.StorageWebDAVConfig) (*Client, error) {
+	endpoint, err := url.Parse(config.Endpoint)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid endpoint")
+	}
+	if endpoint.Scheme != "http" && endpoint.Scheme != "https" {
+		return nil, errors.Errorf("invalid endpoint scheme %q", endpoint.Scheme)
+	}
+	if !strings.HasSuffix(endpoint.Path, "/") {
+		endpoint.Path += "/"
+	}
+	endpoint.RawPath = ""
+	return &Client{
+		endpoint:   endpoint,
+		username:   config.Username,
+		password:   config.Password,
+		httpClient: &http.Client{},
+	}, nil
+}
+
+func (c *Client) url(key string) string {
+	u := *c.endpoint
+	u.Path = path.Join(c.endpoint.Path, path.Clean("/"+key))
+	return u.String()
+}
+
+func (c *Client) do(ctx context.Context, method, key string, body io.Reader, header http.Header) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.url(key), body)
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	if c.username != "" || c.password != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+	return c.httpClient.Do(req)
+}
+
+func (c *Client) Put(ctx context.Context, key string, contentType string, content io.Reader) (int64, error) {
+	// The parent collections must exist before the file is created.
+	segments := strings.Split(strings.Trim(path.Clean("/"+key), "/"), "/")
+	for i := 1; i < len(segments); i++ {
+		if err := c.makeCollection(ctx, strings.Join(segments[:i], "/")+"/"); err != nil {
+			return 0, err
+		}
+	}
+
+	counter := &countingReader{Reader: content}
+	resp, err := c.do(ctx, http.MethodPut, key, counter, http.Header{"Content-Type": {contentType}})
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to put file")
+	}
+	if err := checkResponse(resp, "failed to put file"); err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	return counter.n, nil
+}
+
+func (c *Client) makeCollection(ctx context.Context, key string) error {
+	resp, err := c.do(ctx, "MKCOL", key, nil, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to create collection")
+	}
+	resp.Body.Close()
+	// 405 Method Not Allowed is returned for existing collections.
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusMethodNotAllowed {
+		return errors.Errorf("failed to create collection %q: %s", key, resp.Status)
+	}
+	return nil
+}
+
+func (c *Client) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	return c.Range(ctx, key, 0, -1)
+}
+
+func (c *Client) Range(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error) {
+	header := http.Header{}
+	if length >= 0 {
+		header.Set("Range", fmt.Sprintf("bytes=%d-%d", offset, offset+length-1))
+	} else if offset > 0 {
+		header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+	resp, err := c.do(ctx, http.MethodGet, key, nil, header)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get file")
+	}
+	if err := checkResponse(resp, "failed to get file"); err != nil {
+		return nil, err
+	}
+	if header.Get("Range") == "" || resp.StatusCode == http.StatusPartialContent {
+		return resp.Body, nil
+	}
+	// Servers without range requests send the whole file.
+	if _, err := io.CopyN(io.Discard, resp.Body, offset); err != nil {
+		resp.Body.Close()
+		return nil, errors.Wrap(err, "failed to skip to the offset")
+	}
+	if length < 0 {
+		return resp.Body, nil
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{io.LimitReader(resp.Body, length), resp.Body}, nil
+}
+
+func (c *Client) Delete(ctx context.Context, key string) error {
+	resp, err := c.do(ctx, http.MethodDelete, key, nil, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete file")
+	}
+	if err := checkResponse(resp, "failed to delete file"); err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+func (*Client) Presign(context.Context, string, time.Duration) (string, error) {
+	return "", storage.ErrNotSupported
+}
+
+func (c *Client) Stat(ctx context.Context, key string) (*storage.ObjectInfo, error) {
+	resp, err := c.do(ctx, http.MethodHead, key, nil, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to stat file")
+	}
+	if err := checkResponse(resp, "failed to stat file"); err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	if resp.ContentLength < 0 {
+		return nil, errors.New("failed to stat file: unknown size")
+	}
+	info := &storage.ObjectInfo{Size: resp.ContentLength}
+	if modTime, err := http.ParseTime(resp.Header.Get("Last-Modified")); err == nil {
+		info.ModTime = modTime
+	}
+	return info, nil
+}
+
+// checkResponse closes the body of unsuccessful responses and returns their error.
+func checkResponse(resp *http.Response, message string) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return errors.Wrap(storage.ErrNotFound, message)
+	}
+	return errors.Errorf("%s: %s", message, resp.Status)
+}
+
+// countingReader counts the bytes read from the reader.
+type countingReader struct {
+	io.Reader
+	n int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	r.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,57 @@
+package webdav
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/webdav"
+
+	"github.com/usememos/memos/plugin/storage/storagetest"
+	storepb "github.com/usememos/memos/proto/gen/store"
+)
+
+func newTestingServer(t *testing.T) *httptest.Server {
+	fs := webdav.NewMemFS()
+	require.NoError(t, fs.Mkdir(context.Background(), "/files", 0755))
+	handler := &webdav.Handler{
+		Prefix:     "/dav",
+		FileSystem: fs,
+		LockSystem: webdav.NewMemLS(),
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if username, password, ok := r.BasicAuth(); !ok || username != "memos" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestBackend(t *testing.T) {
+	server := newTestingServer(t)
+	client, err := NewClient(&storepb.StorageWebDAVConfig{
+		Endpoint: server.URL + "/dav/files",
+		Username: "memos",
+		Password: "secret",
+	})
+	require.NoError(t, err)
+	storagetest.TestBackend(t, client)
+}
+
+func TestUnauthorized(t *testing.T) {
+	server := newTestingServer(t)
+	client, err := NewClient(&storepb.StorageWebDAVConfig{
+		Endpoint: server.URL + "/dav",
+		Username: "memos",
+		Password: "wrong",
+	})
+	require.NoError(t, err)
+	_, err = client.Put(context.Background(), "file.txt", "text/plain", bytes.NewReader([]byte("content")))
+	require.ErrorContains(t, err, "401")
+}
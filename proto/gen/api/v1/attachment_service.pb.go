@@ -26,6 +26,61 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type VerifyAttachmentsResponse_Issue_Problem int32
+
+const (
+	VerifyAttachmentsResponse_Issue_PROBLEM_UNSPECIFIED VerifyAttachmentsResponse_Issue_Problem = 0
+	// The content is not found in its storage.
+	VerifyAttachmentsResponse_Issue_MISSING VerifyAttachmentsResponse_Issue_Problem = 1
+	// The checksum or the size of the content differs from the recorded one.
+	VerifyAttachmentsResponse_Issue_MISMATCHED VerifyAttachmentsResponse_Issue_Problem = 2
+	// The content fails to be read, e.g. from an unreachable storage.
+	VerifyAttachmentsResponse_Issue_UNREADABLE VerifyAttachmentsResponse_Issue_Problem = 3
+)
+
+// Enum value maps for VerifyAttachmentsResponse_Issue_Problem.
+var (
+	VerifyAttachmentsResponse_Issue_Problem_name = map[int32]string{
+		0: "PROBLEM_UNSPECIFIED",
+		1: "MISSING",
+		2: "MISMATCHED",
+		3: "UNREADABLE",
+	}
+	VerifyAttachmentsResponse_Issue_Problem_value = map[string]int32{
+		"PROBLEM_UNSPECIFIED": 0,
+		"MISSING":             1,
+		"MISMATCHED":          2,
+		"UNREADABLE":          3,
+	}
+)
+
+func (x VerifyAttachmentsResponse_Issue_Problem) Enum() *VerifyAttachmentsResponse_Issue_Problem {
+	p := new(VerifyAttachmentsResponse_Issue_Problem)
+	*p = x
+	return p
+}
+
+func (x VerifyAttachmentsResponse_Issue_Problem) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (VerifyAttachmentsResponse_Issue_Problem) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_v1_attachment_service_proto_enumTypes[0].Descriptor()
+}
+
+func (VerifyAttachmentsResponse_Issue_Problem) Type() protoreflect.EnumType {
+	return &file_api_v1_attachment_service_proto_enumTypes[0]
+}
+
+func (x VerifyAttachmentsResponse_Issue_Problem) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use VerifyAttachmentsResponse_Issue_Problem.Descriptor instead.
+func (VerifyAttachmentsResponse_Issue_Problem) EnumDescriptor() ([]byte, []int) {
+	return file_api_v1_attachment_service_proto_rawDescGZIP(), []int{9, 0, 0}
+}
+
 type Attachment struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// The name of the attachment.
@@ -35,7 +90,8 @@ type Attachment struct {
 	CreateTime *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
 	// The filename of the attachment.
 	Filename string `protobuf:"bytes,3,opt,name=filename,proto3" json:"filename,omitempty"`
-	// Input only. The content of the attachment.
+	// Input only. The content of the attachment, up to 32 MiB. Larger files are uploaded to
+	// /file/attachments, which streams them to the storage, or with CreateAttachmentUploadURL.
 	Content []byte `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
 	// Optional. The external link of the attachment.
 	ExternalLink string `protobuf:"bytes,5,opt,name=external_link,json=externalLink,proto3" json:"external_link,omitempty"`
@@ -45,7 +101,12 @@ type Attachment struct {
 	Size int64 `protobuf:"varint,7,opt,name=size,proto3" json:"size,omitempty"`
 	// Optional. The related memo. Refer to `Memo.name`.
 	// Format: memos/{memo}
-	Memo          *string `protobuf:"bytes,8,opt,name=memo,proto3,oneof" json:"memo,omitempty"`
+	Memo *string `protobuf:"bytes,8,opt,name=memo,proto3,oneof" json:"memo,omitempty"`
+	// Output only. The hex encoded SHA-256 checksum of the content.
+	// Empty for external links and for attachments uploaded before checksums were recorded.
+	Sha256 string `protobuf:"bytes,9,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	// Output only. The metadata of JPEG and PNG images, extracted on upload.
+	ImageMetadata *Attachment_ImageMetadata `protobuf:"bytes,10,opt,name=image_metadata,json=imageMetadata,proto3" json:"image_metadata,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -136,6 +197,20 @@ func (x *Attachment) GetMemo() string {
 	return ""
 }
 
+func (x *Attachment) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+func (x *Attachment) GetImageMetadata() *Attachment_ImageMetadata {
+	if x != nil {
+		return x.ImageMetadata
+	}
+	return nil
+}
+
 type CreateAttachmentRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Required. The attachment to create.
@@ -389,7 +464,10 @@ type GetAttachmentBinaryRequest struct {
 	// The filename of the attachment. Mainly used for downloading.
 	Filename string `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
 	// Optional. A flag indicating if the thumbnail version of the attachment should be returned.
-	Thumbnail     bool `protobuf:"varint,3,opt,name=thumbnail,proto3" json:"thumbnail,omitempty"`
+	Thumbnail bool `protobuf:"varint,3,opt,name=thumbnail,proto3" json:"thumbnail,omitempty"`
+	// Optional. The size of the thumbnail to return, "small" or "medium".
+	// Thumbnails are returned as WebP, and thumbnail alone returns the medium size.
+	ThumbnailSize string `protobuf:"bytes,4,opt,name=thumbnail_size,json=thumbnailSize,proto3" json:"thumbnail_size,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -445,6 +523,13 @@ func (x *GetAttachmentBinaryRequest) GetThumbnail() bool {
 	return false
 }
 
+func (x *GetAttachmentBinaryRequest) GetThumbnailSize() string {
+	if x != nil {
+		return x.ThumbnailSize
+	}
+	return ""
+}
+
 type UpdateAttachmentRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Required. The attachment which replaces the attachment on the server.
@@ -545,11 +630,579 @@ func (x *DeleteAttachmentRequest) GetName() string {
 	return ""
 }
 
+type VerifyAttachmentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyAttachmentsRequest) Reset() {
+	*x = VerifyAttachmentsRequest{}
+	mi := &file_api_v1_attachment_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyAttachmentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyAttachmentsRequest) ProtoMessage() {}
+
+func (x *VerifyAttachmentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_attachment_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyAttachmentsRequest.ProtoReflect.Descriptor instead.
+func (*VerifyAttachmentsRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_attachment_service_proto_rawDescGZIP(), []int{8}
+}
+
+type VerifyAttachmentsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The number of verified attachments, external links excluded.
+	Total int32 `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	// The number of attachments whose checksum was recorded by the verification,
+	// since they were uploaded before checksums were recorded.
+	Recorded int32 `protobuf:"varint,2,opt,name=recorded,proto3" json:"recorded,omitempty"`
+	// The problems found by the verification.
+	Issues        []*VerifyAttachmentsResponse_Issue `protobuf:"bytes,3,rep,name=issues,proto3" json:"issues,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyAttachmentsResponse) Reset() {
+	*x = VerifyAttachmentsResponse{}
+	mi := &file_api_v1_attachment_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyAttachmentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyAttachmentsResponse) ProtoMessage() {}
+
+func (x *VerifyAttachmentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_attachment_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyAttachmentsResponse.ProtoReflect.Descriptor instead.
+func (*VerifyAttachmentsResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_attachment_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *VerifyAttachmentsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *VerifyAttachmentsResponse) GetRecorded() int32 {
+	if x != nil {
+		return x.Recorded
+	}
+	return 0
+}
+
+func (x *VerifyAttachmentsResponse) GetIssues() []*VerifyAttachmentsResponse_Issue {
+	if x != nil {
+		return x.Issues
+	}
+	return nil
+}
+
+type CleanupAttachmentsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Optional. The age in days after which attachments without memo and local files without
+	// attachment are deleted. Defaults to the orphan retention of the storage setting, or 7 days.
+	RetentionDays int32 `protobuf:"varint,1,opt,name=retention_days,json=retentionDays,proto3" json:"retention_days,omitempty"`
+	// Optional. If true, the garbage is reported without being deleted.
+	DryRun        bool `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CleanupAttachmentsRequest) Reset() {
+	*x = CleanupAttachmentsRequest{}
+	mi := &file_api_v1_attachment_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CleanupAttachmentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CleanupAttachmentsRequest) ProtoMessage() {}
+
+func (x *CleanupAttachmentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_attachment_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CleanupAttachmentsRequest.ProtoReflect.Descriptor instead.
+func (*CleanupAttachmentsRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_attachment_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CleanupAttachmentsRequest) GetRetentionDays() int32 {
+	if x != nil {
+		return x.RetentionDays
+	}
+	return 0
+}
+
+func (x *CleanupAttachmentsRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type CleanupAttachmentsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The names of the attachments without memo.
+	// Format: attachments/{attachment}
+	Attachments []string `protobuf:"bytes,1,rep,name=attachments,proto3" json:"attachments,omitempty"`
+	// The paths of the local files without attachment, relative to the data directory.
+	Files []string `protobuf:"bytes,2,rep,name=files,proto3" json:"files,omitempty"`
+	// The paths of the thumbnails of deleted attachments, relative to the data directory.
+	Thumbnails []string `protobuf:"bytes,3,rep,name=thumbnails,proto3" json:"thumbnails,omitempty"`
+	// The total size of the garbage in bytes.
+	Size          int64 `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CleanupAttachmentsResponse) Reset() {
+	*x = CleanupAttachmentsResponse{}
+	mi := &file_api_v1_attachment_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CleanupAttachmentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CleanupAttachmentsResponse) ProtoMessage() {}
+
+func (x *CleanupAttachmentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_attachment_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CleanupAttachmentsResponse.ProtoReflect.Descriptor instead.
+func (*CleanupAttachmentsResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_attachment_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CleanupAttachmentsResponse) GetAttachments() []string {
+	if x != nil {
+		return x.Attachments
+	}
+	return nil
+}
+
+func (x *CleanupAttachmentsResponse) GetFiles() []string {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+func (x *CleanupAttachmentsResponse) GetThumbnails() []string {
+	if x != nil {
+		return x.Thumbnails
+	}
+	return nil
+}
+
+func (x *CleanupAttachmentsResponse) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+type CreateAttachmentUploadURLRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. The filename of the attachment.
+	Filename string `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	// Required. The MIME type of the attachment.
+	Type string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	// Required. The size of the content in bytes.
+	Size          int64 `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAttachmentUploadURLRequest) Reset() {
+	*x = CreateAttachmentUploadURLRequest{}
+	mi := &file_api_v1_attachment_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAttachmentUploadURLRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAttachmentUploadURLRequest) ProtoMessage() {}
+
+func (x *CreateAttachmentUploadURLRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_attachment_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAttachmentUploadURLRequest.ProtoReflect.Descriptor instead.
+func (*CreateAttachmentUploadURLRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_attachment_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CreateAttachmentUploadURLRequest) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *CreateAttachmentUploadURLRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *CreateAttachmentUploadURLRequest) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+type CreateAttachmentUploadURLResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The presigned URL to upload the content to.
+	UploadUrl string `protobuf:"bytes,1,opt,name=upload_url,json=uploadUrl,proto3" json:"upload_url,omitempty"`
+	// The HTTP method of the upload, e.g. PUT.
+	Method string `protobuf:"bytes,2,opt,name=method,proto3" json:"method,omitempty"`
+	// The headers that must be sent with the upload.
+	Headers map[string]string `protobuf:"bytes,3,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// The token to finalize the attachment with after the upload.
+	UploadToken string `protobuf:"bytes,4,opt,name=upload_token,json=uploadToken,proto3" json:"upload_token,omitempty"`
+	// The time after which the upload URL and the token expire.
+	ExpireTime    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expire_time,json=expireTime,proto3" json:"expire_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAttachmentUploadURLResponse) Reset() {
+	*x = CreateAttachmentUploadURLResponse{}
+	mi := &file_api_v1_attachment_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAttachmentUploadURLResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAttachmentUploadURLResponse) ProtoMessage() {}
+
+func (x *CreateAttachmentUploadURLResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_attachment_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAttachmentUploadURLResponse.ProtoReflect.Descriptor instead.
+func (*CreateAttachmentUploadURLResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_attachment_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CreateAttachmentUploadURLResponse) GetUploadUrl() string {
+	if x != nil {
+		return x.UploadUrl
+	}
+	return ""
+}
+
+func (x *CreateAttachmentUploadURLResponse) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *CreateAttachmentUploadURLResponse) GetHeaders() map[string]string {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+func (x *CreateAttachmentUploadURLResponse) GetUploadToken() string {
+	if x != nil {
+		return x.UploadToken
+	}
+	return ""
+}
+
+func (x *CreateAttachmentUploadURLResponse) GetExpireTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpireTime
+	}
+	return nil
+}
+
+type FinalizeAttachmentRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. The token returned with the upload URL.
+	UploadToken string `protobuf:"bytes,1,opt,name=upload_token,json=uploadToken,proto3" json:"upload_token,omitempty"`
+	// Optional. The related memo of the attachment.
+	// Format: memos/{memo}
+	Memo          *string `protobuf:"bytes,2,opt,name=memo,proto3,oneof" json:"memo,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FinalizeAttachmentRequest) Reset() {
+	*x = FinalizeAttachmentRequest{}
+	mi := &file_api_v1_attachment_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FinalizeAttachmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FinalizeAttachmentRequest) ProtoMessage() {}
+
+func (x *FinalizeAttachmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_attachment_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FinalizeAttachmentRequest.ProtoReflect.Descriptor instead.
+func (*FinalizeAttachmentRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_attachment_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *FinalizeAttachmentRequest) GetUploadToken() string {
+	if x != nil {
+		return x.UploadToken
+	}
+	return ""
+}
+
+func (x *FinalizeAttachmentRequest) GetMemo() string {
+	if x != nil && x.Memo != nil {
+		return *x.Memo
+	}
+	return ""
+}
+
+type Attachment_ImageMetadata struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The dimensions of the image as displayed.
+	Width  int32 `protobuf:"varint,1,opt,name=width,proto3" json:"width,omitempty"`
+	Height int32 `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	// The time the photo was taken.
+	CaptureTime   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=capture_time,json=captureTime,proto3" json:"capture_time,omitempty"`
+	CameraMake    string                 `protobuf:"bytes,4,opt,name=camera_make,json=cameraMake,proto3" json:"camera_make,omitempty"`
+	CameraModel   string                 `protobuf:"bytes,5,opt,name=camera_model,json=cameraModel,proto3" json:"camera_model,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Attachment_ImageMetadata) Reset() {
+	*x = Attachment_ImageMetadata{}
+	mi := &file_api_v1_attachment_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Attachment_ImageMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Attachment_ImageMetadata) ProtoMessage() {}
+
+func (x *Attachment_ImageMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_attachment_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Attachment_ImageMetadata.ProtoReflect.Descriptor instead.
+func (*Attachment_ImageMetadata) Descriptor() ([]byte, []int) {
+	return file_api_v1_attachment_service_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *Attachment_ImageMetadata) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *Attachment_ImageMetadata) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *Attachment_ImageMetadata) GetCaptureTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CaptureTime
+	}
+	return nil
+}
+
+func (x *Attachment_ImageMetadata) GetCameraMake() string {
+	if x != nil {
+		return x.CameraMake
+	}
+	return ""
+}
+
+func (x *Attachment_ImageMetadata) GetCameraModel() string {
+	if x != nil {
+		return x.CameraModel
+	}
+	return ""
+}
+
+// A problem of the content of an attachment.
+type VerifyAttachmentsResponse_Issue struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The name of the attachment.
+	// Format: attachments/{attachment}
+	Attachment    string                                  `protobuf:"bytes,1,opt,name=attachment,proto3" json:"attachment,omitempty"`
+	Problem       VerifyAttachmentsResponse_Issue_Problem `protobuf:"varint,2,opt,name=problem,proto3,enum=memos.api.v1.VerifyAttachmentsResponse_Issue_Problem" json:"problem,omitempty"`
+	Detail        string                                  `protobuf:"bytes,3,opt,name=detail,proto3" json:"detail,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyAttachmentsResponse_Issue) Reset() {
+	*x = VerifyAttachmentsResponse_Issue{}
+	mi := &file_api_v1_attachment_service_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyAttachmentsResponse_Issue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyAttachmentsResponse_Issue) ProtoMessage() {}
+
+func (x *VerifyAttachmentsResponse_Issue) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_attachment_service_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyAttachmentsResponse_Issue.ProtoReflect.Descriptor instead.
+func (*VerifyAttachmentsResponse_Issue) Descriptor() ([]byte, []int) {
+	return file_api_v1_attachment_service_proto_rawDescGZIP(), []int{9, 0}
+}
+
+func (x *VerifyAttachmentsResponse_Issue) GetAttachment() string {
+	if x != nil {
+		return x.Attachment
+	}
+	return ""
+}
+
+func (x *VerifyAttachmentsResponse_Issue) GetProblem() VerifyAttachmentsResponse_Issue_Problem {
+	if x != nil {
+		return x.Problem
+	}
+	return VerifyAttachmentsResponse_Issue_PROBLEM_UNSPECIFIED
+}
+
+func (x *VerifyAttachmentsResponse_Issue) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
 var File_api_v1_attachment_service_proto protoreflect.FileDescriptor
 
 const file_api_v1_attachment_service_proto_rawDesc = "" +
 	"\n" +
-	"\x1fapi/v1/attachment_service.proto\x12\fmemos.api.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x17google/api/client.proto\x1a\x1fgoogle/api/field_behavior.proto\x1a\x19google/api/httpbody.proto\x1a\x19google/api/resource.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xfb\x02\n" +
+	"\x1fapi/v1/attachment_service.proto\x12\fmemos.api.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x17google/api/client.proto\x1a\x1fgoogle/api/field_behavior.proto\x1a\x19google/api/httpbody.proto\x1a\x19google/api/resource.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xaf\x05\n" +
 	"\n" +
 	"Attachment\x12\x17\n" +
 	"\x04name\x18\x01 \x01(\tB\x03\xe0A\bR\x04name\x12@\n" +
@@ -560,7 +1213,17 @@ const file_api_v1_attachment_service_proto_rawDesc = "" +
 	"\rexternal_link\x18\x05 \x01(\tB\x03\xe0A\x01R\fexternalLink\x12\x17\n" +
 	"\x04type\x18\x06 \x01(\tB\x03\xe0A\x02R\x04type\x12\x17\n" +
 	"\x04size\x18\a \x01(\x03B\x03\xe0A\x03R\x04size\x12\x1c\n" +
-	"\x04memo\x18\b \x01(\tB\x03\xe0A\x01H\x00R\x04memo\x88\x01\x01:O\xeaAL\n" +
+	"\x04memo\x18\b \x01(\tB\x03\xe0A\x01H\x00R\x04memo\x88\x01\x01\x12\x1b\n" +
+	"\x06sha256\x18\t \x01(\tB\x03\xe0A\x03R\x06sha256\x12R\n" +
+	"\x0eimage_metadata\x18\n" +
+	" \x01(\v2&.memos.api.v1.Attachment.ImageMetadataB\x03\xe0A\x03R\rimageMetadata\x1a\xc0\x01\n" +
+	"\rImageMetadata\x12\x14\n" +
+	"\x05width\x18\x01 \x01(\x05R\x05width\x12\x16\n" +
+	"\x06height\x18\x02 \x01(\x05R\x06height\x12=\n" +
+	"\fcapture_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\vcaptureTime\x12\x1f\n" +
+	"\vcamera_make\x18\x04 \x01(\tR\n" +
+	"cameraMake\x12!\n" +
+	"\fcamera_model\x18\x05 \x01(\tR\vcameraModel:O\xeaAL\n" +
 	"\x17memos.api.v1/Attachment\x12\x18attachments/{attachment}*\vattachments2\n" +
 	"attachmentB\a\n" +
 	"\x05_memo\"\x82\x01\n" +
@@ -582,12 +1245,13 @@ const file_api_v1_attachment_service_proto_rawDesc = "" +
 	"total_size\x18\x03 \x01(\x05R\ttotalSize\"K\n" +
 	"\x14GetAttachmentRequest\x123\n" +
 	"\x04name\x18\x01 \x01(\tB\x1f\xe0A\x02\xfaA\x19\n" +
-	"\x17memos.api.v1/AttachmentR\x04name\"\x95\x01\n" +
+	"\x17memos.api.v1/AttachmentR\x04name\"\xc1\x01\n" +
 	"\x1aGetAttachmentBinaryRequest\x123\n" +
 	"\x04name\x18\x01 \x01(\tB\x1f\xe0A\x02\xfaA\x19\n" +
 	"\x17memos.api.v1/AttachmentR\x04name\x12\x1f\n" +
 	"\bfilename\x18\x02 \x01(\tB\x03\xe0A\x02R\bfilename\x12!\n" +
-	"\tthumbnail\x18\x03 \x01(\bB\x03\xe0A\x01R\tthumbnail\"\x9a\x01\n" +
+	"\tthumbnail\x18\x03 \x01(\bB\x03\xe0A\x01R\tthumbnail\x12*\n" +
+	"\x0ethumbnail_size\x18\x04 \x01(\tB\x03\xe0A\x01R\rthumbnailSize\"\x9a\x01\n" +
 	"\x17UpdateAttachmentRequest\x12=\n" +
 	"\n" +
 	"attachment\x18\x01 \x01(\v2\x18.memos.api.v1.AttachmentB\x03\xe0A\x02R\n" +
@@ -596,7 +1260,54 @@ const file_api_v1_attachment_service_proto_rawDesc = "" +
 	"updateMask\"N\n" +
 	"\x17DeleteAttachmentRequest\x123\n" +
 	"\x04name\x18\x01 \x01(\tB\x1f\xe0A\x02\xfaA\x19\n" +
-	"\x17memos.api.v1/AttachmentR\x04name2\xe5\x06\n" +
+	"\x17memos.api.v1/AttachmentR\x04name\"\x1a\n" +
+	"\x18VerifyAttachmentsRequest\"\xf8\x02\n" +
+	"\x19VerifyAttachmentsResponse\x12\x14\n" +
+	"\x05total\x18\x01 \x01(\x05R\x05total\x12\x1a\n" +
+	"\brecorded\x18\x02 \x01(\x05R\brecorded\x12E\n" +
+	"\x06issues\x18\x03 \x03(\v2-.memos.api.v1.VerifyAttachmentsResponse.IssueR\x06issues\x1a\xe1\x01\n" +
+	"\x05Issue\x12\x1e\n" +
+	"\n" +
+	"attachment\x18\x01 \x01(\tR\n" +
+	"attachment\x12O\n" +
+	"\aproblem\x18\x02 \x01(\x0e25.memos.api.v1.VerifyAttachmentsResponse.Issue.ProblemR\aproblem\x12\x16\n" +
+	"\x06detail\x18\x03 \x01(\tR\x06detail\"O\n" +
+	"\aProblem\x12\x17\n" +
+	"\x13PROBLEM_UNSPECIFIED\x10\x00\x12\v\n" +
+	"\aMISSING\x10\x01\x12\x0e\n" +
+	"\n" +
+	"MISMATCHED\x10\x02\x12\x0e\n" +
+	"\n" +
+	"UNREADABLE\x10\x03\"e\n" +
+	"\x19CleanupAttachmentsRequest\x12*\n" +
+	"\x0eretention_days\x18\x01 \x01(\x05B\x03\xe0A\x01R\rretentionDays\x12\x1c\n" +
+	"\adry_run\x18\x02 \x01(\bB\x03\xe0A\x01R\x06dryRun\"\x88\x01\n" +
+	"\x1aCleanupAttachmentsResponse\x12 \n" +
+	"\vattachments\x18\x01 \x03(\tR\vattachments\x12\x14\n" +
+	"\x05files\x18\x02 \x03(\tR\x05files\x12\x1e\n" +
+	"\n" +
+	"thumbnails\x18\x03 \x03(\tR\n" +
+	"thumbnails\x12\x12\n" +
+	"\x04size\x18\x04 \x01(\x03R\x04size\"u\n" +
+	" CreateAttachmentUploadURLRequest\x12\x1f\n" +
+	"\bfilename\x18\x01 \x01(\tB\x03\xe0A\x02R\bfilename\x12\x17\n" +
+	"\x04type\x18\x02 \x01(\tB\x03\xe0A\x02R\x04type\x12\x17\n" +
+	"\x04size\x18\x03 \x01(\x03B\x03\xe0A\x02R\x04size\"\xce\x02\n" +
+	"!CreateAttachmentUploadURLResponse\x12\x1d\n" +
+	"\n" +
+	"upload_url\x18\x01 \x01(\tR\tuploadUrl\x12\x16\n" +
+	"\x06method\x18\x02 \x01(\tR\x06method\x12V\n" +
+	"\aheaders\x18\x03 \x03(\v2<.memos.api.v1.CreateAttachmentUploadURLResponse.HeadersEntryR\aheaders\x12!\n" +
+	"\fupload_token\x18\x04 \x01(\tR\vuploadToken\x12;\n" +
+	"\vexpire_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"expireTime\x1a:\n" +
+	"\fHeadersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"j\n" +
+	"\x19FinalizeAttachmentRequest\x12&\n" +
+	"\fupload_token\x18\x01 \x01(\tB\x03\xe0A\x02R\vuploadToken\x12\x1c\n" +
+	"\x04memo\x18\x02 \x01(\tB\x03\xe0A\x01H\x00R\x04memo\x88\x01\x01B\a\n" +
+	"\x05_memo2\xb7\v\n" +
 	"\x11AttachmentService\x12\x89\x01\n" +
 	"\x10CreateAttachment\x12%.memos.api.v1.CreateAttachmentRequest\x1a\x18.memos.api.v1.Attachment\"4\xdaA\n" +
 	"attachment\x82\xd3\xe4\x93\x02!:\n" +
@@ -606,7 +1317,11 @@ const file_api_v1_attachment_service_proto_rawDesc = "" +
 	"\x13GetAttachmentBinary\x12(.memos.api.v1.GetAttachmentBinaryRequest\x1a\x14.google.api.HttpBody\"G\xdaA\x17name,filename,thumbnail\x82\xd3\xe4\x93\x02'\x12%/file/{name=attachments/*}/{filename}\x12\xa9\x01\n" +
 	"\x10UpdateAttachment\x12%.memos.api.v1.UpdateAttachmentRequest\x1a\x18.memos.api.v1.Attachment\"T\xdaA\x16attachment,update_mask\x82\xd3\xe4\x93\x025:\n" +
 	"attachment2'/api/v1/{attachment.name=attachments/*}\x12~\n" +
-	"\x10DeleteAttachment\x12%.memos.api.v1.DeleteAttachmentRequest\x1a\x16.google.protobuf.Empty\"+\xdaA\x04name\x82\xd3\xe4\x93\x02\x1e*\x1c/api/v1/{name=attachments/*}B\xae\x01\n" +
+	"\x10DeleteAttachment\x12%.memos.api.v1.DeleteAttachmentRequest\x1a\x16.google.protobuf.Empty\"+\xdaA\x04name\x82\xd3\xe4\x93\x02\x1e*\x1c/api/v1/{name=attachments/*}\x12\x8b\x01\n" +
+	"\x11VerifyAttachments\x12&.memos.api.v1.VerifyAttachmentsRequest\x1a'.memos.api.v1.VerifyAttachmentsResponse\"%\x82\xd3\xe4\x93\x02\x1f:\x01*\"\x1a/api/v1/attachments:verify\x12\x8f\x01\n" +
+	"\x12CleanupAttachments\x12'.memos.api.v1.CleanupAttachmentsRequest\x1a(.memos.api.v1.CleanupAttachmentsResponse\"&\x82\xd3\xe4\x93\x02 :\x01*\"\x1b/api/v1/attachments:cleanup\x12\xac\x01\n" +
+	"\x19CreateAttachmentUploadURL\x12..memos.api.v1.CreateAttachmentUploadURLRequest\x1a/.memos.api.v1.CreateAttachmentUploadURLResponse\".\x82\xd3\xe4\x93\x02(:\x01*\"#/api/v1/attachments:createUploadUrl\x12\x80\x01\n" +
+	"\x12FinalizeAttachment\x12'.memos.api.v1.FinalizeAttachmentRequest\x1a\x18.memos.api.v1.Attachment\"'\x82\xd3\xe4\x93\x02!:\x01*\"\x1c/api/v1/attachments:finalizeB\xae\x01\n" +
 	"\x10com.memos.api.v1B\x16AttachmentServiceProtoP\x01Z0github.com/usememos/memos/proto/gen/api/v1;apiv1\xa2\x02\x03MAX\xaa\x02\fMemos.Api.V1\xca\x02\fMemos\\Api\\V1\xe2\x02\x18Memos\\Api\\V1\\GPBMetadata\xea\x02\x0eMemos::Api::V1b\x06proto3"
 
 var (
@@ -621,44 +1336,70 @@ func file_api_v1_attachment_service_proto_rawDescGZIP() []byte {
 	return file_api_v1_attachment_service_proto_rawDescData
 }
 
-var file_api_v1_attachment_service_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_api_v1_attachment_service_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_api_v1_attachment_service_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
 var file_api_v1_attachment_service_proto_goTypes = []any{
-	(*Attachment)(nil),                 // 0: memos.api.v1.Attachment
-	(*CreateAttachmentRequest)(nil),    // 1: memos.api.v1.CreateAttachmentRequest
-	(*ListAttachmentsRequest)(nil),     // 2: memos.api.v1.ListAttachmentsRequest
-	(*ListAttachmentsResponse)(nil),    // 3: memos.api.v1.ListAttachmentsResponse
-	(*GetAttachmentRequest)(nil),       // 4: memos.api.v1.GetAttachmentRequest
-	(*GetAttachmentBinaryRequest)(nil), // 5: memos.api.v1.GetAttachmentBinaryRequest
-	(*UpdateAttachmentRequest)(nil),    // 6: memos.api.v1.UpdateAttachmentRequest
-	(*DeleteAttachmentRequest)(nil),    // 7: memos.api.v1.DeleteAttachmentRequest
-	(*timestamppb.Timestamp)(nil),      // 8: google.protobuf.Timestamp
-	(*fieldmaskpb.FieldMask)(nil),      // 9: google.protobuf.FieldMask
-	(*httpbody.HttpBody)(nil),          // 10: google.api.HttpBody
-	(*emptypb.Empty)(nil),              // 11: google.protobuf.Empty
+	(VerifyAttachmentsResponse_Issue_Problem)(0), // 0: memos.api.v1.VerifyAttachmentsResponse.Issue.Problem
+	(*Attachment)(nil),                           // 1: memos.api.v1.Attachment
+	(*CreateAttachmentRequest)(nil),              // 2: memos.api.v1.CreateAttachmentRequest
+	(*ListAttachmentsRequest)(nil),               // 3: memos.api.v1.ListAttachmentsRequest
+	(*ListAttachmentsResponse)(nil),              // 4: memos.api.v1.ListAttachmentsResponse
+	(*GetAttachmentRequest)(nil),                 // 5: memos.api.v1.GetAttachmentRequest
+	(*GetAttachmentBinaryRequest)(nil),           // 6: memos.api.v1.GetAttachmentBinaryRequest
+	(*UpdateAttachmentRequest)(nil),              // 7: memos.api.v1.UpdateAttachmentRequest
+	(*DeleteAttachmentRequest)(nil),              // 8: memos.api.v1.DeleteAttachmentRequest
+	(*VerifyAttachmentsRequest)(nil),             // 9: memos.api.v1.VerifyAttachmentsRequest
+	(*VerifyAttachmentsResponse)(nil),            // 10: memos.api.v1.VerifyAttachmentsResponse
+	(*CleanupAttachmentsRequest)(nil),            // 11: memos.api.v1.CleanupAttachmentsRequest
+	(*CleanupAttachmentsResponse)(nil),           // 12: memos.api.v1.CleanupAttachmentsResponse
+	(*CreateAttachmentUploadURLRequest)(nil),     // 13: memos.api.v1.CreateAttachmentUploadURLRequest
+	(*CreateAttachmentUploadURLResponse)(nil),    // 14: memos.api.v1.CreateAttachmentUploadURLResponse
+	(*FinalizeAttachmentRequest)(nil),            // 15: memos.api.v1.FinalizeAttachmentRequest
+	(*Attachment_ImageMetadata)(nil),             // 16: memos.api.v1.Attachment.ImageMetadata
+	(*VerifyAttachmentsResponse_Issue)(nil),      // 17: memos.api.v1.VerifyAttachmentsResponse.Issue
+	nil,                                          // 18: memos.api.v1.CreateAttachmentUploadURLResponse.HeadersEntry
+	(*timestamppb.Timestamp)(nil),                // 19: google.protobuf.Timestamp
+	(*fieldmaskpb.FieldMask)(nil),                // 20: google.protobuf.FieldMask
+	(*httpbody.HttpBody)(nil),                    // 21: google.api.HttpBody
+	(*emptypb.Empty)(nil),                        // 22: google.protobuf.Empty
 }
 var file_api_v1_attachment_service_proto_depIdxs = []int32{
-	8,  // 0: memos.api.v1.Attachment.create_time:type_name -> google.protobuf.Timestamp
-	0,  // 1: memos.api.v1.CreateAttachmentRequest.attachment:type_name -> memos.api.v1.Attachment
-	0,  // 2: memos.api.v1.ListAttachmentsResponse.attachments:type_name -> memos.api.v1.Attachment
-	0,  // 3: memos.api.v1.UpdateAttachmentRequest.attachment:type_name -> memos.api.v1.Attachment
-	9,  // 4: memos.api.v1.UpdateAttachmentRequest.update_mask:type_name -> google.protobuf.FieldMask
-	1,  // 5: memos.api.v1.AttachmentService.CreateAttachment:input_type -> memos.api.v1.CreateAttachmentRequest
-	2,  // 6: memos.api.v1.AttachmentService.ListAttachments:input_type -> memos.api.v1.ListAttachmentsRequest
-	4,  // 7: memos.api.v1.AttachmentService.GetAttachment:input_type -> memos.api.v1.GetAttachmentRequest
-	5,  // 8: memos.api.v1.AttachmentService.GetAttachmentBinary:input_type -> memos.api.v1.GetAttachmentBinaryRequest
-	6,  // 9: memos.api.v1.AttachmentService.UpdateAttachment:input_type -> memos.api.v1.UpdateAttachmentRequest
-	7,  // 10: memos.api.v1.AttachmentService.DeleteAttachment:input_type -> memos.api.v1.DeleteAttachmentRequest
-	0,  // 11: memos.api.v1.AttachmentService.CreateAttachment:output_type -> memos.api.v1.Attachment
-	3,  // 12: memos.api.v1.AttachmentService.ListAttachments:output_type -> memos.api.v1.ListAttachmentsResponse
-	0,  // 13: memos.api.v1.AttachmentService.GetAttachment:output_type -> memos.api.v1.Attachment
-	10, // 14: memos.api.v1.AttachmentService.GetAttachmentBinary:output_type -> google.api.HttpBody
-	0,  // 15: memos.api.v1.AttachmentService.UpdateAttachment:output_type -> memos.api.v1.Attachment
-	11, // 16: memos.api.v1.AttachmentService.DeleteAttachment:output_type -> google.protobuf.Empty
-	11, // [11:17] is the sub-list for method output_type
-	5,  // [5:11] is the sub-list for method input_type
-	5,  // [5:5] is the sub-list for extension type_name
-	5,  // [5:5] is the sub-list for extension extendee
-	0,  // [0:5] is the sub-list for field type_name
+	19, // 0: memos.api.v1.Attachment.create_time:type_name -> google.protobuf.Timestamp
+	16, // 1: memos.api.v1.Attachment.image_metadata:type_name -> memos.api.v1.Attachment.ImageMetadata
+	1,  // 2: memos.api.v1.CreateAttachmentRequest.attachment:type_name -> memos.api.v1.Attachment
+	1,  // 3: memos.api.v1.ListAttachmentsResponse.attachments:type_name -> memos.api.v1.Attachment
+	1,  // 4: memos.api.v1.UpdateAttachmentRequest.attachment:type_name -> memos.api.v1.Attachment
+	20, // 5: memos.api.v1.UpdateAttachmentRequest.update_mask:type_name -> google.protobuf.FieldMask
+	17, // 6: memos.api.v1.VerifyAttachmentsResponse.issues:type_name -> memos.api.v1.VerifyAttachmentsResponse.Issue
+	18, // 7: memos.api.v1.CreateAttachmentUploadURLResponse.headers:type_name -> memos.api.v1.CreateAttachmentUploadURLResponse.HeadersEntry
+	19, // 8: memos.api.v1.CreateAttachmentUploadURLResponse.expire_time:type_name -> google.protobuf.Timestamp
+	19, // 9: memos.api.v1.Attachment.ImageMetadata.capture_time:type_name -> google.protobuf.Timestamp
+	0,  // 10: memos.api.v1.VerifyAttachmentsResponse.Issue.problem:type_name -> memos.api.v1.VerifyAttachmentsResponse.Issue.Problem
+	2,  // 11: memos.api.v1.AttachmentService.CreateAttachment:input_type -> memos.api.v1.CreateAttachmentRequest
+	3,  // 12: memos.api.v1.AttachmentService.ListAttachments:input_type -> memos.api.v1.ListAttachmentsRequest
+	5,  // 13: memos.api.v1.AttachmentService.GetAttachment:input_type -> memos.api.v1.GetAttachmentRequest
+	6,  // 14: memos.api.v1.AttachmentService.GetAttachmentBinary:input_type -> memos.api.v1.GetAttachmentBinaryRequest
+	7,  // 15: memos.api.v1.AttachmentService.UpdateAttachment:input_type -> memos.api.v1.UpdateAttachmentRequest
+	8,  // 16: memos.api.v1.AttachmentService.DeleteAttachment:input_type -> memos.api.v1.DeleteAttachmentRequest
+	9,  // 17: memos.api.v1.AttachmentService.VerifyAttachments:input_type -> memos.api.v1.VerifyAttachmentsRequest
+	11, // 18: memos.api.v1.AttachmentService.CleanupAttachments:input_type -> memos.api.v1.CleanupAttachmentsRequest
+	13, // 19: memos.api.v1.AttachmentService.CreateAttachmentUploadURL:input_type -> memos.api.v1.CreateAttachmentUploadURLRequest
+	15, // 20: memos.api.v1.AttachmentService.FinalizeAttachment:input_type -> memos.api.v1.FinalizeAttachmentRequest
+	1,  // 21: memos.api.v1.AttachmentService.CreateAttachment:output_type -> memos.api.v1.Attachment
+	4,  // 22: memos.api.v1.AttachmentService.ListAttachments:output_type -> memos.api.v1.ListAttachmentsResponse
+	1,  // 23: memos.api.v1.AttachmentService.GetAttachment:output_type -> memos.api.v1.Attachment
+	21, // 24: memos.api.v1.AttachmentService.GetAttachmentBinary:output_type -> google.api.HttpBody
+	1,  // 25: memos.api.v1.AttachmentService.UpdateAttachment:output_type -> memos.api.v1.Attachment
+	22, // 26: memos.api.v1.AttachmentService.DeleteAttachment:output_type -> google.protobuf.Empty
+	10, // 27: memos.api.v1.AttachmentService.VerifyAttachments:output_type -> memos.api.v1.VerifyAttachmentsResponse
+	12, // 28: memos.api.v1.AttachmentService.CleanupAttachments:output_type -> memos.api.v1.CleanupAttachmentsResponse
+	14, // 29: memos.api.v1.AttachmentService.CreateAttachmentUploadURL:output_type -> memos.api.v1.CreateAttachmentUploadURLResponse
+	1,  // 30: memos.api.v1.AttachmentService.FinalizeAttachment:output_type -> memos.api.v1.Attachment
+	21, // [21:31] is the sub-list for method output_type
+	11, // [11:21] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
 }
 
 func init() { file_api_v1_attachment_service_proto_init() }
@@ -667,18 +1408,20 @@ func file_api_v1_attachment_service_proto_init() {
 		return
 	}
 	file_api_v1_attachment_service_proto_msgTypes[0].OneofWrappers = []any{}
+	file_api_v1_attachment_service_proto_msgTypes[14].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_v1_attachment_service_proto_rawDesc), len(file_api_v1_attachment_service_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   8,
+			NumEnums:      1,
+			NumMessages:   18,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_api_v1_attachment_service_proto_goTypes,
 		DependencyIndexes: file_api_v1_attachment_service_proto_depIdxs,
+		EnumInfos:         file_api_v1_attachment_service_proto_enumTypes,
 		MessageInfos:      file_api_v1_attachment_service_proto_msgTypes,
 	}.Build()
 	File_api_v1_attachment_service_proto = out.File
@@ -21,12 +21,16 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	AttachmentService_CreateAttachment_FullMethodName    = "/memos.api.v1.AttachmentService/CreateAttachment"
-	AttachmentService_ListAttachments_FullMethodName     = "/memos.api.v1.AttachmentService/ListAttachments"
-	AttachmentService_GetAttachment_FullMethodName       = "/memos.api.v1.AttachmentService/GetAttachment"
-	AttachmentService_GetAttachmentBinary_FullMethodName = "/memos.api.v1.AttachmentService/GetAttachmentBinary"
-	AttachmentService_UpdateAttachment_FullMethodName    = "/memos.api.v1.AttachmentService/UpdateAttachment"
-	AttachmentService_DeleteAttachment_FullMethodName    = "/memos.api.v1.AttachmentService/DeleteAttachment"
+	AttachmentService_CreateAttachment_FullMethodName          = "/memos.api.v1.AttachmentService/CreateAttachment"
+	AttachmentService_ListAttachments_FullMethodName           = "/memos.api.v1.AttachmentService/ListAttachments"
+	AttachmentService_GetAttachment_FullMethodName             = "/memos.api.v1.AttachmentService/GetAttachment"
+	AttachmentService_GetAttachmentBinary_FullMethodName       = "/memos.api.v1.AttachmentService/GetAttachmentBinary"
+	AttachmentService_UpdateAttachment_FullMethodName          = "/memos.api.v1.AttachmentService/UpdateAttachment"
+	AttachmentService_DeleteAttachment_FullMethodName          = "/memos.api.v1.AttachmentService/DeleteAttachment"
+	AttachmentService_VerifyAttachments_FullMethodName         = "/memos.api.v1.AttachmentService/VerifyAttachments"
+	AttachmentService_CleanupAttachments_FullMethodName        = "/memos.api.v1.AttachmentService/CleanupAttachments"
+	AttachmentService_CreateAttachmentUploadURL_FullMethodName = "/memos.api.v1.AttachmentService/CreateAttachmentUploadURL"
+	AttachmentService_FinalizeAttachment_FullMethodName        = "/memos.api.v1.AttachmentService/FinalizeAttachment"
 )
 
 // AttachmentServiceClient is the client API for AttachmentService service.
@@ -45,6 +49,16 @@ type AttachmentServiceClient interface {
 	UpdateAttachment(ctx context.Context, in *UpdateAttachmentRequest, opts ...grpc.CallOption) (*Attachment, error)
 	// DeleteAttachment deletes a attachment by name.
 	DeleteAttachment(ctx context.Context, in *DeleteAttachmentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// VerifyAttachments checks the checksums of the contents of all attachments.
+	VerifyAttachments(ctx context.Context, in *VerifyAttachmentsRequest, opts ...grpc.CallOption) (*VerifyAttachmentsResponse, error)
+	// CleanupAttachments deletes the attachments without memo, the local files without attachment
+	// and the thumbnails of deleted attachments.
+	CleanupAttachments(ctx context.Context, in *CleanupAttachmentsRequest, opts ...grpc.CallOption) (*CleanupAttachmentsResponse, error)
+	// CreateAttachmentUploadURL returns a presigned URL to upload the content of an attachment
+	// directly to the S3 storage, and a token to finalize the attachment after the upload.
+	CreateAttachmentUploadURL(ctx context.Context, in *CreateAttachmentUploadURLRequest, opts ...grpc.CallOption) (*CreateAttachmentUploadURLResponse, error)
+	// FinalizeAttachment creates the attachment of content uploaded with a presigned URL.
+	FinalizeAttachment(ctx context.Context, in *FinalizeAttachmentRequest, opts ...grpc.CallOption) (*Attachment, error)
 }
 
 type attachmentServiceClient struct {
@@ -115,6 +129,46 @@ func (c *attachmentServiceClient) DeleteAttachment(ctx context.Context, in *Dele
 	return out, nil
 }
 
+func (c *attachmentServiceClient) VerifyAttachments(ctx context.Context, in *VerifyAttachmentsRequest, opts ...grpc.CallOption) (*VerifyAttachmentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VerifyAttachmentsResponse)
+	err := c.cc.Invoke(ctx, AttachmentService_VerifyAttachments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *attachmentServiceClient) CleanupAttachments(ctx context.Context, in *CleanupAttachmentsRequest, opts ...grpc.CallOption) (*CleanupAttachmentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CleanupAttachmentsResponse)
+	err := c.cc.Invoke(ctx, AttachmentService_CleanupAttachments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *attachmentServiceClient) CreateAttachmentUploadURL(ctx context.Context, in *CreateAttachmentUploadURLRequest, opts ...grpc.CallOption) (*CreateAttachmentUploadURLResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateAttachmentUploadURLResponse)
+	err := c.cc.Invoke(ctx, AttachmentService_CreateAttachmentUploadURL_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *attachmentServiceClient) FinalizeAttachment(ctx context.Context, in *FinalizeAttachmentRequest, opts ...grpc.CallOption) (*Attachment, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Attachment)
+	err := c.cc.Invoke(ctx, AttachmentService_FinalizeAttachment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AttachmentServiceServer is the server API for AttachmentService service.
 // All implementations must embed UnimplementedAttachmentServiceServer
 // for forward compatibility.
@@ -131,6 +185,16 @@ type AttachmentServiceServer interface {
 	UpdateAttachment(context.Context, *UpdateAttachmentRequest) (*Attachment, error)
 	// DeleteAttachment deletes a attachment by name.
 	DeleteAttachment(context.Context, *DeleteAttachmentRequest) (*emptypb.Empty, error)
+	// VerifyAttachments checks the checksums of the contents of all attachments.
+	VerifyAttachments(context.Context, *VerifyAttachmentsRequest) (*VerifyAttachmentsResponse, error)
+	// CleanupAttachments deletes the attachments without memo, the local files without attachment
+	// and the thumbnails of deleted attachments.
+	CleanupAttachments(context.Context, *CleanupAttachmentsRequest) (*CleanupAttachmentsResponse, error)
+	// CreateAttachmentUploadURL returns a presigned URL to upload the content of an attachment
+	// directly to the S3 storage, and a token to finalize the attachment after the upload.
+	CreateAttachmentUploadURL(context.Context, *CreateAttachmentUploadURLRequest) (*CreateAttachmentUploadURLResponse, error)
+	// FinalizeAttachment creates the attachment of content uploaded with a presigned URL.
+	FinalizeAttachment(context.Context, *FinalizeAttachmentRequest) (*Attachment, error)
 	mustEmbedUnimplementedAttachmentServiceServer()
 }
 
@@ -159,6 +223,18 @@ func (UnimplementedAttachmentServiceServer) UpdateAttachment(context.Context, *U
 func (UnimplementedAttachmentServiceServer) DeleteAttachment(context.Context, *DeleteAttachmentRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteAttachment not implemented")
 }
+func (UnimplementedAttachmentServiceServer) VerifyAttachments(context.Context, *VerifyAttachmentsRequest) (*VerifyAttachmentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyAttachments not implemented")
+}
+func (UnimplementedAttachmentServiceServer) CleanupAttachments(context.Context, *CleanupAttachmentsRequest) (*CleanupAttachmentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CleanupAttachments not implemented")
+}
+func (UnimplementedAttachmentServiceServer) CreateAttachmentUploadURL(context.Context, *CreateAttachmentUploadURLRequest) (*CreateAttachmentUploadURLResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAttachmentUploadURL not implemented")
+}
+func (UnimplementedAttachmentServiceServer) FinalizeAttachment(context.Context, *FinalizeAttachmentRequest) (*Attachment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FinalizeAttachment not implemented")
+}
 func (UnimplementedAttachmentServiceServer) mustEmbedUnimplementedAttachmentServiceServer() {}
 func (UnimplementedAttachmentServiceServer) testEmbeddedByValue()                           {}
 
@@ -288,6 +364,78 @@ func _AttachmentService_DeleteAttachment_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AttachmentService_VerifyAttachments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyAttachmentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AttachmentServiceServer).VerifyAttachments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AttachmentService_VerifyAttachments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AttachmentServiceServer).VerifyAttachments(ctx, req.(*VerifyAttachmentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AttachmentService_CleanupAttachments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CleanupAttachmentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AttachmentServiceServer).CleanupAttachments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AttachmentService_CleanupAttachments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AttachmentServiceServer).CleanupAttachments(ctx, req.(*CleanupAttachmentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AttachmentService_CreateAttachmentUploadURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAttachmentUploadURLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AttachmentServiceServer).CreateAttachmentUploadURL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AttachmentService_CreateAttachmentUploadURL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AttachmentServiceServer).CreateAttachmentUploadURL(ctx, req.(*CreateAttachmentUploadURLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AttachmentService_FinalizeAttachment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FinalizeAttachmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AttachmentServiceServer).FinalizeAttachment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AttachmentService_FinalizeAttachment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AttachmentServiceServer).FinalizeAttachment(ctx, req.(*FinalizeAttachmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AttachmentService_ServiceDesc is the grpc.ServiceDesc for AttachmentService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -319,6 +467,22 @@ var AttachmentService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteAttachment",
 			Handler:    _AttachmentService_DeleteAttachment_Handler,
 		},
+		{
+			MethodName: "VerifyAttachments",
+			Handler:    _AttachmentService_VerifyAttachments_Handler,
+		},
+		{
+			MethodName: "CleanupAttachments",
+			Handler:    _AttachmentService_CleanupAttachments_Handler,
+		},
+		{
+			MethodName: "CreateAttachmentUploadURL",
+			Handler:    _AttachmentService_CreateAttachmentUploadURL_Handler,
+		},
+		{
+			MethodName: "FinalizeAttachment",
+			Handler:    _AttachmentService_FinalizeAttachment_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/v1/attachment_service.proto",
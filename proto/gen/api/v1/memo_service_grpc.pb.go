@@ -22,6 +22,9 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	MemoService_CreateMemo_FullMethodName          = "/memos.api.v1.MemoService/CreateMemo"
 	MemoService_ListMemos_FullMethodName           = "/memos.api.v1.MemoService/ListMemos"
+	MemoService_ListMemoLocations_FullMethodName   = "/memos.api.v1.MemoService/ListMemoLocations"
+	MemoService_ValidateFilter_FullMethodName      = "/memos.api.v1.MemoService/ValidateFilter"
+	MemoService_ImportMemos_FullMethodName         = "/memos.api.v1.MemoService/ImportMemos"
 	MemoService_GetMemo_FullMethodName             = "/memos.api.v1.MemoService/GetMemo"
 	MemoService_UpdateMemo_FullMethodName          = "/memos.api.v1.MemoService/UpdateMemo"
 	MemoService_DeleteMemo_FullMethodName          = "/memos.api.v1.MemoService/DeleteMemo"
@@ -46,6 +49,14 @@ type MemoServiceClient interface {
 	CreateMemo(ctx context.Context, in *CreateMemoRequest, opts ...grpc.CallOption) (*Memo, error)
 	// ListMemos lists memos with pagination and filter.
 	ListMemos(ctx context.Context, in *ListMemosRequest, opts ...grpc.CallOption) (*ListMemosResponse, error)
+	// ListMemoLocations lists the locations of memos as a GeoJSON feature collection.
+	ListMemoLocations(ctx context.Context, in *ListMemoLocationsRequest, opts ...grpc.CallOption) (*ListMemoLocationsResponse, error)
+	// ValidateFilter validates a filter and reports the position of its errors.
+	ValidateFilter(ctx context.Context, in *ValidateFilterRequest, opts ...grpc.CallOption) (*ValidateFilterResponse, error)
+	// ImportMemos imports the memos of an archive exported from memos or another note-taking app.
+	// The import is not atomic: when it fails partway, the memos created so far are kept and the
+	// error reports their number.
+	ImportMemos(ctx context.Context, in *ImportMemosRequest, opts ...grpc.CallOption) (*ImportMemosResponse, error)
 	// GetMemo gets a memo.
 	GetMemo(ctx context.Context, in *GetMemoRequest, opts ...grpc.CallOption) (*Memo, error)
 	// UpdateMemo updates a memo.
@@ -104,6 +115,36 @@ func (c *memoServiceClient) ListMemos(ctx context.Context, in *ListMemosRequest,
 	return out, nil
 }
 
+func (c *memoServiceClient) ListMemoLocations(ctx context.Context, in *ListMemoLocationsRequest, opts ...grpc.CallOption) (*ListMemoLocationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMemoLocationsResponse)
+	err := c.cc.Invoke(ctx, MemoService_ListMemoLocations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *memoServiceClient) ValidateFilter(ctx context.Context, in *ValidateFilterRequest, opts ...grpc.CallOption) (*ValidateFilterResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ValidateFilterResponse)
+	err := c.cc.Invoke(ctx, MemoService_ValidateFilter_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *memoServiceClient) ImportMemos(ctx context.Context, in *ImportMemosRequest, opts ...grpc.CallOption) (*ImportMemosResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportMemosResponse)
+	err := c.cc.Invoke(ctx, MemoService_ImportMemos_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *memoServiceClient) GetMemo(ctx context.Context, in *GetMemoRequest, opts ...grpc.CallOption) (*Memo, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Memo)
@@ -252,6 +293,14 @@ type MemoServiceServer interface {
 	CreateMemo(context.Context, *CreateMemoRequest) (*Memo, error)
 	// ListMemos lists memos with pagination and filter.
 	ListMemos(context.Context, *ListMemosRequest) (*ListMemosResponse, error)
+	// ListMemoLocations lists the locations of memos as a GeoJSON feature collection.
+	ListMemoLocations(context.Context, *ListMemoLocationsRequest) (*ListMemoLocationsResponse, error)
+	// ValidateFilter validates a filter and reports the position of its errors.
+	ValidateFilter(context.Context, *ValidateFilterRequest) (*ValidateFilterResponse, error)
+	// ImportMemos imports the memos of an archive exported from memos or another note-taking app.
+	// The import is not atomic: when it fails partway, the memos created so far are kept and the
+	// error reports their number.
+	ImportMemos(context.Context, *ImportMemosRequest) (*ImportMemosResponse, error)
 	// GetMemo gets a memo.
 	GetMemo(context.Context, *GetMemoRequest) (*Memo, error)
 	// UpdateMemo updates a memo.
@@ -296,6 +345,15 @@ func (UnimplementedMemoServiceServer) CreateMemo(context.Context, *CreateMemoReq
 func (UnimplementedMemoServiceServer) ListMemos(context.Context, *ListMemosRequest) (*ListMemosResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListMemos not implemented")
 }
+func (UnimplementedMemoServiceServer) ListMemoLocations(context.Context, *ListMemoLocationsRequest) (*ListMemoLocationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMemoLocations not implemented")
+}
+func (UnimplementedMemoServiceServer) ValidateFilter(context.Context, *ValidateFilterRequest) (*ValidateFilterResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateFilter not implemented")
+}
+func (UnimplementedMemoServiceServer) ImportMemos(context.Context, *ImportMemosRequest) (*ImportMemosResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportMemos not implemented")
+}
 func (UnimplementedMemoServiceServer) GetMemo(context.Context, *GetMemoRequest) (*Memo, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetMemo not implemented")
 }
@@ -395,6 +453,60 @@ func _MemoService_ListMemos_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MemoService_ListMemoLocations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMemoLocationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MemoServiceServer).ListMemoLocations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MemoService_ListMemoLocations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MemoServiceServer).ListMemoLocations(ctx, req.(*ListMemoLocationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MemoService_ValidateFilter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateFilterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MemoServiceServer).ValidateFilter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MemoService_ValidateFilter_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MemoServiceServer).ValidateFilter(ctx, req.(*ValidateFilterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MemoService_ImportMemos_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportMemosRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MemoServiceServer).ImportMemos(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MemoService_ImportMemos_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MemoServiceServer).ImportMemos(ctx, req.(*ImportMemosRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MemoService_GetMemo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetMemoRequest)
 	if err := dec(in); err != nil {
@@ -662,6 +774,18 @@ var MemoService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListMemos",
 			Handler:    _MemoService_ListMemos_Handler,
 		},
+		{
+			MethodName: "ListMemoLocations",
+			Handler:    _MemoService_ListMemoLocations_Handler,
+		},
+		{
+			MethodName: "ValidateFilter",
+			Handler:    _MemoService_ValidateFilter_Handler,
+		},
+		{
+			MethodName: "ImportMemos",
+			Handler:    _MemoService_ImportMemos_Handler,
+		},
 		{
 			MethodName: "GetMemo",
 			Handler:    _MemoService_GetMemo_Handler,
@@ -12,6 +12,7 @@ import (
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
 	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -24,6 +25,61 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type Shortcut_Visibility int32
+
+const (
+	Shortcut_VISIBILITY_UNSPECIFIED Shortcut_Visibility = 0
+	// Only the owner can see the shortcut.
+	Shortcut_PRIVATE Shortcut_Visibility = 1
+	// All users of the workspace can see the shortcut.
+	Shortcut_WORKSPACE Shortcut_Visibility = 2
+	// Only the owner and the shared users can see the shortcut.
+	Shortcut_USERS Shortcut_Visibility = 3
+)
+
+// Enum value maps for Shortcut_Visibility.
+var (
+	Shortcut_Visibility_name = map[int32]string{
+		0: "VISIBILITY_UNSPECIFIED",
+		1: "PRIVATE",
+		2: "WORKSPACE",
+		3: "USERS",
+	}
+	Shortcut_Visibility_value = map[string]int32{
+		"VISIBILITY_UNSPECIFIED": 0,
+		"PRIVATE":                1,
+		"WORKSPACE":              2,
+		"USERS":                  3,
+	}
+)
+
+func (x Shortcut_Visibility) Enum() *Shortcut_Visibility {
+	p := new(Shortcut_Visibility)
+	*p = x
+	return p
+}
+
+func (x Shortcut_Visibility) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Shortcut_Visibility) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_v1_shortcut_service_proto_enumTypes[0].Descriptor()
+}
+
+func (Shortcut_Visibility) Type() protoreflect.EnumType {
+	return &file_api_v1_shortcut_service_proto_enumTypes[0]
+}
+
+func (x Shortcut_Visibility) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Shortcut_Visibility.Descriptor instead.
+func (Shortcut_Visibility) EnumDescriptor() ([]byte, []int) {
+	return file_api_v1_shortcut_service_proto_rawDescGZIP(), []int{0, 0}
+}
+
 type Shortcut struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// The resource name of the shortcut.
@@ -32,7 +88,31 @@ type Shortcut struct {
 	// The title of the shortcut.
 	Title string `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
 	// The filter expression for the shortcut.
-	Filter        string `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+	Filter string `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+	// The owner of the shortcut.
+	// Format: users/{user}
+	Owner string `protobuf:"bytes,4,opt,name=owner,proto3" json:"owner,omitempty"`
+	// The visibility of the shortcut. Defaults to PRIVATE.
+	Visibility Shortcut_Visibility `protobuf:"varint,5,opt,name=visibility,proto3,enum=memos.api.v1.Shortcut_Visibility" json:"visibility,omitempty"`
+	// The users the shortcut is shared with when the visibility is USERS.
+	// Format: users/{user}
+	SharedUsers []string `protobuf:"bytes,6,rep,name=shared_users,json=sharedUsers,proto3" json:"shared_users,omitempty"`
+	// Whether the current user subscribed to the shortcut.
+	// Always false for the shortcuts owned by the current user.
+	Subscribed bool `protobuf:"varint,7,opt,name=subscribed,proto3" json:"subscribed,omitempty"`
+	// The number of memos visible to the current user that match the filter.
+	MemoCount int32 `protobuf:"varint,8,opt,name=memo_count,json=memoCount,proto3" json:"memo_count,omitempty"`
+	// The number of matching memos created since the current user last opened the shortcut.
+	NewMemoCount int32 `protobuf:"varint,9,opt,name=new_memo_count,json=newMemoCount,proto3" json:"new_memo_count,omitempty"`
+	// The last time the current user opened the shortcut.
+	LastOpenTime *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=last_open_time,json=lastOpenTime,proto3" json:"last_open_time,omitempty"`
+	// Whether the current user gets an inbox notification when a new memo matches the filter.
+	// Can be updated by the owner and the subscribers.
+	NotifyInbox bool `protobuf:"varint,11,opt,name=notify_inbox,json=notifyInbox,proto3" json:"notify_inbox,omitempty"`
+	// The webhook of the current user called when a new memo matches the filter.
+	// Can be updated by the owner and the subscribers.
+	// Format: users/{user}/webhooks/{webhook}
+	Webhook       string `protobuf:"bytes,12,opt,name=webhook,proto3" json:"webhook,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -88,11 +168,77 @@ func (x *Shortcut) GetFilter() string {
 	return ""
 }
 
+func (x *Shortcut) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *Shortcut) GetVisibility() Shortcut_Visibility {
+	if x != nil {
+		return x.Visibility
+	}
+	return Shortcut_VISIBILITY_UNSPECIFIED
+}
+
+func (x *Shortcut) GetSharedUsers() []string {
+	if x != nil {
+		return x.SharedUsers
+	}
+	return nil
+}
+
+func (x *Shortcut) GetSubscribed() bool {
+	if x != nil {
+		return x.Subscribed
+	}
+	return false
+}
+
+func (x *Shortcut) GetMemoCount() int32 {
+	if x != nil {
+		return x.MemoCount
+	}
+	return 0
+}
+
+func (x *Shortcut) GetNewMemoCount() int32 {
+	if x != nil {
+		return x.NewMemoCount
+	}
+	return 0
+}
+
+func (x *Shortcut) GetLastOpenTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastOpenTime
+	}
+	return nil
+}
+
+func (x *Shortcut) GetNotifyInbox() bool {
+	if x != nil {
+		return x.NotifyInbox
+	}
+	return false
+}
+
+func (x *Shortcut) GetWebhook() string {
+	if x != nil {
+		return x.Webhook
+	}
+	return ""
+}
+
 type ListShortcutsRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Required. The parent resource where shortcuts are listed.
 	// Format: users/{user}
-	Parent        string `protobuf:"bytes,1,opt,name=parent,proto3" json:"parent,omitempty"`
+	Parent string `protobuf:"bytes,1,opt,name=parent,proto3" json:"parent,omitempty"`
+	// Optional. If set, also list the shortcuts shared with the user that they did not subscribe to.
+	// By default only the shortcuts owned or subscribed by the user are listed.
+	ShowShared    bool `protobuf:"varint,2,opt,name=show_shared,json=showShared,proto3" json:"show_shared,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -134,6 +280,13 @@ func (x *ListShortcutsRequest) GetParent() string {
 	return ""
 }
 
+func (x *ListShortcutsRequest) GetShowShared() bool {
+	if x != nil {
+		return x.ShowShared
+	}
+	return false
+}
+
 type ListShortcutsResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// The list of shortcuts.
@@ -389,18 +542,179 @@ func (x *DeleteShortcutRequest) GetName() string {
 	return ""
 }
 
+type SubscribeShortcutRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. The resource name of the shortcut to subscribe to.
+	// Format: users/{user}/shortcuts/{shortcut}
+	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeShortcutRequest) Reset() {
+	*x = SubscribeShortcutRequest{}
+	mi := &file_api_v1_shortcut_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeShortcutRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeShortcutRequest) ProtoMessage() {}
+
+func (x *SubscribeShortcutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_shortcut_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeShortcutRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeShortcutRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_shortcut_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SubscribeShortcutRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type UnsubscribeShortcutRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. The resource name of the shortcut to unsubscribe from.
+	// Format: users/{user}/shortcuts/{shortcut}
+	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnsubscribeShortcutRequest) Reset() {
+	*x = UnsubscribeShortcutRequest{}
+	mi := &file_api_v1_shortcut_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnsubscribeShortcutRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnsubscribeShortcutRequest) ProtoMessage() {}
+
+func (x *UnsubscribeShortcutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_shortcut_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnsubscribeShortcutRequest.ProtoReflect.Descriptor instead.
+func (*UnsubscribeShortcutRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_shortcut_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UnsubscribeShortcutRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type OpenShortcutRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. The resource name of the shortcut to open.
+	// Format: users/{user}/shortcuts/{shortcut}
+	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OpenShortcutRequest) Reset() {
+	*x = OpenShortcutRequest{}
+	mi := &file_api_v1_shortcut_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OpenShortcutRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OpenShortcutRequest) ProtoMessage() {}
+
+func (x *OpenShortcutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_shortcut_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OpenShortcutRequest.ProtoReflect.Descriptor instead.
+func (*OpenShortcutRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_shortcut_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *OpenShortcutRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
 var File_api_v1_shortcut_service_proto protoreflect.FileDescriptor
 
 const file_api_v1_shortcut_service_proto_rawDesc = "" +
 	"\n" +
-	"\x1dapi/v1/shortcut_service.proto\x12\fmemos.api.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x17google/api/client.proto\x1a\x1fgoogle/api/field_behavior.proto\x1a\x19google/api/resource.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a google/protobuf/field_mask.proto\"\xaf\x01\n" +
+	"\x1dapi/v1/shortcut_service.proto\x12\fmemos.api.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x17google/api/client.proto\x1a\x1fgoogle/api/field_behavior.proto\x1a\x19google/api/resource.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x8d\x05\n" +
 	"\bShortcut\x12\x17\n" +
 	"\x04name\x18\x01 \x01(\tB\x03\xe0A\bR\x04name\x12\x19\n" +
 	"\x05title\x18\x02 \x01(\tB\x03\xe0A\x02R\x05title\x12\x1b\n" +
-	"\x06filter\x18\x03 \x01(\tB\x03\xe0A\x01R\x06filter:R\xeaAO\n" +
-	"\x15memos.api.v1/Shortcut\x12!users/{user}/shortcuts/{shortcut}*\tshortcuts2\bshortcut\"M\n" +
+	"\x06filter\x18\x03 \x01(\tB\x03\xe0A\x01R\x06filter\x12\x19\n" +
+	"\x05owner\x18\x04 \x01(\tB\x03\xe0A\x03R\x05owner\x12F\n" +
+	"\n" +
+	"visibility\x18\x05 \x01(\x0e2!.memos.api.v1.Shortcut.VisibilityB\x03\xe0A\x01R\n" +
+	"visibility\x12&\n" +
+	"\fshared_users\x18\x06 \x03(\tB\x03\xe0A\x01R\vsharedUsers\x12#\n" +
+	"\n" +
+	"subscribed\x18\a \x01(\bB\x03\xe0A\x03R\n" +
+	"subscribed\x12\"\n" +
+	"\n" +
+	"memo_count\x18\b \x01(\x05B\x03\xe0A\x03R\tmemoCount\x12)\n" +
+	"\x0enew_memo_count\x18\t \x01(\x05B\x03\xe0A\x03R\fnewMemoCount\x12E\n" +
+	"\x0elast_open_time\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampB\x03\xe0A\x03R\flastOpenTime\x12&\n" +
+	"\fnotify_inbox\x18\v \x01(\bB\x03\xe0A\x01R\vnotifyInbox\x12\x1d\n" +
+	"\awebhook\x18\f \x01(\tB\x03\xe0A\x01R\awebhook\"O\n" +
+	"\n" +
+	"Visibility\x12\x1a\n" +
+	"\x16VISIBILITY_UNSPECIFIED\x10\x00\x12\v\n" +
+	"\aPRIVATE\x10\x01\x12\r\n" +
+	"\tWORKSPACE\x10\x02\x12\t\n" +
+	"\x05USERS\x10\x03:R\xeaAO\n" +
+	"\x15memos.api.v1/Shortcut\x12!users/{user}/shortcuts/{shortcut}*\tshortcuts2\bshortcut\"s\n" +
 	"\x14ListShortcutsRequest\x125\n" +
-	"\x06parent\x18\x01 \x01(\tB\x1d\xe0A\x02\xfaA\x17\x12\x15memos.api.v1/ShortcutR\x06parent\"M\n" +
+	"\x06parent\x18\x01 \x01(\tB\x1d\xe0A\x02\xfaA\x17\x12\x15memos.api.v1/ShortcutR\x06parent\x12$\n" +
+	"\vshow_shared\x18\x02 \x01(\bB\x03\xe0A\x01R\n" +
+	"showShared\"M\n" +
 	"\x15ListShortcutsResponse\x124\n" +
 	"\tshortcuts\x18\x01 \x03(\v2\x16.memos.api.v1.ShortcutR\tshortcuts\"G\n" +
 	"\x12GetShortcutRequest\x121\n" +
@@ -416,13 +730,25 @@ const file_api_v1_shortcut_service_proto_rawDesc = "" +
 	"updateMask\"J\n" +
 	"\x15DeleteShortcutRequest\x121\n" +
 	"\x04name\x18\x01 \x01(\tB\x1d\xe0A\x02\xfaA\x17\n" +
-	"\x15memos.api.v1/ShortcutR\x04name2\xde\x05\n" +
+	"\x15memos.api.v1/ShortcutR\x04name\"M\n" +
+	"\x18SubscribeShortcutRequest\x121\n" +
+	"\x04name\x18\x01 \x01(\tB\x1d\xe0A\x02\xfaA\x17\n" +
+	"\x15memos.api.v1/ShortcutR\x04name\"O\n" +
+	"\x1aUnsubscribeShortcutRequest\x121\n" +
+	"\x04name\x18\x01 \x01(\tB\x1d\xe0A\x02\xfaA\x17\n" +
+	"\x15memos.api.v1/ShortcutR\x04name\"H\n" +
+	"\x13OpenShortcutRequest\x121\n" +
+	"\x04name\x18\x01 \x01(\tB\x1d\xe0A\x02\xfaA\x17\n" +
+	"\x15memos.api.v1/ShortcutR\x04name2\x97\t\n" +
 	"\x0fShortcutService\x12\x8d\x01\n" +
 	"\rListShortcuts\x12\".memos.api.v1.ListShortcutsRequest\x1a#.memos.api.v1.ListShortcutsResponse\"3\xdaA\x06parent\x82\xd3\xe4\x93\x02$\x12\"/api/v1/{parent=users/*}/shortcuts\x12z\n" +
 	"\vGetShortcut\x12 .memos.api.v1.GetShortcutRequest\x1a\x16.memos.api.v1.Shortcut\"1\xdaA\x04name\x82\xd3\xe4\x93\x02$\x12\"/api/v1/{name=users/*/shortcuts/*}\x12\x95\x01\n" +
 	"\x0eCreateShortcut\x12#.memos.api.v1.CreateShortcutRequest\x1a\x16.memos.api.v1.Shortcut\"F\xdaA\x0fparent,shortcut\x82\xd3\xe4\x93\x02.:\bshortcut\"\"/api/v1/{parent=users/*}/shortcuts\x12\xa3\x01\n" +
 	"\x0eUpdateShortcut\x12#.memos.api.v1.UpdateShortcutRequest\x1a\x16.memos.api.v1.Shortcut\"T\xdaA\x14shortcut,update_mask\x82\xd3\xe4\x93\x027:\bshortcut2+/api/v1/{shortcut.name=users/*/shortcuts/*}\x12\x80\x01\n" +
-	"\x0eDeleteShortcut\x12#.memos.api.v1.DeleteShortcutRequest\x1a\x16.google.protobuf.Empty\"1\xdaA\x04name\x82\xd3\xe4\x93\x02$*\"/api/v1/{name=users/*/shortcuts/*}B\xac\x01\n" +
+	"\x0eDeleteShortcut\x12#.memos.api.v1.DeleteShortcutRequest\x1a\x16.google.protobuf.Empty\"1\xdaA\x04name\x82\xd3\xe4\x93\x02$*\"/api/v1/{name=users/*/shortcuts/*}\x12\x93\x01\n" +
+	"\x11SubscribeShortcut\x12&.memos.api.v1.SubscribeShortcutRequest\x1a\x16.memos.api.v1.Shortcut\">\xdaA\x04name\x82\xd3\xe4\x93\x021:\x01*\",/api/v1/{name=users/*/shortcuts/*}:subscribe\x12\x84\x01\n" +
+	"\fOpenShortcut\x12!.memos.api.v1.OpenShortcutRequest\x1a\x16.memos.api.v1.Shortcut\"9\xdaA\x04name\x82\xd3\xe4\x93\x02,:\x01*\"'/api/v1/{name=users/*/shortcuts/*}:open\x12\x99\x01\n" +
+	"\x13UnsubscribeShortcut\x12(.memos.api.v1.UnsubscribeShortcutRequest\x1a\x16.google.protobuf.Empty\"@\xdaA\x04name\x82\xd3\xe4\x93\x023:\x01*\"./api/v1/{name=users/*/shortcuts/*}:unsubscribeB\xac\x01\n" +
 	"\x10com.memos.api.v1B\x14ShortcutServiceProtoP\x01Z0github.com/usememos/memos/proto/gen/api/v1;apiv1\xa2\x02\x03MAX\xaa\x02\fMemos.Api.V1\xca\x02\fMemos\\Api\\V1\xe2\x02\x18Memos\\Api\\V1\\GPBMetadata\xea\x02\x0eMemos::Api::V1b\x06proto3"
 
 var (
@@ -437,38 +763,52 @@ func file_api_v1_shortcut_service_proto_rawDescGZIP() []byte {
 	return file_api_v1_shortcut_service_proto_rawDescData
 }
 
-var file_api_v1_shortcut_service_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_api_v1_shortcut_service_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_api_v1_shortcut_service_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
 var file_api_v1_shortcut_service_proto_goTypes = []any{
-	(*Shortcut)(nil),              // 0: memos.api.v1.Shortcut
-	(*ListShortcutsRequest)(nil),  // 1: memos.api.v1.ListShortcutsRequest
-	(*ListShortcutsResponse)(nil), // 2: memos.api.v1.ListShortcutsResponse
-	(*GetShortcutRequest)(nil),    // 3: memos.api.v1.GetShortcutRequest
-	(*CreateShortcutRequest)(nil), // 4: memos.api.v1.CreateShortcutRequest
-	(*UpdateShortcutRequest)(nil), // 5: memos.api.v1.UpdateShortcutRequest
-	(*DeleteShortcutRequest)(nil), // 6: memos.api.v1.DeleteShortcutRequest
-	(*fieldmaskpb.FieldMask)(nil), // 7: google.protobuf.FieldMask
-	(*emptypb.Empty)(nil),         // 8: google.protobuf.Empty
+	(Shortcut_Visibility)(0),           // 0: memos.api.v1.Shortcut.Visibility
+	(*Shortcut)(nil),                   // 1: memos.api.v1.Shortcut
+	(*ListShortcutsRequest)(nil),       // 2: memos.api.v1.ListShortcutsRequest
+	(*ListShortcutsResponse)(nil),      // 3: memos.api.v1.ListShortcutsResponse
+	(*GetShortcutRequest)(nil),         // 4: memos.api.v1.GetShortcutRequest
+	(*CreateShortcutRequest)(nil),      // 5: memos.api.v1.CreateShortcutRequest
+	(*UpdateShortcutRequest)(nil),      // 6: memos.api.v1.UpdateShortcutRequest
+	(*DeleteShortcutRequest)(nil),      // 7: memos.api.v1.DeleteShortcutRequest
+	(*SubscribeShortcutRequest)(nil),   // 8: memos.api.v1.SubscribeShortcutRequest
+	(*UnsubscribeShortcutRequest)(nil), // 9: memos.api.v1.UnsubscribeShortcutRequest
+	(*OpenShortcutRequest)(nil),        // 10: memos.api.v1.OpenShortcutRequest
+	(*timestamppb.Timestamp)(nil),      // 11: google.protobuf.Timestamp
+	(*fieldmaskpb.FieldMask)(nil),      // 12: google.protobuf.FieldMask
+	(*emptypb.Empty)(nil),              // 13: google.protobuf.Empty
 }
 var file_api_v1_shortcut_service_proto_depIdxs = []int32{
-	0, // 0: memos.api.v1.ListShortcutsResponse.shortcuts:type_name -> memos.api.v1.Shortcut
-	0, // 1: memos.api.v1.CreateShortcutRequest.shortcut:type_name -> memos.api.v1.Shortcut
-	0, // 2: memos.api.v1.UpdateShortcutRequest.shortcut:type_name -> memos.api.v1.Shortcut
-	7, // 3: memos.api.v1.UpdateShortcutRequest.update_mask:type_name -> google.protobuf.FieldMask
-	1, // 4: memos.api.v1.ShortcutService.ListShortcuts:input_type -> memos.api.v1.ListShortcutsRequest
-	3, // 5: memos.api.v1.ShortcutService.GetShortcut:input_type -> memos.api.v1.GetShortcutRequest
-	4, // 6: memos.api.v1.ShortcutService.CreateShortcut:input_type -> memos.api.v1.CreateShortcutRequest
-	5, // 7: memos.api.v1.ShortcutService.UpdateShortcut:input_type -> memos.api.v1.UpdateShortcutRequest
-	6, // 8: memos.api.v1.ShortcutService.DeleteShortcut:input_type -> memos.api.v1.DeleteShortcutRequest
-	2, // 9: memos.api.v1.ShortcutService.ListShortcuts:output_type -> memos.api.v1.ListShortcutsResponse
-	0, // 10: memos.api.v1.ShortcutService.GetShortcut:output_type -> memos.api.v1.Shortcut
-	0, // 11: memos.api.v1.ShortcutService.CreateShortcut:output_type -> memos.api.v1.Shortcut
-	0, // 12: memos.api.v1.ShortcutService.UpdateShortcut:output_type -> memos.api.v1.Shortcut
-	8, // 13: memos.api.v1.ShortcutService.DeleteShortcut:output_type -> google.protobuf.Empty
-	9, // [9:14] is the sub-list for method output_type
-	4, // [4:9] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	0,  // 0: memos.api.v1.Shortcut.visibility:type_name -> memos.api.v1.Shortcut.Visibility
+	11, // 1: memos.api.v1.Shortcut.last_open_time:type_name -> google.protobuf.Timestamp
+	1,  // 2: memos.api.v1.ListShortcutsResponse.shortcuts:type_name -> memos.api.v1.Shortcut
+	1,  // 3: memos.api.v1.CreateShortcutRequest.shortcut:type_name -> memos.api.v1.Shortcut
+	1,  // 4: memos.api.v1.UpdateShortcutRequest.shortcut:type_name -> memos.api.v1.Shortcut
+	12, // 5: memos.api.v1.UpdateShortcutRequest.update_mask:type_name -> google.protobuf.FieldMask
+	2,  // 6: memos.api.v1.ShortcutService.ListShortcuts:input_type -> memos.api.v1.ListShortcutsRequest
+	4,  // 7: memos.api.v1.ShortcutService.GetShortcut:input_type -> memos.api.v1.GetShortcutRequest
+	5,  // 8: memos.api.v1.ShortcutService.CreateShortcut:input_type -> memos.api.v1.CreateShortcutRequest
+	6,  // 9: memos.api.v1.ShortcutService.UpdateShortcut:input_type -> memos.api.v1.UpdateShortcutRequest
+	7,  // 10: memos.api.v1.ShortcutService.DeleteShortcut:input_type -> memos.api.v1.DeleteShortcutRequest
+	8,  // 11: memos.api.v1.ShortcutService.SubscribeShortcut:input_type -> memos.api.v1.SubscribeShortcutRequest
+	10, // 12: memos.api.v1.ShortcutService.OpenShortcut:input_type -> memos.api.v1.OpenShortcutRequest
+	9,  // 13: memos.api.v1.ShortcutService.UnsubscribeShortcut:input_type -> memos.api.v1.UnsubscribeShortcutRequest
+	3,  // 14: memos.api.v1.ShortcutService.ListShortcuts:output_type -> memos.api.v1.ListShortcutsResponse
+	1,  // 15: memos.api.v1.ShortcutService.GetShortcut:output_type -> memos.api.v1.Shortcut
+	1,  // 16: memos.api.v1.ShortcutService.CreateShortcut:output_type -> memos.api.v1.Shortcut
+	1,  // 17: memos.api.v1.ShortcutService.UpdateShortcut:output_type -> memos.api.v1.Shortcut
+	13, // 18: memos.api.v1.ShortcutService.DeleteShortcut:output_type -> google.protobuf.Empty
+	1,  // 19: memos.api.v1.ShortcutService.SubscribeShortcut:output_type -> memos.api.v1.Shortcut
+	1,  // 20: memos.api.v1.ShortcutService.OpenShortcut:output_type -> memos.api.v1.Shortcut
+	13, // 21: memos.api.v1.ShortcutService.UnsubscribeShortcut:output_type -> google.protobuf.Empty
+	14, // [14:22] is the sub-list for method output_type
+	6,  // [6:14] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_api_v1_shortcut_service_proto_init() }
@@ -481,13 +821,14 @@ func file_api_v1_shortcut_service_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_v1_shortcut_service_proto_rawDesc), len(file_api_v1_shortcut_service_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   7,
+			NumEnums:      1,
+			NumMessages:   10,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_api_v1_shortcut_service_proto_goTypes,
 		DependencyIndexes: file_api_v1_shortcut_service_proto_depIdxs,
+		EnumInfos:         file_api_v1_shortcut_service_proto_enumTypes,
 		MessageInfos:      file_api_v1_shortcut_service_proto_msgTypes,
 	}.Build()
 	File_api_v1_shortcut_service_proto = out.File
@@ -20,11 +20,14 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ShortcutService_ListShortcuts_FullMethodName  = "/memos.api.v1.ShortcutService/ListShortcuts"
-	ShortcutService_GetShortcut_FullMethodName    = "/memos.api.v1.ShortcutService/GetShortcut"
-	ShortcutService_CreateShortcut_FullMethodName = "/memos.api.v1.ShortcutService/CreateShortcut"
-	ShortcutService_UpdateShortcut_FullMethodName = "/memos.api.v1.ShortcutService/UpdateShortcut"
-	ShortcutService_DeleteShortcut_FullMethodName = "/memos.api.v1.ShortcutService/DeleteShortcut"
+	ShortcutService_ListShortcuts_FullMethodName       = "/memos.api.v1.ShortcutService/ListShortcuts"
+	ShortcutService_GetShortcut_FullMethodName         = "/memos.api.v1.ShortcutService/GetShortcut"
+	ShortcutService_CreateShortcut_FullMethodName      = "/memos.api.v1.ShortcutService/CreateShortcut"
+	ShortcutService_UpdateShortcut_FullMethodName      = "/memos.api.v1.ShortcutService/UpdateShortcut"
+	ShortcutService_DeleteShortcut_FullMethodName      = "/memos.api.v1.ShortcutService/DeleteShortcut"
+	ShortcutService_SubscribeShortcut_FullMethodName   = "/memos.api.v1.ShortcutService/SubscribeShortcut"
+	ShortcutService_OpenShortcut_FullMethodName        = "/memos.api.v1.ShortcutService/OpenShortcut"
+	ShortcutService_UnsubscribeShortcut_FullMethodName = "/memos.api.v1.ShortcutService/UnsubscribeShortcut"
 )
 
 // ShortcutServiceClient is the client API for ShortcutService service.
@@ -41,6 +44,12 @@ type ShortcutServiceClient interface {
 	UpdateShortcut(ctx context.Context, in *UpdateShortcutRequest, opts ...grpc.CallOption) (*Shortcut, error)
 	// DeleteShortcut deletes a shortcut for a user.
 	DeleteShortcut(ctx context.Context, in *DeleteShortcutRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// SubscribeShortcut subscribes the current user to a shortcut shared with them.
+	SubscribeShortcut(ctx context.Context, in *SubscribeShortcutRequest, opts ...grpc.CallOption) (*Shortcut, error)
+	// OpenShortcut records that the current user opened a shortcut, which resets its new memo count.
+	OpenShortcut(ctx context.Context, in *OpenShortcutRequest, opts ...grpc.CallOption) (*Shortcut, error)
+	// UnsubscribeShortcut removes the subscription of the current user to a shortcut.
+	UnsubscribeShortcut(ctx context.Context, in *UnsubscribeShortcutRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 }
 
 type shortcutServiceClient struct {
@@ -101,6 +110,36 @@ func (c *shortcutServiceClient) DeleteShortcut(ctx context.Context, in *DeleteSh
 	return out, nil
 }
 
+func (c *shortcutServiceClient) SubscribeShortcut(ctx context.Context, in *SubscribeShortcutRequest, opts ...grpc.CallOption) (*Shortcut, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Shortcut)
+	err := c.cc.Invoke(ctx, ShortcutService_SubscribeShortcut_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortcutServiceClient) OpenShortcut(ctx context.Context, in *OpenShortcutRequest, opts ...grpc.CallOption) (*Shortcut, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Shortcut)
+	err := c.cc.Invoke(ctx, ShortcutService_OpenShortcut_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortcutServiceClient) UnsubscribeShortcut(ctx context.Context, in *UnsubscribeShortcutRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ShortcutService_UnsubscribeShortcut_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ShortcutServiceServer is the server API for ShortcutService service.
 // All implementations must embed UnimplementedShortcutServiceServer
 // for forward compatibility.
@@ -115,6 +154,12 @@ type ShortcutServiceServer interface {
 	UpdateShortcut(context.Context, *UpdateShortcutRequest) (*Shortcut, error)
 	// DeleteShortcut deletes a shortcut for a user.
 	DeleteShortcut(context.Context, *DeleteShortcutRequest) (*emptypb.Empty, error)
+	// SubscribeShortcut subscribes the current user to a shortcut shared with them.
+	SubscribeShortcut(context.Context, *SubscribeShortcutRequest) (*Shortcut, error)
+	// OpenShortcut records that the current user opened a shortcut, which resets its new memo count.
+	OpenShortcut(context.Context, *OpenShortcutRequest) (*Shortcut, error)
+	// UnsubscribeShortcut removes the subscription of the current user to a shortcut.
+	UnsubscribeShortcut(context.Context, *UnsubscribeShortcutRequest) (*emptypb.Empty, error)
 	mustEmbedUnimplementedShortcutServiceServer()
 }
 
@@ -140,6 +185,15 @@ func (UnimplementedShortcutServiceServer) UpdateShortcut(context.Context, *Updat
 func (UnimplementedShortcutServiceServer) DeleteShortcut(context.Context, *DeleteShortcutRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteShortcut not implemented")
 }
+func (UnimplementedShortcutServiceServer) SubscribeShortcut(context.Context, *SubscribeShortcutRequest) (*Shortcut, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubscribeShortcut not implemented")
+}
+func (UnimplementedShortcutServiceServer) OpenShortcut(context.Context, *OpenShortcutRequest) (*Shortcut, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OpenShortcut not implemented")
+}
+func (UnimplementedShortcutServiceServer) UnsubscribeShortcut(context.Context, *UnsubscribeShortcutRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnsubscribeShortcut not implemented")
+}
 func (UnimplementedShortcutServiceServer) mustEmbedUnimplementedShortcutServiceServer() {}
 func (UnimplementedShortcutServiceServer) testEmbeddedByValue()                         {}
 
@@ -251,6 +305,60 @@ func _ShortcutService_DeleteShortcut_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ShortcutService_SubscribeShortcut_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubscribeShortcutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortcutServiceServer).SubscribeShortcut(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortcutService_SubscribeShortcut_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortcutServiceServer).SubscribeShortcut(ctx, req.(*SubscribeShortcutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortcutService_OpenShortcut_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OpenShortcutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortcutServiceServer).OpenShortcut(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortcutService_OpenShortcut_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortcutServiceServer).OpenShortcut(ctx, req.(*OpenShortcutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortcutService_UnsubscribeShortcut_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnsubscribeShortcutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortcutServiceServer).UnsubscribeShortcut(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortcutService_UnsubscribeShortcut_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortcutServiceServer).UnsubscribeShortcut(ctx, req.(*UnsubscribeShortcutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ShortcutService_ServiceDesc is the grpc.ServiceDesc for ShortcutService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -278,6 +386,18 @@ var ShortcutService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteShortcut",
 			Handler:    _ShortcutService_DeleteShortcut_Handler,
 		},
+		{
+			MethodName: "SubscribeShortcut",
+			Handler:    _ShortcutService_SubscribeShortcut_Handler,
+		},
+		{
+			MethodName: "OpenShortcut",
+			Handler:    _ShortcutService_OpenShortcut_Handler,
+		},
+		{
+			MethodName: "UnsubscribeShortcut",
+			Handler:    _ShortcutService_UnsubscribeShortcut_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/v1/shortcut_service.proto",
@@ -29,12 +29,18 @@ const (
 	UserService_GetUserAvatar_FullMethodName         = "/memos.api.v1.UserService/GetUserAvatar"
 	UserService_ListAllUserStats_FullMethodName      = "/memos.api.v1.UserService/ListAllUserStats"
 	UserService_GetUserStats_FullMethodName          = "/memos.api.v1.UserService/GetUserStats"
+	UserService_ListStorageUsage_FullMethodName      = "/memos.api.v1.UserService/ListStorageUsage"
+	UserService_GetUserStorageUsage_FullMethodName   = "/memos.api.v1.UserService/GetUserStorageUsage"
+	UserService_ExportUserData_FullMethodName        = "/memos.api.v1.UserService/ExportUserData"
 	UserService_GetUserSetting_FullMethodName        = "/memos.api.v1.UserService/GetUserSetting"
 	UserService_UpdateUserSetting_FullMethodName     = "/memos.api.v1.UserService/UpdateUserSetting"
 	UserService_ListUserSettings_FullMethodName      = "/memos.api.v1.UserService/ListUserSettings"
 	UserService_ListUserAccessTokens_FullMethodName  = "/memos.api.v1.UserService/ListUserAccessTokens"
 	UserService_CreateUserAccessToken_FullMethodName = "/memos.api.v1.UserService/CreateUserAccessToken"
 	UserService_DeleteUserAccessToken_FullMethodName = "/memos.api.v1.UserService/DeleteUserAccessToken"
+	UserService_ListUserFeedTokens_FullMethodName    = "/memos.api.v1.UserService/ListUserFeedTokens"
+	UserService_CreateUserFeedToken_FullMethodName   = "/memos.api.v1.UserService/CreateUserFeedToken"
+	UserService_DeleteUserFeedToken_FullMethodName   = "/memos.api.v1.UserService/DeleteUserFeedToken"
 	UserService_ListUserSessions_FullMethodName      = "/memos.api.v1.UserService/ListUserSessions"
 	UserService_RevokeUserSession_FullMethodName     = "/memos.api.v1.UserService/RevokeUserSession"
 	UserService_ListUserWebhooks_FullMethodName      = "/memos.api.v1.UserService/ListUserWebhooks"
@@ -63,6 +69,13 @@ type UserServiceClient interface {
 	ListAllUserStats(ctx context.Context, in *ListAllUserStatsRequest, opts ...grpc.CallOption) (*ListAllUserStatsResponse, error)
 	// GetUserStats returns statistics for a specific user.
 	GetUserStats(ctx context.Context, in *GetUserStatsRequest, opts ...grpc.CallOption) (*UserStats, error)
+	// ListStorageUsage returns the storage usage of all users.
+	ListStorageUsage(ctx context.Context, in *ListStorageUsageRequest, opts ...grpc.CallOption) (*ListStorageUsageResponse, error)
+	// GetUserStorageUsage returns the storage usage and the storage quota of a user.
+	GetUserStorageUsage(ctx context.Context, in *GetUserStorageUsageRequest, opts ...grpc.CallOption) (*UserStorageUsage, error)
+	// ExportUserData streams a ZIP archive with one Markdown file per memo of the user
+	// and the attachments of the user.
+	ExportUserData(ctx context.Context, in *ExportUserDataRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[httpbody.HttpBody], error)
 	// GetUserSetting returns the user setting.
 	GetUserSetting(ctx context.Context, in *GetUserSettingRequest, opts ...grpc.CallOption) (*UserSetting, error)
 	// UpdateUserSetting updates the user setting.
@@ -75,6 +88,12 @@ type UserServiceClient interface {
 	CreateUserAccessToken(ctx context.Context, in *CreateUserAccessTokenRequest, opts ...grpc.CallOption) (*UserAccessToken, error)
 	// DeleteUserAccessToken deletes an access token.
 	DeleteUserAccessToken(ctx context.Context, in *DeleteUserAccessTokenRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// ListUserFeedTokens returns a list of feed tokens for a user.
+	ListUserFeedTokens(ctx context.Context, in *ListUserFeedTokensRequest, opts ...grpc.CallOption) (*ListUserFeedTokensResponse, error)
+	// CreateUserFeedToken creates a new feed token for a user.
+	CreateUserFeedToken(ctx context.Context, in *CreateUserFeedTokenRequest, opts ...grpc.CallOption) (*UserFeedToken, error)
+	// DeleteUserFeedToken deletes a feed token.
+	DeleteUserFeedToken(ctx context.Context, in *DeleteUserFeedTokenRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// ListUserSessions returns a list of active sessions for a user.
 	ListUserSessions(ctx context.Context, in *ListUserSessionsRequest, opts ...grpc.CallOption) (*ListUserSessionsResponse, error)
 	// RevokeUserSession revokes a specific session for a user.
@@ -177,6 +196,45 @@ func (c *userServiceClient) GetUserStats(ctx context.Context, in *GetUserStatsRe
 	return out, nil
 }
 
+func (c *userServiceClient) ListStorageUsage(ctx context.Context, in *ListStorageUsageRequest, opts ...grpc.CallOption) (*ListStorageUsageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListStorageUsageResponse)
+	err := c.cc.Invoke(ctx, UserService_ListStorageUsage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetUserStorageUsage(ctx context.Context, in *GetUserStorageUsageRequest, opts ...grpc.CallOption) (*UserStorageUsage, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserStorageUsage)
+	err := c.cc.Invoke(ctx, UserService_GetUserStorageUsage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ExportUserData(ctx context.Context, in *ExportUserDataRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[httpbody.HttpBody], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &UserService_ServiceDesc.Streams[0], UserService_ExportUserData_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportUserDataRequest, httpbody.HttpBody]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type UserService_ExportUserDataClient = grpc.ServerStreamingClient[httpbody.HttpBody]
+
 func (c *userServiceClient) GetUserSetting(ctx context.Context, in *GetUserSettingRequest, opts ...grpc.CallOption) (*UserSetting, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(UserSetting)
@@ -237,6 +295,36 @@ func (c *userServiceClient) DeleteUserAccessToken(ctx context.Context, in *Delet
 	return out, nil
 }
 
+func (c *userServiceClient) ListUserFeedTokens(ctx context.Context, in *ListUserFeedTokensRequest, opts ...grpc.CallOption) (*ListUserFeedTokensResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListUserFeedTokensResponse)
+	err := c.cc.Invoke(ctx, UserService_ListUserFeedTokens_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) CreateUserFeedToken(ctx context.Context, in *CreateUserFeedTokenRequest, opts ...grpc.CallOption) (*UserFeedToken, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserFeedToken)
+	err := c.cc.Invoke(ctx, UserService_CreateUserFeedToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) DeleteUserFeedToken(ctx context.Context, in *DeleteUserFeedTokenRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, UserService_DeleteUserFeedToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *userServiceClient) ListUserSessions(ctx context.Context, in *ListUserSessionsRequest, opts ...grpc.CallOption) (*ListUserSessionsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListUserSessionsResponse)
@@ -317,6 +405,13 @@ type UserServiceServer interface {
 	ListAllUserStats(context.Context, *ListAllUserStatsRequest) (*ListAllUserStatsResponse, error)
 	// GetUserStats returns statistics for a specific user.
 	GetUserStats(context.Context, *GetUserStatsRequest) (*UserStats, error)
+	// ListStorageUsage returns the storage usage of all users.
+	ListStorageUsage(context.Context, *ListStorageUsageRequest) (*ListStorageUsageResponse, error)
+	// GetUserStorageUsage returns the storage usage and the storage quota of a user.
+	GetUserStorageUsage(context.Context, *GetUserStorageUsageRequest) (*UserStorageUsage, error)
+	// ExportUserData streams a ZIP archive with one Markdown file per memo of the user
+	// and the attachments of the user.
+	ExportUserData(*ExportUserDataRequest, grpc.ServerStreamingServer[httpbody.HttpBody]) error
 	// GetUserSetting returns the user setting.
 	GetUserSetting(context.Context, *GetUserSettingRequest) (*UserSetting, error)
 	// UpdateUserSetting updates the user setting.
@@ -329,6 +424,12 @@ type UserServiceServer interface {
 	CreateUserAccessToken(context.Context, *CreateUserAccessTokenRequest) (*UserAccessToken, error)
 	// DeleteUserAccessToken deletes an access token.
 	DeleteUserAccessToken(context.Context, *DeleteUserAccessTokenRequest) (*emptypb.Empty, error)
+	// ListUserFeedTokens returns a list of feed tokens for a user.
+	ListUserFeedTokens(context.Context, *ListUserFeedTokensRequest) (*ListUserFeedTokensResponse, error)
+	// CreateUserFeedToken creates a new feed token for a user.
+	CreateUserFeedToken(context.Context, *CreateUserFeedTokenRequest) (*UserFeedToken, error)
+	// DeleteUserFeedToken deletes a feed token.
+	DeleteUserFeedToken(context.Context, *DeleteUserFeedTokenRequest) (*emptypb.Empty, error)
 	// ListUserSessions returns a list of active sessions for a user.
 	ListUserSessions(context.Context, *ListUserSessionsRequest) (*ListUserSessionsResponse, error)
 	// RevokeUserSession revokes a specific session for a user.
@@ -375,6 +476,15 @@ func (UnimplementedUserServiceServer) ListAllUserStats(context.Context, *ListAll
 func (UnimplementedUserServiceServer) GetUserStats(context.Context, *GetUserStatsRequest) (*UserStats, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetUserStats not implemented")
 }
+func (UnimplementedUserServiceServer) ListStorageUsage(context.Context, *ListStorageUsageRequest) (*ListStorageUsageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListStorageUsage not implemented")
+}
+func (UnimplementedUserServiceServer) GetUserStorageUsage(context.Context, *GetUserStorageUsageRequest) (*UserStorageUsage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserStorageUsage not implemented")
+}
+func (UnimplementedUserServiceServer) ExportUserData(*ExportUserDataRequest, grpc.ServerStreamingServer[httpbody.HttpBody]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportUserData not implemented")
+}
 func (UnimplementedUserServiceServer) GetUserSetting(context.Context, *GetUserSettingRequest) (*UserSetting, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetUserSetting not implemented")
 }
@@ -393,6 +503,15 @@ func (UnimplementedUserServiceServer) CreateUserAccessToken(context.Context, *Cr
 func (UnimplementedUserServiceServer) DeleteUserAccessToken(context.Context, *DeleteUserAccessTokenRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteUserAccessToken not implemented")
 }
+func (UnimplementedUserServiceServer) ListUserFeedTokens(context.Context, *ListUserFeedTokensRequest) (*ListUserFeedTokensResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUserFeedTokens not implemented")
+}
+func (UnimplementedUserServiceServer) CreateUserFeedToken(context.Context, *CreateUserFeedTokenRequest) (*UserFeedToken, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateUserFeedToken not implemented")
+}
+func (UnimplementedUserServiceServer) DeleteUserFeedToken(context.Context, *DeleteUserFeedTokenRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteUserFeedToken not implemented")
+}
 func (UnimplementedUserServiceServer) ListUserSessions(context.Context, *ListUserSessionsRequest) (*ListUserSessionsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListUserSessions not implemented")
 }
@@ -576,6 +695,53 @@ func _UserService_GetUserStats_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_ListStorageUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListStorageUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListStorageUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListStorageUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListStorageUsage(ctx, req.(*ListStorageUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetUserStorageUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserStorageUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetUserStorageUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetUserStorageUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetUserStorageUsage(ctx, req.(*GetUserStorageUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ExportUserData_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportUserDataRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(UserServiceServer).ExportUserData(m, &grpc.GenericServerStream[ExportUserDataRequest, httpbody.HttpBody]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type UserService_ExportUserDataServer = grpc.ServerStreamingServer[httpbody.HttpBody]
+
 func _UserService_GetUserSetting_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetUserSettingRequest)
 	if err := dec(in); err != nil {
@@ -684,6 +850,60 @@ func _UserService_DeleteUserAccessToken_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_ListUserFeedTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUserFeedTokensRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListUserFeedTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListUserFeedTokens_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListUserFeedTokens(ctx, req.(*ListUserFeedTokensRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_CreateUserFeedToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateUserFeedTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).CreateUserFeedToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_CreateUserFeedToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).CreateUserFeedToken(ctx, req.(*CreateUserFeedTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_DeleteUserFeedToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteUserFeedTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).DeleteUserFeedToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_DeleteUserFeedToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).DeleteUserFeedToken(ctx, req.(*DeleteUserFeedTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _UserService_ListUserSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListUserSessionsRequest)
 	if err := dec(in); err != nil {
@@ -831,6 +1051,14 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetUserStats",
 			Handler:    _UserService_GetUserStats_Handler,
 		},
+		{
+			MethodName: "ListStorageUsage",
+			Handler:    _UserService_ListStorageUsage_Handler,
+		},
+		{
+			MethodName: "GetUserStorageUsage",
+			Handler:    _UserService_GetUserStorageUsage_Handler,
+		},
 		{
 			MethodName: "GetUserSetting",
 			Handler:    _UserService_GetUserSetting_Handler,
@@ -855,6 +1083,18 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteUserAccessToken",
 			Handler:    _UserService_DeleteUserAccessToken_Handler,
 		},
+		{
+			MethodName: "ListUserFeedTokens",
+			Handler:    _UserService_ListUserFeedTokens_Handler,
+		},
+		{
+			MethodName: "CreateUserFeedToken",
+			Handler:    _UserService_CreateUserFeedToken_Handler,
+		},
+		{
+			MethodName: "DeleteUserFeedToken",
+			Handler:    _UserService_DeleteUserFeedToken_Handler,
+		},
 		{
 			MethodName: "ListUserSessions",
 			Handler:    _UserService_ListUserSessions_Handler,
@@ -880,6 +1120,12 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _UserService_DeleteUserWebhook_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportUserData",
+			Handler:       _UserService_ExportUserData_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "api/v1/user_service.proto",
 }
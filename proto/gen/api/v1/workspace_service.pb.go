@@ -11,6 +11,7 @@ import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -34,6 +35,8 @@ const (
 	WorkspaceSetting_STORAGE WorkspaceSetting_Key = 2
 	// MEMO_RELATED is the key for memo related settings.
 	WorkspaceSetting_MEMO_RELATED WorkspaceSetting_Key = 3
+	// BACKUP is the key for scheduled backup settings.
+	WorkspaceSetting_BACKUP WorkspaceSetting_Key = 4
 )
 
 // Enum value maps for WorkspaceSetting_Key.
@@ -43,12 +46,14 @@ var (
 		1: "GENERAL",
 		2: "STORAGE",
 		3: "MEMO_RELATED",
+		4: "BACKUP",
 	}
 	WorkspaceSetting_Key_value = map[string]int32{
 		"KEY_UNSPECIFIED": 0,
 		"GENERAL":         1,
 		"STORAGE":         2,
 		"MEMO_RELATED":    3,
+		"BACKUP":          4,
 	}
 )
 
@@ -90,6 +95,10 @@ const (
 	WorkspaceSetting_StorageSetting_LOCAL WorkspaceSetting_StorageSetting_StorageType = 2
 	// S3 is the S3 storage type.
 	WorkspaceSetting_StorageSetting_S3 WorkspaceSetting_StorageSetting_StorageType = 3
+	// WEBDAV is the WebDAV storage type.
+	WorkspaceSetting_StorageSetting_WEBDAV WorkspaceSetting_StorageSetting_StorageType = 4
+	// SFTP is the SFTP storage type.
+	WorkspaceSetting_StorageSetting_SFTP WorkspaceSetting_StorageSetting_StorageType = 5
 )
 
 // Enum value maps for WorkspaceSetting_StorageSetting_StorageType.
@@ -99,12 +108,16 @@ var (
 		1: "DATABASE",
 		2: "LOCAL",
 		3: "S3",
+		4: "WEBDAV",
+		5: "SFTP",
 	}
 	WorkspaceSetting_StorageSetting_StorageType_value = map[string]int32{
 		"STORAGE_TYPE_UNSPECIFIED": 0,
 		"DATABASE":                 1,
 		"LOCAL":                    2,
 		"S3":                       3,
+		"WEBDAV":                   4,
+		"SFTP":                     5,
 	}
 )
 
@@ -135,6 +148,162 @@ func (WorkspaceSetting_StorageSetting_StorageType) EnumDescriptor() ([]byte, []i
 	return file_api_v1_workspace_service_proto_rawDescGZIP(), []int{2, 1, 0}
 }
 
+type WorkspaceSetting_StorageSetting_ImageMetadataStripping int32
+
+const (
+	// The metadata of uploaded images is kept.
+	WorkspaceSetting_StorageSetting_IMAGE_METADATA_STRIPPING_UNSPECIFIED WorkspaceSetting_StorageSetting_ImageMetadataStripping = 0
+	// The GPS location is removed from uploaded images.
+	WorkspaceSetting_StorageSetting_STRIP_GPS WorkspaceSetting_StorageSetting_ImageMetadataStripping = 1
+	// The EXIF, XMP and IPTC metadata is removed from uploaded images, except the orientation.
+	WorkspaceSetting_StorageSetting_STRIP_ALL WorkspaceSetting_StorageSetting_ImageMetadataStripping = 2
+)
+
+// Enum value maps for WorkspaceSetting_StorageSetting_ImageMetadataStripping.
+var (
+	WorkspaceSetting_StorageSetting_ImageMetadataStripping_name = map[int32]string{
+		0: "IMAGE_METADATA_STRIPPING_UNSPECIFIED",
+		1: "STRIP_GPS",
+		2: "STRIP_ALL",
+	}
+	WorkspaceSetting_StorageSetting_ImageMetadataStripping_value = map[string]int32{
+		"IMAGE_METADATA_STRIPPING_UNSPECIFIED": 0,
+		"STRIP_GPS":                            1,
+		"STRIP_ALL":                            2,
+	}
+)
+
+func (x WorkspaceSetting_StorageSetting_ImageMetadataStripping) Enum() *WorkspaceSetting_StorageSetting_ImageMetadataStripping {
+	p := new(WorkspaceSetting_StorageSetting_ImageMetadataStripping)
+	*p = x
+	return p
+}
+
+func (x WorkspaceSetting_StorageSetting_ImageMetadataStripping) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WorkspaceSetting_StorageSetting_ImageMetadataStripping) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_v1_workspace_service_proto_enumTypes[2].Descriptor()
+}
+
+func (WorkspaceSetting_StorageSetting_ImageMetadataStripping) Type() protoreflect.EnumType {
+	return &file_api_v1_workspace_service_proto_enumTypes[2]
+}
+
+func (x WorkspaceSetting_StorageSetting_ImageMetadataStripping) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WorkspaceSetting_StorageSetting_ImageMetadataStripping.Descriptor instead.
+func (WorkspaceSetting_StorageSetting_ImageMetadataStripping) EnumDescriptor() ([]byte, []int) {
+	return file_api_v1_workspace_service_proto_rawDescGZIP(), []int{2, 1, 1}
+}
+
+// Destination of the backups.
+type WorkspaceSetting_BackupSetting_Destination int32
+
+const (
+	WorkspaceSetting_BackupSetting_DESTINATION_UNSPECIFIED WorkspaceSetting_BackupSetting_Destination = 0
+	// LOCAL writes the backups to a local directory.
+	WorkspaceSetting_BackupSetting_LOCAL WorkspaceSetting_BackupSetting_Destination = 1
+	// S3 uploads the backups to the bucket of the S3 storage config.
+	WorkspaceSetting_BackupSetting_S3 WorkspaceSetting_BackupSetting_Destination = 2
+)
+
+// Enum value maps for WorkspaceSetting_BackupSetting_Destination.
+var (
+	WorkspaceSetting_BackupSetting_Destination_name = map[int32]string{
+		0: "DESTINATION_UNSPECIFIED",
+		1: "LOCAL",
+		2: "S3",
+	}
+	WorkspaceSetting_BackupSetting_Destination_value = map[string]int32{
+		"DESTINATION_UNSPECIFIED": 0,
+		"LOCAL":                   1,
+		"S3":                      2,
+	}
+)
+
+func (x WorkspaceSetting_BackupSetting_Destination) Enum() *WorkspaceSetting_BackupSetting_Destination {
+	p := new(WorkspaceSetting_BackupSetting_Destination)
+	*p = x
+	return p
+}
+
+func (x WorkspaceSetting_BackupSetting_Destination) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WorkspaceSetting_BackupSetting_Destination) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_v1_workspace_service_proto_enumTypes[3].Descriptor()
+}
+
+func (WorkspaceSetting_BackupSetting_Destination) Type() protoreflect.EnumType {
+	return &file_api_v1_workspace_service_proto_enumTypes[3]
+}
+
+func (x WorkspaceSetting_BackupSetting_Destination) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WorkspaceSetting_BackupSetting_Destination.Descriptor instead.
+func (WorkspaceSetting_BackupSetting_Destination) EnumDescriptor() ([]byte, []int) {
+	return file_api_v1_workspace_service_proto_rawDescGZIP(), []int{2, 3, 0}
+}
+
+type AttachmentMigration_State int32
+
+const (
+	AttachmentMigration_STATE_UNSPECIFIED AttachmentMigration_State = 0
+	AttachmentMigration_RUNNING           AttachmentMigration_State = 1
+	AttachmentMigration_SUCCEEDED         AttachmentMigration_State = 2
+	AttachmentMigration_FAILED            AttachmentMigration_State = 3
+)
+
+// Enum value maps for AttachmentMigration_State.
+var (
+	AttachmentMigration_State_name = map[int32]string{
+		0: "STATE_UNSPECIFIED",
+		1: "RUNNING",
+		2: "SUCCEEDED",
+		3: "FAILED",
+	}
+	AttachmentMigration_State_value = map[string]int32{
+		"STATE_UNSPECIFIED": 0,
+		"RUNNING":           1,
+		"SUCCEEDED":         2,
+		"FAILED":            3,
+	}
+)
+
+func (x AttachmentMigration_State) Enum() *AttachmentMigration_State {
+	p := new(AttachmentMigration_State)
+	*p = x
+	return p
+}
+
+func (x AttachmentMigration_State) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AttachmentMigration_State) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_v1_workspace_service_proto_enumTypes[4].Descriptor()
+}
+
+func (AttachmentMigration_State) Type() protoreflect.EnumType {
+	return &file_api_v1_workspace_service_proto_enumTypes[4]
+}
+
+func (x AttachmentMigration_State) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AttachmentMigration_State.Descriptor instead.
+func (AttachmentMigration_State) EnumDescriptor() ([]byte, []int) {
+	return file_api_v1_workspace_service_proto_rawDescGZIP(), []int{8, 0}
+}
+
 // Workspace profile message containing basic workspace information.
 type WorkspaceProfile struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -257,6 +426,7 @@ type WorkspaceSetting struct {
 	//	*WorkspaceSetting_GeneralSetting_
 	//	*WorkspaceSetting_StorageSetting_
 	//	*WorkspaceSetting_MemoRelatedSetting_
+	//	*WorkspaceSetting_BackupSetting_
 	Value         isWorkspaceSetting_Value `protobuf_oneof:"value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -333,6 +503,15 @@ func (x *WorkspaceSetting) GetMemoRelatedSetting() *WorkspaceSetting_MemoRelated
 	return nil
 }
 
+func (x *WorkspaceSetting) GetBackupSetting() *WorkspaceSetting_BackupSetting {
+	if x != nil {
+		if x, ok := x.Value.(*WorkspaceSetting_BackupSetting_); ok {
+			return x.BackupSetting
+		}
+	}
+	return nil
+}
+
 type isWorkspaceSetting_Value interface {
 	isWorkspaceSetting_Value()
 }
@@ -349,12 +528,18 @@ type WorkspaceSetting_MemoRelatedSetting_ struct {
 	MemoRelatedSetting *WorkspaceSetting_MemoRelatedSetting `protobuf:"bytes,4,opt,name=memo_related_setting,json=memoRelatedSetting,proto3,oneof"`
 }
 
+type WorkspaceSetting_BackupSetting_ struct {
+	BackupSetting *WorkspaceSetting_BackupSetting `protobuf:"bytes,5,opt,name=backup_setting,json=backupSetting,proto3,oneof"`
+}
+
 func (*WorkspaceSetting_GeneralSetting_) isWorkspaceSetting_Value() {}
 
 func (*WorkspaceSetting_StorageSetting_) isWorkspaceSetting_Value() {}
 
 func (*WorkspaceSetting_MemoRelatedSetting_) isWorkspaceSetting_Value() {}
 
+func (*WorkspaceSetting_BackupSetting_) isWorkspaceSetting_Value() {}
+
 // Request message for GetWorkspaceSetting method.
 type GetWorkspaceSettingRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -395,39 +580,390 @@ func (*GetWorkspaceSettingRequest) Descriptor() ([]byte, []int) {
 	return file_api_v1_workspace_service_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *GetWorkspaceSettingRequest) GetName() string {
+func (x *GetWorkspaceSettingRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// Request message for UpdateWorkspaceSetting method.
+type UpdateWorkspaceSettingRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The workspace setting resource which replaces the resource on the server.
+	Setting *WorkspaceSetting `protobuf:"bytes,1,opt,name=setting,proto3" json:"setting,omitempty"`
+	// The list of fields to update.
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateWorkspaceSettingRequest) Reset() {
+	*x = UpdateWorkspaceSettingRequest{}
+	mi := &file_api_v1_workspace_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateWorkspaceSettingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateWorkspaceSettingRequest) ProtoMessage() {}
+
+func (x *UpdateWorkspaceSettingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_workspace_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateWorkspaceSettingRequest.ProtoReflect.Descriptor instead.
+func (*UpdateWorkspaceSettingRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_workspace_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *UpdateWorkspaceSettingRequest) GetSetting() *WorkspaceSetting {
+	if x != nil {
+		return x.Setting
+	}
+	return nil
+}
+
+func (x *UpdateWorkspaceSettingRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+// A backup of the instance.
+type Backup struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The name of the backup.
+	// Format: workspace/backups/{filename}
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The size of the backup in bytes.
+	Size int64 `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	// The time the backup was created.
+	CreateTime    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Backup) Reset() {
+	*x = Backup{}
+	mi := &file_api_v1_workspace_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Backup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Backup) ProtoMessage() {}
+
+func (x *Backup) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_workspace_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Backup.ProtoReflect.Descriptor instead.
+func (*Backup) Descriptor() ([]byte, []int) {
+	return file_api_v1_workspace_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Backup) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Backup) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *Backup) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+// Request message for ListBackups method.
+type ListBackupsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBackupsRequest) Reset() {
+	*x = ListBackupsRequest{}
+	mi := &file_api_v1_workspace_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBackupsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBackupsRequest) ProtoMessage() {}
+
+func (x *ListBackupsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_workspace_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBackupsRequest.ProtoReflect.Descriptor instead.
+func (*ListBackupsRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_workspace_service_proto_rawDescGZIP(), []int{6}
+}
+
+// Response message for ListBackups method.
+type ListBackupsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The backups, newest first.
+	Backups       []*Backup `protobuf:"bytes,1,rep,name=backups,proto3" json:"backups,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBackupsResponse) Reset() {
+	*x = ListBackupsResponse{}
+	mi := &file_api_v1_workspace_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBackupsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBackupsResponse) ProtoMessage() {}
+
+func (x *ListBackupsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_workspace_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBackupsResponse.ProtoReflect.Descriptor instead.
+func (*ListBackupsResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_workspace_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListBackupsResponse) GetBackups() []*Backup {
+	if x != nil {
+		return x.Backups
+	}
+	return nil
+}
+
+// A migration of the attachments to the storage of the storage setting.
+type AttachmentMigration struct {
+	state protoimpl.MessageState    `protogen:"open.v1"`
+	State AttachmentMigration_State `protobuf:"varint,1,opt,name=state,proto3,enum=memos.api.v1.AttachmentMigration_State" json:"state,omitempty"`
+	// The number of attachments to migrate.
+	Total int32 `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	// The number of migrated attachments.
+	Migrated int32 `protobuf:"varint,3,opt,name=migrated,proto3" json:"migrated,omitempty"`
+	// The number of attachments that failed to migrate.
+	Failed int32 `protobuf:"varint,4,opt,name=failed,proto3" json:"failed,omitempty"`
+	// The errors of the first attachments that failed to migrate.
+	Failures  []string               `protobuf:"bytes,5,rep,name=failures,proto3" json:"failures,omitempty"`
+	StartTime *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime   *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// The error that stopped the migration.
+	Error         string `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttachmentMigration) Reset() {
+	*x = AttachmentMigration{}
+	mi := &file_api_v1_workspace_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttachmentMigration) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttachmentMigration) ProtoMessage() {}
+
+func (x *AttachmentMigration) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_workspace_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttachmentMigration.ProtoReflect.Descriptor instead.
+func (*AttachmentMigration) Descriptor() ([]byte, []int) {
+	return file_api_v1_workspace_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *AttachmentMigration) GetState() AttachmentMigration_State {
+	if x != nil {
+		return x.State
+	}
+	return AttachmentMigration_STATE_UNSPECIFIED
+}
+
+func (x *AttachmentMigration) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *AttachmentMigration) GetMigrated() int32 {
+	if x != nil {
+		return x.Migrated
+	}
+	return 0
+}
+
+func (x *AttachmentMigration) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *AttachmentMigration) GetFailures() []string {
+	if x != nil {
+		return x.Failures
+	}
+	return nil
+}
+
+func (x *AttachmentMigration) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *AttachmentMigration) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *AttachmentMigration) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// Request message for MigrateAttachments method.
+type MigrateAttachmentsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The number of attachments listed at once, 100 by default.
+	BatchSize     int32 `protobuf:"varint,1,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MigrateAttachmentsRequest) Reset() {
+	*x = MigrateAttachmentsRequest{}
+	mi := &file_api_v1_workspace_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MigrateAttachmentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MigrateAttachmentsRequest) ProtoMessage() {}
+
+func (x *MigrateAttachmentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_workspace_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MigrateAttachmentsRequest.ProtoReflect.Descriptor instead.
+func (*MigrateAttachmentsRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_workspace_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *MigrateAttachmentsRequest) GetBatchSize() int32 {
 	if x != nil {
-		return x.Name
+		return x.BatchSize
 	}
-	return ""
+	return 0
 }
 
-// Request message for UpdateWorkspaceSetting method.
-type UpdateWorkspaceSettingRequest struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// The workspace setting resource which replaces the resource on the server.
-	Setting *WorkspaceSetting `protobuf:"bytes,1,opt,name=setting,proto3" json:"setting,omitempty"`
-	// The list of fields to update.
-	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+// Request message for GetAttachmentMigration method.
+type GetAttachmentMigrationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateWorkspaceSettingRequest) Reset() {
-	*x = UpdateWorkspaceSettingRequest{}
-	mi := &file_api_v1_workspace_service_proto_msgTypes[4]
+func (x *GetAttachmentMigrationRequest) Reset() {
+	*x = GetAttachmentMigrationRequest{}
+	mi := &file_api_v1_workspace_service_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateWorkspaceSettingRequest) String() string {
+func (x *GetAttachmentMigrationRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateWorkspaceSettingRequest) ProtoMessage() {}
+func (*GetAttachmentMigrationRequest) ProtoMessage() {}
 
-func (x *UpdateWorkspaceSettingRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_workspace_service_proto_msgTypes[4]
+func (x *GetAttachmentMigrationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_workspace_service_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -438,23 +974,9 @@ func (x *UpdateWorkspaceSettingRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateWorkspaceSettingRequest.ProtoReflect.Descriptor instead.
-func (*UpdateWorkspaceSettingRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_workspace_service_proto_rawDescGZIP(), []int{4}
-}
-
-func (x *UpdateWorkspaceSettingRequest) GetSetting() *WorkspaceSetting {
-	if x != nil {
-		return x.Setting
-	}
-	return nil
-}
-
-func (x *UpdateWorkspaceSettingRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
-	if x != nil {
-		return x.UpdateMask
-	}
-	return nil
+// Deprecated: Use GetAttachmentMigrationRequest.ProtoReflect.Descriptor instead.
+func (*GetAttachmentMigrationRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_workspace_service_proto_rawDescGZIP(), []int{10}
 }
 
 // General workspace settings configuration.
@@ -481,13 +1003,16 @@ type WorkspaceSetting_GeneralSetting struct {
 	DisallowChangeUsername bool `protobuf:"varint,8,opt,name=disallow_change_username,json=disallowChangeUsername,proto3" json:"disallow_change_username,omitempty"`
 	// disallow_change_nickname disallows changing nickname.
 	DisallowChangeNickname bool `protobuf:"varint,9,opt,name=disallow_change_nickname,json=disallowChangeNickname,proto3" json:"disallow_change_nickname,omitempty"`
-	unknownFields          protoimpl.UnknownFields
-	sizeCache              protoimpl.SizeCache
+	// timezone is the default timezone of the workspace, as an IANA time zone name.
+	// e.g. "Europe/Berlin". Empty means the server local timezone.
+	Timezone      string `protobuf:"bytes,10,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *WorkspaceSetting_GeneralSetting) Reset() {
 	*x = WorkspaceSetting_GeneralSetting{}
-	mi := &file_api_v1_workspace_service_proto_msgTypes[5]
+	mi := &file_api_v1_workspace_service_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -499,7 +1024,7 @@ func (x *WorkspaceSetting_GeneralSetting) String() string {
 func (*WorkspaceSetting_GeneralSetting) ProtoMessage() {}
 
 func (x *WorkspaceSetting_GeneralSetting) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_workspace_service_proto_msgTypes[5]
+	mi := &file_api_v1_workspace_service_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -578,6 +1103,13 @@ func (x *WorkspaceSetting_GeneralSetting) GetDisallowChangeNickname() bool {
 	return false
 }
 
+func (x *WorkspaceSetting_GeneralSetting) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
 // Storage configuration settings for workspace attachments.
 type WorkspaceSetting_StorageSetting struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -589,14 +1121,30 @@ type WorkspaceSetting_StorageSetting struct {
 	// The max upload size in megabytes.
 	UploadSizeLimitMb int64 `protobuf:"varint,3,opt,name=upload_size_limit_mb,json=uploadSizeLimitMb,proto3" json:"upload_size_limit_mb,omitempty"`
 	// The S3 config.
-	S3Config      *WorkspaceSetting_StorageSetting_S3Config `protobuf:"bytes,4,opt,name=s3_config,json=s3Config,proto3" json:"s3_config,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	S3Config *WorkspaceSetting_StorageSetting_S3Config `protobuf:"bytes,4,opt,name=s3_config,json=s3Config,proto3" json:"s3_config,omitempty"`
+	// The WebDAV config.
+	WebdavConfig *WorkspaceSetting_StorageSetting_WebDAVConfig `protobuf:"bytes,5,opt,name=webdav_config,json=webdavConfig,proto3" json:"webdav_config,omitempty"`
+	// The SFTP config.
+	SftpConfig *WorkspaceSetting_StorageSetting_SFTPConfig `protobuf:"bytes,6,opt,name=sftp_config,json=sftpConfig,proto3" json:"sftp_config,omitempty"`
+	// deduplicate stores identical LOCAL and S3 attachments once, shared by their attachments.
+	Deduplicate bool `protobuf:"varint,7,opt,name=deduplicate,proto3" json:"deduplicate,omitempty"`
+	// The storage quotas in megabytes of the users of a role by role, e.g. USER.
+	// Roles without a quota or with a quota of 0 are unlimited.
+	RoleQuotaMb map[string]int64 `protobuf:"bytes,8,rep,name=role_quota_mb,json=roleQuotaMb,proto3" json:"role_quota_mb,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	// The storage quotas in megabytes by user name, e.g. users/1, over the quotas of the roles. 0 is unlimited.
+	UserQuotaMb map[string]int64 `protobuf:"bytes,9,rep,name=user_quota_mb,json=userQuotaMb,proto3" json:"user_quota_mb,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	// The age in days after which attachments without memo and local files without attachment
+	// are deleted by the daily garbage collection. 0 disables the garbage collection.
+	OrphanRetentionDays int32 `protobuf:"varint,10,opt,name=orphan_retention_days,json=orphanRetentionDays,proto3" json:"orphan_retention_days,omitempty"`
+	// The metadata that is removed from uploaded JPEG and PNG images.
+	ImageMetadataStripping WorkspaceSetting_StorageSetting_ImageMetadataStripping `protobuf:"varint,11,opt,name=image_metadata_stripping,json=imageMetadataStripping,proto3,enum=memos.api.v1.WorkspaceSetting_StorageSetting_ImageMetadataStripping" json:"image_metadata_stripping,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
 }
 
 func (x *WorkspaceSetting_StorageSetting) Reset() {
 	*x = WorkspaceSetting_StorageSetting{}
-	mi := &file_api_v1_workspace_service_proto_msgTypes[6]
+	mi := &file_api_v1_workspace_service_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -608,7 +1156,7 @@ func (x *WorkspaceSetting_StorageSetting) String() string {
 func (*WorkspaceSetting_StorageSetting) ProtoMessage() {}
 
 func (x *WorkspaceSetting_StorageSetting) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_workspace_service_proto_msgTypes[6]
+	mi := &file_api_v1_workspace_service_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -652,6 +1200,55 @@ func (x *WorkspaceSetting_StorageSetting) GetS3Config() *WorkspaceSetting_Storag
 	return nil
 }
 
+func (x *WorkspaceSetting_StorageSetting) GetWebdavConfig() *WorkspaceSetting_StorageSetting_WebDAVConfig {
+	if x != nil {
+		return x.WebdavConfig
+	}
+	return nil
+}
+
+func (x *WorkspaceSetting_StorageSetting) GetSftpConfig() *WorkspaceSetting_StorageSetting_SFTPConfig {
+	if x != nil {
+		return x.SftpConfig
+	}
+	return nil
+}
+
+func (x *WorkspaceSetting_StorageSetting) GetDeduplicate() bool {
+	if x != nil {
+		return x.Deduplicate
+	}
+	return false
+}
+
+func (x *WorkspaceSetting_StorageSetting) GetRoleQuotaMb() map[string]int64 {
+	if x != nil {
+		return x.RoleQuotaMb
+	}
+	return nil
+}
+
+func (x *WorkspaceSetting_StorageSetting) GetUserQuotaMb() map[string]int64 {
+	if x != nil {
+		return x.UserQuotaMb
+	}
+	return nil
+}
+
+func (x *WorkspaceSetting_StorageSetting) GetOrphanRetentionDays() int32 {
+	if x != nil {
+		return x.OrphanRetentionDays
+	}
+	return 0
+}
+
+func (x *WorkspaceSetting_StorageSetting) GetImageMetadataStripping() WorkspaceSetting_StorageSetting_ImageMetadataStripping {
+	if x != nil {
+		return x.ImageMetadataStripping
+	}
+	return WorkspaceSetting_StorageSetting_IMAGE_METADATA_STRIPPING_UNSPECIFIED
+}
+
 // Memo-related workspace settings and policies.
 type WorkspaceSetting_MemoRelatedSetting struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -679,7 +1276,7 @@ type WorkspaceSetting_MemoRelatedSetting struct {
 
 func (x *WorkspaceSetting_MemoRelatedSetting) Reset() {
 	*x = WorkspaceSetting_MemoRelatedSetting{}
-	mi := &file_api_v1_workspace_service_proto_msgTypes[7]
+	mi := &file_api_v1_workspace_service_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -691,7 +1288,7 @@ func (x *WorkspaceSetting_MemoRelatedSetting) String() string {
 func (*WorkspaceSetting_MemoRelatedSetting) ProtoMessage() {}
 
 func (x *WorkspaceSetting_MemoRelatedSetting) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_workspace_service_proto_msgTypes[7]
+	mi := &file_api_v1_workspace_service_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -770,6 +1367,116 @@ func (x *WorkspaceSetting_MemoRelatedSetting) GetNsfwTags() []string {
 	return nil
 }
 
+// Scheduled backup settings.
+type WorkspaceSetting_BackupSetting struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// enabled enables scheduled backups.
+	Enabled bool `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	// cron_spec is the schedule of the backups in the workspace timezone.
+	// e.g. "0 3 * * *" or "@daily"
+	CronSpec string `protobuf:"bytes,2,opt,name=cron_spec,json=cronSpec,proto3" json:"cron_spec,omitempty"`
+	// destination is where the backups are written.
+	Destination WorkspaceSetting_BackupSetting_Destination `protobuf:"varint,3,opt,name=destination,proto3,enum=memos.api.v1.WorkspaceSetting_BackupSetting_Destination" json:"destination,omitempty"`
+	// directory is the directory of local backups, relative to the data directory unless absolute.
+	Directory string `protobuf:"bytes,4,opt,name=directory,proto3" json:"directory,omitempty"`
+	// s3_prefix is the key prefix of backups in the S3 bucket.
+	S3Prefix string `protobuf:"bytes,5,opt,name=s3_prefix,json=s3Prefix,proto3" json:"s3_prefix,omitempty"`
+	// keep_last keeps the most recent backups.
+	KeepLast int32 `protobuf:"varint,6,opt,name=keep_last,json=keepLast,proto3" json:"keep_last,omitempty"`
+	// keep_daily keeps the most recent backup of each of the most recent days.
+	KeepDaily int32 `protobuf:"varint,7,opt,name=keep_daily,json=keepDaily,proto3" json:"keep_daily,omitempty"`
+	// keep_weekly keeps the most recent backup of each of the most recent weeks.
+	KeepWeekly    int32 `protobuf:"varint,8,opt,name=keep_weekly,json=keepWeekly,proto3" json:"keep_weekly,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkspaceSetting_BackupSetting) Reset() {
+	*x = WorkspaceSetting_BackupSetting{}
+	mi := &file_api_v1_workspace_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkspaceSetting_BackupSetting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkspaceSetting_BackupSetting) ProtoMessage() {}
+
+func (x *WorkspaceSetting_BackupSetting) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_workspace_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkspaceSetting_BackupSetting.ProtoReflect.Descriptor instead.
+func (*WorkspaceSetting_BackupSetting) Descriptor() ([]byte, []int) {
+	return file_api_v1_workspace_service_proto_rawDescGZIP(), []int{2, 3}
+}
+
+func (x *WorkspaceSetting_BackupSetting) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *WorkspaceSetting_BackupSetting) GetCronSpec() string {
+	if x != nil {
+		return x.CronSpec
+	}
+	return ""
+}
+
+func (x *WorkspaceSetting_BackupSetting) GetDestination() WorkspaceSetting_BackupSetting_Destination {
+	if x != nil {
+		return x.Destination
+	}
+	return WorkspaceSetting_BackupSetting_DESTINATION_UNSPECIFIED
+}
+
+func (x *WorkspaceSetting_BackupSetting) GetDirectory() string {
+	if x != nil {
+		return x.Directory
+	}
+	return ""
+}
+
+func (x *WorkspaceSetting_BackupSetting) GetS3Prefix() string {
+	if x != nil {
+		return x.S3Prefix
+	}
+	return ""
+}
+
+func (x *WorkspaceSetting_BackupSetting) GetKeepLast() int32 {
+	if x != nil {
+		return x.KeepLast
+	}
+	return 0
+}
+
+func (x *WorkspaceSetting_BackupSetting) GetKeepDaily() int32 {
+	if x != nil {
+		return x.KeepDaily
+	}
+	return 0
+}
+
+func (x *WorkspaceSetting_BackupSetting) GetKeepWeekly() int32 {
+	if x != nil {
+		return x.KeepWeekly
+	}
+	return 0
+}
+
 // Custom profile configuration for workspace branding.
 type WorkspaceSetting_GeneralSetting_CustomProfile struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -783,7 +1490,7 @@ type WorkspaceSetting_GeneralSetting_CustomProfile struct {
 
 func (x *WorkspaceSetting_GeneralSetting_CustomProfile) Reset() {
 	*x = WorkspaceSetting_GeneralSetting_CustomProfile{}
-	mi := &file_api_v1_workspace_service_proto_msgTypes[8]
+	mi := &file_api_v1_workspace_service_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -795,7 +1502,7 @@ func (x *WorkspaceSetting_GeneralSetting_CustomProfile) String() string {
 func (*WorkspaceSetting_GeneralSetting_CustomProfile) ProtoMessage() {}
 
 func (x *WorkspaceSetting_GeneralSetting_CustomProfile) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_workspace_service_proto_msgTypes[8]
+	mi := &file_api_v1_workspace_service_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -855,7 +1562,7 @@ type WorkspaceSetting_StorageSetting_S3Config struct {
 
 func (x *WorkspaceSetting_StorageSetting_S3Config) Reset() {
 	*x = WorkspaceSetting_StorageSetting_S3Config{}
-	mi := &file_api_v1_workspace_service_proto_msgTypes[9]
+	mi := &file_api_v1_workspace_service_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -867,7 +1574,7 @@ func (x *WorkspaceSetting_StorageSetting_S3Config) String() string {
 func (*WorkspaceSetting_StorageSetting_S3Config) ProtoMessage() {}
 
 func (x *WorkspaceSetting_StorageSetting_S3Config) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_workspace_service_proto_msgTypes[9]
+	mi := &file_api_v1_workspace_service_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -925,22 +1632,165 @@ func (x *WorkspaceSetting_StorageSetting_S3Config) GetUsePathStyle() bool {
 	return false
 }
 
+// WebDAV configuration for WebDAV storage backend.
+type WorkspaceSetting_StorageSetting_WebDAVConfig struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The URL of the directory of the files.
+	Endpoint      string `protobuf:"bytes,1,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	Username      string `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Password      string `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkspaceSetting_StorageSetting_WebDAVConfig) Reset() {
+	*x = WorkspaceSetting_StorageSetting_WebDAVConfig{}
+	mi := &file_api_v1_workspace_service_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkspaceSetting_StorageSetting_WebDAVConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkspaceSetting_StorageSetting_WebDAVConfig) ProtoMessage() {}
+
+func (x *WorkspaceSetting_StorageSetting_WebDAVConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_workspace_service_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkspaceSetting_StorageSetting_WebDAVConfig.ProtoReflect.Descriptor instead.
+func (*WorkspaceSetting_StorageSetting_WebDAVConfig) Descriptor() ([]byte, []int) {
+	return file_api_v1_workspace_service_proto_rawDescGZIP(), []int{2, 1, 1}
+}
+
+func (x *WorkspaceSetting_StorageSetting_WebDAVConfig) GetEndpoint() string {
+	if x != nil {
+		return x.Endpoint
+	}
+	return ""
+}
+
+func (x *WorkspaceSetting_StorageSetting_WebDAVConfig) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *WorkspaceSetting_StorageSetting_WebDAVConfig) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+// SFTP configuration for SFTP storage backend.
+type WorkspaceSetting_StorageSetting_SFTPConfig struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The address of the server, e.g. sftp.example.com:22.
+	Address  string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Username string `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Password string `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
+	// The private key in PEM format.
+	PrivateKey string `protobuf:"bytes,4,opt,name=private_key,json=privateKey,proto3" json:"private_key,omitempty"`
+	// The public key of the server in the authorized_keys format.
+	HostPublicKey string `protobuf:"bytes,5,opt,name=host_public_key,json=hostPublicKey,proto3" json:"host_public_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkspaceSetting_StorageSetting_SFTPConfig) Reset() {
+	*x = WorkspaceSetting_StorageSetting_SFTPConfig{}
+	mi := &file_api_v1_workspace_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkspaceSetting_StorageSetting_SFTPConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkspaceSetting_StorageSetting_SFTPConfig) ProtoMessage() {}
+
+func (x *WorkspaceSetting_StorageSetting_SFTPConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_workspace_service_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkspaceSetting_StorageSetting_SFTPConfig.ProtoReflect.Descriptor instead.
+func (*WorkspaceSetting_StorageSetting_SFTPConfig) Descriptor() ([]byte, []int) {
+	return file_api_v1_workspace_service_proto_rawDescGZIP(), []int{2, 1, 2}
+}
+
+func (x *WorkspaceSetting_StorageSetting_SFTPConfig) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *WorkspaceSetting_StorageSetting_SFTPConfig) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *WorkspaceSetting_StorageSetting_SFTPConfig) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *WorkspaceSetting_StorageSetting_SFTPConfig) GetPrivateKey() string {
+	if x != nil {
+		return x.PrivateKey
+	}
+	return ""
+}
+
+func (x *WorkspaceSetting_StorageSetting_SFTPConfig) GetHostPublicKey() string {
+	if x != nil {
+		return x.HostPublicKey
+	}
+	return ""
+}
+
 var File_api_v1_workspace_service_proto protoreflect.FileDescriptor
 
 const file_api_v1_workspace_service_proto_rawDesc = "" +
 	"\n" +
-	"\x1eapi/v1/workspace_service.proto\x12\fmemos.api.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x17google/api/client.proto\x1a\x1fgoogle/api/field_behavior.proto\x1a\x19google/api/resource.proto\x1a google/protobuf/field_mask.proto\"y\n" +
+	"\x1eapi/v1/workspace_service.proto\x12\fmemos.api.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x17google/api/client.proto\x1a\x1fgoogle/api/field_behavior.proto\x1a\x19google/api/resource.proto\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"y\n" +
 	"\x10WorkspaceProfile\x12\x14\n" +
 	"\x05owner\x18\x01 \x01(\tR\x05owner\x12\x18\n" +
 	"\aversion\x18\x02 \x01(\tR\aversion\x12\x12\n" +
 	"\x04mode\x18\x03 \x01(\tR\x04mode\x12!\n" +
 	"\finstance_url\x18\x06 \x01(\tR\vinstanceUrl\"\x1c\n" +
-	"\x1aGetWorkspaceProfileRequest\"\x97\x11\n" +
+	"\x1aGetWorkspaceProfileRequest\"\xf2\x1d\n" +
 	"\x10WorkspaceSetting\x12\x17\n" +
 	"\x04name\x18\x01 \x01(\tB\x03\xe0A\bR\x04name\x12X\n" +
 	"\x0fgeneral_setting\x18\x02 \x01(\v2-.memos.api.v1.WorkspaceSetting.GeneralSettingH\x00R\x0egeneralSetting\x12X\n" +
 	"\x0fstorage_setting\x18\x03 \x01(\v2-.memos.api.v1.WorkspaceSetting.StorageSettingH\x00R\x0estorageSetting\x12e\n" +
-	"\x14memo_related_setting\x18\x04 \x01(\v21.memos.api.v1.WorkspaceSetting.MemoRelatedSettingH\x00R\x12memoRelatedSetting\x1a\xf9\x04\n" +
+	"\x14memo_related_setting\x18\x04 \x01(\v21.memos.api.v1.WorkspaceSetting.MemoRelatedSettingH\x00R\x12memoRelatedSetting\x12U\n" +
+	"\x0ebackup_setting\x18\x05 \x01(\v2,.memos.api.v1.WorkspaceSetting.BackupSettingH\x00R\rbackupSetting\x1a\x95\x05\n" +
 	"\x0eGeneralSetting\x12\x14\n" +
 	"\x05theme\x18\x01 \x01(\tR\x05theme\x12<\n" +
 	"\x1adisallow_user_registration\x18\x02 \x01(\bR\x18disallowUserRegistration\x124\n" +
@@ -950,29 +1800,65 @@ const file_api_v1_workspace_service_proto_rawDesc = "" +
 	"\x0ecustom_profile\x18\x06 \x01(\v2;.memos.api.v1.WorkspaceSetting.GeneralSetting.CustomProfileR\rcustomProfile\x121\n" +
 	"\x15week_start_day_offset\x18\a \x01(\x05R\x12weekStartDayOffset\x128\n" +
 	"\x18disallow_change_username\x18\b \x01(\bR\x16disallowChangeUsername\x128\n" +
-	"\x18disallow_change_nickname\x18\t \x01(\bR\x16disallowChangeNickname\x1az\n" +
+	"\x18disallow_change_nickname\x18\t \x01(\bR\x16disallowChangeNickname\x12\x1a\n" +
+	"\btimezone\x18\n" +
+	" \x01(\tR\btimezone\x1az\n" +
 	"\rCustomProfile\x12\x14\n" +
 	"\x05title\x18\x01 \x01(\tR\x05title\x12 \n" +
 	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x19\n" +
 	"\blogo_url\x18\x03 \x01(\tR\alogoUrl\x12\x16\n" +
-	"\x06locale\x18\x04 \x01(\tR\x06locale\x1a\xbe\x04\n" +
+	"\x06locale\x18\x04 \x01(\tR\x06locale\x1a\x9e\r\n" +
 	"\x0eStorageSetting\x12\\\n" +
 	"\fstorage_type\x18\x01 \x01(\x0e29.memos.api.v1.WorkspaceSetting.StorageSetting.StorageTypeR\vstorageType\x12+\n" +
 	"\x11filepath_template\x18\x02 \x01(\tR\x10filepathTemplate\x12/\n" +
 	"\x14upload_size_limit_mb\x18\x03 \x01(\x03R\x11uploadSizeLimitMb\x12S\n" +
-	"\ts3_config\x18\x04 \x01(\v26.memos.api.v1.WorkspaceSetting.StorageSetting.S3ConfigR\bs3Config\x1a\xcc\x01\n" +
+	"\ts3_config\x18\x04 \x01(\v26.memos.api.v1.WorkspaceSetting.StorageSetting.S3ConfigR\bs3Config\x12_\n" +
+	"\rwebdav_config\x18\x05 \x01(\v2:.memos.api.v1.WorkspaceSetting.StorageSetting.WebDAVConfigR\fwebdavConfig\x12Y\n" +
+	"\vsftp_config\x18\x06 \x01(\v28.memos.api.v1.WorkspaceSetting.StorageSetting.SFTPConfigR\n" +
+	"sftpConfig\x12 \n" +
+	"\vdeduplicate\x18\a \x01(\bR\vdeduplicate\x12b\n" +
+	"\rrole_quota_mb\x18\b \x03(\v2>.memos.api.v1.WorkspaceSetting.StorageSetting.RoleQuotaMbEntryR\vroleQuotaMb\x12b\n" +
+	"\ruser_quota_mb\x18\t \x03(\v2>.memos.api.v1.WorkspaceSetting.StorageSetting.UserQuotaMbEntryR\vuserQuotaMb\x122\n" +
+	"\x15orphan_retention_days\x18\n" +
+	" \x01(\x05R\x13orphanRetentionDays\x12~\n" +
+	"\x18image_metadata_stripping\x18\v \x01(\x0e2D.memos.api.v1.WorkspaceSetting.StorageSetting.ImageMetadataStrippingR\x16imageMetadataStripping\x1a\xcc\x01\n" +
 	"\bS3Config\x12\"\n" +
 	"\raccess_key_id\x18\x01 \x01(\tR\vaccessKeyId\x12*\n" +
 	"\x11access_key_secret\x18\x02 \x01(\tR\x0faccessKeySecret\x12\x1a\n" +
 	"\bendpoint\x18\x03 \x01(\tR\bendpoint\x12\x16\n" +
 	"\x06region\x18\x04 \x01(\tR\x06region\x12\x16\n" +
 	"\x06bucket\x18\x05 \x01(\tR\x06bucket\x12$\n" +
-	"\x0euse_path_style\x18\x06 \x01(\bR\fusePathStyle\"L\n" +
+	"\x0euse_path_style\x18\x06 \x01(\bR\fusePathStyle\x1ab\n" +
+	"\fWebDAVConfig\x12\x1a\n" +
+	"\bendpoint\x18\x01 \x01(\tR\bendpoint\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x1a\n" +
+	"\bpassword\x18\x03 \x01(\tR\bpassword\x1a\xa7\x01\n" +
+	"\n" +
+	"SFTPConfig\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x1a\n" +
+	"\bpassword\x18\x03 \x01(\tR\bpassword\x12\x1f\n" +
+	"\vprivate_key\x18\x04 \x01(\tR\n" +
+	"privateKey\x12&\n" +
+	"\x0fhost_public_key\x18\x05 \x01(\tR\rhostPublicKey\x1a>\n" +
+	"\x10RoleQuotaMbEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\x1a>\n" +
+	"\x10UserQuotaMbEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"b\n" +
 	"\vStorageType\x12\x1c\n" +
 	"\x18STORAGE_TYPE_UNSPECIFIED\x10\x00\x12\f\n" +
 	"\bDATABASE\x10\x01\x12\t\n" +
 	"\x05LOCAL\x10\x02\x12\x06\n" +
-	"\x02S3\x10\x03\x1a\xd8\x03\n" +
+	"\x02S3\x10\x03\x12\n" +
+	"\n" +
+	"\x06WEBDAV\x10\x04\x12\b\n" +
+	"\x04SFTP\x10\x05\"`\n" +
+	"\x16ImageMetadataStripping\x12(\n" +
+	"$IMAGE_METADATA_STRIPPING_UNSPECIFIED\x10\x00\x12\r\n" +
+	"\tSTRIP_GPS\x10\x01\x12\r\n" +
+	"\tSTRIP_ALL\x10\x02\x1a\xd8\x03\n" +
 	"\x12MemoRelatedSetting\x12<\n" +
 	"\x1adisallow_public_visibility\x18\x01 \x01(\bR\x18disallowPublicVisibility\x127\n" +
 	"\x18display_with_update_time\x18\x02 \x01(\bR\x15displayWithUpdateTime\x120\n" +
@@ -983,12 +1869,29 @@ const file_api_v1_workspace_service_proto_rawDesc = "" +
 	"\x1adisable_markdown_shortcuts\x18\b \x01(\bR\x18disableMarkdownShortcuts\x127\n" +
 	"\x18enable_blur_nsfw_content\x18\t \x01(\bR\x15enableBlurNsfwContent\x12\x1b\n" +
 	"\tnsfw_tags\x18\n" +
-	" \x03(\tR\bnsfwTags\"F\n" +
+	" \x03(\tR\bnsfwTags\x1a\xf9\x02\n" +
+	"\rBackupSetting\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12\x1b\n" +
+	"\tcron_spec\x18\x02 \x01(\tR\bcronSpec\x12Z\n" +
+	"\vdestination\x18\x03 \x01(\x0e28.memos.api.v1.WorkspaceSetting.BackupSetting.DestinationR\vdestination\x12\x1c\n" +
+	"\tdirectory\x18\x04 \x01(\tR\tdirectory\x12\x1b\n" +
+	"\ts3_prefix\x18\x05 \x01(\tR\bs3Prefix\x12\x1b\n" +
+	"\tkeep_last\x18\x06 \x01(\x05R\bkeepLast\x12\x1d\n" +
+	"\n" +
+	"keep_daily\x18\a \x01(\x05R\tkeepDaily\x12\x1f\n" +
+	"\vkeep_weekly\x18\b \x01(\x05R\n" +
+	"keepWeekly\"=\n" +
+	"\vDestination\x12\x1b\n" +
+	"\x17DESTINATION_UNSPECIFIED\x10\x00\x12\t\n" +
+	"\x05LOCAL\x10\x01\x12\x06\n" +
+	"\x02S3\x10\x02\"R\n" +
 	"\x03Key\x12\x13\n" +
 	"\x0fKEY_UNSPECIFIED\x10\x00\x12\v\n" +
 	"\aGENERAL\x10\x01\x12\v\n" +
 	"\aSTORAGE\x10\x02\x12\x10\n" +
-	"\fMEMO_RELATED\x10\x03:f\xeaAc\n" +
+	"\fMEMO_RELATED\x10\x03\x12\n" +
+	"\n" +
+	"\x06BACKUP\x10\x04:f\xeaAc\n" +
 	"\x1eapi.memos.dev/WorkspaceSetting\x12\x1cworkspace/settings/{setting}*\x11workspaceSettings2\x10workspaceSettingB\a\n" +
 	"\x05value\"X\n" +
 	"\x1aGetWorkspaceSettingRequest\x12:\n" +
@@ -997,11 +1900,42 @@ const file_api_v1_workspace_service_proto_rawDesc = "" +
 	"\x1dUpdateWorkspaceSettingRequest\x12=\n" +
 	"\asetting\x18\x01 \x01(\v2\x1e.memos.api.v1.WorkspaceSettingB\x03\xe0A\x02R\asetting\x12@\n" +
 	"\vupdate_mask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskB\x03\xe0A\x01R\n" +
-	"updateMask2\xe9\x03\n" +
+	"updateMask\"r\n" +
+	"\x06Backup\x12\x17\n" +
+	"\x04name\x18\x01 \x01(\tB\x03\xe0A\bR\x04name\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x03R\x04size\x12;\n" +
+	"\vcreate_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"createTime\"\x14\n" +
+	"\x12ListBackupsRequest\"E\n" +
+	"\x13ListBackupsResponse\x12.\n" +
+	"\abackups\x18\x01 \x03(\v2\x14.memos.api.v1.BackupR\abackups\"\x8a\x03\n" +
+	"\x13AttachmentMigration\x12=\n" +
+	"\x05state\x18\x01 \x01(\x0e2'.memos.api.v1.AttachmentMigration.StateR\x05state\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x1a\n" +
+	"\bmigrated\x18\x03 \x01(\x05R\bmigrated\x12\x16\n" +
+	"\x06failed\x18\x04 \x01(\x05R\x06failed\x12\x1a\n" +
+	"\bfailures\x18\x05 \x03(\tR\bfailures\x129\n" +
+	"\n" +
+	"start_time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12\x14\n" +
+	"\x05error\x18\b \x01(\tR\x05error\"F\n" +
+	"\x05State\x12\x15\n" +
+	"\x11STATE_UNSPECIFIED\x10\x00\x12\v\n" +
+	"\aRUNNING\x10\x01\x12\r\n" +
+	"\tSUCCEEDED\x10\x02\x12\n" +
+	"\n" +
+	"\x06FAILED\x10\x03\"?\n" +
+	"\x19MigrateAttachmentsRequest\x12\"\n" +
+	"\n" +
+	"batch_size\x18\x01 \x01(\x05B\x03\xe0A\x01R\tbatchSize\"\x1f\n" +
+	"\x1dGetAttachmentMigrationRequest2\x8f\a\n" +
 	"\x10WorkspaceService\x12\x82\x01\n" +
 	"\x13GetWorkspaceProfile\x12(.memos.api.v1.GetWorkspaceProfileRequest\x1a\x1e.memos.api.v1.WorkspaceProfile\"!\x82\xd3\xe4\x93\x02\x1b\x12\x19/api/v1/workspace/profile\x12\x93\x01\n" +
 	"\x13GetWorkspaceSetting\x12(.memos.api.v1.GetWorkspaceSettingRequest\x1a\x1e.memos.api.v1.WorkspaceSetting\"2\xdaA\x04name\x82\xd3\xe4\x93\x02%\x12#/api/v1/{name=workspace/settings/*}\x12\xb9\x01\n" +
-	"\x16UpdateWorkspaceSetting\x12+.memos.api.v1.UpdateWorkspaceSettingRequest\x1a\x1e.memos.api.v1.WorkspaceSetting\"R\xdaA\x13setting,update_mask\x82\xd3\xe4\x93\x026:\asetting2+/api/v1/{setting.name=workspace/settings/*}B\xad\x01\n" +
+	"\x16UpdateWorkspaceSetting\x12+.memos.api.v1.UpdateWorkspaceSettingRequest\x1a\x1e.memos.api.v1.WorkspaceSetting\"R\xdaA\x13setting,update_mask\x82\xd3\xe4\x93\x026:\asetting2+/api/v1/{setting.name=workspace/settings/*}\x12u\n" +
+	"\vListBackups\x12 .memos.api.v1.ListBackupsRequest\x1a!.memos.api.v1.ListBackupsResponse\"!\x82\xd3\xe4\x93\x02\x1b\x12\x19/api/v1/workspace/backups\x12\x92\x01\n" +
+	"\x12MigrateAttachments\x12'.memos.api.v1.MigrateAttachmentsRequest\x1a!.memos.api.v1.AttachmentMigration\"0\x82\xd3\xe4\x93\x02*:\x01*\"%/api/v1/workspace/attachmentMigration\x12\x97\x01\n" +
+	"\x16GetAttachmentMigration\x12+.memos.api.v1.GetAttachmentMigrationRequest\x1a!.memos.api.v1.AttachmentMigration\"-\x82\xd3\xe4\x93\x02'\x12%/api/v1/workspace/attachmentMigrationB\xad\x01\n" +
 	"\x10com.memos.api.v1B\x15WorkspaceServiceProtoP\x01Z0github.com/usememos/memos/proto/gen/api/v1;apiv1\xa2\x02\x03MAX\xaa\x02\fMemos.Api.V1\xca\x02\fMemos\\Api\\V1\xe2\x02\x18Memos\\Api\\V1\\GPBMetadata\xea\x02\x0eMemos::Api::V1b\x06proto3"
 
 var (
@@ -1016,43 +1950,76 @@ func file_api_v1_workspace_service_proto_rawDescGZIP() []byte {
 	return file_api_v1_workspace_service_proto_rawDescData
 }
 
-var file_api_v1_workspace_service_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_api_v1_workspace_service_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_api_v1_workspace_service_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_api_v1_workspace_service_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
 var file_api_v1_workspace_service_proto_goTypes = []any{
-	(WorkspaceSetting_Key)(0),                             // 0: memos.api.v1.WorkspaceSetting.Key
-	(WorkspaceSetting_StorageSetting_StorageType)(0),      // 1: memos.api.v1.WorkspaceSetting.StorageSetting.StorageType
-	(*WorkspaceProfile)(nil),                              // 2: memos.api.v1.WorkspaceProfile
-	(*GetWorkspaceProfileRequest)(nil),                    // 3: memos.api.v1.GetWorkspaceProfileRequest
-	(*WorkspaceSetting)(nil),                              // 4: memos.api.v1.WorkspaceSetting
-	(*GetWorkspaceSettingRequest)(nil),                    // 5: memos.api.v1.GetWorkspaceSettingRequest
-	(*UpdateWorkspaceSettingRequest)(nil),                 // 6: memos.api.v1.UpdateWorkspaceSettingRequest
-	(*WorkspaceSetting_GeneralSetting)(nil),               // 7: memos.api.v1.WorkspaceSetting.GeneralSetting
-	(*WorkspaceSetting_StorageSetting)(nil),               // 8: memos.api.v1.WorkspaceSetting.StorageSetting
-	(*WorkspaceSetting_MemoRelatedSetting)(nil),           // 9: memos.api.v1.WorkspaceSetting.MemoRelatedSetting
-	(*WorkspaceSetting_GeneralSetting_CustomProfile)(nil), // 10: memos.api.v1.WorkspaceSetting.GeneralSetting.CustomProfile
-	(*WorkspaceSetting_StorageSetting_S3Config)(nil),      // 11: memos.api.v1.WorkspaceSetting.StorageSetting.S3Config
-	(*fieldmaskpb.FieldMask)(nil),                         // 12: google.protobuf.FieldMask
+	(WorkspaceSetting_Key)(0),                                   // 0: memos.api.v1.WorkspaceSetting.Key
+	(WorkspaceSetting_StorageSetting_StorageType)(0),            // 1: memos.api.v1.WorkspaceSetting.StorageSetting.StorageType
+	(WorkspaceSetting_StorageSetting_ImageMetadataStripping)(0), // 2: memos.api.v1.WorkspaceSetting.StorageSetting.ImageMetadataStripping
+	(WorkspaceSetting_BackupSetting_Destination)(0),             // 3: memos.api.v1.WorkspaceSetting.BackupSetting.Destination
+	(AttachmentMigration_State)(0),                              // 4: memos.api.v1.AttachmentMigration.State
+	(*WorkspaceProfile)(nil),                                    // 5: memos.api.v1.WorkspaceProfile
+	(*GetWorkspaceProfileRequest)(nil),                          // 6: memos.api.v1.GetWorkspaceProfileRequest
+	(*WorkspaceSetting)(nil),                                    // 7: memos.api.v1.WorkspaceSetting
+	(*GetWorkspaceSettingRequest)(nil),                          // 8: memos.api.v1.GetWorkspaceSettingRequest
+	(*UpdateWorkspaceSettingRequest)(nil),                       // 9: memos.api.v1.UpdateWorkspaceSettingRequest
+	(*Backup)(nil),                                              // 10: memos.api.v1.Backup
+	(*ListBackupsRequest)(nil),                                  // 11: memos.api.v1.ListBackupsRequest
+	(*ListBackupsResponse)(nil),                                 // 12: memos.api.v1.ListBackupsResponse
+	(*AttachmentMigration)(nil),                                 // 13: memos.api.v1.AttachmentMigration
+	(*MigrateAttachmentsRequest)(nil),                           // 14: memos.api.v1.MigrateAttachmentsRequest
+	(*GetAttachmentMigrationRequest)(nil),                       // 15: memos.api.v1.GetAttachmentMigrationRequest
+	(*WorkspaceSetting_GeneralSetting)(nil),                     // 16: memos.api.v1.WorkspaceSetting.GeneralSetting
+	(*WorkspaceSetting_StorageSetting)(nil),                     // 17: memos.api.v1.WorkspaceSetting.StorageSetting
+	(*WorkspaceSetting_MemoRelatedSetting)(nil),                 // 18: memos.api.v1.WorkspaceSetting.MemoRelatedSetting
+	(*WorkspaceSetting_BackupSetting)(nil),                      // 19: memos.api.v1.WorkspaceSetting.BackupSetting
+	(*WorkspaceSetting_GeneralSetting_CustomProfile)(nil),       // 20: memos.api.v1.WorkspaceSetting.GeneralSetting.CustomProfile
+	(*WorkspaceSetting_StorageSetting_S3Config)(nil),            // 21: memos.api.v1.WorkspaceSetting.StorageSetting.S3Config
+	(*WorkspaceSetting_StorageSetting_WebDAVConfig)(nil),        // 22: memos.api.v1.WorkspaceSetting.StorageSetting.WebDAVConfig
+	(*WorkspaceSetting_StorageSetting_SFTPConfig)(nil),          // 23: memos.api.v1.WorkspaceSetting.StorageSetting.SFTPConfig
+	nil,                           // 24: memos.api.v1.WorkspaceSetting.StorageSetting.RoleQuotaMbEntry
+	nil,                           // 25: memos.api.v1.WorkspaceSetting.StorageSetting.UserQuotaMbEntry
+	(*fieldmaskpb.FieldMask)(nil), // 26: google.protobuf.FieldMask
+	(*timestamppb.Timestamp)(nil), // 27: google.protobuf.Timestamp
 }
 var file_api_v1_workspace_service_proto_depIdxs = []int32{
-	7,  // 0: memos.api.v1.WorkspaceSetting.general_setting:type_name -> memos.api.v1.WorkspaceSetting.GeneralSetting
-	8,  // 1: memos.api.v1.WorkspaceSetting.storage_setting:type_name -> memos.api.v1.WorkspaceSetting.StorageSetting
-	9,  // 2: memos.api.v1.WorkspaceSetting.memo_related_setting:type_name -> memos.api.v1.WorkspaceSetting.MemoRelatedSetting
-	4,  // 3: memos.api.v1.UpdateWorkspaceSettingRequest.setting:type_name -> memos.api.v1.WorkspaceSetting
-	12, // 4: memos.api.v1.UpdateWorkspaceSettingRequest.update_mask:type_name -> google.protobuf.FieldMask
-	10, // 5: memos.api.v1.WorkspaceSetting.GeneralSetting.custom_profile:type_name -> memos.api.v1.WorkspaceSetting.GeneralSetting.CustomProfile
-	1,  // 6: memos.api.v1.WorkspaceSetting.StorageSetting.storage_type:type_name -> memos.api.v1.WorkspaceSetting.StorageSetting.StorageType
-	11, // 7: memos.api.v1.WorkspaceSetting.StorageSetting.s3_config:type_name -> memos.api.v1.WorkspaceSetting.StorageSetting.S3Config
-	3,  // 8: memos.api.v1.WorkspaceService.GetWorkspaceProfile:input_type -> memos.api.v1.GetWorkspaceProfileRequest
-	5,  // 9: memos.api.v1.WorkspaceService.GetWorkspaceSetting:input_type -> memos.api.v1.GetWorkspaceSettingRequest
-	6,  // 10: memos.api.v1.WorkspaceService.UpdateWorkspaceSetting:input_type -> memos.api.v1.UpdateWorkspaceSettingRequest
-	2,  // 11: memos.api.v1.WorkspaceService.GetWorkspaceProfile:output_type -> memos.api.v1.WorkspaceProfile
-	4,  // 12: memos.api.v1.WorkspaceService.GetWorkspaceSetting:output_type -> memos.api.v1.WorkspaceSetting
-	4,  // 13: memos.api.v1.WorkspaceService.UpdateWorkspaceSetting:output_type -> memos.api.v1.WorkspaceSetting
-	11, // [11:14] is the sub-list for method output_type
-	8,  // [8:11] is the sub-list for method input_type
-	8,  // [8:8] is the sub-list for extension type_name
-	8,  // [8:8] is the sub-list for extension extendee
-	0,  // [0:8] is the sub-list for field type_name
+	16, // 0: memos.api.v1.WorkspaceSetting.general_setting:type_name -> memos.api.v1.WorkspaceSetting.GeneralSetting
+	17, // 1: memos.api.v1.WorkspaceSetting.storage_setting:type_name -> memos.api.v1.WorkspaceSetting.StorageSetting
+	18, // 2: memos.api.v1.WorkspaceSetting.memo_related_setting:type_name -> memos.api.v1.WorkspaceSetting.MemoRelatedSetting
+	19, // 3: memos.api.v1.WorkspaceSetting.backup_setting:type_name -> memos.api.v1.WorkspaceSetting.BackupSetting
+	7,  // 4: memos.api.v1.UpdateWorkspaceSettingRequest.setting:type_name -> memos.api.v1.WorkspaceSetting
+	26, // 5: memos.api.v1.UpdateWorkspaceSettingRequest.update_mask:type_name -> google.protobuf.FieldMask
+	27, // 6: memos.api.v1.Backup.create_time:type_name -> google.protobuf.Timestamp
+	10, // 7: memos.api.v1.ListBackupsResponse.backups:type_name -> memos.api.v1.Backup
+	4,  // 8: memos.api.v1.AttachmentMigration.state:type_name -> memos.api.v1.AttachmentMigration.State
+	27, // 9: memos.api.v1.AttachmentMigration.start_time:type_name -> google.protobuf.Timestamp
+	27, // 10: memos.api.v1.AttachmentMigration.end_time:type_name -> google.protobuf.Timestamp
+	20, // 11: memos.api.v1.WorkspaceSetting.GeneralSetting.custom_profile:type_name -> memos.api.v1.WorkspaceSetting.GeneralSetting.CustomProfile
+	1,  // 12: memos.api.v1.WorkspaceSetting.StorageSetting.storage_type:type_name -> memos.api.v1.WorkspaceSetting.StorageSetting.StorageType
+	21, // 13: memos.api.v1.WorkspaceSetting.StorageSetting.s3_config:type_name -> memos.api.v1.WorkspaceSetting.StorageSetting.S3Config
+	22, // 14: memos.api.v1.WorkspaceSetting.StorageSetting.webdav_config:type_name -> memos.api.v1.WorkspaceSetting.StorageSetting.WebDAVConfig
+	23, // 15: memos.api.v1.WorkspaceSetting.StorageSetting.sftp_config:type_name -> memos.api.v1.WorkspaceSetting.StorageSetting.SFTPConfig
+	24, // 16: memos.api.v1.WorkspaceSetting.StorageSetting.role_quota_mb:type_name -> memos.api.v1.WorkspaceSetting.StorageSetting.RoleQuotaMbEntry
+	25, // 17: memos.api.v1.WorkspaceSetting.StorageSetting.user_quota_mb:type_name -> memos.api.v1.WorkspaceSetting.StorageSetting.UserQuotaMbEntry
+	2,  // 18: memos.api.v1.WorkspaceSetting.StorageSetting.image_metadata_stripping:type_name -> memos.api.v1.WorkspaceSetting.StorageSetting.ImageMetadataStripping
+	3,  // 19: memos.api.v1.WorkspaceSetting.BackupSetting.destination:type_name -> memos.api.v1.WorkspaceSetting.BackupSetting.Destination
+	6,  // 20: memos.api.v1.WorkspaceService.GetWorkspaceProfile:input_type -> memos.api.v1.GetWorkspaceProfileRequest
+	8,  // 21: memos.api.v1.WorkspaceService.GetWorkspaceSetting:input_type -> memos.api.v1.GetWorkspaceSettingRequest
+	9,  // 22: memos.api.v1.WorkspaceService.UpdateWorkspaceSetting:input_type -> memos.api.v1.UpdateWorkspaceSettingRequest
+	11, // 23: memos.api.v1.WorkspaceService.ListBackups:input_type -> memos.api.v1.ListBackupsRequest
+	14, // 24: memos.api.v1.WorkspaceService.MigrateAttachments:input_type -> memos.api.v1.MigrateAttachmentsRequest
+	15, // 25: memos.api.v1.WorkspaceService.GetAttachmentMigration:input_type -> memos.api.v1.GetAttachmentMigrationRequest
+	5,  // 26: memos.api.v1.WorkspaceService.GetWorkspaceProfile:output_type -> memos.api.v1.WorkspaceProfile
+	7,  // 27: memos.api.v1.WorkspaceService.GetWorkspaceSetting:output_type -> memos.api.v1.WorkspaceSetting
+	7,  // 28: memos.api.v1.WorkspaceService.UpdateWorkspaceSetting:output_type -> memos.api.v1.WorkspaceSetting
+	12, // 29: memos.api.v1.WorkspaceService.ListBackups:output_type -> memos.api.v1.ListBackupsResponse
+	13, // 30: memos.api.v1.WorkspaceService.MigrateAttachments:output_type -> memos.api.v1.AttachmentMigration
+	13, // 31: memos.api.v1.WorkspaceService.GetAttachmentMigration:output_type -> memos.api.v1.AttachmentMigration
+	26, // [26:32] is the sub-list for method output_type
+	20, // [20:26] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
 }
 
 func init() { file_api_v1_workspace_service_proto_init() }
@@ -1064,14 +2031,15 @@ func file_api_v1_workspace_service_proto_init() {
 		(*WorkspaceSetting_GeneralSetting_)(nil),
 		(*WorkspaceSetting_StorageSetting_)(nil),
 		(*WorkspaceSetting_MemoRelatedSetting_)(nil),
+		(*WorkspaceSetting_BackupSetting_)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_v1_workspace_service_proto_rawDesc), len(file_api_v1_workspace_service_proto_rawDesc)),
-			NumEnums:      2,
-			NumMessages:   10,
+			NumEnums:      5,
+			NumMessages:   21,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
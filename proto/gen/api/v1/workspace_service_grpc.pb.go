@@ -22,6 +22,9 @@ const (
 	WorkspaceService_GetWorkspaceProfile_FullMethodName    = "/memos.api.v1.WorkspaceService/GetWorkspaceProfile"
 	WorkspaceService_GetWorkspaceSetting_FullMethodName    = "/memos.api.v1.WorkspaceService/GetWorkspaceSetting"
 	WorkspaceService_UpdateWorkspaceSetting_FullMethodName = "/memos.api.v1.WorkspaceService/UpdateWorkspaceSetting"
+	WorkspaceService_ListBackups_FullMethodName            = "/memos.api.v1.WorkspaceService/ListBackups"
+	WorkspaceService_MigrateAttachments_FullMethodName     = "/memos.api.v1.WorkspaceService/MigrateAttachments"
+	WorkspaceService_GetAttachmentMigration_FullMethodName = "/memos.api.v1.WorkspaceService/GetAttachmentMigration"
 )
 
 // WorkspaceServiceClient is the client API for WorkspaceService service.
@@ -34,6 +37,12 @@ type WorkspaceServiceClient interface {
 	GetWorkspaceSetting(ctx context.Context, in *GetWorkspaceSettingRequest, opts ...grpc.CallOption) (*WorkspaceSetting, error)
 	// Updates a workspace setting.
 	UpdateWorkspaceSetting(ctx context.Context, in *UpdateWorkspaceSettingRequest, opts ...grpc.CallOption) (*WorkspaceSetting, error)
+	// Lists the backups at the destination of the backup setting.
+	ListBackups(ctx context.Context, in *ListBackupsRequest, opts ...grpc.CallOption) (*ListBackupsResponse, error)
+	// Starts migrating the attachments to the storage of the storage setting.
+	MigrateAttachments(ctx context.Context, in *MigrateAttachmentsRequest, opts ...grpc.CallOption) (*AttachmentMigration, error)
+	// Gets the state of the last attachment migration.
+	GetAttachmentMigration(ctx context.Context, in *GetAttachmentMigrationRequest, opts ...grpc.CallOption) (*AttachmentMigration, error)
 }
 
 type workspaceServiceClient struct {
@@ -74,6 +83,36 @@ func (c *workspaceServiceClient) UpdateWorkspaceSetting(ctx context.Context, in
 	return out, nil
 }
 
+func (c *workspaceServiceClient) ListBackups(ctx context.Context, in *ListBackupsRequest, opts ...grpc.CallOption) (*ListBackupsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListBackupsResponse)
+	err := c.cc.Invoke(ctx, WorkspaceService_ListBackups_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workspaceServiceClient) MigrateAttachments(ctx context.Context, in *MigrateAttachmentsRequest, opts ...grpc.CallOption) (*AttachmentMigration, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AttachmentMigration)
+	err := c.cc.Invoke(ctx, WorkspaceService_MigrateAttachments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workspaceServiceClient) GetAttachmentMigration(ctx context.Context, in *GetAttachmentMigrationRequest, opts ...grpc.CallOption) (*AttachmentMigration, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AttachmentMigration)
+	err := c.cc.Invoke(ctx, WorkspaceService_GetAttachmentMigration_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // WorkspaceServiceServer is the server API for WorkspaceService service.
 // All implementations must embed UnimplementedWorkspaceServiceServer
 // for forward compatibility.
@@ -84,6 +123,12 @@ type WorkspaceServiceServer interface {
 	GetWorkspaceSetting(context.Context, *GetWorkspaceSettingRequest) (*WorkspaceSetting, error)
 	// Updates a workspace setting.
 	UpdateWorkspaceSetting(context.Context, *UpdateWorkspaceSettingRequest) (*WorkspaceSetting, error)
+	// Lists the backups at the destination of the backup setting.
+	ListBackups(context.Context, *ListBackupsRequest) (*ListBackupsResponse, error)
+	// Starts migrating the attachments to the storage of the storage setting.
+	MigrateAttachments(context.Context, *MigrateAttachmentsRequest) (*AttachmentMigration, error)
+	// Gets the state of the last attachment migration.
+	GetAttachmentMigration(context.Context, *GetAttachmentMigrationRequest) (*AttachmentMigration, error)
 	mustEmbedUnimplementedWorkspaceServiceServer()
 }
 
@@ -103,6 +148,15 @@ func (UnimplementedWorkspaceServiceServer) GetWorkspaceSetting(context.Context,
 func (UnimplementedWorkspaceServiceServer) UpdateWorkspaceSetting(context.Context, *UpdateWorkspaceSettingRequest) (*WorkspaceSetting, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateWorkspaceSetting not implemented")
 }
+func (UnimplementedWorkspaceServiceServer) ListBackups(context.Context, *ListBackupsRequest) (*ListBackupsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBackups not implemented")
+}
+func (UnimplementedWorkspaceServiceServer) MigrateAttachments(context.Context, *MigrateAttachmentsRequest) (*AttachmentMigration, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MigrateAttachments not implemented")
+}
+func (UnimplementedWorkspaceServiceServer) GetAttachmentMigration(context.Context, *GetAttachmentMigrationRequest) (*AttachmentMigration, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAttachmentMigration not implemented")
+}
 func (UnimplementedWorkspaceServiceServer) mustEmbedUnimplementedWorkspaceServiceServer() {}
 func (UnimplementedWorkspaceServiceServer) testEmbeddedByValue()                          {}
 
@@ -178,6 +232,60 @@ func _WorkspaceService_UpdateWorkspaceSetting_Handler(srv interface{}, ctx conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WorkspaceService_ListBackups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBackupsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkspaceServiceServer).ListBackups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WorkspaceService_ListBackups_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkspaceServiceServer).ListBackups(ctx, req.(*ListBackupsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorkspaceService_MigrateAttachments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MigrateAttachmentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkspaceServiceServer).MigrateAttachments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WorkspaceService_MigrateAttachments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkspaceServiceServer).MigrateAttachments(ctx, req.(*MigrateAttachmentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorkspaceService_GetAttachmentMigration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAttachmentMigrationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkspaceServiceServer).GetAttachmentMigration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WorkspaceService_GetAttachmentMigration_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkspaceServiceServer).GetAttachmentMigration(ctx, req.(*GetAttachmentMigrationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // WorkspaceService_ServiceDesc is the grpc.ServiceDesc for WorkspaceService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -197,6 +305,18 @@ var WorkspaceService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateWorkspaceSetting",
 			Handler:    _WorkspaceService_UpdateWorkspaceSetting_Handler,
 		},
+		{
+			MethodName: "ListBackups",
+			Handler:    _WorkspaceService_ListBackups_Handler,
+		},
+		{
+			MethodName: "MigrateAttachments",
+			Handler:    _WorkspaceService_MigrateAttachments_Handler,
+		},
+		{
+			MethodName: "GetAttachmentMigration",
+			Handler:    _WorkspaceService_GetAttachmentMigration_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/v1/workspace_service.proto",
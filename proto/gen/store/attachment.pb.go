@@ -32,6 +32,10 @@ const (
 	AttachmentStorageType_S3 AttachmentStorageType = 2
 	// Attachment is stored in an external storage. The reference is a URL.
 	AttachmentStorageType_EXTERNAL AttachmentStorageType = 3
+	// Attachment is stored in a WebDAV server.
+	AttachmentStorageType_WEBDAV AttachmentStorageType = 4
+	// Attachment is stored in an SFTP server.
+	AttachmentStorageType_SFTP AttachmentStorageType = 5
 )
 
 // Enum value maps for AttachmentStorageType.
@@ -41,12 +45,16 @@ var (
 		1: "LOCAL",
 		2: "S3",
 		3: "EXTERNAL",
+		4: "WEBDAV",
+		5: "SFTP",
 	}
 	AttachmentStorageType_value = map[string]int32{
 		"ATTACHMENT_STORAGE_TYPE_UNSPECIFIED": 0,
 		"LOCAL":                               1,
 		"S3":                                  2,
 		"EXTERNAL":                            3,
+		"WEBDAV":                              4,
+		"SFTP":                                5,
 	}
 )
 
@@ -82,7 +90,11 @@ type AttachmentPayload struct {
 	// Types that are valid to be assigned to Payload:
 	//
 	//	*AttachmentPayload_S3Object_
-	Payload       isAttachmentPayload_Payload `protobuf_oneof:"payload"`
+	//	*AttachmentPayload_WebdavObject
+	//	*AttachmentPayload_SftpObject
+	Payload isAttachmentPayload_Payload `protobuf_oneof:"payload"`
+	// The metadata of image attachments, extracted on upload.
+	ImageMetadata *AttachmentPayload_ImageMetadata `protobuf:"bytes,4,opt,name=image_metadata,json=imageMetadata,proto3" json:"image_metadata,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -133,6 +145,31 @@ func (x *AttachmentPayload) GetS3Object() *AttachmentPayload_S3Object {
 	return nil
 }
 
+func (x *AttachmentPayload) GetWebdavObject() *AttachmentPayload_WebDAVObject {
+	if x != nil {
+		if x, ok := x.Payload.(*AttachmentPayload_WebdavObject); ok {
+			return x.WebdavObject
+		}
+	}
+	return nil
+}
+
+func (x *AttachmentPayload) GetSftpObject() *AttachmentPayload_SFTPObject {
+	if x != nil {
+		if x, ok := x.Payload.(*AttachmentPayload_SftpObject); ok {
+			return x.SftpObject
+		}
+	}
+	return nil
+}
+
+func (x *AttachmentPayload) GetImageMetadata() *AttachmentPayload_ImageMetadata {
+	if x != nil {
+		return x.ImageMetadata
+	}
+	return nil
+}
+
 type isAttachmentPayload_Payload interface {
 	isAttachmentPayload_Payload()
 }
@@ -141,8 +178,20 @@ type AttachmentPayload_S3Object_ struct {
 	S3Object *AttachmentPayload_S3Object `protobuf:"bytes,1,opt,name=s3_object,json=s3Object,proto3,oneof"`
 }
 
+type AttachmentPayload_WebdavObject struct {
+	WebdavObject *AttachmentPayload_WebDAVObject `protobuf:"bytes,2,opt,name=webdav_object,json=webdavObject,proto3,oneof"`
+}
+
+type AttachmentPayload_SftpObject struct {
+	SftpObject *AttachmentPayload_SFTPObject `protobuf:"bytes,3,opt,name=sftp_object,json=sftpObject,proto3,oneof"`
+}
+
 func (*AttachmentPayload_S3Object_) isAttachmentPayload_Payload() {}
 
+func (*AttachmentPayload_WebdavObject) isAttachmentPayload_Payload() {}
+
+func (*AttachmentPayload_SftpObject) isAttachmentPayload_Payload() {}
+
 type AttachmentPayload_S3Object struct {
 	state    protoimpl.MessageState `protogen:"open.v1"`
 	S3Config *StorageS3Config       `protobuf:"bytes,1,opt,name=s3_config,json=s3Config,proto3" json:"s3_config,omitempty"`
@@ -206,23 +255,294 @@ func (x *AttachmentPayload_S3Object) GetLastPresignedTime() *timestamppb.Timesta
 	return nil
 }
 
+type AttachmentPayload_WebDAVObject struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	WebdavConfig *StorageWebDAVConfig   `protobuf:"bytes,1,opt,name=webdav_config,json=webdavConfig,proto3" json:"webdav_config,omitempty"`
+	// path is the path of the file relative to the endpoint.
+	Path          string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttachmentPayload_WebDAVObject) Reset() {
+	*x = AttachmentPayload_WebDAVObject{}
+	mi := &file_store_attachment_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttachmentPayload_WebDAVObject) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttachmentPayload_WebDAVObject) ProtoMessage() {}
+
+func (x *AttachmentPayload_WebDAVObject) ProtoReflect() protoreflect.Message {
+	mi := &file_store_attachment_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttachmentPayload_WebDAVObject.ProtoReflect.Descriptor instead.
+func (*AttachmentPayload_WebDAVObject) Descriptor() ([]byte, []int) {
+	return file_store_attachment_proto_rawDescGZIP(), []int{0, 1}
+}
+
+func (x *AttachmentPayload_WebDAVObject) GetWebdavConfig() *StorageWebDAVConfig {
+	if x != nil {
+		return x.WebdavConfig
+	}
+	return nil
+}
+
+func (x *AttachmentPayload_WebDAVObject) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+type AttachmentPayload_SFTPObject struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	SftpConfig *StorageSFTPConfig     `protobuf:"bytes,1,opt,name=sftp_config,json=sftpConfig,proto3" json:"sftp_config,omitempty"`
+	// path is the path of the file on the server.
+	Path          string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttachmentPayload_SFTPObject) Reset() {
+	*x = AttachmentPayload_SFTPObject{}
+	mi := &file_store_attachment_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttachmentPayload_SFTPObject) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttachmentPayload_SFTPObject) ProtoMessage() {}
+
+func (x *AttachmentPayload_SFTPObject) ProtoReflect() protoreflect.Message {
+	mi := &file_store_attachment_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttachmentPayload_SFTPObject.ProtoReflect.Descriptor instead.
+func (*AttachmentPayload_SFTPObject) Descriptor() ([]byte, []int) {
+	return file_store_attachment_proto_rawDescGZIP(), []int{0, 2}
+}
+
+func (x *AttachmentPayload_SFTPObject) GetSftpConfig() *StorageSFTPConfig {
+	if x != nil {
+		return x.SftpConfig
+	}
+	return nil
+}
+
+func (x *AttachmentPayload_SFTPObject) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+type AttachmentPayload_ImageMetadata struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The dimensions of the image as displayed.
+	Width       int32                  `protobuf:"varint,1,opt,name=width,proto3" json:"width,omitempty"`
+	Height      int32                  `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	CaptureTime *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=capture_time,json=captureTime,proto3" json:"capture_time,omitempty"`
+	CameraMake  string                 `protobuf:"bytes,4,opt,name=camera_make,json=cameraMake,proto3" json:"camera_make,omitempty"`
+	CameraModel string                 `protobuf:"bytes,5,opt,name=camera_model,json=cameraModel,proto3" json:"camera_model,omitempty"`
+	// The GPS location of the photo. It is only kept for users who fill the location of
+	// memos from their photos.
+	Location      *AttachmentPayload_Location `protobuf:"bytes,6,opt,name=location,proto3" json:"location,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttachmentPayload_ImageMetadata) Reset() {
+	*x = AttachmentPayload_ImageMetadata{}
+	mi := &file_store_attachment_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttachmentPayload_ImageMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttachmentPayload_ImageMetadata) ProtoMessage() {}
+
+func (x *AttachmentPayload_ImageMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_store_attachment_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttachmentPayload_ImageMetadata.ProtoReflect.Descriptor instead.
+func (*AttachmentPayload_ImageMetadata) Descriptor() ([]byte, []int) {
+	return file_store_attachment_proto_rawDescGZIP(), []int{0, 3}
+}
+
+func (x *AttachmentPayload_ImageMetadata) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *AttachmentPayload_ImageMetadata) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *AttachmentPayload_ImageMetadata) GetCaptureTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CaptureTime
+	}
+	return nil
+}
+
+func (x *AttachmentPayload_ImageMetadata) GetCameraMake() string {
+	if x != nil {
+		return x.CameraMake
+	}
+	return ""
+}
+
+func (x *AttachmentPayload_ImageMetadata) GetCameraModel() string {
+	if x != nil {
+		return x.CameraModel
+	}
+	return ""
+}
+
+func (x *AttachmentPayload_ImageMetadata) GetLocation() *AttachmentPayload_Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+type AttachmentPayload_Location struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Latitude      float64                `protobuf:"fixed64,1,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude     float64                `protobuf:"fixed64,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttachmentPayload_Location) Reset() {
+	*x = AttachmentPayload_Location{}
+	mi := &file_store_attachment_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttachmentPayload_Location) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttachmentPayload_Location) ProtoMessage() {}
+
+func (x *AttachmentPayload_Location) ProtoReflect() protoreflect.Message {
+	mi := &file_store_attachment_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttachmentPayload_Location.ProtoReflect.Descriptor instead.
+func (*AttachmentPayload_Location) Descriptor() ([]byte, []int) {
+	return file_store_attachment_proto_rawDescGZIP(), []int{0, 4}
+}
+
+func (x *AttachmentPayload_Location) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *AttachmentPayload_Location) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
 var File_store_attachment_proto protoreflect.FileDescriptor
 
 const file_store_attachment_proto_rawDesc = "" +
 	"\n" +
-	"\x16store/attachment.proto\x12\vmemos.store\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1dstore/workspace_setting.proto\"\x8c\x02\n" +
+	"\x16store/attachment.proto\x12\vmemos.store\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1dstore/workspace_setting.proto\"\x9f\b\n" +
 	"\x11AttachmentPayload\x12F\n" +
-	"\ts3_object\x18\x01 \x01(\v2'.memos.store.AttachmentPayload.S3ObjectH\x00R\bs3Object\x1a\xa3\x01\n" +
+	"\ts3_object\x18\x01 \x01(\v2'.memos.store.AttachmentPayload.S3ObjectH\x00R\bs3Object\x12R\n" +
+	"\rwebdav_object\x18\x02 \x01(\v2+.memos.store.AttachmentPayload.WebDAVObjectH\x00R\fwebdavObject\x12L\n" +
+	"\vsftp_object\x18\x03 \x01(\v2).memos.store.AttachmentPayload.SFTPObjectH\x00R\n" +
+	"sftpObject\x12S\n" +
+	"\x0eimage_metadata\x18\x04 \x01(\v2,.memos.store.AttachmentPayload.ImageMetadataR\rimageMetadata\x1a\xa3\x01\n" +
 	"\bS3Object\x129\n" +
 	"\ts3_config\x18\x01 \x01(\v2\x1c.memos.store.StorageS3ConfigR\bs3Config\x12\x10\n" +
 	"\x03key\x18\x02 \x01(\tR\x03key\x12J\n" +
-	"\x13last_presigned_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x11lastPresignedTimeB\t\n" +
-	"\apayload*a\n" +
+	"\x13last_presigned_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x11lastPresignedTime\x1ai\n" +
+	"\fWebDAVObject\x12E\n" +
+	"\rwebdav_config\x18\x01 \x01(\v2 .memos.store.StorageWebDAVConfigR\fwebdavConfig\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x1aa\n" +
+	"\n" +
+	"SFTPObject\x12?\n" +
+	"\vsftp_config\x18\x01 \x01(\v2\x1e.memos.store.StorageSFTPConfigR\n" +
+	"sftpConfig\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x1a\x85\x02\n" +
+	"\rImageMetadata\x12\x14\n" +
+	"\x05width\x18\x01 \x01(\x05R\x05width\x12\x16\n" +
+	"\x06height\x18\x02 \x01(\x05R\x06height\x12=\n" +
+	"\fcapture_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\vcaptureTime\x12\x1f\n" +
+	"\vcamera_make\x18\x04 \x01(\tR\n" +
+	"cameraMake\x12!\n" +
+	"\fcamera_model\x18\x05 \x01(\tR\vcameraModel\x12C\n" +
+	"\blocation\x18\x06 \x01(\v2'.memos.store.AttachmentPayload.LocationR\blocation\x1aD\n" +
+	"\bLocation\x12\x1a\n" +
+	"\blatitude\x18\x01 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x02 \x01(\x01R\tlongitudeB\t\n" +
+	"\apayload*w\n" +
 	"\x15AttachmentStorageType\x12'\n" +
 	"#ATTACHMENT_STORAGE_TYPE_UNSPECIFIED\x10\x00\x12\t\n" +
 	"\x05LOCAL\x10\x01\x12\x06\n" +
 	"\x02S3\x10\x02\x12\f\n" +
-	"\bEXTERNAL\x10\x03B\x9a\x01\n" +
+	"\bEXTERNAL\x10\x03\x12\n" +
+	"\n" +
+	"\x06WEBDAV\x10\x04\x12\b\n" +
+	"\x04SFTP\x10\x05B\x9a\x01\n" +
 	"\x0fcom.memos.storeB\x0fAttachmentProtoP\x01Z)github.com/usememos/memos/proto/gen/store\xa2\x02\x03MSX\xaa\x02\vMemos.Store\xca\x02\vMemos\\Store\xe2\x02\x17Memos\\Store\\GPBMetadata\xea\x02\fMemos::Storeb\x06proto3"
 
 var (
@@ -238,23 +558,36 @@ func file_store_attachment_proto_rawDescGZIP() []byte {
 }
 
 var file_store_attachment_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_store_attachment_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_store_attachment_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
 var file_store_attachment_proto_goTypes = []any{
-	(AttachmentStorageType)(0),         // 0: memos.store.AttachmentStorageType
-	(*AttachmentPayload)(nil),          // 1: memos.store.AttachmentPayload
-	(*AttachmentPayload_S3Object)(nil), // 2: memos.store.AttachmentPayload.S3Object
-	(*StorageS3Config)(nil),            // 3: memos.store.StorageS3Config
-	(*timestamppb.Timestamp)(nil),      // 4: google.protobuf.Timestamp
+	(AttachmentStorageType)(0),              // 0: memos.store.AttachmentStorageType
+	(*AttachmentPayload)(nil),               // 1: memos.store.AttachmentPayload
+	(*AttachmentPayload_S3Object)(nil),      // 2: memos.store.AttachmentPayload.S3Object
+	(*AttachmentPayload_WebDAVObject)(nil),  // 3: memos.store.AttachmentPayload.WebDAVObject
+	(*AttachmentPayload_SFTPObject)(nil),    // 4: memos.store.AttachmentPayload.SFTPObject
+	(*AttachmentPayload_ImageMetadata)(nil), // 5: memos.store.AttachmentPayload.ImageMetadata
+	(*AttachmentPayload_Location)(nil),      // 6: memos.store.AttachmentPayload.Location
+	(*StorageS3Config)(nil),                 // 7: memos.store.StorageS3Config
+	(*timestamppb.Timestamp)(nil),           // 8: google.protobuf.Timestamp
+	(*StorageWebDAVConfig)(nil),             // 9: memos.store.StorageWebDAVConfig
+	(*StorageSFTPConfig)(nil),               // 10: memos.store.StorageSFTPConfig
 }
 var file_store_attachment_proto_depIdxs = []int32{
-	2, // 0: memos.store.AttachmentPayload.s3_object:type_name -> memos.store.AttachmentPayload.S3Object
-	3, // 1: memos.store.AttachmentPayload.S3Object.s3_config:type_name -> memos.store.StorageS3Config
-	4, // 2: memos.store.AttachmentPayload.S3Object.last_presigned_time:type_name -> google.protobuf.Timestamp
-	3, // [3:3] is the sub-list for method output_type
-	3, // [3:3] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	2,  // 0: memos.store.AttachmentPayload.s3_object:type_name -> memos.store.AttachmentPayload.S3Object
+	3,  // 1: memos.store.AttachmentPayload.webdav_object:type_name -> memos.store.AttachmentPayload.WebDAVObject
+	4,  // 2: memos.store.AttachmentPayload.sftp_object:type_name -> memos.store.AttachmentPayload.SFTPObject
+	5,  // 3: memos.store.AttachmentPayload.image_metadata:type_name -> memos.store.AttachmentPayload.ImageMetadata
+	7,  // 4: memos.store.AttachmentPayload.S3Object.s3_config:type_name -> memos.store.StorageS3Config
+	8,  // 5: memos.store.AttachmentPayload.S3Object.last_presigned_time:type_name -> google.protobuf.Timestamp
+	9,  // 6: memos.store.AttachmentPayload.WebDAVObject.webdav_config:type_name -> memos.store.StorageWebDAVConfig
+	10, // 7: memos.store.AttachmentPayload.SFTPObject.sftp_config:type_name -> memos.store.StorageSFTPConfig
+	8,  // 8: memos.store.AttachmentPayload.ImageMetadata.capture_time:type_name -> google.protobuf.Timestamp
+	6,  // 9: memos.store.AttachmentPayload.ImageMetadata.location:type_name -> memos.store.AttachmentPayload.Location
+	10, // [10:10] is the sub-list for method output_type
+	10, // [10:10] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
 }
 
 func init() { file_store_attachment_proto_init() }
@@ -265,6 +598,8 @@ func file_store_attachment_proto_init() {
 	file_store_workspace_setting_proto_init()
 	file_store_attachment_proto_msgTypes[0].OneofWrappers = []any{
 		(*AttachmentPayload_S3Object_)(nil),
+		(*AttachmentPayload_WebdavObject)(nil),
+		(*AttachmentPayload_SftpObject)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -272,7 +607,7 @@ func file_store_attachment_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_store_attachment_proto_rawDesc), len(file_store_attachment_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   2,
+			NumMessages:   6,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
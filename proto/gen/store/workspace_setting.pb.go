@@ -33,6 +33,8 @@ const (
 	WorkspaceSettingKey_STORAGE WorkspaceSettingKey = 3
 	// MEMO_RELATED is the key for memo related settings.
 	WorkspaceSettingKey_MEMO_RELATED WorkspaceSettingKey = 4
+	// BACKUP is the key for scheduled backup settings.
+	WorkspaceSettingKey_BACKUP WorkspaceSettingKey = 5
 )
 
 // Enum value maps for WorkspaceSettingKey.
@@ -43,6 +45,7 @@ var (
 		2: "GENERAL",
 		3: "STORAGE",
 		4: "MEMO_RELATED",
+		5: "BACKUP",
 	}
 	WorkspaceSettingKey_value = map[string]int32{
 		"WORKSPACE_SETTING_KEY_UNSPECIFIED": 0,
@@ -50,6 +53,7 @@ var (
 		"GENERAL":                           2,
 		"STORAGE":                           3,
 		"MEMO_RELATED":                      4,
+		"BACKUP":                            5,
 	}
 )
 
@@ -90,6 +94,10 @@ const (
 	WorkspaceStorageSetting_LOCAL WorkspaceStorageSetting_StorageType = 2
 	// STORAGE_TYPE_S3 is the S3 storage type.
 	WorkspaceStorageSetting_S3 WorkspaceStorageSetting_StorageType = 3
+	// STORAGE_TYPE_WEBDAV is the WebDAV storage type.
+	WorkspaceStorageSetting_WEBDAV WorkspaceStorageSetting_StorageType = 4
+	// STORAGE_TYPE_SFTP is the SFTP storage type.
+	WorkspaceStorageSetting_SFTP WorkspaceStorageSetting_StorageType = 5
 )
 
 // Enum value maps for WorkspaceStorageSetting_StorageType.
@@ -99,12 +107,16 @@ var (
 		1: "DATABASE",
 		2: "LOCAL",
 		3: "S3",
+		4: "WEBDAV",
+		5: "SFTP",
 	}
 	WorkspaceStorageSetting_StorageType_value = map[string]int32{
 		"STORAGE_TYPE_UNSPECIFIED": 0,
 		"DATABASE":                 1,
 		"LOCAL":                    2,
 		"S3":                       3,
+		"WEBDAV":                   4,
+		"SFTP":                     5,
 	}
 )
 
@@ -135,6 +147,109 @@ func (WorkspaceStorageSetting_StorageType) EnumDescriptor() ([]byte, []int) {
 	return file_store_workspace_setting_proto_rawDescGZIP(), []int{4, 0}
 }
 
+type WorkspaceStorageSetting_ImageMetadataStripping int32
+
+const (
+	// The metadata of uploaded images is kept.
+	WorkspaceStorageSetting_IMAGE_METADATA_STRIPPING_UNSPECIFIED WorkspaceStorageSetting_ImageMetadataStripping = 0
+	// The GPS location is removed from uploaded images.
+	WorkspaceStorageSetting_STRIP_GPS WorkspaceStorageSetting_ImageMetadataStripping = 1
+	// The EXIF, XMP and IPTC metadata is removed from uploaded images, except the orientation.
+	WorkspaceStorageSetting_STRIP_ALL WorkspaceStorageSetting_ImageMetadataStripping = 2
+)
+
+// Enum value maps for WorkspaceStorageSetting_ImageMetadataStripping.
+var (
+	WorkspaceStorageSetting_ImageMetadataStripping_name = map[int32]string{
+		0: "IMAGE_METADATA_STRIPPING_UNSPECIFIED",
+		1: "STRIP_GPS",
+		2: "STRIP_ALL",
+	}
+	WorkspaceStorageSetting_ImageMetadataStripping_value = map[string]int32{
+		"IMAGE_METADATA_STRIPPING_UNSPECIFIED": 0,
+		"STRIP_GPS":                            1,
+		"STRIP_ALL":                            2,
+	}
+)
+
+func (x WorkspaceStorageSetting_ImageMetadataStripping) Enum() *WorkspaceStorageSetting_ImageMetadataStripping {
+	p := new(WorkspaceStorageSetting_ImageMetadataStripping)
+	*p = x
+	return p
+}
+
+func (x WorkspaceStorageSetting_ImageMetadataStripping) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WorkspaceStorageSetting_ImageMetadataStripping) Descriptor() protoreflect.EnumDescriptor {
+	return file_store_workspace_setting_proto_enumTypes[2].Descriptor()
+}
+
+func (WorkspaceStorageSetting_ImageMetadataStripping) Type() protoreflect.EnumType {
+	return &file_store_workspace_setting_proto_enumTypes[2]
+}
+
+func (x WorkspaceStorageSetting_ImageMetadataStripping) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WorkspaceStorageSetting_ImageMetadataStripping.Descriptor instead.
+func (WorkspaceStorageSetting_ImageMetadataStripping) EnumDescriptor() ([]byte, []int) {
+	return file_store_workspace_setting_proto_rawDescGZIP(), []int{4, 1}
+}
+
+type WorkspaceBackupSetting_Destination int32
+
+const (
+	WorkspaceBackupSetting_DESTINATION_UNSPECIFIED WorkspaceBackupSetting_Destination = 0
+	// LOCAL writes the backups to a local directory.
+	WorkspaceBackupSetting_LOCAL WorkspaceBackupSetting_Destination = 1
+	// S3 uploads the backups to the bucket of the S3 storage config.
+	WorkspaceBackupSetting_S3 WorkspaceBackupSetting_Destination = 2
+)
+
+// Enum value maps for WorkspaceBackupSetting_Destination.
+var (
+	WorkspaceBackupSetting_Destination_name = map[int32]string{
+		0: "DESTINATION_UNSPECIFIED",
+		1: "LOCAL",
+		2: "S3",
+	}
+	WorkspaceBackupSetting_Destination_value = map[string]int32{
+		"DESTINATION_UNSPECIFIED": 0,
+		"LOCAL":                   1,
+		"S3":                      2,
+	}
+)
+
+func (x WorkspaceBackupSetting_Destination) Enum() *WorkspaceBackupSetting_Destination {
+	p := new(WorkspaceBackupSetting_Destination)
+	*p = x
+	return p
+}
+
+func (x WorkspaceBackupSetting_Destination) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WorkspaceBackupSetting_Destination) Descriptor() protoreflect.EnumDescriptor {
+	return file_store_workspace_setting_proto_enumTypes[3].Descriptor()
+}
+
+func (WorkspaceBackupSetting_Destination) Type() protoreflect.EnumType {
+	return &file_store_workspace_setting_proto_enumTypes[3]
+}
+
+func (x WorkspaceBackupSetting_Destination) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WorkspaceBackupSetting_Destination.Descriptor instead.
+func (WorkspaceBackupSetting_Destination) EnumDescriptor() ([]byte, []int) {
+	return file_store_workspace_setting_proto_rawDescGZIP(), []int{9, 0}
+}
+
 type WorkspaceSetting struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	Key   WorkspaceSettingKey    `protobuf:"varint,1,opt,name=key,proto3,enum=memos.store.WorkspaceSettingKey" json:"key,omitempty"`
@@ -144,6 +259,7 @@ type WorkspaceSetting struct {
 	//	*WorkspaceSetting_GeneralSetting
 	//	*WorkspaceSetting_StorageSetting
 	//	*WorkspaceSetting_MemoRelatedSetting
+	//	*WorkspaceSetting_BackupSetting
 	Value         isWorkspaceSetting_Value `protobuf_oneof:"value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -229,6 +345,15 @@ func (x *WorkspaceSetting) GetMemoRelatedSetting() *WorkspaceMemoRelatedSetting
 	return nil
 }
 
+func (x *WorkspaceSetting) GetBackupSetting() *WorkspaceBackupSetting {
+	if x != nil {
+		if x, ok := x.Value.(*WorkspaceSetting_BackupSetting); ok {
+			return x.BackupSetting
+		}
+	}
+	return nil
+}
+
 type isWorkspaceSetting_Value interface {
 	isWorkspaceSetting_Value()
 }
@@ -249,6 +374,10 @@ type WorkspaceSetting_MemoRelatedSetting struct {
 	MemoRelatedSetting *WorkspaceMemoRelatedSetting `protobuf:"bytes,5,opt,name=memo_related_setting,json=memoRelatedSetting,proto3,oneof"`
 }
 
+type WorkspaceSetting_BackupSetting struct {
+	BackupSetting *WorkspaceBackupSetting `protobuf:"bytes,6,opt,name=backup_setting,json=backupSetting,proto3,oneof"`
+}
+
 func (*WorkspaceSetting_BasicSetting) isWorkspaceSetting_Value() {}
 
 func (*WorkspaceSetting_GeneralSetting) isWorkspaceSetting_Value() {}
@@ -257,6 +386,8 @@ func (*WorkspaceSetting_StorageSetting) isWorkspaceSetting_Value() {}
 
 func (*WorkspaceSetting_MemoRelatedSetting) isWorkspaceSetting_Value() {}
 
+func (*WorkspaceSetting_BackupSetting) isWorkspaceSetting_Value() {}
+
 type WorkspaceBasicSetting struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// The secret key for workspace. Mainly used for session management.
@@ -334,8 +465,11 @@ type WorkspaceGeneralSetting struct {
 	DisallowChangeUsername bool `protobuf:"varint,8,opt,name=disallow_change_username,json=disallowChangeUsername,proto3" json:"disallow_change_username,omitempty"`
 	// disallow_change_nickname disallows changing nickname.
 	DisallowChangeNickname bool `protobuf:"varint,9,opt,name=disallow_change_nickname,json=disallowChangeNickname,proto3" json:"disallow_change_nickname,omitempty"`
-	unknownFields          protoimpl.UnknownFields
-	sizeCache              protoimpl.SizeCache
+	// timezone is the default timezone of the workspace, as an IANA time zone name (e.g. "Europe/Berlin").
+	// Empty means the server local timezone.
+	Timezone      string `protobuf:"bytes,10,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *WorkspaceGeneralSetting) Reset() {
@@ -431,6 +565,13 @@ func (x *WorkspaceGeneralSetting) GetDisallowChangeNickname() bool {
 	return false
 }
 
+func (x *WorkspaceGeneralSetting) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
 type WorkspaceCustomProfile struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
@@ -509,9 +650,25 @@ type WorkspaceStorageSetting struct {
 	// The max upload size in megabytes.
 	UploadSizeLimitMb int64 `protobuf:"varint,3,opt,name=upload_size_limit_mb,json=uploadSizeLimitMb,proto3" json:"upload_size_limit_mb,omitempty"`
 	// The S3 config.
-	S3Config      *StorageS3Config `protobuf:"bytes,4,opt,name=s3_config,json=s3Config,proto3" json:"s3_config,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	S3Config *StorageS3Config `protobuf:"bytes,4,opt,name=s3_config,json=s3Config,proto3" json:"s3_config,omitempty"`
+	// The WebDAV config.
+	WebdavConfig *StorageWebDAVConfig `protobuf:"bytes,5,opt,name=webdav_config,json=webdavConfig,proto3" json:"webdav_config,omitempty"`
+	// The SFTP config.
+	SftpConfig *StorageSFTPConfig `protobuf:"bytes,6,opt,name=sftp_config,json=sftpConfig,proto3" json:"sftp_config,omitempty"`
+	// deduplicate stores identical LOCAL and S3 attachments once, shared by their attachments.
+	Deduplicate bool `protobuf:"varint,7,opt,name=deduplicate,proto3" json:"deduplicate,omitempty"`
+	// The storage quotas in megabytes of the users of a role by role, e.g. USER.
+	// Roles without a quota or with a quota of 0 are unlimited.
+	RoleQuotaMb map[string]int64 `protobuf:"bytes,8,rep,name=role_quota_mb,json=roleQuotaMb,proto3" json:"role_quota_mb,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	// The storage quotas in megabytes by user ID, over the quotas of the roles. 0 is unlimited.
+	UserQuotaMb map[int32]int64 `protobuf:"bytes,9,rep,name=user_quota_mb,json=userQuotaMb,proto3" json:"user_quota_mb,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	// The age in days after which attachments without memo and local files without attachment
+	// are deleted by the daily garbage collection. 0 disables the garbage collection.
+	OrphanRetentionDays int32 `protobuf:"varint,10,opt,name=orphan_retention_days,json=orphanRetentionDays,proto3" json:"orphan_retention_days,omitempty"`
+	// The metadata that is removed from uploaded JPEG and PNG images.
+	ImageMetadataStripping WorkspaceStorageSetting_ImageMetadataStripping `protobuf:"varint,11,opt,name=image_metadata_stripping,json=imageMetadataStripping,proto3,enum=memos.store.WorkspaceStorageSetting_ImageMetadataStripping" json:"image_metadata_stripping,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
 }
 
 func (x *WorkspaceStorageSetting) Reset() {
@@ -572,6 +729,55 @@ func (x *WorkspaceStorageSetting) GetS3Config() *StorageS3Config {
 	return nil
 }
 
+func (x *WorkspaceStorageSetting) GetWebdavConfig() *StorageWebDAVConfig {
+	if x != nil {
+		return x.WebdavConfig
+	}
+	return nil
+}
+
+func (x *WorkspaceStorageSetting) GetSftpConfig() *StorageSFTPConfig {
+	if x != nil {
+		return x.SftpConfig
+	}
+	return nil
+}
+
+func (x *WorkspaceStorageSetting) GetDeduplicate() bool {
+	if x != nil {
+		return x.Deduplicate
+	}
+	return false
+}
+
+func (x *WorkspaceStorageSetting) GetRoleQuotaMb() map[string]int64 {
+	if x != nil {
+		return x.RoleQuotaMb
+	}
+	return nil
+}
+
+func (x *WorkspaceStorageSetting) GetUserQuotaMb() map[int32]int64 {
+	if x != nil {
+		return x.UserQuotaMb
+	}
+	return nil
+}
+
+func (x *WorkspaceStorageSetting) GetOrphanRetentionDays() int32 {
+	if x != nil {
+		return x.OrphanRetentionDays
+	}
+	return 0
+}
+
+func (x *WorkspaceStorageSetting) GetImageMetadataStripping() WorkspaceStorageSetting_ImageMetadataStripping {
+	if x != nil {
+		return x.ImageMetadataStripping
+	}
+	return WorkspaceStorageSetting_IMAGE_METADATA_STRIPPING_UNSPECIFIED
+}
+
 // Reference: https://developers.cloudflare.com/r2/examples/aws/aws-sdk-go/
 type StorageS3Config struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
@@ -657,6 +863,147 @@ func (x *StorageS3Config) GetUsePathStyle() bool {
 	return false
 }
 
+type StorageWebDAVConfig struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The URL of the directory of the files, e.g. https://dav.example.com/remote.php/dav/files/memos/.
+	Endpoint      string `protobuf:"bytes,1,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	Username      string `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Password      string `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StorageWebDAVConfig) Reset() {
+	*x = StorageWebDAVConfig{}
+	mi := &file_store_workspace_setting_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StorageWebDAVConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StorageWebDAVConfig) ProtoMessage() {}
+
+func (x *StorageWebDAVConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_store_workspace_setting_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StorageWebDAVConfig.ProtoReflect.Descriptor instead.
+func (*StorageWebDAVConfig) Descriptor() ([]byte, []int) {
+	return file_store_workspace_setting_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *StorageWebDAVConfig) GetEndpoint() string {
+	if x != nil {
+		return x.Endpoint
+	}
+	return ""
+}
+
+func (x *StorageWebDAVConfig) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *StorageWebDAVConfig) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type StorageSFTPConfig struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The address of the server, e.g. sftp.example.com:22.
+	Address  string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Username string `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	// The password or the private key authenticates the user.
+	Password string `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
+	// The private key in PEM format.
+	PrivateKey string `protobuf:"bytes,4,opt,name=private_key,json=privateKey,proto3" json:"private_key,omitempty"`
+	// The public key of the server in the authorized_keys format, to verify the server.
+	HostPublicKey string `protobuf:"bytes,5,opt,name=host_public_key,json=hostPublicKey,proto3" json:"host_public_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StorageSFTPConfig) Reset() {
+	*x = StorageSFTPConfig{}
+	mi := &file_store_workspace_setting_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StorageSFTPConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StorageSFTPConfig) ProtoMessage() {}
+
+func (x *StorageSFTPConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_store_workspace_setting_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StorageSFTPConfig.ProtoReflect.Descriptor instead.
+func (*StorageSFTPConfig) Descriptor() ([]byte, []int) {
+	return file_store_workspace_setting_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *StorageSFTPConfig) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *StorageSFTPConfig) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *StorageSFTPConfig) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *StorageSFTPConfig) GetPrivateKey() string {
+	if x != nil {
+		return x.PrivateKey
+	}
+	return ""
+}
+
+func (x *StorageSFTPConfig) GetHostPublicKey() string {
+	if x != nil {
+		return x.HostPublicKey
+	}
+	return ""
+}
+
 type WorkspaceMemoRelatedSetting struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// disallow_public_visibility disallows set memo as public visibility.
@@ -683,7 +1030,7 @@ type WorkspaceMemoRelatedSetting struct {
 
 func (x *WorkspaceMemoRelatedSetting) Reset() {
 	*x = WorkspaceMemoRelatedSetting{}
-	mi := &file_store_workspace_setting_proto_msgTypes[6]
+	mi := &file_store_workspace_setting_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -695,7 +1042,7 @@ func (x *WorkspaceMemoRelatedSetting) String() string {
 func (*WorkspaceMemoRelatedSetting) ProtoMessage() {}
 
 func (x *WorkspaceMemoRelatedSetting) ProtoReflect() protoreflect.Message {
-	mi := &file_store_workspace_setting_proto_msgTypes[6]
+	mi := &file_store_workspace_setting_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -708,7 +1055,7 @@ func (x *WorkspaceMemoRelatedSetting) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WorkspaceMemoRelatedSetting.ProtoReflect.Descriptor instead.
 func (*WorkspaceMemoRelatedSetting) Descriptor() ([]byte, []int) {
-	return file_store_workspace_setting_proto_rawDescGZIP(), []int{6}
+	return file_store_workspace_setting_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *WorkspaceMemoRelatedSetting) GetDisallowPublicVisibility() bool {
@@ -774,22 +1121,132 @@ func (x *WorkspaceMemoRelatedSetting) GetNsfwTags() []string {
 	return nil
 }
 
+type WorkspaceBackupSetting struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// enabled enables scheduled backups.
+	Enabled bool `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	// cron_spec is the schedule of the backups in the workspace timezone.
+	// e.g. "0 3 * * *" or "@daily"
+	CronSpec string `protobuf:"bytes,2,opt,name=cron_spec,json=cronSpec,proto3" json:"cron_spec,omitempty"`
+	// destination is where the backups are written.
+	Destination WorkspaceBackupSetting_Destination `protobuf:"varint,3,opt,name=destination,proto3,enum=memos.store.WorkspaceBackupSetting_Destination" json:"destination,omitempty"`
+	// directory is the directory of local backups, relative to the data directory unless absolute.
+	Directory string `protobuf:"bytes,4,opt,name=directory,proto3" json:"directory,omitempty"`
+	// s3_prefix is the key prefix of backups in the S3 bucket.
+	S3Prefix string `protobuf:"bytes,5,opt,name=s3_prefix,json=s3Prefix,proto3" json:"s3_prefix,omitempty"`
+	// keep_last keeps the most recent backups.
+	KeepLast int32 `protobuf:"varint,6,opt,name=keep_last,json=keepLast,proto3" json:"keep_last,omitempty"`
+	// keep_daily keeps the most recent backup of each of the most recent days.
+	KeepDaily int32 `protobuf:"varint,7,opt,name=keep_daily,json=keepDaily,proto3" json:"keep_daily,omitempty"`
+	// keep_weekly keeps the most recent backup of each of the most recent weeks.
+	KeepWeekly    int32 `protobuf:"varint,8,opt,name=keep_weekly,json=keepWeekly,proto3" json:"keep_weekly,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkspaceBackupSetting) Reset() {
+	*x = WorkspaceBackupSetting{}
+	mi := &file_store_workspace_setting_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkspaceBackupSetting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkspaceBackupSetting) ProtoMessage() {}
+
+func (x *WorkspaceBackupSetting) ProtoReflect() protoreflect.Message {
+	mi := &file_store_workspace_setting_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkspaceBackupSetting.ProtoReflect.Descriptor instead.
+func (*WorkspaceBackupSetting) Descriptor() ([]byte, []int) {
+	return file_store_workspace_setting_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *WorkspaceBackupSetting) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *WorkspaceBackupSetting) GetCronSpec() string {
+	if x != nil {
+		return x.CronSpec
+	}
+	return ""
+}
+
+func (x *WorkspaceBackupSetting) GetDestination() WorkspaceBackupSetting_Destination {
+	if x != nil {
+		return x.Destination
+	}
+	return WorkspaceBackupSetting_DESTINATION_UNSPECIFIED
+}
+
+func (x *WorkspaceBackupSetting) GetDirectory() string {
+	if x != nil {
+		return x.Directory
+	}
+	return ""
+}
+
+func (x *WorkspaceBackupSetting) GetS3Prefix() string {
+	if x != nil {
+		return x.S3Prefix
+	}
+	return ""
+}
+
+func (x *WorkspaceBackupSetting) GetKeepLast() int32 {
+	if x != nil {
+		return x.KeepLast
+	}
+	return 0
+}
+
+func (x *WorkspaceBackupSetting) GetKeepDaily() int32 {
+	if x != nil {
+		return x.KeepDaily
+	}
+	return 0
+}
+
+func (x *WorkspaceBackupSetting) GetKeepWeekly() int32 {
+	if x != nil {
+		return x.KeepWeekly
+	}
+	return 0
+}
+
 var File_store_workspace_setting_proto protoreflect.FileDescriptor
 
 const file_store_workspace_setting_proto_rawDesc = "" +
 	"\n" +
-	"\x1dstore/workspace_setting.proto\x12\vmemos.store\"\x9a\x03\n" +
+	"\x1dstore/workspace_setting.proto\x12\vmemos.store\"\xe8\x03\n" +
 	"\x10WorkspaceSetting\x122\n" +
 	"\x03key\x18\x01 \x01(\x0e2 .memos.store.WorkspaceSettingKeyR\x03key\x12I\n" +
 	"\rbasic_setting\x18\x02 \x01(\v2\".memos.store.WorkspaceBasicSettingH\x00R\fbasicSetting\x12O\n" +
 	"\x0fgeneral_setting\x18\x03 \x01(\v2$.memos.store.WorkspaceGeneralSettingH\x00R\x0egeneralSetting\x12O\n" +
 	"\x0fstorage_setting\x18\x04 \x01(\v2$.memos.store.WorkspaceStorageSettingH\x00R\x0estorageSetting\x12\\\n" +
-	"\x14memo_related_setting\x18\x05 \x01(\v2(.memos.store.WorkspaceMemoRelatedSettingH\x00R\x12memoRelatedSettingB\a\n" +
+	"\x14memo_related_setting\x18\x05 \x01(\v2(.memos.store.WorkspaceMemoRelatedSettingH\x00R\x12memoRelatedSetting\x12L\n" +
+	"\x0ebackup_setting\x18\x06 \x01(\v2#.memos.store.WorkspaceBackupSettingH\x00R\rbackupSettingB\a\n" +
 	"\x05value\"]\n" +
 	"\x15WorkspaceBasicSetting\x12\x1d\n" +
 	"\n" +
 	"secret_key\x18\x01 \x01(\tR\tsecretKey\x12%\n" +
-	"\x0eschema_version\x18\x02 \x01(\tR\rschemaVersion\"\xee\x03\n" +
+	"\x0eschema_version\x18\x02 \x01(\tR\rschemaVersion\"\x8a\x04\n" +
 	"\x17WorkspaceGeneralSetting\x12\x14\n" +
 	"\x05theme\x18\x01 \x01(\tR\x05theme\x12<\n" +
 	"\x1adisallow_user_registration\x18\x02 \x01(\bR\x18disallowUserRegistration\x124\n" +
@@ -799,29 +1256,64 @@ const file_store_workspace_setting_proto_rawDesc = "" +
 	"\x0ecustom_profile\x18\x06 \x01(\v2#.memos.store.WorkspaceCustomProfileR\rcustomProfile\x121\n" +
 	"\x15week_start_day_offset\x18\a \x01(\x05R\x12weekStartDayOffset\x128\n" +
 	"\x18disallow_change_username\x18\b \x01(\bR\x16disallowChangeUsername\x128\n" +
-	"\x18disallow_change_nickname\x18\t \x01(\bR\x16disallowChangeNickname\"\x83\x01\n" +
+	"\x18disallow_change_nickname\x18\t \x01(\bR\x16disallowChangeNickname\x12\x1a\n" +
+	"\btimezone\x18\n" +
+	" \x01(\tR\btimezone\"\x83\x01\n" +
 	"\x16WorkspaceCustomProfile\x12\x14\n" +
 	"\x05title\x18\x01 \x01(\tR\x05title\x12 \n" +
 	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x19\n" +
 	"\blogo_url\x18\x03 \x01(\tR\alogoUrl\x12\x16\n" +
-	"\x06locale\x18\x04 \x01(\tR\x06locale\"\xd5\x02\n" +
+	"\x06locale\x18\x04 \x01(\tR\x06locale\"\xd8\b\n" +
 	"\x17WorkspaceStorageSetting\x12S\n" +
 	"\fstorage_type\x18\x01 \x01(\x0e20.memos.store.WorkspaceStorageSetting.StorageTypeR\vstorageType\x12+\n" +
 	"\x11filepath_template\x18\x02 \x01(\tR\x10filepathTemplate\x12/\n" +
 	"\x14upload_size_limit_mb\x18\x03 \x01(\x03R\x11uploadSizeLimitMb\x129\n" +
-	"\ts3_config\x18\x04 \x01(\v2\x1c.memos.store.StorageS3ConfigR\bs3Config\"L\n" +
+	"\ts3_config\x18\x04 \x01(\v2\x1c.memos.store.StorageS3ConfigR\bs3Config\x12E\n" +
+	"\rwebdav_config\x18\x05 \x01(\v2 .memos.store.StorageWebDAVConfigR\fwebdavConfig\x12?\n" +
+	"\vsftp_config\x18\x06 \x01(\v2\x1e.memos.store.StorageSFTPConfigR\n" +
+	"sftpConfig\x12 \n" +
+	"\vdeduplicate\x18\a \x01(\bR\vdeduplicate\x12Y\n" +
+	"\rrole_quota_mb\x18\b \x03(\v25.memos.store.WorkspaceStorageSetting.RoleQuotaMbEntryR\vroleQuotaMb\x12Y\n" +
+	"\ruser_quota_mb\x18\t \x03(\v25.memos.store.WorkspaceStorageSetting.UserQuotaMbEntryR\vuserQuotaMb\x122\n" +
+	"\x15orphan_retention_days\x18\n" +
+	" \x01(\x05R\x13orphanRetentionDays\x12u\n" +
+	"\x18image_metadata_stripping\x18\v \x01(\x0e2;.memos.store.WorkspaceStorageSetting.ImageMetadataStrippingR\x16imageMetadataStripping\x1a>\n" +
+	"\x10RoleQuotaMbEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\x1a>\n" +
+	"\x10UserQuotaMbEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\x05R\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"b\n" +
 	"\vStorageType\x12\x1c\n" +
 	"\x18STORAGE_TYPE_UNSPECIFIED\x10\x00\x12\f\n" +
 	"\bDATABASE\x10\x01\x12\t\n" +
 	"\x05LOCAL\x10\x02\x12\x06\n" +
-	"\x02S3\x10\x03\"\xd3\x01\n" +
+	"\x02S3\x10\x03\x12\n" +
+	"\n" +
+	"\x06WEBDAV\x10\x04\x12\b\n" +
+	"\x04SFTP\x10\x05\"`\n" +
+	"\x16ImageMetadataStripping\x12(\n" +
+	"$IMAGE_METADATA_STRIPPING_UNSPECIFIED\x10\x00\x12\r\n" +
+	"\tSTRIP_GPS\x10\x01\x12\r\n" +
+	"\tSTRIP_ALL\x10\x02\"\xd3\x01\n" +
 	"\x0fStorageS3Config\x12\"\n" +
 	"\raccess_key_id\x18\x01 \x01(\tR\vaccessKeyId\x12*\n" +
 	"\x11access_key_secret\x18\x02 \x01(\tR\x0faccessKeySecret\x12\x1a\n" +
 	"\bendpoint\x18\x03 \x01(\tR\bendpoint\x12\x16\n" +
 	"\x06region\x18\x04 \x01(\tR\x06region\x12\x16\n" +
 	"\x06bucket\x18\x05 \x01(\tR\x06bucket\x12$\n" +
-	"\x0euse_path_style\x18\x06 \x01(\bR\fusePathStyle\"\xe1\x03\n" +
+	"\x0euse_path_style\x18\x06 \x01(\bR\fusePathStyle\"i\n" +
+	"\x13StorageWebDAVConfig\x12\x1a\n" +
+	"\bendpoint\x18\x01 \x01(\tR\bendpoint\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x1a\n" +
+	"\bpassword\x18\x03 \x01(\tR\bpassword\"\xae\x01\n" +
+	"\x11StorageSFTPConfig\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x1a\n" +
+	"\bpassword\x18\x03 \x01(\tR\bpassword\x12\x1f\n" +
+	"\vprivate_key\x18\x04 \x01(\tR\n" +
+	"privateKey\x12&\n" +
+	"\x0fhost_public_key\x18\x05 \x01(\tR\rhostPublicKey\"\xe1\x03\n" +
 	"\x1bWorkspaceMemoRelatedSetting\x12<\n" +
 	"\x1adisallow_public_visibility\x18\x01 \x01(\bR\x18disallowPublicVisibility\x127\n" +
 	"\x18display_with_update_time\x18\x02 \x01(\bR\x15displayWithUpdateTime\x120\n" +
@@ -832,13 +1324,30 @@ const file_store_workspace_setting_proto_rawDesc = "" +
 	"\x1adisable_markdown_shortcuts\x18\b \x01(\bR\x18disableMarkdownShortcuts\x127\n" +
 	"\x18enable_blur_nsfw_content\x18\t \x01(\bR\x15enableBlurNsfwContent\x12\x1b\n" +
 	"\tnsfw_tags\x18\n" +
-	" \x03(\tR\bnsfwTags*s\n" +
+	" \x03(\tR\bnsfwTags\"\xf9\x02\n" +
+	"\x16WorkspaceBackupSetting\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12\x1b\n" +
+	"\tcron_spec\x18\x02 \x01(\tR\bcronSpec\x12Q\n" +
+	"\vdestination\x18\x03 \x01(\x0e2/.memos.store.WorkspaceBackupSetting.DestinationR\vdestination\x12\x1c\n" +
+	"\tdirectory\x18\x04 \x01(\tR\tdirectory\x12\x1b\n" +
+	"\ts3_prefix\x18\x05 \x01(\tR\bs3Prefix\x12\x1b\n" +
+	"\tkeep_last\x18\x06 \x01(\x05R\bkeepLast\x12\x1d\n" +
+	"\n" +
+	"keep_daily\x18\a \x01(\x05R\tkeepDaily\x12\x1f\n" +
+	"\vkeep_weekly\x18\b \x01(\x05R\n" +
+	"keepWeekly\"=\n" +
+	"\vDestination\x12\x1b\n" +
+	"\x17DESTINATION_UNSPECIFIED\x10\x00\x12\t\n" +
+	"\x05LOCAL\x10\x01\x12\x06\n" +
+	"\x02S3\x10\x02*\x7f\n" +
 	"\x13WorkspaceSettingKey\x12%\n" +
 	"!WORKSPACE_SETTING_KEY_UNSPECIFIED\x10\x00\x12\t\n" +
 	"\x05BASIC\x10\x01\x12\v\n" +
 	"\aGENERAL\x10\x02\x12\v\n" +
 	"\aSTORAGE\x10\x03\x12\x10\n" +
-	"\fMEMO_RELATED\x10\x04B\xa0\x01\n" +
+	"\fMEMO_RELATED\x10\x04\x12\n" +
+	"\n" +
+	"\x06BACKUP\x10\x05B\xa0\x01\n" +
 	"\x0fcom.memos.storeB\x15WorkspaceSettingProtoP\x01Z)github.com/usememos/memos/proto/gen/store\xa2\x02\x03MSX\xaa\x02\vMemos.Store\xca\x02\vMemos\\Store\xe2\x02\x17Memos\\Store\\GPBMetadata\xea\x02\fMemos::Storeb\x06proto3"
 
 var (
@@ -853,33 +1362,47 @@ func file_store_workspace_setting_proto_rawDescGZIP() []byte {
 	return file_store_workspace_setting_proto_rawDescData
 }
 
-var file_store_workspace_setting_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_store_workspace_setting_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_store_workspace_setting_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_store_workspace_setting_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
 var file_store_workspace_setting_proto_goTypes = []any{
-	(WorkspaceSettingKey)(0),                 // 0: memos.store.WorkspaceSettingKey
-	(WorkspaceStorageSetting_StorageType)(0), // 1: memos.store.WorkspaceStorageSetting.StorageType
-	(*WorkspaceSetting)(nil),                 // 2: memos.store.WorkspaceSetting
-	(*WorkspaceBasicSetting)(nil),            // 3: memos.store.WorkspaceBasicSetting
-	(*WorkspaceGeneralSetting)(nil),          // 4: memos.store.WorkspaceGeneralSetting
-	(*WorkspaceCustomProfile)(nil),           // 5: memos.store.WorkspaceCustomProfile
-	(*WorkspaceStorageSetting)(nil),          // 6: memos.store.WorkspaceStorageSetting
-	(*StorageS3Config)(nil),                  // 7: memos.store.StorageS3Config
-	(*WorkspaceMemoRelatedSetting)(nil),      // 8: memos.store.WorkspaceMemoRelatedSetting
+	(WorkspaceSettingKey)(0),                            // 0: memos.store.WorkspaceSettingKey
+	(WorkspaceStorageSetting_StorageType)(0),            // 1: memos.store.WorkspaceStorageSetting.StorageType
+	(WorkspaceStorageSetting_ImageMetadataStripping)(0), // 2: memos.store.WorkspaceStorageSetting.ImageMetadataStripping
+	(WorkspaceBackupSetting_Destination)(0),             // 3: memos.store.WorkspaceBackupSetting.Destination
+	(*WorkspaceSetting)(nil),                            // 4: memos.store.WorkspaceSetting
+	(*WorkspaceBasicSetting)(nil),                       // 5: memos.store.WorkspaceBasicSetting
+	(*WorkspaceGeneralSetting)(nil),                     // 6: memos.store.WorkspaceGeneralSetting
+	(*WorkspaceCustomProfile)(nil),                      // 7: memos.store.WorkspaceCustomProfile
+	(*WorkspaceStorageSetting)(nil),                     // 8: memos.store.WorkspaceStorageSetting
+	(*StorageS3Config)(nil),                             // 9: memos.store.StorageS3Config
+	(*StorageWebDAVConfig)(nil),                         // 10: memos.store.StorageWebDAVConfig
+	(*StorageSFTPConfig)(nil),                           // 11: memos.store.StorageSFTPConfig
+	(*WorkspaceMemoRelatedSetting)(nil),                 // 12: memos.store.WorkspaceMemoRelatedSetting
+	(*WorkspaceBackupSetting)(nil),                      // 13: memos.store.WorkspaceBackupSetting
+	nil,                                                 // 14: memos.store.WorkspaceStorageSetting.RoleQuotaMbEntry
+	nil,                                                 // 15: memos.store.WorkspaceStorageSetting.UserQuotaMbEntry
 }
 var file_store_workspace_setting_proto_depIdxs = []int32{
-	0, // 0: memos.store.WorkspaceSetting.key:type_name -> memos.store.WorkspaceSettingKey
-	3, // 1: memos.store.WorkspaceSetting.basic_setting:type_name -> memos.store.WorkspaceBasicSetting
-	4, // 2: memos.store.WorkspaceSetting.general_setting:type_name -> memos.store.WorkspaceGeneralSetting
-	6, // 3: memos.store.WorkspaceSetting.storage_setting:type_name -> memos.store.WorkspaceStorageSetting
-	8, // 4: memos.store.WorkspaceSetting.memo_related_setting:type_name -> memos.store.WorkspaceMemoRelatedSetting
-	5, // 5: memos.store.WorkspaceGeneralSetting.custom_profile:type_name -> memos.store.WorkspaceCustomProfile
-	1, // 6: memos.store.WorkspaceStorageSetting.storage_type:type_name -> memos.store.WorkspaceStorageSetting.StorageType
-	7, // 7: memos.store.WorkspaceStorageSetting.s3_config:type_name -> memos.store.StorageS3Config
-	8, // [8:8] is the sub-list for method output_type
-	8, // [8:8] is the sub-list for method input_type
-	8, // [8:8] is the sub-list for extension type_name
-	8, // [8:8] is the sub-list for extension extendee
-	0, // [0:8] is the sub-list for field type_name
+	0,  // 0: memos.store.WorkspaceSetting.key:type_name -> memos.store.WorkspaceSettingKey
+	5,  // 1: memos.store.WorkspaceSetting.basic_setting:type_name -> memos.store.WorkspaceBasicSetting
+	6,  // 2: memos.store.WorkspaceSetting.general_setting:type_name -> memos.store.WorkspaceGeneralSetting
+	8,  // 3: memos.store.WorkspaceSetting.storage_setting:type_name -> memos.store.WorkspaceStorageSetting
+	12, // 4: memos.store.WorkspaceSetting.memo_related_setting:type_name -> memos.store.WorkspaceMemoRelatedSetting
+	13, // 5: memos.store.WorkspaceSetting.backup_setting:type_name -> memos.store.WorkspaceBackupSetting
+	7,  // 6: memos.store.WorkspaceGeneralSetting.custom_profile:type_name -> memos.store.WorkspaceCustomProfile
+	1,  // 7: memos.store.WorkspaceStorageSetting.storage_type:type_name -> memos.store.WorkspaceStorageSetting.StorageType
+	9,  // 8: memos.store.WorkspaceStorageSetting.s3_config:type_name -> memos.store.StorageS3Config
+	10, // 9: memos.store.WorkspaceStorageSetting.webdav_config:type_name -> memos.store.StorageWebDAVConfig
+	11, // 10: memos.store.WorkspaceStorageSetting.sftp_config:type_name -> memos.store.StorageSFTPConfig
+	14, // 11: memos.store.WorkspaceStorageSetting.role_quota_mb:type_name -> memos.store.WorkspaceStorageSetting.RoleQuotaMbEntry
+	15, // 12: memos.store.WorkspaceStorageSetting.user_quota_mb:type_name -> memos.store.WorkspaceStorageSetting.UserQuotaMbEntry
+	2,  // 13: memos.store.WorkspaceStorageSetting.image_metadata_stripping:type_name -> memos.store.WorkspaceStorageSetting.ImageMetadataStripping
+	3,  // 14: memos.store.WorkspaceBackupSetting.destination:type_name -> memos.store.WorkspaceBackupSetting.Destination
+	15, // [15:15] is the sub-list for method output_type
+	15, // [15:15] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
 }
 
 func init() { file_store_workspace_setting_proto_init() }
@@ -892,14 +1415,15 @@ func file_store_workspace_setting_proto_init() {
 		(*WorkspaceSetting_GeneralSetting)(nil),
 		(*WorkspaceSetting_StorageSetting)(nil),
 		(*WorkspaceSetting_MemoRelatedSetting)(nil),
+		(*WorkspaceSetting_BackupSetting)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_store_workspace_setting_proto_rawDesc), len(file_store_workspace_setting_proto_rawDesc)),
-			NumEnums:      2,
-			NumMessages:   7,
+			NumEnums:      4,
+			NumMessages:   12,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -38,39 +38,48 @@ func (s *Service) DispatchMemoWebhooks(ctx context.Context, memo *v1pb.Memo, act
     }
 
     for _, h := range hooks {
-        typ, target := classifyWebhook(h)
-        hostKey := hostKeyFor(target)
-        release := acquire(hostKey)
-        go func(typ webhookType, target string, hostKey string, release func()) {
-            defer release()
-            start := time.Now()
-            var err error
-            switch typ {
-            case webhookTypeWeCom:
-                err = sendWithRetry(ctx, hostKey, func() error { return sendWeCom(ctx, target, memo, activityType) })
-            case webhookTypeBark:
-                err = sendWithRetry(ctx, hostKey, func() error { return sendBark(ctx, target, memo, activityType) })
-            default:
-                payload, perr := convertMemoToWebhookPayload(memo)
-                if perr != nil {
-                    slog.Warn("convert payload failed", slog.Any("err", perr))
-                    return
-                }
-                payload.ActivityType = activityType
-                payload.URL = target
-                err = sendWithRetry(ctx, hostKey, func() error { return webhook.Post(payload) })
-            }
-            duration := time.Since(start)
-            if err != nil {
-                slog.Warn("Webhook dispatch failed", slog.String("type", string(typ)), slog.String("url", target), slog.Duration("latency", duration), slog.Any("err", err))
-            } else {
-                slog.Info("Webhook dispatched", slog.String("type", string(typ)), slog.String("url", target), slog.Duration("latency", duration))
-            }
-        }(typ, target, hostKey, release)
+        s.dispatchWebhook(ctx, h, memo, activityType)
     }
     return nil
 }
 
+// DispatchShortcutWebhook sends the memo that newly matches a shortcut to the given user webhook.
+func (s *Service) DispatchShortcutWebhook(ctx context.Context, h *storepb.WebhooksUserSetting_Webhook, memo *v1pb.Memo) {
+    s.dispatchWebhook(ctx, h, memo, "memos.shortcut.matched")
+}
+
+func (*Service) dispatchWebhook(ctx context.Context, h *storepb.WebhooksUserSetting_Webhook, memo *v1pb.Memo, activityType string) {
+    typ, target := classifyWebhook(h)
+    hostKey := hostKeyFor(target)
+    release := acquire(hostKey)
+    go func(typ webhookType, target string, hostKey string, release func()) {
+        defer release()
+        start := time.Now()
+        var err error
+        switch typ {
+        case webhookTypeWeCom:
+            err = sendWithRetry(ctx, hostKey, func() error { return sendWeCom(ctx, target, memo, activityType) })
+        case webhookTypeBark:
+            err = sendWithRetry(ctx, hostKey, func() error { return sendBark(ctx, target, memo, activityType) })
+        default:
+            payload, perr := convertMemoToWebhookPayload(memo)
+            if perr != nil {
+                slog.Warn("convert payload failed", slog.Any("err", perr))
+                return
+            }
+            payload.ActivityType = activityType
+            payload.URL = target
+            err = sendWithRetry(ctx, hostKey, func() error { return webhook.Post(payload) })
+        }
+        duration := time.Since(start)
+        if err != nil {
+            slog.Warn("Webhook dispatch failed", slog.String("type", string(typ)), slog.String("url", target), slog.Duration("latency", duration), slog.Any("err", err))
+        } else {
+            slog.Info("Webhook dispatched", slog.String("type", string(typ)), slog.String("url", target), slog.Duration("latency", duration))
+        }
+    }(typ, target, hostKey, release)
+}
+
 func classifyWebhook(h *storepb.WebhooksUserSetting_Webhook) (webhookType, string) {
     raw := strings.TrimSpace(h.GetUrl())
     if raw == "" {
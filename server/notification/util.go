@@ -84,6 +84,8 @@ func activityTitle(activity string) string {
         return "Memo Updated"
     case "memos.memo.deleted":
         return "Memo Deleted"
+    case "memos.shortcut.matched":
+        return "Shortcut Matched"
     default:
         return activity
     }
@@ -47,6 +47,34 @@ func NewGRPCAuthInterceptor(store *store.Store, secret string) *GRPCAuthIntercep
 
 // AuthenticationInterceptor is the unary interceptor for gRPC API.
 func (in *GRPCAuthInterceptor) AuthenticationInterceptor(ctx context.Context, request any, serverInfo *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, err := in.authenticate(ctx, serverInfo.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, request)
+}
+
+// StreamAuthenticationInterceptor is the stream interceptor for gRPC API.
+func (in *GRPCAuthInterceptor) StreamAuthenticationInterceptor(srv any, stream grpc.ServerStream, serverInfo *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := in.authenticate(stream.Context(), serverInfo.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &authenticatedServerStream{ServerStream: stream, ctx: ctx})
+}
+
+// authenticatedServerStream overrides the context of a server stream with the authenticated one.
+type authenticatedServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// authenticate returns the context of the authenticated user for the method.
+func (in *GRPCAuthInterceptor) authenticate(ctx context.Context, fullMethod string) (context.Context, error) {
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
 		return nil, status.Errorf(codes.Unauthenticated, "failed to parse metadata from incoming context")
@@ -61,7 +89,7 @@ func (in *GRPCAuthInterceptor) AuthenticationInterceptor(ctx context.Context, re
 			if parseErr != nil {
 				return nil, status.Errorf(codes.Internal, "failed to parse session cookie: %v", parseErr)
 			}
-			return in.handleAuthenticatedRequest(ctx, request, serverInfo, handler, user, sessionID, "")
+			return in.handleAuthenticatedRequest(ctx, fullMethod, user, sessionID, "")
 		}
 	}
 
@@ -69,26 +97,45 @@ func (in *GRPCAuthInterceptor) AuthenticationInterceptor(ctx context.Context, re
 	if accessToken, err := getAccessTokenFromMetadata(md); err == nil && accessToken != "" {
 		user, err := in.authenticateByJWT(ctx, accessToken)
 		if err == nil && user != nil {
-			return in.handleAuthenticatedRequest(ctx, request, serverInfo, handler, user, "", accessToken)
+			return in.handleAuthenticatedRequest(ctx, fullMethod, user, "", accessToken)
 		}
 	}
 
 	// If no valid authentication found, check if this method is in the allowlist (public endpoints)
-	if isUnauthorizeAllowedMethod(serverInfo.FullMethod) {
-		return handler(ctx, request)
+	if isUnauthorizeAllowedMethod(fullMethod) {
+		return ctx, nil
 	}
 
 	// If authentication is required but not found, reject the request
 	return nil, status.Errorf(codes.Unauthenticated, "authentication required")
 }
 
-// handleAuthenticatedRequest processes an authenticated request with the given user and auth info.
-func (in *GRPCAuthInterceptor) handleAuthenticatedRequest(ctx context.Context, request any, serverInfo *grpc.UnaryServerInfo, handler grpc.UnaryHandler, user *store.User, sessionID, accessToken string) (any, error) {
+// authenticateHTTPRequest returns the user of the session cookie or the access token of an HTTP
+// request served outside of the gateway, or nil for anonymous requests.
+func (in *GRPCAuthInterceptor) authenticateHTTPRequest(ctx context.Context, header http.Header) *store.User {
+	md := metadata.MD{}
+	md.Set("cookie", header.Values("Cookie")...)
+	md.Set("authorization", header.Values("Authorization")...)
+	if sessionCookieValue, err := getSessionIDFromMetadata(md); err == nil && sessionCookieValue != "" {
+		if user, err := in.authenticateBySession(ctx, sessionCookieValue); err == nil && user != nil {
+			return user
+		}
+	}
+	if accessToken, err := getAccessTokenFromMetadata(md); err == nil && accessToken != "" {
+		if user, err := in.authenticateByJWT(ctx, accessToken); err == nil && user != nil {
+			return user
+		}
+	}
+	return nil
+}
+
+// handleAuthenticatedRequest returns the context of an authenticated request with the given user and auth info.
+func (in *GRPCAuthInterceptor) handleAuthenticatedRequest(ctx context.Context, fullMethod string, user *store.User, sessionID, accessToken string) (context.Context, error) {
 	// Check user status
 	if user.RowStatus == store.Archived {
 		return nil, errors.Errorf("user %q is archived", user.Username)
 	}
-	if isOnlyForAdminAllowedMethod(serverInfo.FullMethod) && user.Role != store.RoleHost && user.Role != store.RoleAdmin {
+	if isOnlyForAdminAllowedMethod(fullMethod) && user.Role != store.RoleHost && user.Role != store.RoleAdmin {
 		return nil, errors.Errorf("user %q is not admin", user.Username)
 	}
 
@@ -105,7 +152,7 @@ func (in *GRPCAuthInterceptor) handleAuthenticatedRequest(ctx context.Context, r
 		ctx = context.WithValue(ctx, accessTokenContextKey, accessToken)
 	}
 
-	return handler(ctx, request)
+	return ctx, nil
 }
 
 // authenticateByJWT authenticates a user using JWT access token from Authorization header.
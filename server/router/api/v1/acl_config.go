@@ -14,6 +14,7 @@ var authenticationAllowlistMethods = map[string]bool{
 	"/memos.api.v1.UserService/SearchUsers":                       true,
 	"/memos.api.v1.MemoService/GetMemo":                           true,
 	"/memos.api.v1.MemoService/ListMemos":                         true,
+	"/memos.api.v1.MemoService/ListMemoLocations":                 true,
 	"/memos.api.v1.MarkdownService/GetLinkMetadata":               true,
 	"/memos.api.v1.AttachmentService/GetAttachmentBinary":         true,
 }
@@ -26,6 +27,12 @@ func isUnauthorizeAllowedMethod(fullMethodName string) bool {
 var allowedMethodsOnlyForAdmin = map[string]bool{
 	"/memos.api.v1.UserService/CreateUser":                  true,
 	"/memos.api.v1.WorkspaceService/UpdateWorkspaceSetting": true,
+	"/memos.api.v1.WorkspaceService/ListBackups":            true,
+	"/memos.api.v1.WorkspaceService/MigrateAttachments":     true,
+	"/memos.api.v1.WorkspaceService/GetAttachmentMigration": true,
+	"/memos.api.v1.AttachmentService/VerifyAttachments":     true,
+	"/memos.api.v1.AttachmentService/CleanupAttachments":    true,
+	"/memos.api.v1.UserService/ListStorageUsage":            true,
 }
 
 // isOnlyForAdminAllowedMethod returns true if the method is allowed to be called only by admin.
@@ -75,6 +75,8 @@ func (s *APIV1Service) convertActivityFromStore(ctx context.Context, activity *s
 	switch activity.Type {
 	case store.ActivityTypeMemoComment:
 		activityType = v1pb.Activity_MEMO_COMMENT
+	case store.ActivityTypeShortcutMatch:
+		activityType = v1pb.Activity_SHORTCUT_MATCH
 	default:
 		activityType = v1pb.Activity_TYPE_UNSPECIFIED
 	}
@@ -125,5 +127,23 @@ func (s *APIV1Service) convertActivityPayloadFromStore(ctx context.Context, payl
 			},
 		}
 	}
+	if payload.ShortcutMatch != nil {
+		memo, err := s.Store.GetMemo(ctx, &store.FindMemo{
+			ID:             &payload.ShortcutMatch.MemoId,
+			ExcludeContent: true,
+		})
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get memo: %v", err)
+		}
+		if memo == nil {
+			return nil, status.Errorf(codes.NotFound, "memo does not exist")
+		}
+		v2Payload.Payload = &v1pb.ActivityPayload_ShortcutMatch{
+			ShortcutMatch: &v1pb.ActivityShortcutMatchPayload{
+				Memo:     fmt.Sprintf("%s%s", MemoNamePrefix, memo.UID),
+				Shortcut: payload.ShortcutMatch.Shortcut,
+			},
+		}
+	}
 	return v2Payload, nil
 }
@@ -0,0 +1,257 @@
+package v1
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/lithammer/shortuuid/v4"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/usememos/memos/internal/util"
+	"github.com/usememos/memos/plugin/exif"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+// maxUploadFieldSize is the max size of the form fields of attachment uploads, except the file.
+const maxUploadFieldSize = 1024
+
+// registerFileRoutes registers the HTTP routes that stream the content of attachments from and to
+// the storage, instead of buffering it in gRPC messages.
+func (s *APIV1Service) registerFileRoutes(g *echo.Group) {
+	g.POST("/file/attachments", s.uploadAttachment)
+	g.GET("/file/attachments/:uid/:filename", s.downloadAttachment)
+	g.HEAD("/file/attachments/:uid/:filename", s.downloadAttachment)
+}
+
+// uploadAttachment creates an attachment from a multipart/form-data request with the fields:
+//   - attachment_id: optional, the ID of the attachment.
+//   - memo: optional, the name of the memo of the attachment, e.g. memos/{memo}.
+//   - file: the content of the attachment, with its filename and content type.
+//
+// The file is streamed to the storage, so the other fields must come before it.
+func (s *APIV1Service) uploadAttachment(c echo.Context) error {
+	ctx := c.Request().Context()
+	user := NewGRPCAuthInterceptor(s.Store, s.Secret).authenticateHTTPRequest(ctx, c.Request().Header)
+	if user == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "user not authenticated")
+	}
+
+	workspaceStorageSetting, err := s.Store.GetWorkspaceStorageSetting(ctx)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get workspace storage setting").SetInternal(err)
+	}
+	uploadSizeLimit := int64(workspaceStorageSetting.UploadSizeLimitMb) * MebiByte
+	if uploadSizeLimit == 0 {
+		uploadSizeLimit = MaxUploadBufferSizeBytes
+	}
+	// The remaining storage quota of the user limits the upload when it is below the size limit.
+	remainingQuota, err := s.getRemainingStorageQuota(ctx, user)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get storage quota").SetInternal(err)
+	}
+	limitedByQuota := remainingQuota >= 0 && remainingQuota < uploadSizeLimit
+	if limitedByQuota {
+		uploadSizeLimit = remainingQuota
+	}
+	reader, err := c.Request().MultipartReader()
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid multipart form").SetInternal(err)
+	}
+
+	create := &store.Attachment{
+		UID:       shortuuid.New(),
+		CreatorID: user.ID,
+	}
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return echo.NewHTTPError(http.StatusBadRequest, "file is required")
+		}
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "invalid multipart form").SetInternal(err)
+		}
+
+		switch part.FormName() {
+		case "attachment_id":
+			value, err := readUploadField(part)
+			if err != nil {
+				return err
+			}
+			if value != "" {
+				create.UID = value
+			}
+		case "memo":
+			value, err := readUploadField(part)
+			if err != nil {
+				return err
+			}
+			memoUID, err := ExtractMemoUIDFromName(value)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, "invalid memo name").SetInternal(err)
+			}
+			memo, err := s.Store.GetMemo(ctx, &store.FindMemo{UID: &memoUID})
+			if err != nil {
+				return echo.NewHTTPError(http.StatusInternalServerError, "failed to find memo").SetInternal(err)
+			}
+			if memo == nil {
+				return echo.NewHTTPError(http.StatusNotFound, "memo not found")
+			}
+			create.MemoID = &memo.ID
+		case "file":
+			create.Filename = filepath.Base(part.FileName())
+			if create.Filename == "" || create.Filename == "." {
+				return echo.NewHTTPError(http.StatusBadRequest, "filename is required")
+			}
+			create.Type = getUploadContentType(part.Header.Get(echo.HeaderContentType), create.Filename)
+			content := &uploadReader{Reader: part, limit: uploadSizeLimit}
+			if err := SaveAttachmentContent(ctx, s.Store, create, content); err != nil {
+				if content.exceeded && limitedByQuota {
+					return echo.NewHTTPError(http.StatusRequestEntityTooLarge, "storage quota exceeded")
+				}
+				if content.exceeded {
+					return echo.NewHTTPError(http.StatusRequestEntityTooLarge, "file size exceeds the limit")
+				}
+				if errors.Is(err, exif.ErrUnsupported) {
+					return echo.NewHTTPError(http.StatusUnsupportedMediaType, "image metadata cannot be stripped").SetInternal(err)
+				}
+				return echo.NewHTTPError(http.StatusInternalServerError, "failed to save attachment content").SetInternal(err)
+			}
+			attachment, err := s.Store.CreateAttachment(ctx, create)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusInternalServerError, "failed to create attachment").SetInternal(err)
+			}
+			s.generateThumbnailsInBackground(attachment)
+			data, err := protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(convertAttachmentFromStore(attachment))
+			if err != nil {
+				return echo.NewHTTPError(http.StatusInternalServerError, "failed to marshal attachment").SetInternal(err)
+			}
+			return c.JSONBlob(http.StatusOK, data)
+		}
+	}
+}
+
+// downloadAttachment streams the content of an attachment with support for range and conditional requests.
+func (s *APIV1Service) downloadAttachment(c echo.Context) error {
+	ctx := c.Request().Context()
+	attachmentUID := c.Param("uid")
+	attachment, err := s.Store.GetAttachment(ctx, &store.FindAttachment{UID: &attachmentUID})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get attachment").SetInternal(err)
+	}
+	if attachment == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "attachment not found")
+	}
+	user := NewGRPCAuthInterceptor(s.Store, s.Secret).authenticateHTTPRequest(ctx, c.Request().Header)
+	public, err := s.checkAttachmentAccess(ctx, attachment, user)
+	if err != nil {
+		if status.Code(err) == codes.Unauthenticated {
+			return echo.NewHTTPError(http.StatusUnauthorized, "unauthorized access")
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to check attachment access").SetInternal(err)
+	}
+	if attachment.StorageType == storepb.AttachmentStorageType_EXTERNAL {
+		return c.Redirect(http.StatusFound, attachment.Reference)
+	}
+
+	thumbnailSize, thumbnail := parseThumbnailSize(c.QueryParam("thumbnail"))
+	thumbnail = thumbnail && util.HasPrefixes(attachment.Type, SupportedThumbnailMimeTypes...)
+	etag := getAttachmentETag(attachment, "")
+	if thumbnail {
+		etag = getAttachmentETag(attachment, thumbnailSize.Name)
+	}
+	lastModified := time.Unix(attachment.UpdatedTs, 0)
+	header := c.Response().Header()
+	header.Set("ETag", etag)
+	header.Set(echo.HeaderCacheControl, getAttachmentCacheControl(public))
+	header.Set(echo.HeaderXContentTypeOptions, "nosniff")
+	// Answer conditional requests before opening the content.
+	if util.IsNotModified(c.Request().Header.Get("If-None-Match"), c.Request().Header.Get(echo.HeaderIfModifiedSince), etag, lastModified) {
+		header.Set(echo.HeaderLastModified, lastModified.UTC().Format(http.TimeFormat))
+		return c.NoContent(http.StatusNotModified)
+	}
+	// Load the blob of database attachments only when the content is sent.
+	if attachment.StorageType == storepb.AttachmentStorageType_ATTACHMENT_STORAGE_TYPE_UNSPECIFIED {
+		attachment, err = s.Store.GetAttachment(ctx, &store.FindAttachment{GetBlob: true, ID: &attachment.ID})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "failed to get attachment").SetInternal(err)
+		}
+		if attachment == nil {
+			return echo.NewHTTPError(http.StatusNotFound, "attachment not found")
+		}
+	}
+
+	if thumbnail {
+		thumbnailBlob, err := s.getOrGenerateThumbnail(ctx, attachment, thumbnailSize)
+		if err == nil {
+			header.Set(echo.HeaderContentType, "image/webp")
+			http.ServeContent(c.Response(), c.Request(), attachment.Filename, lastModified, bytes.NewReader(thumbnailBlob))
+			return nil
+		}
+		// The attachment is served in place of a thumbnail that fails.
+		slog.Warn("failed to get attachment thumbnail image", slog.Any("error", err))
+		header.Set("ETag", getAttachmentETag(attachment, ""))
+	}
+
+	content, err := s.OpenAttachmentContent(ctx, attachment)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to open attachment content").SetInternal(err)
+	}
+	defer content.Close()
+	header.Set(echo.HeaderContentType, getAttachmentContentType(attachment))
+	http.ServeContent(c.Response(), c.Request(), attachment.Filename, lastModified, content)
+	return nil
+}
+
+func readUploadField(part io.Reader) (string, error) {
+	value, err := io.ReadAll(io.LimitReader(part, maxUploadFieldSize+1))
+	if err != nil {
+		return "", echo.NewHTTPError(http.StatusBadRequest, "invalid multipart form").SetInternal(err)
+	}
+	if len(value) > maxUploadFieldSize {
+		return "", echo.NewHTTPError(http.StatusBadRequest, "form field is too long")
+	}
+	return strings.TrimSpace(string(value)), nil
+}
+
+// getUploadContentType returns the content type of an uploaded file from its part header,
+// or from its extension when the client sends none.
+func getUploadContentType(contentType, filename string) string {
+	if contentType != "" && contentType != "application/octet-stream" {
+		return contentType
+	}
+	if contentType := mime.TypeByExtension(filepath.Ext(filename)); contentType != "" {
+		return contentType
+	}
+	return "application/octet-stream"
+}
+
+// uploadReader fails the upload of files larger than the limit.
+type uploadReader struct {
+	io.Reader
+	limit    int64
+	read     int64
+	exceeded bool
+}
+
+func (r *uploadReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	r.read += int64(n)
+	if r.read > r.limit {
+		r.exceeded = true
+		return n, errUploadTooLarge
+	}
+	return n, err
+}
+
+var errUploadTooLarge = errors.New("file size exceeds the limit")
@@ -0,0 +1,175 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/internal/profile"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+	teststore "github.com/usememos/memos/store/test"
+)
+
+func TestAttachmentFileRoutes(t *testing.T) {
+	ctx := context.Background()
+	testStore := teststore.NewTestingStore(ctx, t)
+	defer testStore.Close()
+	service := &APIV1Service{
+		Secret:  "test-secret",
+		Profile: &profile.Profile{Mode: "dev", Data: t.TempDir()},
+		Store:   testStore,
+	}
+	assetsDir := t.TempDir()
+	_, err := testStore.UpsertWorkspaceSetting(ctx, &storepb.WorkspaceSetting{
+		Key: storepb.WorkspaceSettingKey_STORAGE,
+		Value: &storepb.WorkspaceSetting_StorageSetting{StorageSetting: &storepb.WorkspaceStorageSetting{
+			StorageType:       storepb.WorkspaceStorageSetting_LOCAL,
+			FilepathTemplate:  filepath.ToSlash(filepath.Join(assetsDir, "{filename}")),
+			UploadSizeLimitMb: 1,
+		}},
+	})
+	require.NoError(t, err)
+
+	user, err := testStore.CreateUser(ctx, &store.User{Username: "user", Role: store.RoleUser, Email: "user@example.com"})
+	require.NoError(t, err)
+	accessToken, err := GenerateAccessToken(user.Username, user.ID, time.Now().Add(time.Hour), []byte(service.Secret))
+	require.NoError(t, err)
+	require.NoError(t, service.UpsertAccessTokenToStore(ctx, user, accessToken, "test"))
+	memo, err := testStore.CreateMemo(ctx, &store.Memo{UID: "memo", CreatorID: user.ID, Content: "video", Visibility: store.Private})
+	require.NoError(t, err)
+
+	e := echo.New()
+	service.registerFileRoutes(e.Group(""))
+	serve := func(req *http.Request) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+	upload := func(filename string, content []byte, authorized bool) *httptest.ResponseRecorder {
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		require.NoError(t, writer.WriteField("memo", MemoNamePrefix+memo.UID))
+		part, err := writer.CreateFormFile("file", filename)
+		require.NoError(t, err)
+		_, err = part.Write(content)
+		require.NoError(t, err)
+		require.NoError(t, writer.Close())
+		req := httptest.NewRequest(http.MethodPost, "/file/attachments", body)
+		req.Header.Set(echo.HeaderContentType, writer.FormDataContentType())
+		if authorized {
+			req.Header.Set(echo.HeaderAuthorization, "Bearer "+accessToken)
+		}
+		return serve(req)
+	}
+
+	content := []byte(strings.Repeat("0123456789", 1000))
+	rec := upload("video.mp4", content, true)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	response := map[string]any{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	require.Equal(t, "video.mp4", response["filename"])
+	require.Equal(t, "video/mp4", response["type"])
+	attachmentName, ok := response["name"].(string)
+	require.True(t, ok)
+	attachmentUID := strings.TrimPrefix(attachmentName, AttachmentNamePrefix)
+
+	attachment, err := testStore.GetAttachment(ctx, &store.FindAttachment{UID: &attachmentUID})
+	require.NoError(t, err)
+	require.Equal(t, storepb.AttachmentStorageType_LOCAL, attachment.StorageType)
+	require.Equal(t, int64(len(content)), attachment.Size)
+	require.Equal(t, memo.ID, *attachment.MemoID)
+	stored, err := os.ReadFile(filepath.Join(assetsDir, "video.mp4"))
+	require.NoError(t, err)
+	require.Equal(t, content, stored)
+
+	t.Run("Upload requires authentication", func(t *testing.T) {
+		rec := upload("file.txt", []byte("content"), false)
+		require.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+
+	t.Run("Upload exceeding the size limit", func(t *testing.T) {
+		rec := upload("large.bin", bytes.Repeat([]byte{0}, MebiByte+1), true)
+		require.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+		list, err := testStore.ListAttachments(ctx, &store.FindAttachment{CreatorID: &user.ID})
+		require.NoError(t, err)
+		require.Len(t, list, 1)
+	})
+
+	t.Run("Upload exceeding the storage quota", func(t *testing.T) {
+		setting, err := testStore.GetWorkspaceStorageSetting(ctx)
+		require.NoError(t, err)
+		setting.UserQuotaMb = map[int32]int64{user.ID: 1}
+		_, err = testStore.UpsertWorkspaceSetting(ctx, &storepb.WorkspaceSetting{
+			Key:   storepb.WorkspaceSettingKey_STORAGE,
+			Value: &storepb.WorkspaceSetting_StorageSetting{StorageSetting: setting},
+		})
+		require.NoError(t, err)
+		// The file is below the size limit, but the earlier upload leaves less than 1 MiB of the quota.
+		rec := upload("large.bin", bytes.Repeat([]byte{0}, MebiByte-len(content)+1), true)
+		require.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+		require.Contains(t, rec.Body.String(), "storage quota exceeded")
+		rec = upload("small.bin", bytes.Repeat([]byte{0}, MebiByte-len(content)), true)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	})
+
+	target := "/file/" + attachmentName + "/video.mp4"
+	t.Run("Download requires access", func(t *testing.T) {
+		rec := serve(httptest.NewRequest(http.MethodGet, target, nil))
+		require.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+
+	authorizedRequest := func(method string) *http.Request {
+		req := httptest.NewRequest(method, target, nil)
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+accessToken)
+		return req
+	}
+
+	t.Run("Download full content", func(t *testing.T) {
+		rec := serve(authorizedRequest(http.MethodGet))
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Equal(t, content, rec.Body.Bytes())
+		require.Equal(t, "video/mp4", rec.Header().Get(echo.HeaderContentType))
+		require.Equal(t, "bytes", rec.Header().Get("Accept-Ranges"))
+		require.NotEmpty(t, rec.Header().Get("ETag"))
+	})
+
+	t.Run("Download a range", func(t *testing.T) {
+		req := authorizedRequest(http.MethodGet)
+		req.Header.Set("Range", "bytes=5000-5009")
+		rec := serve(req)
+		require.Equal(t, http.StatusPartialContent, rec.Code)
+		require.Equal(t, content[5000:5010], rec.Body.Bytes())
+		require.Equal(t, "bytes 5000-5009/10000", rec.Header().Get("Content-Range"))
+	})
+
+	t.Run("Head request", func(t *testing.T) {
+		rec := serve(authorizedRequest(http.MethodHead))
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Equal(t, "10000", rec.Header().Get(echo.HeaderContentLength))
+		require.Empty(t, rec.Body.Bytes())
+	})
+
+	t.Run("Conditional request", func(t *testing.T) {
+		rec := serve(authorizedRequest(http.MethodGet))
+		req := authorizedRequest(http.MethodGet)
+		req.Header.Set("If-None-Match", rec.Header().Get("ETag"))
+		rec = serve(req)
+		require.Equal(t, http.StatusNotModified, rec.Code)
+		body, err := io.ReadAll(rec.Body)
+		require.NoError(t, err)
+		require.Empty(t, body)
+	})
+}
@@ -0,0 +1,111 @@
+package v1
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	"github.com/usememos/memos/store"
+)
+
+// attachmentMigrationJob keeps the state of the last attachment migration of the instance.
+type attachmentMigrationJob struct {
+	mutex     sync.Mutex
+	migration *v1pb.AttachmentMigration
+}
+
+// MigrateAttachments starts migrating the attachments to the storage of the workspace storage
+// setting in the background. The state of the migration is returned by GetAttachmentMigration.
+func (s *APIV1Service) MigrateAttachments(ctx context.Context, request *v1pb.MigrateAttachmentsRequest) (*v1pb.AttachmentMigration, error) {
+	user, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get current user: %v", err)
+	}
+	if user == nil {
+		return nil, status.Errorf(codes.Unauthenticated, "user not authenticated")
+	}
+	if !isSuperUser(user) {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+	if request.BatchSize < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "batch size must not be negative")
+	}
+
+	job := &s.attachmentMigration
+	job.mutex.Lock()
+	defer job.mutex.Unlock()
+	if job.migration != nil && job.migration.State == v1pb.AttachmentMigration_RUNNING {
+		return nil, status.Errorf(codes.FailedPrecondition, "attachment migration is already running")
+	}
+	job.migration = &v1pb.AttachmentMigration{
+		State:     v1pb.AttachmentMigration_RUNNING,
+		Failures:  []string{},
+		StartTime: timestamppb.Now(),
+	}
+	migration := proto.Clone(job.migration).(*v1pb.AttachmentMigration)
+
+	// The migration outlives the request, so it does not use the context of the request.
+	go s.runAttachmentMigration(context.Background(), int(request.BatchSize))
+	return migration, nil
+}
+
+// GetAttachmentMigration gets the state of the last attachment migration.
+func (s *APIV1Service) GetAttachmentMigration(ctx context.Context, _ *v1pb.GetAttachmentMigrationRequest) (*v1pb.AttachmentMigration, error) {
+	user, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get current user: %v", err)
+	}
+	if user == nil {
+		return nil, status.Errorf(codes.Unauthenticated, "user not authenticated")
+	}
+	if !isSuperUser(user) {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+
+	job := &s.attachmentMigration
+	job.mutex.Lock()
+	defer job.mutex.Unlock()
+	if job.migration == nil {
+		return nil, status.Errorf(codes.NotFound, "attachment migration not found")
+	}
+	return proto.Clone(job.migration).(*v1pb.AttachmentMigration), nil
+}
+
+func (s *APIV1Service) runAttachmentMigration(ctx context.Context, batchSize int) {
+	job := &s.attachmentMigration
+	updateProgress := func(progress *store.AttachmentMigrationProgress) {
+		job.migration.Total = int32(progress.Total)
+		job.migration.Migrated = int32(progress.Migrated)
+		job.migration.Failed = int32(progress.Failed)
+		job.migration.Failures = append([]string{}, progress.Failures...)
+	}
+	progress, err := s.Store.MigrateAttachments(ctx, store.MigrateAttachmentsOptions{
+		BatchSize: batchSize,
+		OnProgress: func(progress *store.AttachmentMigrationProgress) {
+			job.mutex.Lock()
+			defer job.mutex.Unlock()
+			updateProgress(progress)
+		},
+	})
+
+	job.mutex.Lock()
+	defer job.mutex.Unlock()
+	if progress != nil {
+		updateProgress(progress)
+	}
+	job.migration.EndTime = timestamppb.New(time.Now())
+	if err != nil {
+		slog.Error("failed to migrate attachments", slog.Any("error", err))
+		job.migration.State = v1pb.AttachmentMigration_FAILED
+		job.migration.Error = err.Error()
+		return
+	}
+	job.migration.State = v1pb.AttachmentMigration_SUCCEEDED
+}
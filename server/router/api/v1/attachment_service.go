@@ -7,14 +7,9 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
-	"os"
-	"path/filepath"
-	"regexp"
-	"strconv"
 	"strings"
 	"time"
 
-	"github.com/disintegration/imaging"
 	"github.com/lithammer/shortuuid/v4"
 	"github.com/pkg/errors"
 	"google.golang.org/genproto/googleapis/api/httpbody"
@@ -25,9 +20,9 @@ import (
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
-	"github.com/usememos/memos/internal/profile"
 	"github.com/usememos/memos/internal/util"
-	"github.com/usememos/memos/plugin/storage/s3"
+	"github.com/usememos/memos/plugin/exif"
+	"github.com/usememos/memos/plugin/storage"
 	v1pb "github.com/usememos/memos/proto/gen/api/v1"
 	storepb "github.com/usememos/memos/proto/gen/store"
 	"github.com/usememos/memos/store"
@@ -39,15 +34,13 @@ const (
 	// This is unrelated to maximum upload size limit, which is now set through system setting.
 	MaxUploadBufferSizeBytes = 32 << 20
 	MebiByte                 = 1024 * 1024
+	// MaxMessageSizeBytes is the maximum size of received gRPC messages, which bounds the content of
+	// the attachments created with CreateAttachment to the upload memory buffer.
+	MaxMessageSizeBytes = MaxUploadBufferSizeBytes + MebiByte
 	// ThumbnailCacheFolder is the folder name where the thumbnail images are stored.
 	ThumbnailCacheFolder = ".thumbnail_cache"
 )
 
-var SupportedThumbnailMimeTypes = []string{
-	"image/png",
-	"image/jpeg",
-}
-
 func (s *APIV1Service) CreateAttachment(ctx context.Context, request *v1pb.CreateAttachmentRequest) (*v1pb.Attachment, error) {
 	user, err := s.GetCurrentUser(ctx)
 	if err != nil {
@@ -93,10 +86,20 @@ func (s *APIV1Service) CreateAttachment(ctx context.Context, request *v1pb.Creat
 	if size > uploadSizeLimit {
 		return nil, status.Errorf(codes.InvalidArgument, "file size exceeds the limit")
 	}
+	remainingQuota, err := s.getRemainingStorageQuota(ctx, user)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get storage quota: %v", err)
+	}
+	if remainingQuota >= 0 && int64(size) > remainingQuota {
+		return nil, status.Errorf(codes.ResourceExhausted, "storage quota exceeded")
+	}
 	create.Size = int64(size)
 	create.Blob = request.Attachment.Content
 
-	if err := SaveAttachmentBlob(ctx, s.Profile, s.Store, create); err != nil {
+	if err := SaveAttachmentBlob(ctx, s.Store, create); err != nil {
+		if errors.Is(err, exif.ErrUnsupported) {
+			return nil, status.Errorf(codes.InvalidArgument, "image metadata cannot be stripped: %v", err)
+		}
 		return nil, status.Errorf(codes.Internal, "failed to save attachment blob: %v", err)
 	}
 
@@ -118,6 +121,7 @@ func (s *APIV1Service) CreateAttachment(ctx context.Context, request *v1pb.Creat
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to create attachment: %v", err)
 	}
+	s.generateThumbnailsInBackground(attachment)
 
 	return convertAttachmentFromStore(attachment), nil
 }
@@ -218,14 +222,15 @@ func (s *APIV1Service) GetAttachment(ctx context.Context, request *v1pb.GetAttac
 	return convertAttachmentFromStore(attachment), nil
 }
 
+// GetAttachmentBinary returns the content of an attachment to gRPC clients.
+// HTTP clients download attachments from the streaming handler of the file routes.
 func (s *APIV1Service) GetAttachmentBinary(ctx context.Context, request *v1pb.GetAttachmentBinaryRequest) (*httpbody.HttpBody, error) {
 	attachmentUID, err := ExtractAttachmentUIDFromName(request.Name)
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "invalid attachment id: %v", err)
 	}
 	attachment, err := s.Store.GetAttachment(ctx, &store.FindAttachment{
-		GetBlob: true,
-		UID:     &attachmentUID,
+		UID: &attachmentUID,
 	})
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to get attachment: %v", err)
@@ -233,100 +238,124 @@ func (s *APIV1Service) GetAttachmentBinary(ctx context.Context, request *v1pb.Ge
 	if attachment == nil {
 		return nil, status.Errorf(codes.NotFound, "attachment not found")
 	}
-	// Check the related memo visibility.
-	if attachment.MemoID != nil {
-		memo, err := s.Store.GetMemo(ctx, &store.FindMemo{
-			ID: attachment.MemoID,
+	user, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get current user: %v", err)
+	}
+	public, err := s.checkAttachmentAccess(ctx, attachment, user)
+	if err != nil {
+		return nil, err
+	}
+
+	thumbnailSize, thumbnail := parseThumbnailSize(request.ThumbnailSize)
+	if !thumbnail && request.Thumbnail {
+		thumbnailSize, thumbnail = thumbnailSizeMedium, true
+	}
+	thumbnail = thumbnail && util.HasPrefixes(attachment.Type, SupportedThumbnailMimeTypes...)
+	etag := getAttachmentETag(attachment, "")
+	if thumbnail {
+		etag = getAttachmentETag(attachment, thumbnailSize.Name)
+	}
+	if setCacheHeaders(ctx, cacheValidators{
+		etag:         etag,
+		lastModified: time.Unix(attachment.UpdatedTs, 0),
+		cacheControl: getAttachmentCacheControl(public),
+	}) {
+		return notModifiedResponse(), nil
+	}
+	// Load the blob of database attachments only when the content is sent.
+	if attachment.StorageType == storepb.AttachmentStorageType_ATTACHMENT_STORAGE_TYPE_UNSPECIFIED {
+		attachment, err = s.Store.GetAttachment(ctx, &store.FindAttachment{
+			GetBlob: true,
+			ID:      &attachment.ID,
 		})
 		if err != nil {
-			return nil, status.Errorf(codes.Internal, "failed to find memo by ID: %v", attachment.MemoID)
+			return nil, status.Errorf(codes.Internal, "failed to get attachment: %v", err)
 		}
-		if memo != nil && memo.Visibility != store.Public {
-			user, err := s.GetCurrentUser(ctx)
-			if err != nil {
-				return nil, status.Errorf(codes.Internal, "failed to get current user: %v", err)
-			}
-			if user == nil {
-				return nil, status.Errorf(codes.Unauthenticated, "unauthorized access")
-			}
-			if memo.Visibility == store.Private && user.ID != attachment.CreatorID {
-				return nil, status.Errorf(codes.Unauthenticated, "unauthorized access")
-			}
+		if attachment == nil {
+			return nil, status.Errorf(codes.NotFound, "attachment not found")
 		}
 	}
 
-	if request.Thumbnail && util.HasPrefixes(attachment.Type, SupportedThumbnailMimeTypes...) {
-		thumbnailBlob, err := s.getOrGenerateThumbnail(attachment)
+	if thumbnail {
+		thumbnailBlob, err := s.getOrGenerateThumbnail(ctx, attachment, thumbnailSize)
 		if err != nil {
 			// thumbnail failures are logged as warnings and not cosidered critical failures as
 			// a attachment image can be used in its place.
 			slog.Warn("failed to get attachment thumbnail image", slog.Any("error", err))
 		} else {
 			return &httpbody.HttpBody{
-				ContentType: attachment.Type,
+				ContentType: "image/webp",
 				Data:        thumbnailBlob,
 			}, nil
 		}
 	}
 
-	blob, err := s.GetAttachmentBlob(attachment)
+	blob, err := s.GetAttachmentBlob(ctx, attachment)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to get attachment blob: %v", err)
 	}
+	return &httpbody.HttpBody{
+		ContentType: getAttachmentContentType(attachment),
+		Data:        blob,
+	}, nil
+}
 
-	contentType := attachment.Type
-	if strings.HasPrefix(contentType, "text/") {
-		contentType += "; charset=utf-8"
+// checkAttachmentAccess checks that the user, nil for anonymous users, can read the attachment
+// through the visibility of its memo, and returns whether the attachment is public.
+func (s *APIV1Service) checkAttachmentAccess(ctx context.Context, attachment *store.Attachment, user *store.User) (bool, error) {
+	if attachment.MemoID == nil {
+		return false, nil
 	}
-	// Prevent XSS attacks by serving potentially unsafe files with a content type that prevents script execution.
-	if strings.EqualFold(contentType, "image/svg+xml") ||
-		strings.EqualFold(contentType, "text/html") ||
-		strings.EqualFold(contentType, "application/xhtml+xml") {
-		contentType = "application/octet-stream"
+	memo, err := s.Store.GetMemo(ctx, &store.FindMemo{
+		ID: attachment.MemoID,
+	})
+	if err != nil {
+		return false, status.Errorf(codes.Internal, "failed to find memo by ID: %v", attachment.MemoID)
 	}
-
-	// Extract range header from gRPC metadata for iOS Safari video support
-	var rangeHeader string
-	if md, ok := metadata.FromIncomingContext(ctx); ok {
-		// Check for range header from gRPC-Gateway
-		if ranges := md.Get("grpcgateway-range"); len(ranges) > 0 {
-			rangeHeader = ranges[0]
-		} else if ranges := md.Get("range"); len(ranges) > 0 {
-			rangeHeader = ranges[0]
-		}
-
-		// Log for debugging iOS Safari issues
-		if userAgents := md.Get("user-agent"); len(userAgents) > 0 {
-			userAgent := userAgents[0]
-			if strings.Contains(strings.ToLower(userAgent), "safari") && rangeHeader != "" {
-				slog.Debug("Safari range request detected",
-					slog.String("range", rangeHeader),
-					slog.String("user-agent", userAgent),
-					slog.String("content-type", contentType))
-			}
-		}
+	if memo == nil || memo.Visibility == store.Public {
+		return memo != nil, nil
+	}
+	if user == nil {
+		return false, status.Errorf(codes.Unauthenticated, "unauthorized access")
 	}
+	if memo.Visibility == store.Private && user.ID != attachment.CreatorID {
+		return false, status.Errorf(codes.Unauthenticated, "unauthorized access")
+	}
+	return false, nil
+}
 
-	// Handle range requests for video/audio streaming (iOS Safari requirement)
-	if rangeHeader != "" && (strings.HasPrefix(contentType, "video/") || strings.HasPrefix(contentType, "audio/")) {
-		return s.handleRangeRequest(ctx, blob, rangeHeader, contentType)
+// getAttachmentETag returns the entity tag of the content of the attachment, or of its thumbnail
+// of the size unless the size is empty.
+func getAttachmentETag(attachment *store.Attachment, thumbnailSize string) string {
+	if thumbnailSize != "" {
+		return fmt.Sprintf(`"%s-%d-%d-thumbnail-%s"`, attachment.UID, attachment.UpdatedTs, attachment.Size, thumbnailSize)
 	}
+	return fmt.Sprintf(`"%s-%d-%d"`, attachment.UID, attachment.UpdatedTs, attachment.Size)
+}
 
-	// Set headers for streaming support
-	if strings.HasPrefix(contentType, "video/") || strings.HasPrefix(contentType, "audio/") {
-		if err := setResponseHeaders(ctx, map[string]string{
-			"accept-ranges":  "bytes",
-			"content-length": fmt.Sprintf("%d", len(blob)),
-			"cache-control":  "public, max-age=3600", // 1 hour cache
-		}); err != nil {
-			slog.Warn("failed to set streaming headers", slog.Any("error", err))
-		}
+// getAttachmentCacheControl returns the cache control of attachments. Only the attachments of
+// public memos may be stored by shared caches.
+func getAttachmentCacheControl(public bool) string {
+	if public {
+		return "public, max-age=3600"
 	}
+	return "private, max-age=3600"
+}
 
-	return &httpbody.HttpBody{
-		ContentType: contentType,
-		Data:        blob,
-	}, nil
+// getAttachmentContentType returns the content type to serve the attachment with.
+func getAttachmentContentType(attachment *store.Attachment) string {
+	contentType := attachment.Type
+	// Prevent XSS attacks by serving potentially unsafe files with a content type that prevents script execution.
+	if strings.EqualFold(contentType, "image/svg+xml") ||
+		strings.EqualFold(contentType, "text/html") ||
+		strings.EqualFold(contentType, "application/xhtml+xml") {
+		return "application/octet-stream"
+	}
+	if strings.HasPrefix(contentType, "text/") {
+		contentType += "; charset=utf-8"
+	}
+	return contentType
 }
 
 func (s *APIV1Service) UpdateAttachment(ctx context.Context, request *v1pb.UpdateAttachmentRequest) (*v1pb.Attachment, error) {
@@ -392,6 +421,88 @@ func (s *APIV1Service) DeleteAttachment(ctx context.Context, request *v1pb.Delet
 	return &emptypb.Empty{}, nil
 }
 
+// VerifyAttachments checks the checksums of the contents of all attachments.
+func (s *APIV1Service) VerifyAttachments(ctx context.Context, _ *v1pb.VerifyAttachmentsRequest) (*v1pb.VerifyAttachmentsResponse, error) {
+	user, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get current user: %v", err)
+	}
+	if user == nil {
+		return nil, status.Errorf(codes.Unauthenticated, "user not authenticated")
+	}
+	if !isSuperUser(user) {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+
+	verification, err := s.Store.VerifyAttachments(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to verify attachments: %v", err)
+	}
+	response := &v1pb.VerifyAttachmentsResponse{
+		Total:    int32(verification.Total),
+		Recorded: int32(verification.Recorded),
+		Issues:   []*v1pb.VerifyAttachmentsResponse_Issue{},
+	}
+	for _, issue := range verification.Issues {
+		response.Issues = append(response.Issues, &v1pb.VerifyAttachmentsResponse_Issue{
+			Attachment: fmt.Sprintf("%s%s", AttachmentNamePrefix, issue.AttachmentUID),
+			Problem:    v1pb.VerifyAttachmentsResponse_Issue_Problem(v1pb.VerifyAttachmentsResponse_Issue_Problem_value[string(issue.Problem)]),
+			Detail:     issue.Detail,
+		})
+	}
+	return response, nil
+}
+
+// defaultOrphanRetentionDays is the retention of a cleanup without retention in the request or the storage setting.
+const defaultOrphanRetentionDays = 7
+
+// CleanupAttachments deletes the attachments without memo, the local files without attachment
+// and the thumbnails of deleted attachments, or only reports them on a dry run.
+func (s *APIV1Service) CleanupAttachments(ctx context.Context, request *v1pb.CleanupAttachmentsRequest) (*v1pb.CleanupAttachmentsResponse, error) {
+	user, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get current user: %v", err)
+	}
+	if user == nil {
+		return nil, status.Errorf(codes.Unauthenticated, "user not authenticated")
+	}
+	if !isSuperUser(user) {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+	if request.RetentionDays < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid retention days")
+	}
+
+	retentionDays := request.RetentionDays
+	if retentionDays == 0 {
+		workspaceStorageSetting, err := s.Store.GetWorkspaceStorageSetting(ctx)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get workspace storage setting: %v", err)
+		}
+		retentionDays = workspaceStorageSetting.OrphanRetentionDays
+	}
+	if retentionDays <= 0 {
+		retentionDays = defaultOrphanRetentionDays
+	}
+	garbage, err := s.Store.CollectAttachmentGarbage(ctx, store.CollectAttachmentGarbageOptions{
+		Retention: time.Duration(retentionDays) * 24 * time.Hour,
+		DryRun:    request.DryRun,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to cleanup attachments: %v", err)
+	}
+	response := &v1pb.CleanupAttachmentsResponse{
+		Attachments: []string{},
+		Files:       garbage.Files,
+		Thumbnails:  garbage.Thumbnails,
+		Size:        garbage.Size,
+	}
+	for _, attachment := range garbage.Attachments {
+		response.Attachments = append(response.Attachments, fmt.Sprintf("%s%s", AttachmentNamePrefix, attachment.UID))
+	}
+	return response, nil
+}
+
 func convertAttachmentFromStore(attachment *store.Attachment) *v1pb.Attachment {
 	attachmentMessage := &v1pb.Attachment{
 		Name:       fmt.Sprintf("%s%s", AttachmentNamePrefix, attachment.UID),
@@ -399,6 +510,16 @@ func convertAttachmentFromStore(attachment *store.Attachment) *v1pb.Attachment {
 		Filename:   attachment.Filename,
 		Type:       attachment.Type,
 		Size:       attachment.Size,
+		Sha256:     attachment.Sha256,
+	}
+	if imageMetadata := attachment.Payload.GetImageMetadata(); imageMetadata != nil {
+		attachmentMessage.ImageMetadata = &v1pb.Attachment_ImageMetadata{
+			Width:       imageMetadata.Width,
+			Height:      imageMetadata.Height,
+			CaptureTime: imageMetadata.CaptureTime,
+			CameraMake:  imageMetadata.CameraMake,
+			CameraModel: imageMetadata.CameraModel,
+		}
 	}
 	if attachment.MemoUID != nil && *attachment.MemoUID != "" {
 		memoName := fmt.Sprintf("%s%s", MemoNamePrefix, *attachment.MemoUID)
@@ -412,260 +533,126 @@ func convertAttachmentFromStore(attachment *store.Attachment) *v1pb.Attachment {
 }
 
 // SaveAttachmentBlob save the blob of attachment based on the storage config.
-func SaveAttachmentBlob(ctx context.Context, profile *profile.Profile, stores *store.Store, create *store.Attachment) error {
+func SaveAttachmentBlob(ctx context.Context, stores *store.Store, create *store.Attachment) error {
+	return SaveAttachmentContent(ctx, stores, create, bytes.NewReader(create.Blob))
+}
+
+// SaveAttachmentContent streams the content of attachment to the storage of the storage config
+// and sets the size of the attachment to the size of the content.
+func SaveAttachmentContent(ctx context.Context, stores *store.Store, create *store.Attachment, content io.Reader) error {
 	workspaceStorageSetting, err := stores.GetWorkspaceStorageSetting(ctx)
 	if err != nil {
 		return errors.Wrap(err, "Failed to find workspace storage setting")
 	}
+	// Date placeholders in the filepath template are resolved in the uploader's timezone.
+	location, err := stores.GetUserLocation(ctx, create.CreatorID)
+	if err != nil {
+		return errors.Wrap(err, "Failed to get user location")
+	}
 
-	if workspaceStorageSetting.StorageType == storepb.WorkspaceStorageSetting_LOCAL {
-		filepathTemplate := "assets/{timestamp}_{filename}"
-		if workspaceStorageSetting.FilepathTemplate != "" {
-			filepathTemplate = workspaceStorageSetting.FilepathTemplate
-		}
-
-		internalPath := filepathTemplate
-		if !strings.Contains(internalPath, "{filename}") {
-			internalPath = filepath.Join(internalPath, "{filename}")
-		}
-		internalPath = replaceFilenameWithPathTemplate(internalPath, create.Filename)
-		internalPath = filepath.ToSlash(internalPath)
-
-		// Ensure the directory exists.
-		osPath := filepath.FromSlash(internalPath)
-		if !filepath.IsAbs(osPath) {
-			osPath = filepath.Join(profile.Data, osPath)
-		}
-		dir := filepath.Dir(osPath)
-		if err = os.MkdirAll(dir, os.ModePerm); err != nil {
-			return errors.Wrap(err, "Failed to create directory")
-		}
-		dst, err := os.Create(osPath)
-		if err != nil {
-			return errors.Wrap(err, "Failed to create file")
-		}
-		defer dst.Close()
-
-		// Write the blob to the file.
-		if err := os.WriteFile(osPath, create.Blob, 0644); err != nil {
-			return errors.Wrap(err, "Failed to write file")
-		}
-		create.Reference = internalPath
-		create.Blob = nil
-		create.StorageType = storepb.AttachmentStorageType_LOCAL
-	} else if workspaceStorageSetting.StorageType == storepb.WorkspaceStorageSetting_S3 {
-		s3Config := workspaceStorageSetting.S3Config
-		if s3Config == nil {
-			return errors.Errorf("No actived external storage found")
-		}
-		s3Client, err := s3.NewClient(ctx, s3Config)
-		if err != nil {
-			return errors.Wrap(err, "Failed to create s3 client")
-		}
-
-		filepathTemplate := workspaceStorageSetting.FilepathTemplate
-		if !strings.Contains(filepathTemplate, "{filename}") {
-			filepathTemplate = filepath.Join(filepathTemplate, "{filename}")
-		}
-		filepathTemplate = replaceFilenameWithPathTemplate(filepathTemplate, create.Filename)
-		key, err := s3Client.UploadObject(ctx, filepathTemplate, create.Type, bytes.NewReader(create.Blob))
-		if err != nil {
-			return errors.Wrap(err, "Failed to upload via s3 client")
-		}
-		presignURL, err := s3Client.PresignGetObject(ctx, key)
-		if err != nil {
-			return errors.Wrap(err, "Failed to presign via s3 client")
-		}
-
-		create.Reference = presignURL
-		create.Blob = nil
-		create.StorageType = storepb.AttachmentStorageType_S3
-		create.Payload = &storepb.AttachmentPayload{
-			Payload: &storepb.AttachmentPayload_S3Object_{
-				S3Object: &storepb.AttachmentPayload_S3Object{
-					S3Config:          s3Config,
-					Key:               key,
-					LastPresignedTime: timestamppb.New(time.Now()),
-				},
-			},
-		}
+	// The metadata of images is read, and stripped, while the content is streamed to the storage.
+	content, metadata, err := exif.Process(content, create.Type, getImageMetadataStrip(workspaceStorageSetting.ImageMetadataStripping), location)
+	if err != nil {
+		return errors.Wrap(err, "Failed to process image metadata")
 	}
 
-	return nil
+	key := store.NewAttachmentKey(workspaceStorageSetting, create.Filename, time.Now().In(location))
+	if err := stores.PutAttachmentContent(ctx, workspaceStorageSetting, create, key, content); err != nil {
+		return errors.Wrap(err, "Failed to save attachment content")
+	}
+	return setAttachmentImageMetadata(ctx, stores, create, metadata)
 }
 
-func (s *APIV1Service) GetAttachmentBlob(attachment *store.Attachment) ([]byte, error) {
-	// For local storage, read the file from the local disk.
-	if attachment.StorageType == storepb.AttachmentStorageType_LOCAL {
-		attachmentPath := filepath.FromSlash(attachment.Reference)
-		if !filepath.IsAbs(attachmentPath) {
-			attachmentPath = filepath.Join(s.Profile.Data, attachmentPath)
-		}
-
-		file, err := os.Open(attachmentPath)
-		if err != nil {
-			if os.IsNotExist(err) {
-				return nil, errors.Wrap(err, "file not found")
+// setAttachmentImageMetadata sets the image metadata of the payload of attachment from the metadata
+// read from its content, if any.
+func setAttachmentImageMetadata(ctx context.Context, stores *store.Store, create *store.Attachment, metadata *exif.Metadata) error {
+	if metadata != nil {
+		imageMetadata := &storepb.AttachmentPayload_ImageMetadata{
+			Width:       int32(metadata.Width),
+			Height:      int32(metadata.Height),
+			CameraMake:  metadata.CameraMake,
+			CameraModel: metadata.CameraModel,
+		}
+		if !metadata.CaptureTime.IsZero() {
+			imageMetadata.CaptureTime = timestamppb.New(metadata.CaptureTime)
+		}
+		if metadata.Location != nil {
+			userSetting, err := stores.GetUserSetting(ctx, &store.FindUserSetting{
+				UserID: &create.CreatorID,
+				Key:    storepb.UserSetting_GENERAL,
+			})
+			if err != nil {
+				return errors.Wrap(err, "Failed to get user general setting")
+			}
+			// The GPS location is only kept for users who fill the location of memos from their photos.
+			if userSetting.GetGeneral().GetLocationFromPhotos() {
+				imageMetadata.Location = &storepb.AttachmentPayload_Location{
+					Latitude:  metadata.Location.Latitude,
+					Longitude: metadata.Location.Longitude,
+				}
 			}
-			return nil, errors.Wrap(err, "failed to open the file")
 		}
-		defer file.Close()
-		blob, err := io.ReadAll(file)
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to read the file")
+		if create.Payload == nil {
+			create.Payload = &storepb.AttachmentPayload{}
 		}
-		return blob, nil
+		create.Payload.ImageMetadata = imageMetadata
 	}
-	// For database storage, return the blob from the database.
-	return attachment.Blob, nil
+	return nil
 }
 
-const (
-	// thumbnailRatio is the ratio of the thumbnail image.
-	thumbnailRatio = 0.8
-)
-
-// getOrGenerateThumbnail returns the thumbnail image of the attachment.
-func (s *APIV1Service) getOrGenerateThumbnail(attachment *store.Attachment) ([]byte, error) {
-	thumbnailCacheFolder := filepath.Join(s.Profile.Data, ThumbnailCacheFolder)
-	if err := os.MkdirAll(thumbnailCacheFolder, os.ModePerm); err != nil {
-		return nil, errors.Wrap(err, "failed to create thumbnail cache folder")
-	}
-	filePath := filepath.Join(thumbnailCacheFolder, fmt.Sprintf("%d%s", attachment.ID, filepath.Ext(attachment.Filename)))
-	if _, err := os.Stat(filePath); err != nil {
-		if !os.IsNotExist(err) {
-			return nil, errors.Wrap(err, "failed to check thumbnail image stat")
-		}
-
-		// If thumbnail image does not exist, generate and save the thumbnail image.
-		blob, err := s.GetAttachmentBlob(attachment)
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to get attachment blob")
-		}
-		img, err := imaging.Decode(bytes.NewReader(blob), imaging.AutoOrientation(true))
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to decode thumbnail image")
-		}
-
-		thumbnailWidth := int(float64(img.Bounds().Dx()) * thumbnailRatio)
-		// Resize the image to the thumbnailWidth.
-		thumbnailImage := imaging.Resize(img, thumbnailWidth, 0, imaging.Lanczos)
-		if err := imaging.Save(thumbnailImage, filePath); err != nil {
-			return nil, errors.Wrap(err, "failed to save thumbnail file")
-		}
+// getImageMetadataStrip returns the metadata that is stripped from images with the stripping setting.
+func getImageMetadataStrip(stripping storepb.WorkspaceStorageSetting_ImageMetadataStripping) exif.Strip {
+	switch stripping {
+	case storepb.WorkspaceStorageSetting_STRIP_GPS:
+		return exif.StripGPS
+	case storepb.WorkspaceStorageSetting_STRIP_ALL:
+		return exif.StripAll
+	default:
+		return exif.StripNone
 	}
+}
 
-	thumbnailFile, err := os.Open(filePath)
+// GetAttachmentBlob returns the content of the attachment.
+func (s *APIV1Service) GetAttachmentBlob(ctx context.Context, attachment *store.Attachment) ([]byte, error) {
+	content, err := s.OpenAttachmentContent(ctx, attachment)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to open thumbnail file")
+		return nil, err
 	}
-	defer thumbnailFile.Close()
-	blob, err := io.ReadAll(thumbnailFile)
+	defer content.Close()
+	blob, err := io.ReadAll(content)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to read thumbnail file")
+		return nil, errors.Wrap(err, "failed to read the content")
 	}
 	return blob, nil
 }
 
-var fileKeyPattern = regexp.MustCompile(`\{[a-z]{1,9}\}`)
-
-func replaceFilenameWithPathTemplate(path, filename string) string {
-	t := time.Now()
-	path = fileKeyPattern.ReplaceAllStringFunc(path, func(s string) string {
-		switch s {
-		case "{filename}":
-			return filename
-		case "{timestamp}":
-			return fmt.Sprintf("%d", t.Unix())
-		case "{year}":
-			return fmt.Sprintf("%d", t.Year())
-		case "{month}":
-			return fmt.Sprintf("%02d", t.Month())
-		case "{day}":
-			return fmt.Sprintf("%02d", t.Day())
-		case "{hour}":
-			return fmt.Sprintf("%02d", t.Hour())
-		case "{minute}":
-			return fmt.Sprintf("%02d", t.Minute())
-		case "{second}":
-			return fmt.Sprintf("%02d", t.Second())
-		case "{uuid}":
-			return util.GenUUID()
-		default:
-			return s
-		}
-	})
-	return path
-}
-
-// handleRangeRequest handles HTTP range requests for video/audio streaming (iOS Safari requirement).
-func (*APIV1Service) handleRangeRequest(ctx context.Context, data []byte, rangeHeader, contentType string) (*httpbody.HttpBody, error) {
-	// Parse "bytes=start-end"
-	if !strings.HasPrefix(rangeHeader, "bytes=") {
-		return nil, status.Errorf(codes.InvalidArgument, "invalid range header format")
+// OpenAttachmentContent opens the content of the attachment for reading with seeking.
+// The blob of database attachments must be loaded.
+func (s *APIV1Service) OpenAttachmentContent(ctx context.Context, attachment *store.Attachment) (io.ReadSeekCloser, error) {
+	if attachment.StorageType == storepb.AttachmentStorageType_EXTERNAL {
+		return nil, errors.New("external attachments have no content")
 	}
-
-	rangeSpec := strings.TrimPrefix(rangeHeader, "bytes=")
-	parts := strings.Split(rangeSpec, "-")
-	if len(parts) != 2 {
-		return nil, status.Errorf(codes.InvalidArgument, "invalid range specification")
+	// For database storage, read the blob from the database.
+	if attachment.StorageType == storepb.AttachmentStorageType_ATTACHMENT_STORAGE_TYPE_UNSPECIFIED {
+		return nopCloser{bytes.NewReader(attachment.Blob)}, nil
 	}
-
-	fileSize := int64(len(data))
-	start, end := int64(0), fileSize-1
-
-	// Parse start position
-	if parts[0] != "" {
-		if s, err := strconv.ParseInt(parts[0], 10, 64); err == nil {
-			start = s
-		} else {
-			return nil, status.Errorf(codes.InvalidArgument, "invalid range start: %s", parts[0])
-		}
-	}
-
-	// Parse end position
-	if parts[1] != "" {
-		if e, err := strconv.ParseInt(parts[1], 10, 64); err == nil {
-			end = e
-		} else {
-			return nil, status.Errorf(codes.InvalidArgument, "invalid range end: %s", parts[1])
-		}
-	}
-
-	// Validate range
-	if start < 0 || end >= fileSize || start > end {
-		// Set Content-Range header for 416 response
-		if err := setResponseHeaders(ctx, map[string]string{
-			"content-range": fmt.Sprintf("bytes */%d", fileSize),
-		}); err != nil {
-			slog.Warn("failed to set content-range header", slog.Any("error", err))
-		}
-		return nil, status.Errorf(codes.OutOfRange, "requested range not satisfiable")
+	backend, key, err := s.Store.GetAttachmentStorage(ctx, attachment)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get attachment storage")
 	}
-
-	// Set partial content headers (HTTP 206)
-	if err := setResponseHeaders(ctx, map[string]string{
-		"accept-ranges":  "bytes",
-		"content-range":  fmt.Sprintf("bytes %d-%d/%d", start, end, fileSize),
-		"content-length": fmt.Sprintf("%d", end-start+1),
-		"cache-control":  "public, max-age=3600",
-	}); err != nil {
-		slog.Warn("failed to set partial content headers", slog.Any("error", err))
+	reader, err := storage.NewReader(ctx, backend, key)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open the content")
 	}
+	return reader, nil
+}
 
-	// Extract the requested range
-	rangeData := data[start : end+1]
-
-	slog.Debug("serving partial content",
-		slog.Int64("start", start),
-		slog.Int64("end", end),
-		slog.Int64("total", fileSize),
-		slog.Int("chunk_size", len(rangeData)))
+type nopCloser struct {
+	io.ReadSeeker
+}
 
-	return &httpbody.HttpBody{
-		ContentType: contentType,
-		Data:        rangeData,
-	}, nil
+func (nopCloser) Close() error {
+	return nil
 }
 
 // setResponseHeaders is a helper function to set gRPC response headers.
@@ -0,0 +1,223 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/HugoSmits86/nativewebp"
+	"github.com/disintegration/imaging"
+	"github.com/pkg/errors"
+	"golang.org/x/sync/singleflight"
+
+	// Register the WebP decoder for thumbnails of WebP images.
+	_ "golang.org/x/image/webp"
+
+	"github.com/usememos/memos/internal/util"
+	"github.com/usememos/memos/plugin/exif"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+// SupportedThumbnailMimeTypes are the types of the attachments that have thumbnails.
+// The thumbnails of HEIC images are made of the JPEG preview they embed, since decoding their HEVC
+// coded image needs cgo. HEIC images without a preview are served unchanged.
+// Videos have no poster thumbnails, since extracting a frame needs a video decoder like ffmpeg,
+// which the server does not depend on.
+var SupportedThumbnailMimeTypes = []string{
+	"image/png",
+	"image/jpeg",
+	"image/gif",
+	"image/webp",
+	"image/heic",
+	"image/heif",
+}
+
+const (
+	// maxThumbnailConcurrency limits the thumbnails generated at once, since every generation
+	// holds the decoded image in memory.
+	maxThumbnailConcurrency = 2
+	// maxThumbnailSourcePixels is the largest image, in pixels, that thumbnails are generated of.
+	maxThumbnailSourcePixels = 64 << 20
+)
+
+// thumbnailSize is a size variant of the attachment thumbnails.
+type thumbnailSize struct {
+	Name string
+	// MaxDimension is the maximum width and height of the thumbnail in pixels.
+	MaxDimension int
+}
+
+var (
+	thumbnailSizeSmall  = thumbnailSize{Name: "small", MaxDimension: 256}
+	thumbnailSizeMedium = thumbnailSize{Name: "medium", MaxDimension: 1024}
+	// thumbnailSizes are the size variants that are generated after upload.
+	thumbnailSizes = []thumbnailSize{thumbnailSizeSmall, thumbnailSizeMedium}
+)
+
+// parseThumbnailSize returns the thumbnail size of a thumbnail parameter, which is either the
+// name of a size or a boolean for the medium size, and false if no thumbnail is requested.
+func parseThumbnailSize(value string) (thumbnailSize, bool) {
+	for _, size := range thumbnailSizes {
+		if strings.EqualFold(value, size.Name) {
+			return size, true
+		}
+	}
+	if thumbnail, _ := strconv.ParseBool(value); thumbnail {
+		return thumbnailSizeMedium, true
+	}
+	return thumbnailSize{}, false
+}
+
+// thumbnailGenerator limits the concurrency of thumbnail generations and joins the concurrent
+// requests of the same thumbnail.
+type thumbnailGenerator struct {
+	once      sync.Once
+	semaphore chan struct{}
+	group     singleflight.Group
+}
+
+func (g *thumbnailGenerator) acquire(ctx context.Context) error {
+	g.once.Do(func() {
+		g.semaphore = make(chan struct{}, maxThumbnailConcurrency)
+	})
+	select {
+	case g.semaphore <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (g *thumbnailGenerator) release() {
+	<-g.semaphore
+}
+
+// getThumbnailPath returns the path of the cached thumbnail of the attachment in the size.
+func (s *APIV1Service) getThumbnailPath(attachment *store.Attachment, size thumbnailSize) string {
+	return filepath.Join(s.Profile.Data, ThumbnailCacheFolder, fmt.Sprintf("%d_%s.webp", attachment.ID, size.Name))
+}
+
+// getOrGenerateThumbnail returns the WebP thumbnail of the attachment in the size, and generates
+// it unless it is cached.
+func (s *APIV1Service) getOrGenerateThumbnail(ctx context.Context, attachment *store.Attachment, size thumbnailSize) ([]byte, error) {
+	filePath := s.getThumbnailPath(attachment, size)
+	blob, err := os.ReadFile(filePath)
+	if err == nil {
+		return blob, nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return nil, errors.Wrap(err, "failed to read thumbnail file")
+	}
+	if _, err, _ := s.thumbnails.group.Do(filePath, func() (any, error) {
+		return nil, s.generateThumbnails(ctx, attachment, size)
+	}); err != nil {
+		return nil, err
+	}
+	blob, err = os.ReadFile(filePath)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read thumbnail file")
+	}
+	return blob, nil
+}
+
+// generateThumbnails decodes the image of the attachment once and saves its thumbnails in the sizes.
+func (s *APIV1Service) generateThumbnails(ctx context.Context, attachment *store.Attachment, sizes ...thumbnailSize) error {
+	if err := s.thumbnails.acquire(ctx); err != nil {
+		return err
+	}
+	defer s.thumbnails.release()
+
+	// The blob of database attachments is only loaded once the generation starts, so that waiting
+	// generations do not hold it in memory.
+	if attachment.StorageType == storepb.AttachmentStorageType_ATTACHMENT_STORAGE_TYPE_UNSPECIFIED && attachment.Blob == nil {
+		withBlob, err := s.Store.GetAttachment(ctx, &store.FindAttachment{ID: &attachment.ID, GetBlob: true})
+		if err != nil {
+			return errors.Wrap(err, "failed to get attachment blob")
+		}
+		if withBlob == nil {
+			return errors.New("attachment not found")
+		}
+		attachment = withBlob
+	}
+	blob, err := s.GetAttachmentBlob(ctx, attachment)
+	if err != nil {
+		return errors.Wrap(err, "failed to get attachment blob")
+	}
+	if preview, ok := exif.HEIFPreview(blob); ok {
+		blob = preview
+	}
+	// The dimensions are checked before decoding, since decoding allocates all pixels.
+	config, _, err := image.DecodeConfig(bytes.NewReader(blob))
+	if err != nil {
+		return errors.Wrap(err, "failed to decode thumbnail image config")
+	}
+	if config.Width*config.Height > maxThumbnailSourcePixels {
+		return errors.Errorf("image of %dx%d pixels is too large for a thumbnail", config.Width, config.Height)
+	}
+	img, err := imaging.Decode(bytes.NewReader(blob), imaging.AutoOrientation(true))
+	if err != nil {
+		return errors.Wrap(err, "failed to decode thumbnail image")
+	}
+
+	thumbnailCacheFolder := filepath.Join(s.Profile.Data, ThumbnailCacheFolder)
+	if err := os.MkdirAll(thumbnailCacheFolder, os.ModePerm); err != nil {
+		return errors.Wrap(err, "failed to create thumbnail cache folder")
+	}
+	for _, size := range sizes {
+		thumbnail := img
+		// Images are only scaled down.
+		if bounds := img.Bounds(); bounds.Dx() > size.MaxDimension || bounds.Dy() > size.MaxDimension {
+			thumbnail = imaging.Fit(img, size.MaxDimension, size.MaxDimension, imaging.Lanczos)
+		}
+		if err := saveThumbnail(s.getThumbnailPath(attachment, size), thumbnail); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// saveThumbnail encodes the thumbnail as WebP into a temporary file that is renamed to the path,
+// so that partially written thumbnails are never read.
+func saveThumbnail(filePath string, thumbnail image.Image) error {
+	file, err := os.CreateTemp(filepath.Dir(filePath), ".thumbnail-*")
+	if err != nil {
+		return errors.Wrap(err, "failed to create thumbnail file")
+	}
+	defer os.Remove(file.Name())
+	if err := nativewebp.Encode(file, thumbnail, nil); err != nil {
+		file.Close()
+		return errors.Wrap(err, "failed to encode thumbnail image")
+	}
+	if err := file.Close(); err != nil {
+		return errors.Wrap(err, "failed to write thumbnail file")
+	}
+	if err := os.Rename(file.Name(), filePath); err != nil {
+		return errors.Wrap(err, "failed to save thumbnail file")
+	}
+	return nil
+}
+
+// generateThumbnailsInBackground generates the thumbnails of all sizes of a new attachment, so
+// that they are cached before the attachment is first shown.
+func (s *APIV1Service) generateThumbnailsInBackground(attachment *store.Attachment) {
+	if attachment.StorageType == storepb.AttachmentStorageType_EXTERNAL || !util.HasPrefixes(attachment.Type, SupportedThumbnailMimeTypes...) {
+		return
+	}
+	go func() {
+		// The blob of database attachments is not kept by the upload, and is loaded by the generation.
+		attachment := *attachment
+		attachment.Blob = nil
+		if err := s.generateThumbnails(context.Background(), &attachment, thumbnailSizes...); err != nil {
+			slog.Warn("failed to generate attachment thumbnails", slog.String("attachment", attachment.UID), slog.Any("error", err))
+		}
+	}()
+}
@@ -0,0 +1,149 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/image/webp"
+
+	"github.com/usememos/memos/internal/profile"
+	"github.com/usememos/memos/store"
+	teststore "github.com/usememos/memos/store/test"
+)
+
+func TestParseThumbnailSize(t *testing.T) {
+	tests := []struct {
+		value     string
+		size      thumbnailSize
+		thumbnail bool
+	}{
+		{value: "small", size: thumbnailSizeSmall, thumbnail: true},
+		{value: "Medium", size: thumbnailSizeMedium, thumbnail: true},
+		{value: "true", size: thumbnailSizeMedium, thumbnail: true},
+		{value: "false"},
+		{value: ""},
+		{value: "huge"},
+	}
+	for _, test := range tests {
+		size, thumbnail := parseThumbnailSize(test.value)
+		require.Equal(t, test.thumbnail, thumbnail, test.value)
+		require.Equal(t, test.size, size, test.value)
+	}
+}
+
+// newTestHEIC returns a HEIC image whose only item is EXIF metadata with the orientation and the
+// JPEG thumbnail.
+func newTestHEIC(orientation uint16, thumbnail []byte) []byte {
+	box := func(typ string, content ...[]byte) []byte {
+		data := bytes.Join(content, nil)
+		return append(append(binary.BigEndian.AppendUint32(nil, uint32(8+len(data))), typ...), data...)
+	}
+	// The TIFF header follows the EXIF prefix, and its first directory at 8 points to the thumbnail
+	// directory at 26, which points to the thumbnail at 56.
+	exifItem := []byte("\x00\x00\x00\x06Exif\x00\x00MM\x00\x2a\x00\x00\x00\x08\x00\x01\x01\x12\x00\x03\x00\x00\x00\x01")
+	exifItem = append(binary.BigEndian.AppendUint16(exifItem, orientation), 0, 0, 0, 0, 0, 26, 0, 2)
+	exifItem = append(exifItem, 0x02, 0x01, 0, 4, 0, 0, 0, 1, 0, 0, 0, 56)
+	exifItem = append(exifItem, 0x02, 0x02, 0, 4, 0, 0, 0, 1)
+	exifItem = append(binary.BigEndian.AppendUint32(exifItem, uint32(len(thumbnail))), 0, 0, 0, 0)
+	exifItem = append(exifItem, thumbnail...)
+	ftyp := box("ftyp", []byte("heic\x00\x00\x00\x00mif1heic"))
+	meta := func(offset uint32) []byte {
+		iinf := box("iinf", []byte{0, 0, 0, 0, 0, 1}, box("infe", []byte{2, 0, 0, 0, 0, 1, 0, 0}, []byte("Exif\x00")))
+		iloc := binary.BigEndian.AppendUint32([]byte{0, 0, 0, 0, 0x44, 0, 0, 1, 0, 1, 0, 0, 0, 1}, offset)
+		return box("meta", []byte{0, 0, 0, 0}, iinf, box("iloc", binary.BigEndian.AppendUint32(iloc, uint32(len(exifItem)))))
+	}
+	return append(append(ftyp, meta(uint32(len(ftyp)+len(meta(0))+8))...), box("mdat", exifItem)...)
+}
+
+func TestAttachmentThumbnails(t *testing.T) {
+	ctx := context.Background()
+	testStore := teststore.NewTestingStore(ctx, t)
+	defer testStore.Close()
+	service := &APIV1Service{
+		Profile: &profile.Profile{Mode: "dev", Data: t.TempDir()},
+		Store:   testStore,
+	}
+	user, err := testStore.CreateUser(ctx, &store.User{Username: "user", Role: store.RoleUser, Email: "user@example.com"})
+	require.NoError(t, err)
+	createImage := func(uid string, width, height int) *store.Attachment {
+		img := image.NewRGBA(image.Rect(0, 0, width, height))
+		for x := 0; x < width; x++ {
+			img.Set(x, x*height/width, color.RGBA{R: 255, A: 255})
+		}
+		buffer := &bytes.Buffer{}
+		require.NoError(t, png.Encode(buffer, img))
+		attachment, err := testStore.CreateAttachment(ctx, &store.Attachment{
+			UID:       uid,
+			CreatorID: user.ID,
+			Filename:  uid + ".png",
+			Type:      "image/png",
+			Blob:      buffer.Bytes(),
+			Size:      int64(buffer.Len()),
+		})
+		require.NoError(t, err)
+		return attachment
+	}
+	decodeBounds := func(blob []byte) image.Rectangle {
+		img, err := webp.Decode(bytes.NewReader(blob))
+		require.NoError(t, err)
+		return img.Bounds()
+	}
+
+	large := createImage("large", 2000, 1000)
+	blob, err := service.getOrGenerateThumbnail(ctx, large, thumbnailSizeSmall)
+	require.NoError(t, err)
+	require.Equal(t, image.Rect(0, 0, 256, 128), decodeBounds(blob))
+	blob, err = service.getOrGenerateThumbnail(ctx, large, thumbnailSizeMedium)
+	require.NoError(t, err)
+	require.Equal(t, image.Rect(0, 0, 1024, 512), decodeBounds(blob))
+
+	// Images smaller than the thumbnail size are not scaled up.
+	small := createImage("small", 100, 50)
+	blob, err = service.getOrGenerateThumbnail(ctx, small, thumbnailSizeMedium)
+	require.NoError(t, err)
+	require.Equal(t, image.Rect(0, 0, 100, 50), decodeBounds(blob))
+
+	// The thumbnails of all sizes are generated in the background after upload.
+	uploaded := createImage("uploaded", 600, 300)
+	service.generateThumbnailsInBackground(&store.Attachment{ID: uploaded.ID, Type: uploaded.Type})
+	require.Eventually(t, func() bool {
+		for _, size := range thumbnailSizes {
+			if _, err := os.Stat(service.getThumbnailPath(uploaded, size)); err != nil {
+				return false
+			}
+		}
+		return true
+	}, 10*time.Second, 10*time.Millisecond)
+	blob, err = service.getOrGenerateThumbnail(ctx, uploaded, thumbnailSizeSmall)
+	require.NoError(t, err)
+	require.Equal(t, image.Rect(0, 0, 256, 128), decodeBounds(blob))
+
+	// The thumbnails of HEIC images are made of their JPEG preview in the orientation of the image.
+	preview := &bytes.Buffer{}
+	require.NoError(t, jpeg.Encode(preview, image.NewGray(image.Rect(0, 0, 600, 300)), nil))
+	heic := newTestHEIC(6, preview.Bytes())
+	photo, err := testStore.CreateAttachment(ctx, &store.Attachment{
+		UID:       "photo",
+		CreatorID: user.ID,
+		Filename:  "photo.heic",
+		Type:      "image/heic",
+		Blob:      heic,
+		Size:      int64(len(heic)),
+	})
+	require.NoError(t, err)
+	blob, err = service.getOrGenerateThumbnail(ctx, photo, thumbnailSizeSmall)
+	require.NoError(t, err)
+	require.Equal(t, image.Rect(0, 0, 128, 256), decodeBounds(blob))
+
+	_, err = service.getOrGenerateThumbnail(ctx, &store.Attachment{ID: 999, Type: "image/png", Blob: []byte("not an image")}, thumbnailSizeSmall)
+	require.Error(t, err)
+}
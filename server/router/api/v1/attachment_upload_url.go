@@ -0,0 +1,318 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"path/filepath"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/lithammer/shortuuid/v4"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/usememos/memos/plugin/exif"
+	"github.com/usememos/memos/plugin/storage"
+	"github.com/usememos/memos/plugin/storage/s3"
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+const (
+	// UploadTokenAudienceName is the audience name of the tokens of direct uploads, which are
+	// rejected as access tokens since they have no subject.
+	UploadTokenAudienceName = "attachment.upload-token"
+	// uploadURLExpiration is the expiration time of the presigned upload URLs and their tokens.
+	uploadURLExpiration = 15 * time.Minute
+)
+
+// uploadTokenClaims are the claims of the token of a direct upload, which record the attachment
+// that is created when the upload is finalized.
+type uploadTokenClaims struct {
+	CreatorID int32  `json:"creator_id"`
+	UID       string `json:"uid"`
+	Key       string `json:"key"`
+	Filename  string `json:"filename"`
+	Type      string `json:"type"`
+	Size      int64  `json:"size"`
+	jwt.RegisteredClaims
+}
+
+func (s *APIV1Service) CreateAttachmentUploadURL(ctx context.Context, request *v1pb.CreateAttachmentUploadURLRequest) (*v1pb.CreateAttachmentUploadURLResponse, error) {
+	user, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get current user: %v", err)
+	}
+	if user == nil {
+		return nil, status.Errorf(codes.Unauthenticated, "user not authenticated")
+	}
+
+	filename := filepath.Base(request.Filename)
+	if request.Filename == "" || filename == "." || filename == "/" {
+		return nil, status.Errorf(codes.InvalidArgument, "filename is required")
+	}
+	if request.Type == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "type is required")
+	}
+	if request.Size <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "size must be positive")
+	}
+
+	workspaceStorageSetting, client, err := s.getDirectUploadClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	uploadSizeLimit := int64(workspaceStorageSetting.UploadSizeLimitMb) * MebiByte
+	if uploadSizeLimit == 0 {
+		uploadSizeLimit = MaxUploadBufferSizeBytes
+	}
+	if request.Size > uploadSizeLimit {
+		return nil, status.Errorf(codes.InvalidArgument, "file size exceeds the limit")
+	}
+	remainingQuota, err := s.getRemainingStorageQuota(ctx, user)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get storage quota: %v", err)
+	}
+	if remainingQuota >= 0 && request.Size > remainingQuota {
+		return nil, status.Errorf(codes.ResourceExhausted, "storage quota exceeded")
+	}
+
+	// Date placeholders in the filepath template are resolved in the uploader's timezone.
+	location, err := s.Store.GetUserLocation(ctx, user.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get user location: %v", err)
+	}
+	uid := shortuuid.New()
+	now := time.Now()
+	// The key holds the UID so that concurrent uploads never overwrite the content of each other.
+	key := store.NewAttachmentKey(workspaceStorageSetting, fmt.Sprintf("%s_%s", uid, filename), now.In(location))
+
+	presignedRequest, err := client.PresignPutObject(ctx, key, request.Type, request.Size, uploadURLExpiration)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to presign upload: %v", err)
+	}
+	expireTime := now.Add(uploadURLExpiration)
+	uploadToken, err := generateUploadToken(&uploadTokenClaims{
+		CreatorID: user.ID,
+		UID:       uid,
+		Key:       key,
+		Filename:  filename,
+		Type:      request.Type,
+		Size:      request.Size,
+	}, expireTime, []byte(s.Secret))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to generate upload token: %v", err)
+	}
+
+	headers := map[string]string{}
+	for name, values := range presignedRequest.Header {
+		// The host is set by the HTTP client from the URL.
+		if http.CanonicalHeaderKey(name) == "Host" || len(values) == 0 {
+			continue
+		}
+		headers[http.CanonicalHeaderKey(name)] = values[0]
+	}
+	return &v1pb.CreateAttachmentUploadURLResponse{
+		UploadUrl:   presignedRequest.URL,
+		Method:      presignedRequest.Method,
+		Headers:     headers,
+		UploadToken: uploadToken,
+		ExpireTime:  timestamppb.New(expireTime),
+	}, nil
+}
+
+func (s *APIV1Service) FinalizeAttachment(ctx context.Context, request *v1pb.FinalizeAttachmentRequest) (*v1pb.Attachment, error) {
+	user, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get current user: %v", err)
+	}
+	if user == nil {
+		return nil, status.Errorf(codes.Unauthenticated, "user not authenticated")
+	}
+	claims, err := parseUploadToken(request.UploadToken, []byte(s.Secret))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid upload token: %v", err)
+	}
+	if claims.CreatorID != user.ID {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+
+	attachment, err := s.Store.GetAttachment(ctx, &store.FindAttachment{UID: &claims.UID})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get attachment: %v", err)
+	}
+	if attachment != nil {
+		return nil, status.Errorf(codes.AlreadyExists, "attachment is already finalized")
+	}
+
+	workspaceStorageSetting, client, err := s.getDirectUploadClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	info, err := client.Stat(ctx, claims.Key)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, status.Errorf(codes.NotFound, "attachment content is not uploaded")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to stat attachment content: %v", err)
+	}
+	if info.Size != claims.Size {
+		s.deleteDirectUpload(ctx, client, claims.Key)
+		return nil, status.Errorf(codes.FailedPrecondition, "attachment content size %d differs from %d", info.Size, claims.Size)
+	}
+	// The quota is checked again since other uploads may have been finalized in the meantime.
+	remainingQuota, err := s.getRemainingStorageQuota(ctx, user)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get storage quota: %v", err)
+	}
+	if remainingQuota >= 0 && info.Size > remainingQuota {
+		s.deleteDirectUpload(ctx, client, claims.Key)
+		return nil, status.Errorf(codes.ResourceExhausted, "storage quota exceeded")
+	}
+
+	// The uploaded content is read back, as if it was uploaded to the server, to strip the metadata
+	// of images and to compute the checksum used for deduplication.
+	location, err := s.Store.GetUserLocation(ctx, user.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get user location: %v", err)
+	}
+	object, err := client.Get(ctx, claims.Key)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get attachment content: %v", err)
+	}
+	defer object.Close()
+	strip := getImageMetadataStrip(workspaceStorageSetting.ImageMetadataStripping)
+	content, metadata, err := exif.Process(object, claims.Type, strip, location)
+	if err != nil {
+		return nil, s.rejectDirectUpload(ctx, client, claims.Key, err)
+	}
+	create := &store.Attachment{
+		UID:       claims.UID,
+		CreatorID: user.ID,
+		Filename:  claims.Filename,
+		Type:      claims.Type,
+	}
+	if strip != exif.StripNone && metadata != nil {
+		// The stripped image replaces the uploaded one at the same key, so it is read completely
+		// before being written. Images are bounded by the upload size limit.
+		blob, err := io.ReadAll(content)
+		if err != nil {
+			return nil, s.rejectDirectUpload(ctx, client, claims.Key, err)
+		}
+		if err := s.Store.PutAttachmentContent(ctx, workspaceStorageSetting, create, claims.Key, bytes.NewReader(blob)); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to save attachment content: %v", err)
+		}
+	} else {
+		hash := sha256.New()
+		size, err := io.Copy(hash, content)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to read attachment content: %v", err)
+		}
+		create.Size = size
+		create.Sha256 = hex.EncodeToString(hash.Sum(nil))
+		if err := s.Store.AdoptAttachmentContent(ctx, workspaceStorageSetting, create, claims.Key); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to save attachment content: %v", err)
+		}
+	}
+	if err := setAttachmentImageMetadata(ctx, s.Store, create, metadata); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to set image metadata: %v", err)
+	}
+	if request.Memo != nil {
+		memoUID, err := ExtractMemoUIDFromName(*request.Memo)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid memo name: %v", err)
+		}
+		memo, err := s.Store.GetMemo(ctx, &store.FindMemo{UID: &memoUID})
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to find memo: %v", err)
+		}
+		if memo == nil {
+			return nil, status.Errorf(codes.NotFound, "memo not found")
+		}
+		create.MemoID = &memo.ID
+	}
+
+	attachment, err = s.Store.CreateAttachment(ctx, create)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create attachment: %v", err)
+	}
+	s.generateThumbnailsInBackground(attachment)
+	return convertAttachmentFromStore(attachment), nil
+}
+
+// getDirectUploadClient returns the workspace storage setting and the client of its S3 storage,
+// which is the only storage that accepts direct uploads.
+func (s *APIV1Service) getDirectUploadClient(ctx context.Context) (*storepb.WorkspaceStorageSetting, *s3.Client, error) {
+	workspaceStorageSetting, err := s.Store.GetWorkspaceStorageSetting(ctx)
+	if err != nil {
+		return nil, nil, status.Errorf(codes.Internal, "failed to get workspace storage setting: %v", err)
+	}
+	if workspaceStorageSetting.StorageType != storepb.WorkspaceStorageSetting_S3 || workspaceStorageSetting.S3Config == nil {
+		return nil, nil, status.Errorf(codes.FailedPrecondition, "direct uploads require the S3 storage")
+	}
+	client, err := s3.NewClient(ctx, workspaceStorageSetting.S3Config)
+	if err != nil {
+		return nil, nil, status.Errorf(codes.Internal, "failed to create s3 client: %v", err)
+	}
+	return workspaceStorageSetting, client, nil
+}
+
+// deleteDirectUpload deletes the content of a direct upload that is rejected. The content is
+// otherwise left in the bucket without attachment.
+func (*APIV1Service) deleteDirectUpload(ctx context.Context, client *s3.Client, key string) {
+	if err := client.Delete(ctx, key); err != nil && !errors.Is(err, storage.ErrNotFound) {
+		slog.Warn("failed to delete rejected attachment content", slog.String("key", key), slog.Any("error", err))
+	}
+}
+
+// rejectDirectUpload deletes the content of a direct upload whose metadata cannot be processed, and
+// returns the error of the rejection.
+func (s *APIV1Service) rejectDirectUpload(ctx context.Context, client *s3.Client, key string, err error) error {
+	if !errors.Is(err, exif.ErrUnsupported) {
+		return status.Errorf(codes.Internal, "failed to process image metadata: %v", err)
+	}
+	s.deleteDirectUpload(ctx, client, key)
+	return status.Errorf(codes.InvalidArgument, "image metadata cannot be stripped: %v", err)
+}
+
+// generateUploadToken generates the token of a direct upload.
+func generateUploadToken(claims *uploadTokenClaims, expirationTime time.Time, secret []byte) (string, error) {
+	claims.RegisteredClaims = jwt.RegisteredClaims{
+		Issuer:    Issuer,
+		Audience:  jwt.ClaimStrings{UploadTokenAudienceName},
+		IssuedAt:  jwt.NewNumericDate(time.Now()),
+		ExpiresAt: jwt.NewNumericDate(expirationTime),
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	token.Header["kid"] = KeyID
+	return token.SignedString(secret)
+}
+
+// parseUploadToken verifies the token of a direct upload and returns its claims.
+func parseUploadToken(uploadToken string, secret []byte) (*uploadTokenClaims, error) {
+	claims := &uploadTokenClaims{}
+	_, err := jwt.ParseWithClaims(uploadToken, claims, func(t *jwt.Token) (any, error) {
+		if kid, ok := t.Header["kid"].(string); !ok || kid != KeyID {
+			return nil, errors.Errorf("unexpected upload token kid=%v", t.Header["kid"])
+		}
+		return secret, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Name}),
+		jwt.WithAudience(UploadTokenAudienceName),
+		jwt.WithIssuer(Issuer),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
@@ -0,0 +1,102 @@
+package v1
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/usememos/memos/internal/util"
+)
+
+// httpStatusCodeHeader is the response header that sets the HTTP status code of a gateway response.
+const httpStatusCodeHeader = "x-http-code"
+
+// gatewayResponseHeaders are the response headers that the gateway forwards as they are,
+// instead of with the Grpc-Metadata- prefix.
+var gatewayResponseHeaders = map[string]bool{
+	"accept-ranges": true,
+	"cache-control": true,
+	"content-range": true,
+	"etag":          true,
+	"last-modified": true,
+}
+
+func gatewayOutgoingHeaderMatcher(key string) (string, bool) {
+	key = strings.ToLower(key)
+	if key == httpStatusCodeHeader {
+		return "", false
+	}
+	if gatewayResponseHeaders[key] {
+		return key, true
+	}
+	return runtime.MetadataHeaderPrefix + key, true
+}
+
+// gatewayResponseModifier writes the HTTP status code of the httpStatusCodeHeader response header.
+func gatewayResponseModifier(ctx context.Context, w http.ResponseWriter, _ proto.Message) error {
+	md, ok := runtime.ServerMetadataFromContext(ctx)
+	if !ok {
+		return nil
+	}
+	values := md.HeaderMD.Get(httpStatusCodeHeader)
+	if len(values) == 0 {
+		return nil
+	}
+	code, err := strconv.Atoi(values[0])
+	if err != nil {
+		return err
+	}
+	w.WriteHeader(code)
+	return nil
+}
+
+// cacheValidators are the validators of a response for conditional GET requests.
+type cacheValidators struct {
+	etag         string
+	lastModified time.Time
+	cacheControl string
+}
+
+// setCacheHeaders sets the cache headers of the response and reports whether the conditional
+// headers of the gateway request match the validators, in which case the handler should
+// return notModifiedResponse.
+func setCacheHeaders(ctx context.Context, validators cacheValidators) bool {
+	headers := map[string]string{
+		"etag":          validators.etag,
+		"cache-control": validators.cacheControl,
+	}
+	if !validators.lastModified.IsZero() {
+		headers["last-modified"] = validators.lastModified.UTC().Format(http.TimeFormat)
+	}
+
+	// The gateway forwards the request headers with the grpcgateway- prefix.
+	md, _ := metadata.FromIncomingContext(ctx)
+	ifNoneMatch, ifModifiedSince := "", ""
+	if values := md.Get(runtime.MetadataPrefix + "If-None-Match"); len(values) > 0 {
+		ifNoneMatch = values[0]
+	}
+	if values := md.Get(runtime.MetadataPrefix + "If-Modified-Since"); len(values) > 0 {
+		ifModifiedSince = values[0]
+	}
+	notModified := util.IsNotModified(ifNoneMatch, ifModifiedSince, validators.etag, validators.lastModified)
+	if notModified {
+		headers[httpStatusCodeHeader] = strconv.Itoa(http.StatusNotModified)
+	}
+	if err := setResponseHeaders(ctx, headers); err != nil {
+		slog.Warn("failed to set cache headers", slog.Any("error", err))
+	}
+	return notModified
+}
+
+// notModifiedResponse is the empty body of a 304 Not Modified response.
+func notModifiedResponse() *httpbody.HttpBody {
+	return &httpbody.HttpBody{}
+}
@@ -2,14 +2,17 @@ package v1
 
 import (
 	"context"
+	"fmt"
 	"slices"
 	"time"
 
+	"github.com/pkg/errors"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 
 	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	storepb "github.com/usememos/memos/proto/gen/store"
 	"github.com/usememos/memos/store"
 )
 
@@ -72,10 +75,56 @@ func (s *APIV1Service) SetMemoAttachments(ctx context.Context, request *v1pb.Set
 			return nil, status.Errorf(codes.Internal, "failed to update attachment: %v", err)
 		}
 	}
+	if err := s.fillMemoLocationFromAttachments(ctx, memo); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to fill memo location: %v", err)
+	}
 
 	return &emptypb.Empty{}, nil
 }
 
+// fillMemoLocationFromAttachments sets the location of a memo without location to the GPS location
+// of its first photo with one, if the creator of the memo fills the location of memos from their photos.
+func (s *APIV1Service) fillMemoLocationFromAttachments(ctx context.Context, memo *store.Memo) error {
+	if memo.Payload.GetLocation() != nil {
+		return nil
+	}
+	userSetting, err := s.Store.GetUserSetting(ctx, &store.FindUserSetting{
+		UserID: &memo.CreatorID,
+		Key:    storepb.UserSetting_GENERAL,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to get user general setting")
+	}
+	if !userSetting.GetGeneral().GetLocationFromPhotos() {
+		return nil
+	}
+	attachments, err := s.Store.ListAttachments(ctx, &store.FindAttachment{
+		MemoID: &memo.ID,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to list attachments")
+	}
+	for _, attachment := range attachments {
+		location := attachment.Payload.GetImageMetadata().GetLocation()
+		if location == nil {
+			continue
+		}
+		if memo.Payload == nil {
+			memo.Payload = &storepb.MemoPayload{}
+		}
+		memo.Payload.Location = &storepb.MemoPayload_Location{
+			Placeholder: fmt.Sprintf("%.6f, %.6f", location.Latitude, location.Longitude),
+			Latitude:    location.Latitude,
+			Longitude:   location.Longitude,
+		}
+		return s.Store.UpdateMemo(ctx, &store.UpdateMemo{
+			ID:      memo.ID,
+			Payload: memo.Payload,
+		})
+	}
+	return nil
+}
+
 func (s *APIV1Service) ListMemoAttachments(ctx context.Context, request *v1pb.ListMemoAttachmentsRequest) (*v1pb.ListMemoAttachmentsResponse, error) {
 	memoUID, err := ExtractMemoUIDFromName(request.Name)
 	if err != nil {
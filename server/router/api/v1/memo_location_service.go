@@ -0,0 +1,117 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/usememos/memos/plugin/filter"
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	"github.com/usememos/memos/store"
+)
+
+func (s *APIV1Service) ListMemoLocations(ctx context.Context, request *v1pb.ListMemoLocationsRequest) (*v1pb.ListMemoLocationsResponse, error) {
+	state := store.Normal
+	memoFind := &store.FindMemo{
+		RowStatus:       &state,
+		ExcludeComments: true,
+		Filters:         []string{"has_location"},
+	}
+	if request.Filter != "" {
+		if err := s.validateFilter(ctx, request.Filter, filter.MemoFilter); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid filter: %v", err)
+		}
+		memoFind.Filters = append(memoFind.Filters, request.Filter)
+	}
+
+	currentUser, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get user")
+	}
+	if currentUser == nil {
+		memoFind.VisibilityList = []store.Visibility{store.Public}
+	} else {
+		memoFind.Filters = append(memoFind.Filters, fmt.Sprintf(`creator_id == %d || visibility in ["PUBLIC", "PROTECTED"]`, currentUser.ID))
+	}
+	if err := s.setMemoFindTimeContext(ctx, currentUser, memoFind); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get time context: %v", err)
+	}
+
+	workspaceMemoRelatedSetting, err := s.Store.GetWorkspaceMemoRelatedSetting(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get workspace memo related setting")
+	}
+	if workspaceMemoRelatedSetting.DisplayWithUpdateTime {
+		memoFind.OrderByUpdatedTs = true
+	}
+
+	var limit, offset int
+	if request.PageToken != "" {
+		var pageToken v1pb.PageToken
+		if err := unmarshalPageToken(request.PageToken, &pageToken); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid page token: %v", err)
+		}
+		limit = int(pageToken.Limit)
+		offset = int(pageToken.Offset)
+	} else {
+		limit = int(request.PageSize)
+	}
+	if limit <= 0 || limit > MaxPageSize {
+		limit = MaxPageSize
+	}
+	limitPlusOne := limit + 1
+	memoFind.Limit = &limitPlusOne
+	memoFind.Offset = &offset
+	memos, err := s.Store.ListMemos(ctx, memoFind)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list memos: %v", err)
+	}
+
+	nextPageToken := ""
+	if len(memos) == limitPlusOne {
+		memos = memos[:limit]
+		nextPageToken, err = getPageToken(limit, offset+limit)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get next page token, error: %v", err)
+		}
+	}
+
+	features := []*v1pb.ListMemoLocationsResponse_Feature{}
+	for _, memo := range memos {
+		location := memo.Payload.GetLocation()
+		if location == nil {
+			continue
+		}
+		snippet, err := getMemoContentSnippet(memo.Content)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get memo content snippet: %v", err)
+		}
+		displayTs := memo.CreatedTs
+		if workspaceMemoRelatedSetting.DisplayWithUpdateTime {
+			displayTs = memo.UpdatedTs
+		}
+		features = append(features, &v1pb.ListMemoLocationsResponse_Feature{
+			Type: "Feature",
+			Geometry: &v1pb.ListMemoLocationsResponse_Geometry{
+				Type:        "Point",
+				Coordinates: []float64{location.Longitude, location.Latitude},
+			},
+			Properties: &v1pb.ListMemoLocationsResponse_Properties{
+				Name:        fmt.Sprintf("%s%s", MemoNamePrefix, memo.UID),
+				Placeholder: location.Placeholder,
+				Snippet:     snippet,
+				DisplayTime: timestamppb.New(time.Unix(displayTs, 0)),
+			},
+		})
+	}
+
+	return &v1pb.ListMemoLocationsResponse{
+		Type:          "FeatureCollection",
+		Features:      features,
+		NextPageToken: nextPageToken,
+	}, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"slices"
 	"strings"
 	"time"
 	"unicode/utf8"
@@ -17,6 +18,7 @@ import (
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 
+	"github.com/usememos/memos/plugin/filter"
 	v1pb "github.com/usememos/memos/proto/gen/api/v1"
 	storepb "github.com/usememos/memos/proto/gen/store"
 	"github.com/usememos/memos/server/runner/memopayload"
@@ -24,12 +26,23 @@ import (
 )
 
 func (s *APIV1Service) CreateMemo(ctx context.Context, request *v1pb.CreateMemoRequest) (*v1pb.Memo, error) {
+	memo, memoMessage, err := s.createMemo(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	s.dispatchShortcutNotificationsInBackground(memo, memoMessage, nil)
+	return memoMessage, nil
+}
+
+// createMemo creates a memo without notifying the subscribers of shortcuts, which are not notified
+// about comments.
+func (s *APIV1Service) createMemo(ctx context.Context, request *v1pb.CreateMemoRequest) (*store.Memo, *v1pb.Memo, error) {
 	user, err := s.GetCurrentUser(ctx)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to get user")
+		return nil, nil, status.Errorf(codes.Internal, "failed to get user")
 	}
 	if user == nil {
-		return nil, status.Errorf(codes.Unauthenticated, "user not authenticated")
+		return nil, nil, status.Errorf(codes.Unauthenticated, "user not authenticated")
 	}
 
 	create := &store.Memo{
@@ -40,20 +53,20 @@ func (s *APIV1Service) CreateMemo(ctx context.Context, request *v1pb.CreateMemoR
 	}
 	workspaceMemoRelatedSetting, err := s.Store.GetWorkspaceMemoRelatedSetting(ctx)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to get workspace memo related setting")
+		return nil, nil, status.Errorf(codes.Internal, "failed to get workspace memo related setting")
 	}
 	if workspaceMemoRelatedSetting.DisallowPublicVisibility && create.Visibility == store.Public {
-		return nil, status.Errorf(codes.PermissionDenied, "disable public memos system setting is enabled")
+		return nil, nil, status.Errorf(codes.PermissionDenied, "disable public memos system setting is enabled")
 	}
 	contentLengthLimit, err := s.getContentLengthLimit(ctx)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to get content length limit")
+		return nil, nil, status.Errorf(codes.Internal, "failed to get content length limit")
 	}
 	if len(create.Content) > contentLengthLimit {
-		return nil, status.Errorf(codes.InvalidArgument, "content too long (max %d characters)", contentLengthLimit)
+		return nil, nil, status.Errorf(codes.InvalidArgument, "content too long (max %d characters)", contentLengthLimit)
 	}
 	if err := memopayload.RebuildMemoPayload(create); err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to rebuild memo payload: %v", err)
+		return nil, nil, status.Errorf(codes.Internal, "failed to rebuild memo payload: %v", err)
 	}
 	if request.Memo.Location != nil {
 		create.Payload.Location = convertLocationToStore(request.Memo.Location)
@@ -61,7 +74,7 @@ func (s *APIV1Service) CreateMemo(ctx context.Context, request *v1pb.CreateMemoR
 
 	memo, err := s.Store.CreateMemo(ctx, create)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	attachments := []*store.Attachment{}
@@ -72,14 +85,19 @@ func (s *APIV1Service) CreateMemo(ctx context.Context, request *v1pb.CreateMemoR
 			Attachments: request.Memo.Attachments,
 		})
 		if err != nil {
-			return nil, errors.Wrap(err, "failed to set memo attachments")
+			return nil, nil, errors.Wrap(err, "failed to set memo attachments")
+		}
+		// The location of the memo may be filled from its photos.
+		memo, err = s.Store.GetMemo(ctx, &store.FindMemo{ID: &memo.ID})
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to get memo")
 		}
 
 		a, err := s.Store.ListAttachments(ctx, &store.FindAttachment{
 			MemoID: &memo.ID,
 		})
 		if err != nil {
-			return nil, errors.Wrap(err, "failed to get memo attachments")
+			return nil, nil, errors.Wrap(err, "failed to get memo attachments")
 		}
 		attachments = a
 	}
@@ -89,20 +107,20 @@ func (s *APIV1Service) CreateMemo(ctx context.Context, request *v1pb.CreateMemoR
 			Relations: request.Memo.Relations,
 		})
 		if err != nil {
-			return nil, errors.Wrap(err, "failed to set memo relations")
+			return nil, nil, errors.Wrap(err, "failed to set memo relations")
 		}
 	}
 
 	memoMessage, err := s.convertMemoFromStore(ctx, memo, nil, attachments)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to convert memo")
+		return nil, nil, errors.Wrap(err, "failed to convert memo")
 	}
 	// Try to dispatch webhook when memo is created.
 	if err := s.DispatchMemoCreatedWebhook(ctx, memoMessage); err != nil {
 		slog.Warn("Failed to dispatch memo created webhook", slog.Any("err", err))
 	}
 
-	return memoMessage, nil
+	return memo, memoMessage, nil
 }
 
 func (s *APIV1Service) ListMemos(ctx context.Context, request *v1pb.ListMemosRequest) (*v1pb.ListMemosResponse, error) {
@@ -129,7 +147,7 @@ func (s *APIV1Service) ListMemos(ctx context.Context, request *v1pb.ListMemosReq
 	}
 
 	if request.Filter != "" {
-		if err := s.validateFilter(ctx, request.Filter); err != nil {
+		if err := s.validateFilter(ctx, request.Filter, filter.MemoFilter); err != nil {
 			return nil, status.Errorf(codes.InvalidArgument, "invalid filter: %v", err)
 		}
 		memoFind.Filters = append(memoFind.Filters, request.Filter)
@@ -150,6 +168,10 @@ func (s *APIV1Service) ListMemos(ctx context.Context, request *v1pb.ListMemosReq
 		}
 	}
 
+	if err := s.setMemoFindTimeContext(ctx, currentUser, memoFind); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get time context: %v", err)
+	}
+
 	workspaceMemoRelatedSetting, err := s.Store.GetWorkspaceMemoRelatedSetting(ctx)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to get workspace memo related setting")
@@ -328,6 +350,14 @@ func (s *APIV1Service) UpdateMemo(ctx context.Context, request *v1pb.UpdateMemoR
 		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
 	}
 
+	// Keep the memo before the update to notify only the shortcuts it starts matching.
+	var previousFilterMemo *filter.Memo
+	if memo.RowStatus == store.Normal {
+		if previousFilterMemo, err = s.Store.GetFilterMemo(ctx, memo); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get filter memo: %v", err)
+		}
+	}
+
 	update := &store.UpdateMemo{
 		ID: memo.ID,
 	}
@@ -414,6 +444,12 @@ func (s *APIV1Service) UpdateMemo(ctx context.Context, request *v1pb.UpdateMemoR
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get memo")
 	}
+	// The location that is filled from the new photos is kept over a payload updated with them.
+	if slices.Contains(request.UpdateMask.Paths, "attachments") {
+		if err := s.fillMemoLocationFromAttachments(ctx, memo); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to fill memo location: %v", err)
+		}
+	}
 	reactions, err := s.Store.ListReactions(ctx, &store.FindReaction{
 		ContentID: &request.Memo.Name,
 	})
@@ -435,6 +471,7 @@ func (s *APIV1Service) UpdateMemo(ctx context.Context, request *v1pb.UpdateMemoR
 	if err := s.DispatchMemoUpdatedWebhook(ctx, memoMessage); err != nil {
 		slog.Warn("Failed to dispatch memo updated webhook", slog.Any("err", err))
 	}
+	s.dispatchShortcutNotificationsInBackground(memo, memoMessage, previousFilterMemo)
 
 	return memoMessage, nil
 }
@@ -535,18 +572,10 @@ func (s *APIV1Service) CreateMemoComment(ctx context.Context, request *v1pb.Crea
 	}
 
 	// Create the memo comment first.
-	memoComment, err := s.CreateMemo(ctx, &v1pb.CreateMemoRequest{Memo: request.Comment})
+	memo, memoComment, err := s.createMemo(ctx, &v1pb.CreateMemoRequest{Memo: request.Comment})
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to create memo")
 	}
-	memoUID, err = ExtractMemoUIDFromName(memoComment.Name)
-	if err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "invalid memo name: %v", err)
-	}
-	memo, err := s.Store.GetMemo(ctx, &store.FindMemo{UID: &memoUID})
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to get memo")
-	}
 
 	// Build the relation between the comment memo and the original memo.
 	_, err = s.Store.UpsertMemoRelation(ctx, &store.MemoRelation{
@@ -794,6 +823,26 @@ func (s *APIV1Service) DeleteMemoTag(ctx context.Context, request *v1pb.DeleteMe
 	return &emptypb.Empty{}, nil
 }
 
+// setMemoFindTimeContext sets the time zone and week start used by date functions in memo filters.
+// Date functions are evaluated in the viewer's time zone, or the workspace one for anonymous viewers.
+func (s *APIV1Service) setMemoFindTimeContext(ctx context.Context, currentUser *store.User, memoFind *store.FindMemo) error {
+	var err error
+	if currentUser != nil {
+		memoFind.Location, err = s.Store.GetUserLocation(ctx, currentUser.ID)
+	} else {
+		memoFind.Location, err = s.Store.GetWorkspaceLocation(ctx)
+	}
+	if err != nil {
+		return err
+	}
+	workspaceGeneralSetting, err := s.Store.GetWorkspaceGeneralSetting(ctx)
+	if err != nil {
+		return err
+	}
+	memoFind.WeekStart = time.Weekday(workspaceGeneralSetting.WeekStartDayOffset % 7)
+	return nil
+}
+
 func (s *APIV1Service) getContentLengthLimit(ctx context.Context) (int, error) {
 	workspaceMemoRelatedSetting, err := s.Store.GetWorkspaceMemoRelatedSetting(ctx)
 	if err != nil {
@@ -1 +1,70 @@
 package v1
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/usememos/memos/plugin/filter"
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+)
+
+func (s *APIV1Service) ValidateFilter(_ context.Context, request *v1pb.ValidateFilterRequest) (*v1pb.ValidateFilterResponse, error) {
+	var kind filter.Kind
+	switch request.Kind {
+	case v1pb.ValidateFilterRequest_KIND_UNSPECIFIED, v1pb.ValidateFilterRequest_MEMO:
+		kind = filter.MemoFilter
+	case v1pb.ValidateFilterRequest_USER:
+		kind = filter.UserFilter
+	case v1pb.ValidateFilterRequest_ATTACHMENT:
+		kind = filter.AttachmentFilter
+	case v1pb.ValidateFilterRequest_REACTION:
+		kind = filter.ReactionFilter
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "invalid filter kind: %v", request.Kind)
+	}
+
+	response := &v1pb.ValidateFilterResponse{
+		Valid:  true,
+		Errors: []*v1pb.ValidateFilterResponse_Error{},
+	}
+	for _, validationErr := range filter.Validate(request.Filter, kind, s.getFilterDialect()) {
+		response.Valid = false
+		response.Errors = append(response.Errors, &v1pb.ValidateFilterResponse_Error{
+			Message:     validationErr.Message,
+			Line:        int32(validationErr.Line),
+			Column:      int32(validationErr.Column),
+			Offset:      int32(validationErr.Offset),
+			Suggestions: validationErr.Suggestions,
+		})
+	}
+	return response, nil
+}
+
+// validateFilter validates a filter of the kind and returns its first error.
+func (s *APIV1Service) validateFilter(_ context.Context, filterStr string, kind filter.Kind) error {
+	if filterStr == "" {
+		return errors.New("filter cannot be empty")
+	}
+	if validationErrs := filter.Validate(filterStr, kind, s.getFilterDialect()); len(validationErrs) > 0 {
+		return validationErrs[0]
+	}
+	return nil
+}
+
+// getFilterDialect returns the SQL dialect of the database driver.
+func (s *APIV1Service) getFilterDialect() filter.SQLDialect {
+	switch s.Profile.Driver {
+	case "sqlite":
+		return &filter.SQLiteDialect{}
+	case "mysql":
+		return &filter.MySQLDialect{}
+	case "postgres":
+		return &filter.PostgreSQLDialect{}
+	default:
+		// Default to SQLite for unknown drivers
+		return &filter.SQLiteDialect{}
+	}
+}
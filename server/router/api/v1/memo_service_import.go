@@ -0,0 +1,408 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/lithammer/shortuuid/v4"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/usememos/memos/internal/base"
+	"github.com/usememos/memos/plugin/archive"
+	"github.com/usememos/memos/plugin/exif"
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/server/runner/memopayload"
+	"github.com/usememos/memos/store"
+)
+
+func (s *APIV1Service) ImportMemos(ctx context.Context, request *v1pb.ImportMemosRequest) (*v1pb.ImportMemosResponse, error) {
+	user, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get user")
+	}
+	if user == nil {
+		return nil, status.Errorf(codes.Unauthenticated, "user not authenticated")
+	}
+	if len(request.Data) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "data is required")
+	}
+	return s.ImportUserMemos(ctx, user.ID, request)
+}
+
+// ImportUserMemos imports the notes of an import source as memos of the user.
+// It is shared by the ImportMemos API and the import command.
+// The import is not atomic: when it fails partway, the memos created so far are kept and their
+// number is reported in the error.
+func (s *APIV1Service) ImportUserMemos(ctx context.Context, userID int32, request *v1pb.ImportMemosRequest) (*v1pb.ImportMemosResponse, error) {
+	importer, err := s.newMemoImporter(ctx, userID, request.DryRun)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to prepare import: %v", err)
+	}
+	// The files of the source are limited to the upload size limit before they are decompressed.
+	maxFileSize := int64(importer.uploadSizeLimit)
+	format := archive.Format(request.Format.String())
+	if request.Format == v1pb.ImportMemosRequest_FORMAT_UNSPECIFIED {
+		detected, err := archive.DetectFormat(request.Data, maxFileSize)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "failed to detect format: %v", err)
+		}
+		format = detected
+	}
+	location, err := s.Store.GetUserLocation(ctx, userID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get user location: %v", err)
+	}
+	notes, err := archive.ReadNotes(request.Data, format, location, maxFileSize)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to read import source: %v", err)
+	}
+
+	importer.response.Format = v1pb.ImportMemosRequest_Format(v1pb.ImportMemosRequest_Format_value[string(format)])
+	// Import the oldest notes first so the memo ids follow the original order.
+	now := time.Now()
+	for _, note := range notes {
+		if note.FrontMatter.Created.IsZero() {
+			note.FrontMatter.Created = now
+		}
+		if note.FrontMatter.Updated.Before(note.FrontMatter.Created) {
+			note.FrontMatter.Updated = note.FrontMatter.Created
+		}
+	}
+	sort.SliceStable(notes, func(i, j int) bool {
+		return notes[i].FrontMatter.Created.Before(notes[j].FrontMatter.Created)
+	})
+
+	if err := importer.assignUIDs(ctx, notes); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to assign memo uids: %v", err)
+	}
+	for _, note := range notes {
+		if err := importer.importNote(ctx, note); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to import %s after creating %d memos: %v", note.Path, len(importer.memoIDs), err)
+		}
+	}
+	for _, note := range notes {
+		if err := importer.importRelations(ctx, note); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to import relations of %s after creating %d memos: %v", note.Path, len(importer.memoIDs), err)
+		}
+	}
+	return importer.response, nil
+}
+
+// memoImporter creates the memos of the notes of an import source.
+type memoImporter struct {
+	service *APIV1Service
+	userID  int32
+	dryRun  bool
+
+	defaultVisibility        store.Visibility
+	disallowPublicVisibility bool
+	contentLengthLimit       int
+	uploadSizeLimit          int
+	// remainingQuota is the number of bytes the user can still upload, or -1 without storage quota.
+	remainingQuota int64
+
+	// uids maps the paths of the imported notes to the uids of their memos.
+	uids map[string]string
+	// sourceUIDs maps the uids found in the front matter of the notes to the uids of their memos.
+	sourceUIDs map[string]string
+	// memoIDs maps the paths of the imported notes to the ids of their memos.
+	memoIDs  map[string]int32
+	items    map[string]*v1pb.ImportMemosResponse_Item
+	response *v1pb.ImportMemosResponse
+}
+
+func (s *APIV1Service) newMemoImporter(ctx context.Context, userID int32, dryRun bool) (*memoImporter, error) {
+	importer := &memoImporter{
+		service:           s,
+		userID:            userID,
+		dryRun:            dryRun,
+		defaultVisibility: store.Private,
+		uids:              map[string]string{},
+		sourceUIDs:        map[string]string{},
+		memoIDs:           map[string]int32{},
+		items:             map[string]*v1pb.ImportMemosResponse_Item{},
+		response:          &v1pb.ImportMemosResponse{},
+	}
+	userSetting, err := s.Store.GetUserSetting(ctx, &store.FindUserSetting{
+		UserID: &userID,
+		Key:    storepb.UserSetting_GENERAL,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get user general setting")
+	}
+	if visibility, ok := parseStoreVisibility(userSetting.GetGeneral().GetMemoVisibility()); ok {
+		importer.defaultVisibility = visibility
+	}
+	workspaceMemoRelatedSetting, err := s.Store.GetWorkspaceMemoRelatedSetting(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get workspace memo related setting")
+	}
+	importer.disallowPublicVisibility = workspaceMemoRelatedSetting.DisallowPublicVisibility
+	importer.contentLengthLimit = int(workspaceMemoRelatedSetting.ContentLengthLimit)
+	workspaceStorageSetting, err := s.Store.GetWorkspaceStorageSetting(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get workspace storage setting")
+	}
+	importer.uploadSizeLimit = int(workspaceStorageSetting.UploadSizeLimitMb) * MebiByte
+	if importer.uploadSizeLimit == 0 {
+		importer.uploadSizeLimit = MaxUploadBufferSizeBytes
+	}
+	user, err := s.Store.GetUser(ctx, &store.FindUser{ID: &userID})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get user")
+	}
+	if user == nil {
+		return nil, errors.New("user not found")
+	}
+	importer.remainingQuota, err = s.getRemainingStorageQuota(ctx, user)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get storage quota")
+	}
+	return importer, nil
+}
+
+func (i *memoImporter) warn(note *archive.Note, format string, args ...any) {
+	i.response.Warnings = append(i.response.Warnings, note.Path+": "+fmt.Sprintf(format, args...))
+}
+
+// assignUIDs keeps the uids of the notes exported from memos when they are free, and generates the others.
+func (i *memoImporter) assignUIDs(ctx context.Context, notes []*archive.Note) error {
+	used := map[string]bool{}
+	for _, note := range notes {
+		uid := note.FrontMatter.UID
+		if uid != "" && base.UIDMatcher.MatchString(uid) && !used[uid] {
+			memo, err := i.service.Store.GetMemo(ctx, &store.FindMemo{UID: &uid, ExcludeContent: true})
+			if err != nil {
+				return err
+			}
+			if memo != nil {
+				i.warn(note, "memo %s already exists, a new uid is used", uid)
+				uid = ""
+			}
+		} else {
+			uid = ""
+		}
+		if uid == "" {
+			uid = shortuuid.New()
+		}
+		used[uid] = true
+		i.uids[note.Path] = uid
+		if note.FrontMatter.UID != "" {
+			i.sourceUIDs[note.FrontMatter.UID] = uid
+		}
+	}
+	return nil
+}
+
+func (i *memoImporter) importNote(ctx context.Context, note *archive.Note) error {
+	for _, warning := range note.Warnings {
+		i.warn(note, "%s", warning)
+	}
+	if i.contentLengthLimit > 0 && len(note.Content) > i.contentLengthLimit {
+		i.warn(note, "content too long (max %d characters), the note is skipped", i.contentLengthLimit)
+		delete(i.uids, note.Path)
+		return nil
+	}
+	frontMatter := note.FrontMatter
+	item := &v1pb.ImportMemosResponse_Item{
+		Source:     note.Path,
+		CreateTime: timestamppb.New(frontMatter.Created),
+	}
+	i.items[note.Path] = item
+	i.response.Items = append(i.response.Items, item)
+
+	// The attachments are checked before they are read, and read one at a time while they are saved.
+	attachments := []*archive.Attachment{}
+	for _, attachment := range note.Attachments {
+		if attachment.Size > int64(i.uploadSizeLimit) {
+			i.warn(note, "attachment %s exceeds the size limit and is skipped", attachment.Filename)
+			continue
+		}
+		if i.remainingQuota >= 0 {
+			if attachment.Size > i.remainingQuota {
+				i.warn(note, "attachment %s exceeds the storage quota and is skipped", attachment.Filename)
+				continue
+			}
+			i.remainingQuota -= attachment.Size
+		}
+		attachments = append(attachments, attachment)
+	}
+	item.AttachmentCount = int32(len(attachments))
+	if i.dryRun {
+		return nil
+	}
+
+	visibility, ok := parseStoreVisibility(frontMatter.Visibility)
+	if !ok {
+		visibility = i.defaultVisibility
+	}
+	if visibility == store.Public && i.disallowPublicVisibility {
+		visibility = store.Private
+	}
+	create := &store.Memo{
+		UID:        i.uids[note.Path],
+		CreatorID:  i.userID,
+		Content:    note.Content,
+		Visibility: visibility,
+	}
+	if err := memopayload.RebuildMemoPayload(create); err != nil {
+		return errors.Wrap(err, "failed to rebuild memo payload")
+	}
+	if location := frontMatter.Location; location != nil {
+		create.Payload.Location = &storepb.MemoPayload_Location{
+			Placeholder: location.Placeholder,
+			Latitude:    location.Latitude,
+			Longitude:   location.Longitude,
+		}
+	}
+	memo, err := i.service.Store.CreateMemo(ctx, create)
+	if err != nil {
+		return errors.Wrap(err, "failed to create memo")
+	}
+	i.memoIDs[note.Path] = memo.ID
+	item.Memo = fmt.Sprintf("%s%s", MemoNamePrefix, memo.UID)
+
+	// Keep the original timestamps of the note.
+	createdTs, updatedTs := frontMatter.Created.Unix(), frontMatter.Updated.Unix()
+	update := &store.UpdateMemo{
+		ID:        memo.ID,
+		CreatedTs: &createdTs,
+		UpdatedTs: &updatedTs,
+	}
+	if frontMatter.Pinned {
+		update.Pinned = &frontMatter.Pinned
+	}
+	if store.RowStatus(frontMatter.State) == store.Archived {
+		rowStatus := store.Archived
+		update.RowStatus = &rowStatus
+	}
+	if err := i.service.Store.UpdateMemo(ctx, update); err != nil {
+		return errors.Wrap(err, "failed to update memo timestamps")
+	}
+
+	for _, attachment := range attachments {
+		blob, err := attachment.ReadBlob()
+		if err != nil {
+			i.warn(note, "attachment %s cannot be read and is skipped: %v", attachment.Filename, err)
+			item.AttachmentCount--
+			continue
+		}
+		create := &store.Attachment{
+			UID:       shortuuid.New(),
+			CreatorID: i.userID,
+			Filename:  attachment.Filename,
+			Type:      attachment.Type,
+			Size:      int64(len(blob)),
+			Blob:      blob,
+			MemoID:    &memo.ID,
+		}
+		if err := SaveAttachmentBlob(ctx, i.service.Store, create); err != nil {
+			if errors.Is(err, exif.ErrUnsupported) {
+				i.warn(note, "attachment %s has image metadata that cannot be stripped and is skipped", attachment.Filename)
+				item.AttachmentCount--
+				continue
+			}
+			return errors.Wrapf(err, "failed to save attachment %s", attachment.Filename)
+		}
+		if _, err := i.service.Store.CreateAttachment(ctx, create); err != nil {
+			return errors.Wrapf(err, "failed to create attachment %s", attachment.Filename)
+		}
+	}
+	return nil
+}
+
+// importRelations relates the memo of a note to the memos of the notes it links to,
+// and to the memos listed in its front matter.
+func (i *memoImporter) importRelations(ctx context.Context, note *archive.Note) error {
+	item, ok := i.items[note.Path]
+	if !ok {
+		return nil
+	}
+
+	type relation struct {
+		uid          string
+		relationType store.MemoRelationType
+	}
+	relations := []relation{}
+	for _, reference := range note.References {
+		uid, ok := i.uids[reference]
+		if !ok {
+			i.warn(note, "linked note %s is not imported", reference)
+			continue
+		}
+		relations = append(relations, relation{uid: uid, relationType: store.MemoRelationReference})
+	}
+	for _, related := range note.FrontMatter.Relations {
+		relationType := store.MemoRelationType(related.Type)
+		if relationType != store.MemoRelationReference && relationType != store.MemoRelationComment {
+			i.warn(note, "unknown relation type %s", related.Type)
+			continue
+		}
+		if uid, ok := i.sourceUIDs[related.Memo]; ok && i.isImported(uid) {
+			relations = append(relations, relation{uid: uid, relationType: relationType})
+			continue
+		}
+		// The related memo may be part of an earlier import or already exist in the workspace.
+		memo, err := i.service.Store.GetMemo(ctx, &store.FindMemo{UID: &related.Memo, ExcludeContent: true})
+		if err != nil {
+			return errors.Wrap(err, "failed to get related memo")
+		}
+		if memo == nil || (memo.CreatorID != i.userID && memo.Visibility == store.Private) {
+			i.warn(note, "related memo %s not found", related.Memo)
+			continue
+		}
+		relations = append(relations, relation{uid: memo.UID, relationType: relationType})
+	}
+
+	uids := map[string]bool{}
+	for _, relation := range relations {
+		if relation.uid == i.uids[note.Path] || uids[relation.uid] {
+			continue
+		}
+		uids[relation.uid] = true
+		item.RelationCount++
+		if i.dryRun {
+			continue
+		}
+		relatedMemo, err := i.service.Store.GetMemo(ctx, &store.FindMemo{UID: &relation.uid, ExcludeContent: true})
+		if err != nil {
+			return errors.Wrap(err, "failed to get related memo")
+		}
+		if relatedMemo == nil {
+			continue
+		}
+		if _, err := i.service.Store.UpsertMemoRelation(ctx, &store.MemoRelation{
+			MemoID:        i.memoIDs[note.Path],
+			RelatedMemoID: relatedMemo.ID,
+			Type:          relation.relationType,
+		}); err != nil {
+			return errors.Wrap(err, "failed to create memo relation")
+		}
+	}
+	return nil
+}
+
+// isImported reports whether a uid belongs to a memo of the import.
+func (i *memoImporter) isImported(uid string) bool {
+	for _, imported := range i.uids {
+		if imported == uid {
+			return true
+		}
+	}
+	return false
+}
+
+func parseStoreVisibility(visibility string) (store.Visibility, bool) {
+	switch store.Visibility(visibility) {
+	case store.Public, store.Protected, store.Private:
+		return store.Visibility(visibility), true
+	default:
+		return "", false
+	}
+}
@@ -19,6 +19,7 @@ const (
 	IdentityProviderNamePrefix = "identityProviders/"
 	ActivityNamePrefix         = "activities/"
 	WebhookNamePrefix          = "webhooks/"
+	BackupNamePrefix           = "workspace/backups/"
 )
 
 // GetNameParentTokens returns the tokens from a resource name.
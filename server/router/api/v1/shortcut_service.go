@@ -3,12 +3,16 @@ package v1
 import (
 	"context"
 	"fmt"
+	"log/slog"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/usememos/memos/internal/util"
 	"github.com/usememos/memos/plugin/filter"
@@ -57,27 +61,47 @@ func (s *APIV1Service) ListShortcuts(ctx context.Context, request *v1pb.ListShor
 		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
 	}
 
-	userSetting, err := s.Store.GetUserSetting(ctx, &store.FindUserSetting{
-		UserID: &userID,
-		Key:    storepb.UserSetting_SHORTCUTS,
+	userSettings, err := s.Store.ListUserSettings(ctx, &store.FindUserSetting{
+		Key: storepb.UserSetting_SHORTCUTS,
 	})
 	if err != nil {
-		return nil, err
+		return nil, status.Errorf(codes.Internal, "failed to list shortcuts: %v", err)
 	}
-	if userSetting == nil {
-		return &v1pb.ListShortcutsResponse{
-			Shortcuts: []*v1pb.Shortcut{},
-		}, nil
+
+	var subscriptions []string
+	for _, userSetting := range userSettings {
+		if userSetting.UserId == userID {
+			subscriptions = userSetting.GetShortcuts().GetSubscriptions()
+		}
 	}
 
-	shortcutsUserSetting := userSetting.GetShortcuts()
 	shortcuts := []*v1pb.Shortcut{}
-	for _, shortcut := range shortcutsUserSetting.GetShortcuts() {
-		shortcuts = append(shortcuts, &v1pb.Shortcut{
-			Name:   constructShortcutName(userID, shortcut.GetId()),
-			Title:  shortcut.GetTitle(),
-			Filter: shortcut.GetFilter(),
-		})
+	for _, userSetting := range userSettings {
+		if userSetting.UserId != userID {
+			continue
+		}
+		for _, shortcut := range userSetting.GetShortcuts().GetShortcuts() {
+			shortcuts = append(shortcuts, convertShortcutFromStore(userID, shortcut, false))
+		}
+	}
+	// Shortcuts of other users are listed when they are shared with the user and either subscribed or explicitly requested.
+	for _, userSetting := range userSettings {
+		if userSetting.UserId == userID {
+			continue
+		}
+		for _, shortcut := range userSetting.GetShortcuts().GetShortcuts() {
+			if !isShortcutSharedWith(shortcut, userID) {
+				continue
+			}
+			subscribed := slices.Contains(subscriptions, constructShortcutName(userSetting.UserId, shortcut.GetId()))
+			if !subscribed && !request.ShowShared {
+				continue
+			}
+			shortcuts = append(shortcuts, convertShortcutFromStore(userSetting.UserId, shortcut, subscribed))
+		}
+	}
+	if err := s.setShortcutStates(ctx, currentUser, shortcuts...); err != nil {
+		return nil, err
 	}
 
 	return &v1pb.ListShortcutsResponse{
@@ -95,30 +119,25 @@ func (s *APIV1Service) GetShortcut(ctx context.Context, request *v1pb.GetShortcu
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to get current user: %v", err)
 	}
-	if currentUser == nil || currentUser.ID != userID {
+	if currentUser == nil {
 		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
 	}
 
-	userSetting, err := s.Store.GetUserSetting(ctx, &store.FindUserSetting{
-		UserID: &userID,
-		Key:    storepb.UserSetting_SHORTCUTS,
-	})
+	shortcutsUserSetting, err := s.getShortcutsUserSetting(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
-	if userSetting == nil {
-		return nil, status.Errorf(codes.NotFound, "shortcut not found")
-	}
-
-	shortcutsUserSetting := userSetting.GetShortcuts()
 	for _, shortcut := range shortcutsUserSetting.GetShortcuts() {
-		if shortcut.GetId() == shortcutID {
-			return &v1pb.Shortcut{
-				Name:   constructShortcutName(userID, shortcut.GetId()),
-				Title:  shortcut.GetTitle(),
-				Filter: shortcut.GetFilter(),
-			}, nil
+		if shortcut.GetId() != shortcutID {
+			continue
+		}
+		if currentUser.ID != userID && !isShortcutSharedWith(shortcut, currentUser.ID) {
+			return nil, status.Errorf(codes.PermissionDenied, "permission denied")
 		}
+		return s.convertShortcut(ctx, currentUser, userID, shortcut)
+	}
+	if currentUser.ID != userID {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
 	}
 
 	return nil, status.Errorf(codes.NotFound, "shortcut not found")
@@ -146,54 +165,27 @@ func (s *APIV1Service) CreateShortcut(ctx context.Context, request *v1pb.CreateS
 	if newShortcut.Title == "" {
 		return nil, status.Errorf(codes.InvalidArgument, "title is required")
 	}
-	if err := s.validateFilter(ctx, newShortcut.Filter); err != nil {
+	if err := s.validateFilter(ctx, newShortcut.Filter, filter.MemoFilter); err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "invalid filter: %v", err)
 	}
+	if err := s.setShortcutSharing(ctx, newShortcut, request.Shortcut.GetVisibility(), request.Shortcut.GetSharedUsers()); err != nil {
+		return nil, err
+	}
 	if request.ValidateOnly {
-		return &v1pb.Shortcut{
-			Name:   constructShortcutName(userID, newShortcut.GetId()),
-			Title:  newShortcut.GetTitle(),
-			Filter: newShortcut.GetFilter(),
-		}, nil
+		return convertShortcutFromStore(userID, newShortcut, false), nil
 	}
 
-	userSetting, err := s.Store.GetUserSetting(ctx, &store.FindUserSetting{
-		UserID: &userID,
-		Key:    storepb.UserSetting_SHORTCUTS,
-	})
+	shortcutsUserSetting, err := s.getShortcutsUserSetting(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
-	if userSetting == nil {
-		userSetting = &storepb.UserSetting{
-			UserId: userID,
-			Key:    storepb.UserSetting_SHORTCUTS,
-			Value: &storepb.UserSetting_Shortcuts{
-				Shortcuts: &storepb.ShortcutsUserSetting{
-					Shortcuts: []*storepb.ShortcutsUserSetting_Shortcut{},
-				},
-			},
-		}
-	}
-	shortcutsUserSetting := userSetting.GetShortcuts()
-	shortcuts := shortcutsUserSetting.GetShortcuts()
-	shortcuts = append(shortcuts, newShortcut)
-	shortcutsUserSetting.Shortcuts = shortcuts
-
-	userSetting.Value = &storepb.UserSetting_Shortcuts{
-		Shortcuts: shortcutsUserSetting,
-	}
-
-	_, err = s.Store.UpsertUserSetting(ctx, userSetting)
-	if err != nil {
+	shortcutsUserSetting.Shortcuts = append(shortcutsUserSetting.Shortcuts, newShortcut)
+	getShortcutState(shortcutsUserSetting, constructShortcutName(userID, newShortcut.Id)).LastOpenedTs = time.Now().Unix()
+	if err := s.upsertShortcutsUserSetting(ctx, userID, shortcutsUserSetting); err != nil {
 		return nil, err
 	}
 
-	return &v1pb.Shortcut{
-		Name:   constructShortcutName(userID, newShortcut.GetId()),
-		Title:  newShortcut.GetTitle(),
-		Filter: newShortcut.GetFilter(),
-	}, nil
+	return s.convertShortcut(ctx, currentUser, userID, newShortcut)
 }
 
 func (s *APIV1Service) UpdateShortcut(ctx context.Context, request *v1pb.UpdateShortcutRequest) (*v1pb.Shortcut, error) {
@@ -206,22 +198,54 @@ func (s *APIV1Service) UpdateShortcut(ctx context.Context, request *v1pb.UpdateS
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to get current user: %v", err)
 	}
-	if currentUser == nil || currentUser.ID != userID {
+	// The notification settings belong to the current user, so subscribers may update them as well.
+	notificationPaths, shortcutPaths := []string{}, []string{}
+	for _, path := range request.UpdateMask.GetPaths() {
+		if path == "notify_inbox" || path == "webhook" {
+			notificationPaths = append(notificationPaths, path)
+		} else {
+			shortcutPaths = append(shortcutPaths, path)
+		}
+	}
+	if currentUser == nil || (currentUser.ID != userID && (len(shortcutPaths) > 0 || len(notificationPaths) == 0)) {
 		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
 	}
 	if request.UpdateMask == nil || len(request.UpdateMask.Paths) == 0 {
 		return nil, status.Errorf(codes.InvalidArgument, "update mask is required")
 	}
+	if len(shortcutPaths) > 0 {
+		if err := s.updateShortcut(ctx, userID, shortcutID, request.Shortcut, shortcutPaths); err != nil {
+			return nil, err
+		}
+	}
 
+	shortcut, err := s.findAccessibleShortcut(ctx, currentUser.ID, userID, shortcutID)
+	if err != nil {
+		return nil, err
+	}
+	if shortcut == nil {
+		return nil, status.Errorf(codes.NotFound, "shortcut not found")
+	}
+	if len(notificationPaths) > 0 {
+		if err := s.updateShortcutNotification(ctx, currentUser.ID, request.Shortcut, notificationPaths); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.convertShortcut(ctx, currentUser, userID, shortcut)
+}
+
+// updateShortcut updates the fields of the shortcut owned by the user.
+func (s *APIV1Service) updateShortcut(ctx context.Context, userID int32, shortcutID string, update *v1pb.Shortcut, paths []string) error {
 	userSetting, err := s.Store.GetUserSetting(ctx, &store.FindUserSetting{
 		UserID: &userID,
 		Key:    storepb.UserSetting_SHORTCUTS,
 	})
 	if err != nil {
-		return nil, err
+		return err
 	}
 	if userSetting == nil {
-		return nil, status.Errorf(codes.NotFound, "shortcut not found")
+		return status.Errorf(codes.NotFound, "shortcut not found")
 	}
 
 	shortcutsUserSetting := userSetting.GetShortcuts()
@@ -231,17 +255,31 @@ func (s *APIV1Service) UpdateShortcut(ctx context.Context, request *v1pb.UpdateS
 	for _, shortcut := range shortcuts {
 		if shortcut.GetId() == shortcutID {
 			foundShortcut = shortcut
-			for _, field := range request.UpdateMask.Paths {
+			current := convertShortcutFromStore(userID, shortcut, false)
+			visibility, sharedUsers := current.Visibility, current.SharedUsers
+			updateSharing := false
+			for _, field := range paths {
 				if field == "title" {
-					if request.Shortcut.GetTitle() == "" {
-						return nil, status.Errorf(codes.InvalidArgument, "title is required")
+					if update.GetTitle() == "" {
+						return status.Errorf(codes.InvalidArgument, "title is required")
 					}
-					shortcut.Title = request.Shortcut.GetTitle()
+					shortcut.Title = update.GetTitle()
 				} else if field == "filter" {
-					if err := s.validateFilter(ctx, request.Shortcut.GetFilter()); err != nil {
-						return nil, status.Errorf(codes.InvalidArgument, "invalid filter: %v", err)
+					if err := s.validateFilter(ctx, update.GetFilter(), filter.MemoFilter); err != nil {
+						return status.Errorf(codes.InvalidArgument, "invalid filter: %v", err)
 					}
-					shortcut.Filter = request.Shortcut.GetFilter()
+					shortcut.Filter = update.GetFilter()
+				} else if field == "visibility" {
+					visibility = update.GetVisibility()
+					updateSharing = true
+				} else if field == "shared_users" {
+					sharedUsers = update.GetSharedUsers()
+					updateSharing = true
+				}
+			}
+			if updateSharing {
+				if err := s.setShortcutSharing(ctx, shortcut, visibility, sharedUsers); err != nil {
+					return err
 				}
 			}
 		}
@@ -249,7 +287,7 @@ func (s *APIV1Service) UpdateShortcut(ctx context.Context, request *v1pb.UpdateS
 	}
 
 	if foundShortcut == nil {
-		return nil, status.Errorf(codes.NotFound, "shortcut not found")
+		return status.Errorf(codes.NotFound, "shortcut not found")
 	}
 
 	shortcutsUserSetting.Shortcuts = newShortcuts
@@ -257,15 +295,7 @@ func (s *APIV1Service) UpdateShortcut(ctx context.Context, request *v1pb.UpdateS
 		Shortcuts: shortcutsUserSetting,
 	}
 	_, err = s.Store.UpsertUserSetting(ctx, userSetting)
-	if err != nil {
-		return nil, err
-	}
-
-	return &v1pb.Shortcut{
-		Name:   constructShortcutName(userID, foundShortcut.GetId()),
-		Title:  foundShortcut.GetTitle(),
-		Filter: foundShortcut.GetFilter(),
-	}, nil
+	return err
 }
 
 func (s *APIV1Service) DeleteShortcut(ctx context.Context, request *v1pb.DeleteShortcutRequest) (*emptypb.Empty, error) {
@@ -308,6 +338,9 @@ func (s *APIV1Service) DeleteShortcut(ctx context.Context, request *v1pb.DeleteS
 		return nil, status.Errorf(codes.NotFound, "shortcut not found")
 	}
 	shortcutsUserSetting.Shortcuts = newShortcuts
+	shortcutsUserSetting.States = slices.DeleteFunc(shortcutsUserSetting.States, func(state *storepb.ShortcutsUserSetting_State) bool {
+		return state.Shortcut == constructShortcutName(userID, shortcutID)
+	})
 	userSetting.Value = &storepb.UserSetting_Shortcuts{
 		Shortcuts: shortcutsUserSetting,
 	}
@@ -319,35 +352,467 @@ func (s *APIV1Service) DeleteShortcut(ctx context.Context, request *v1pb.DeleteS
 	return &emptypb.Empty{}, nil
 }
 
-func (s *APIV1Service) validateFilter(_ context.Context, filterStr string) error {
-	if filterStr == "" {
-		return errors.New("filter cannot be empty")
+func (s *APIV1Service) SubscribeShortcut(ctx context.Context, request *v1pb.SubscribeShortcutRequest) (*v1pb.Shortcut, error) {
+	userID, shortcutID, err := extractUserAndShortcutIDFromName(request.Name)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid shortcut name: %v", err)
+	}
+
+	currentUser, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get current user: %v", err)
+	}
+	if currentUser == nil {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+	if currentUser.ID == userID {
+		return nil, status.Errorf(codes.InvalidArgument, "cannot subscribe to own shortcut")
 	}
-	// Validate the filter.
-	parsedExpr, err := filter.Parse(filterStr, filter.MemoFilterCELAttributes...)
+
+	// Private shortcuts are reported as missing, so their existence is not leaked.
+	shortcut, err := s.findAccessibleShortcut(ctx, currentUser.ID, userID, shortcutID)
 	if err != nil {
-		return errors.Wrap(err, "failed to parse filter")
+		return nil, err
+	}
+	if shortcut == nil {
+		return nil, status.Errorf(codes.NotFound, "shortcut not found")
 	}
-	convertCtx := filter.NewConvertContext()
 
-	// Determine the dialect based on the actual database driver
-	var dialect filter.SQLDialect
-	switch s.Profile.Driver {
-	case "sqlite":
-		dialect = &filter.SQLiteDialect{}
-	case "mysql":
-		dialect = &filter.MySQLDialect{}
-	case "postgres":
-		dialect = &filter.PostgreSQLDialect{}
-	default:
-		// Default to SQLite for unknown drivers
-		dialect = &filter.SQLiteDialect{}
+	shortcutsUserSetting, err := s.getShortcutsUserSetting(ctx, currentUser.ID)
+	if err != nil {
+		return nil, err
+	}
+	name := constructShortcutName(userID, shortcutID)
+	if !slices.Contains(shortcutsUserSetting.Subscriptions, name) {
+		shortcutsUserSetting.Subscriptions = append(shortcutsUserSetting.Subscriptions, name)
+		getShortcutState(shortcutsUserSetting, name).LastOpenedTs = time.Now().Unix()
+		if err := s.upsertShortcutsUserSetting(ctx, currentUser.ID, shortcutsUserSetting); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.convertShortcut(ctx, currentUser, userID, shortcut)
+}
+
+func (s *APIV1Service) OpenShortcut(ctx context.Context, request *v1pb.OpenShortcutRequest) (*v1pb.Shortcut, error) {
+	userID, shortcutID, err := extractUserAndShortcutIDFromName(request.Name)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid shortcut name: %v", err)
+	}
+
+	currentUser, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get current user: %v", err)
+	}
+	if currentUser == nil {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+
+	shortcut, err := s.findAccessibleShortcut(ctx, currentUser.ID, userID, shortcutID)
+	if err != nil {
+		return nil, err
+	}
+	if shortcut == nil {
+		return nil, status.Errorf(codes.NotFound, "shortcut not found")
+	}
+
+	shortcutsUserSetting, err := s.getShortcutsUserSetting(ctx, currentUser.ID)
+	if err != nil {
+		return nil, err
+	}
+	getShortcutState(shortcutsUserSetting, constructShortcutName(userID, shortcutID)).LastOpenedTs = time.Now().Unix()
+	if err := s.upsertShortcutsUserSetting(ctx, currentUser.ID, shortcutsUserSetting); err != nil {
+		return nil, err
+	}
+
+	return s.convertShortcut(ctx, currentUser, userID, shortcut)
+}
+
+func (s *APIV1Service) UnsubscribeShortcut(ctx context.Context, request *v1pb.UnsubscribeShortcutRequest) (*emptypb.Empty, error) {
+	userID, shortcutID, err := extractUserAndShortcutIDFromName(request.Name)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid shortcut name: %v", err)
+	}
+
+	currentUser, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get current user: %v", err)
+	}
+	if currentUser == nil {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+
+	shortcutsUserSetting, err := s.getShortcutsUserSetting(ctx, currentUser.ID)
+	if err != nil {
+		return nil, err
+	}
+	name := constructShortcutName(userID, shortcutID)
+	index := slices.Index(shortcutsUserSetting.Subscriptions, name)
+	if index < 0 {
+		return nil, status.Errorf(codes.NotFound, "subscription not found")
+	}
+	shortcutsUserSetting.Subscriptions = slices.Delete(shortcutsUserSetting.Subscriptions, index, index+1)
+	shortcutsUserSetting.States = slices.DeleteFunc(shortcutsUserSetting.States, func(state *storepb.ShortcutsUserSetting_State) bool {
+		return state.Shortcut == name
+	})
+	if err := s.upsertShortcutsUserSetting(ctx, currentUser.ID, shortcutsUserSetting); err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// getShortcutsUserSetting returns the shortcuts setting of the user, or an empty one if the user has none.
+func (s *APIV1Service) getShortcutsUserSetting(ctx context.Context, userID int32) (*storepb.ShortcutsUserSetting, error) {
+	userSetting, err := s.Store.GetUserSetting(ctx, &store.FindUserSetting{
+		UserID: &userID,
+		Key:    storepb.UserSetting_SHORTCUTS,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if userSetting == nil || userSetting.GetShortcuts() == nil {
+		return &storepb.ShortcutsUserSetting{
+			Shortcuts: []*storepb.ShortcutsUserSetting_Shortcut{},
+		}, nil
+	}
+	return userSetting.GetShortcuts(), nil
+}
+
+func (s *APIV1Service) upsertShortcutsUserSetting(ctx context.Context, userID int32, shortcutsUserSetting *storepb.ShortcutsUserSetting) error {
+	_, err := s.Store.UpsertUserSetting(ctx, &storepb.UserSetting{
+		UserId: userID,
+		Key:    storepb.UserSetting_SHORTCUTS,
+		Value: &storepb.UserSetting_Shortcuts{
+			Shortcuts: shortcutsUserSetting,
+		},
+	})
+	return err
+}
+
+// findAccessibleShortcut returns the shortcut of the owner if it exists and the user can access it.
+func (s *APIV1Service) findAccessibleShortcut(ctx context.Context, userID, ownerID int32, shortcutID string) (*storepb.ShortcutsUserSetting_Shortcut, error) {
+	shortcutsUserSetting, err := s.getShortcutsUserSetting(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	for _, shortcut := range shortcutsUserSetting.GetShortcuts() {
+		if shortcut.GetId() != shortcutID {
+			continue
+		}
+		if ownerID != userID && !isShortcutSharedWith(shortcut, userID) {
+			return nil, nil
+		}
+		return shortcut, nil
+	}
+	return nil, nil
+}
+
+// updateShortcutNotification updates the notification settings of the user for the shortcut.
+func (s *APIV1Service) updateShortcutNotification(ctx context.Context, userID int32, update *v1pb.Shortcut, paths []string) error {
+	shortcutsUserSetting, err := s.getShortcutsUserSetting(ctx, userID)
+	if err != nil {
+		return err
+	}
+	state := getShortcutState(shortcutsUserSetting, update.Name)
+	for _, path := range paths {
+		if path == "notify_inbox" {
+			state.NotifyInbox = update.NotifyInbox
+		} else if path == "webhook" {
+			state.WebhookId = ""
+			if update.Webhook == "" {
+				continue
+			}
+			webhookID, webhookUserID, err := parseUserWebhookName(update.Webhook)
+			if err != nil {
+				return status.Errorf(codes.InvalidArgument, "invalid webhook name: %v", err)
+			}
+			if webhookUserID != userID {
+				return status.Errorf(codes.PermissionDenied, "permission denied")
+			}
+			webhooks, err := s.Store.GetUserWebhooks(ctx, userID)
+			if err != nil {
+				return status.Errorf(codes.Internal, "failed to get user webhooks: %v", err)
+			}
+			if !slices.ContainsFunc(webhooks, func(webhook *storepb.WebhooksUserSetting_Webhook) bool { return webhook.Id == webhookID }) {
+				return status.Errorf(codes.InvalidArgument, "webhook not found")
+			}
+			state.WebhookId = webhookID
+		}
 	}
+	return s.upsertShortcutsUserSetting(ctx, userID, shortcutsUserSetting)
+}
 
-	converter := filter.NewCommonSQLConverter(dialect)
-	err = converter.ConvertExprToSQL(convertCtx, parsedExpr.GetExpr())
+// getShortcutState returns the state of the shortcut in the setting, adding it if missing.
+func getShortcutState(shortcutsUserSetting *storepb.ShortcutsUserSetting, name string) *storepb.ShortcutsUserSetting_State {
+	for _, state := range shortcutsUserSetting.States {
+		if state.Shortcut == name {
+			return state
+		}
+	}
+	state := &storepb.ShortcutsUserSetting_State{Shortcut: name}
+	shortcutsUserSetting.States = append(shortcutsUserSetting.States, state)
+	return state
+}
+
+func (s *APIV1Service) convertShortcut(ctx context.Context, currentUser *store.User, userID int32, shortcut *storepb.ShortcutsUserSetting_Shortcut) (*v1pb.Shortcut, error) {
+	subscribed := false
+	if currentUser.ID != userID {
+		shortcutsUserSetting, err := s.getShortcutsUserSetting(ctx, currentUser.ID)
+		if err != nil {
+			return nil, err
+		}
+		subscribed = slices.Contains(shortcutsUserSetting.GetSubscriptions(), constructShortcutName(userID, shortcut.GetId()))
+	}
+	shortcutMessage := convertShortcutFromStore(userID, shortcut, subscribed)
+	if err := s.setShortcutStates(ctx, currentUser, shortcutMessage); err != nil {
+		return nil, err
+	}
+	return shortcutMessage, nil
+}
+
+// setShortcutStates sets the memo counts and the notification settings of the shortcuts for the current user.
+// The counts only include the memos the user can see, like ListMemos.
+func (s *APIV1Service) setShortcutStates(ctx context.Context, currentUser *store.User, shortcuts ...*v1pb.Shortcut) error {
+	shortcutsUserSetting, err := s.getShortcutsUserSetting(ctx, currentUser.ID)
 	if err != nil {
-		return errors.Wrap(err, "failed to convert filter to SQL")
+		return err
+	}
+	for _, shortcut := range shortcuts {
+		var lastOpenedTs int64
+		for _, state := range shortcutsUserSetting.GetStates() {
+			if state.Shortcut != shortcut.Name {
+				continue
+			}
+			lastOpenedTs = state.LastOpenedTs
+			shortcut.LastOpenTime = timestamppb.New(time.Unix(state.LastOpenedTs, 0))
+			shortcut.NotifyInbox = state.NotifyInbox
+			if state.WebhookId != "" {
+				shortcut.Webhook = fmt.Sprintf("users/%d/webhooks/%s", currentUser.ID, state.WebhookId)
+			}
+		}
+
+		state := store.Normal
+		memoFind := &store.FindMemo{
+			RowStatus:       &state,
+			ExcludeComments: true,
+			ExcludeContent:  true,
+			Filters:         []string{fmt.Sprintf(`creator_id == %d || visibility in ["PUBLIC", "PROTECTED"]`, currentUser.ID)},
+		}
+		if shortcut.Filter != "" {
+			memoFind.Filters = append(memoFind.Filters, shortcut.Filter)
+		}
+		if err := s.setMemoFindTimeContext(ctx, currentUser, memoFind); err != nil {
+			return status.Errorf(codes.Internal, "failed to get time context: %v", err)
+		}
+		memoCount, err := s.Store.CountMemos(ctx, memoFind)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to count memos: %v", err)
+		}
+		memoFind.Filters = append(memoFind.Filters, fmt.Sprintf(`created_ts > timestamp("%s")`, time.Unix(lastOpenedTs, 0).UTC().Format(time.RFC3339)))
+		newMemoCount, err := s.Store.CountMemos(ctx, memoFind)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to count memos: %v", err)
+		}
+		shortcut.MemoCount = int32(memoCount)
+		shortcut.NewMemoCount = int32(newMemoCount)
 	}
 	return nil
 }
+
+// dispatchShortcutNotificationsInBackground notifies the users whose shortcuts start matching the
+// memo off the request path, since every subscribed shortcut is evaluated against the memo.
+// The previous memo is nil when the memo was not visible before, e.g. when it is created.
+func (s *APIV1Service) dispatchShortcutNotificationsInBackground(memo *store.Memo, memoMessage *v1pb.Memo, previous *filter.Memo) {
+	if memo.RowStatus != store.Normal || memo.Visibility == store.Private {
+		return
+	}
+	go func() {
+		if err := s.dispatchShortcutNotifications(context.Background(), memo, memoMessage, previous); err != nil {
+			slog.Warn("failed to dispatch shortcut notifications", slog.String("memo", memo.UID), slog.Any("error", err))
+		}
+	}()
+}
+
+// dispatchShortcutNotifications notifies the users whose shortcuts start matching the memo.
+func (s *APIV1Service) dispatchShortcutNotifications(ctx context.Context, memo *store.Memo, memoMessage *v1pb.Memo, previous *filter.Memo) error {
+	userSettings, err := s.Store.ListUserSettings(ctx, &store.FindUserSetting{
+		Key: storepb.UserSetting_SHORTCUTS,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to list shortcuts")
+	}
+	shortcuts := map[string]*storepb.ShortcutsUserSetting_Shortcut{}
+	for _, userSetting := range userSettings {
+		for _, shortcut := range userSetting.GetShortcuts().GetShortcuts() {
+			shortcuts[constructShortcutName(userSetting.UserId, shortcut.GetId())] = shortcut
+		}
+	}
+
+	var current *filter.Memo
+	var weekStart time.Weekday
+	for _, userSetting := range userSettings {
+		userID := userSetting.UserId
+		if userID == memo.CreatorID {
+			continue
+		}
+		// The filters are evaluated in the time zone of the subscriber, which is read once per user.
+		var timeContext *filter.TimeContext
+		for _, state := range userSetting.GetShortcuts().GetStates() {
+			if !state.NotifyInbox && state.WebhookId == "" {
+				continue
+			}
+			ownerID, _, err := extractUserAndShortcutIDFromName(state.Shortcut)
+			if err != nil {
+				continue
+			}
+			shortcut, ok := shortcuts[state.Shortcut]
+			if !ok || (ownerID != userID && !isShortcutSharedWith(shortcut, userID)) {
+				continue
+			}
+			if current == nil {
+				if current, err = s.Store.GetFilterMemo(ctx, memo); err != nil {
+					return errors.Wrap(err, "failed to get filter memo")
+				}
+				// Comments do not notify, and their relation is written before the notifications.
+				if current.IsComment {
+					return nil
+				}
+				workspaceGeneralSetting, err := s.Store.GetWorkspaceGeneralSetting(ctx)
+				if err != nil {
+					return errors.Wrap(err, "failed to get workspace general setting")
+				}
+				weekStart = time.Weekday(workspaceGeneralSetting.WeekStartDayOffset % 7)
+			}
+			if timeContext == nil {
+				location, err := s.Store.GetUserLocation(ctx, userID)
+				if err != nil {
+					return errors.Wrap(err, "failed to get user location")
+				}
+				timeContext = filter.NewTimeContext(location, weekStart)
+			}
+
+			evaluator, err := filter.NewMemoEvaluator(shortcut.GetFilter())
+			if err != nil {
+				return errors.Wrap(err, "failed to compile shortcut filter")
+			}
+			evaluator = evaluator.WithTimeContext(timeContext)
+			matched, err := evaluator.Evaluate(current)
+			if err != nil {
+				return errors.Wrapf(err, "failed to evaluate shortcut %s", state.Shortcut)
+			}
+			if !matched {
+				continue
+			}
+			if previous != nil && previous.Visibility != store.Private.String() {
+				if matched, err := evaluator.Evaluate(previous); err != nil || matched {
+					continue
+				}
+			}
+
+			if state.NotifyInbox {
+				activity, err := s.Store.CreateActivity(ctx, &store.Activity{
+					CreatorID: memo.CreatorID,
+					Type:      store.ActivityTypeShortcutMatch,
+					Level:     store.ActivityLevelInfo,
+					Payload: &storepb.ActivityPayload{
+						ShortcutMatch: &storepb.ActivityShortcutMatchPayload{
+							MemoId:   memo.ID,
+							Shortcut: state.Shortcut,
+						},
+					},
+				})
+				if err != nil {
+					return errors.Wrap(err, "failed to create activity")
+				}
+				if _, err := s.Store.CreateInbox(ctx, &store.Inbox{
+					SenderID:   memo.CreatorID,
+					ReceiverID: userID,
+					Status:     store.UNREAD,
+					Message: &storepb.InboxMessage{
+						Type:       storepb.InboxMessage_SHORTCUT_MATCH,
+						ActivityId: &activity.ID,
+					},
+				}); err != nil {
+					return errors.Wrap(err, "failed to create inbox")
+				}
+			}
+			if state.WebhookId != "" && s.Notification != nil {
+				webhooks, err := s.Store.GetUserWebhooks(ctx, userID)
+				if err != nil {
+					return errors.Wrap(err, "failed to get user webhooks")
+				}
+				for _, webhook := range webhooks {
+					if webhook.Id == state.WebhookId {
+						s.Notification.DispatchShortcutWebhook(ctx, webhook, memoMessage)
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// setShortcutSharing validates and sets the visibility and the shared users of the shortcut.
+func (s *APIV1Service) setShortcutSharing(ctx context.Context, shortcut *storepb.ShortcutsUserSetting_Shortcut, visibility v1pb.Shortcut_Visibility, sharedUsers []string) error {
+	if visibility == v1pb.Shortcut_VISIBILITY_UNSPECIFIED {
+		visibility = v1pb.Shortcut_PRIVATE
+	}
+	shortcut.Visibility = storepb.ShortcutsUserSetting_Shortcut_Visibility(visibility)
+	shortcut.SharedUserIds = []int32{}
+	if visibility != v1pb.Shortcut_USERS {
+		return nil
+	}
+	if len(sharedUsers) == 0 {
+		return status.Errorf(codes.InvalidArgument, "shared users are required")
+	}
+	for _, sharedUser := range sharedUsers {
+		sharedUserID, err := ExtractUserIDFromName(sharedUser)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid user name: %v", err)
+		}
+		user, err := s.Store.GetUser(ctx, &store.FindUser{ID: &sharedUserID})
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to get user: %v", err)
+		}
+		if user == nil {
+			return status.Errorf(codes.InvalidArgument, "user not found: %s", sharedUser)
+		}
+		if !slices.Contains(shortcut.SharedUserIds, sharedUserID) {
+			shortcut.SharedUserIds = append(shortcut.SharedUserIds, sharedUserID)
+		}
+	}
+	return nil
+}
+
+// isShortcutSharedWith returns whether the shortcut of another user is visible to the given user.
+func isShortcutSharedWith(shortcut *storepb.ShortcutsUserSetting_Shortcut, userID int32) bool {
+	switch shortcut.GetVisibility() {
+	case storepb.ShortcutsUserSetting_Shortcut_WORKSPACE:
+		return true
+	case storepb.ShortcutsUserSetting_Shortcut_USERS:
+		return slices.Contains(shortcut.GetSharedUserIds(), userID)
+	default:
+		return false
+	}
+}
+
+func convertShortcutFromStore(userID int32, shortcut *storepb.ShortcutsUserSetting_Shortcut, subscribed bool) *v1pb.Shortcut {
+	visibility := v1pb.Shortcut_Visibility(shortcut.GetVisibility())
+	if visibility == v1pb.Shortcut_VISIBILITY_UNSPECIFIED {
+		visibility = v1pb.Shortcut_PRIVATE
+	}
+	sharedUsers := []string{}
+	for _, sharedUserID := range shortcut.GetSharedUserIds() {
+		sharedUsers = append(sharedUsers, fmt.Sprintf("%s%d", UserNamePrefix, sharedUserID))
+	}
+	return &v1pb.Shortcut{
+		Name:        constructShortcutName(userID, shortcut.GetId()),
+		Title:       shortcut.GetTitle(),
+		Filter:      shortcut.GetFilter(),
+		Owner:       fmt.Sprintf("%s%d", UserNamePrefix, userID),
+		Visibility:  visibility,
+		SharedUsers: sharedUsers,
+		Subscribed:  subscribed,
+	}
+}
@@ -0,0 +1,94 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	"github.com/usememos/memos/store"
+)
+
+// withGatewayHeaders returns the context of a gateway request with the HTTP headers.
+func withGatewayHeaders(ctx context.Context, pairs ...string) context.Context {
+	for i := 0; i < len(pairs); i += 2 {
+		pairs[i] = "grpcgateway-" + pairs[i]
+	}
+	return metadata.NewIncomingContext(ctx, metadata.Pairs(pairs...))
+}
+
+func TestGetAttachmentBinaryConditional(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateHostUser(ctx, "test_user")
+	require.NoError(t, err)
+	memo, err := ts.Store.CreateMemo(ctx, &store.Memo{
+		UID:        "memo",
+		CreatorID:  user.ID,
+		Content:    "memo",
+		Visibility: store.Public,
+	})
+	require.NoError(t, err)
+	attachment, err := ts.Store.CreateAttachment(ctx, &store.Attachment{
+		UID:       "attachment",
+		CreatorID: user.ID,
+		Filename:  "hello.txt",
+		Blob:      []byte("hello"),
+		Type:      "text/plain",
+		Size:      5,
+		MemoID:    &memo.ID,
+	})
+	require.NoError(t, err)
+	request := &v1pb.GetAttachmentBinaryRequest{Name: "attachments/attachment", Filename: "hello.txt"}
+	etag := fmt.Sprintf(`"attachment-%d-5"`, attachment.UpdatedTs)
+
+	response, err := ts.Service.GetAttachmentBinary(ctx, request)
+	require.NoError(t, err)
+	require.Equal(t, []byte("hello"), response.Data)
+
+	response, err = ts.Service.GetAttachmentBinary(withGatewayHeaders(ctx, "if-none-match", etag), request)
+	require.NoError(t, err)
+	require.Empty(t, response.Data)
+
+	response, err = ts.Service.GetAttachmentBinary(withGatewayHeaders(ctx, "if-none-match", `"other"`), request)
+	require.NoError(t, err)
+	require.Equal(t, []byte("hello"), response.Data)
+
+	response, err = ts.Service.GetAttachmentBinary(withGatewayHeaders(ctx, "if-modified-since", "Fri, 01 Jan 2100 00:00:00 GMT"), request)
+	require.NoError(t, err)
+	require.Empty(t, response.Data)
+
+	response, err = ts.Service.GetAttachmentBinary(withGatewayHeaders(ctx, "if-modified-since", "Thu, 01 Jan 1970 00:00:00 GMT"), request)
+	require.NoError(t, err)
+	require.Equal(t, []byte("hello"), response.Data)
+}
+
+func TestGetUserAvatarConditional(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.Store.CreateUser(ctx, &store.User{
+		Username:  "test_user",
+		Role:      store.RoleUser,
+		Email:     "test_user@example.com",
+		AvatarURL: "data:image/png;base64,aGVsbG8=",
+	})
+	require.NoError(t, err)
+	request := &v1pb.GetUserAvatarRequest{Name: fmt.Sprintf("users/%d", user.ID)}
+
+	response, err := ts.Service.GetUserAvatar(ctx, request)
+	require.NoError(t, err)
+	require.Equal(t, []byte("hello"), response.Data)
+
+	response, err = ts.Service.GetUserAvatar(withGatewayHeaders(ctx, "if-modified-since", "Fri, 01 Jan 2100 00:00:00 GMT"), request)
+	require.NoError(t, err)
+	require.Empty(t, response.Data)
+}
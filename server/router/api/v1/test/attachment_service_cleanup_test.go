@@ -0,0 +1,69 @@
+package v1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	"github.com/usememos/memos/store"
+)
+
+func TestCleanupAttachments(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	hostUser, err := ts.CreateHostUser(ctx, "admin")
+	require.NoError(t, err)
+	user, err := ts.CreateRegularUser(ctx, "user")
+	require.NoError(t, err)
+	hostCtx := ts.CreateUserContext(ctx, hostUser.ID)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+
+	for _, uid := range []string{"stale", "recent"} {
+		attachment, err := ts.Store.CreateAttachment(ctx, &store.Attachment{
+			UID:       uid,
+			CreatorID: user.ID,
+			Filename:  uid + ".txt",
+			Type:      "text/plain",
+			Blob:      []byte(uid),
+			Size:      int64(len(uid)),
+		})
+		require.NoError(t, err)
+		if uid == "stale" {
+			updatedTs := time.Now().Add(-10 * 24 * time.Hour).Unix()
+			require.NoError(t, ts.Store.UpdateAttachment(ctx, &store.UpdateAttachment{ID: attachment.ID, UpdatedTs: &updatedTs}))
+		}
+	}
+
+	_, err = ts.Service.CleanupAttachments(userCtx, &v1pb.CleanupAttachmentsRequest{DryRun: true})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = ts.Service.CleanupAttachments(hostCtx, &v1pb.CleanupAttachmentsRequest{RetentionDays: -1})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// Without retention in the request and the setting, the default retention of 7 days is used.
+	response, err := ts.Service.CleanupAttachments(hostCtx, &v1pb.CleanupAttachmentsRequest{DryRun: true})
+	require.NoError(t, err)
+	require.Equal(t, []string{"attachments/stale"}, response.Attachments)
+	require.Equal(t, int64(len("stale")), response.Size)
+	response, err = ts.Service.CleanupAttachments(hostCtx, &v1pb.CleanupAttachmentsRequest{RetentionDays: 30, DryRun: true})
+	require.NoError(t, err)
+	require.Empty(t, response.Attachments)
+	attachments, err := ts.Store.ListAttachments(ctx, &store.FindAttachment{})
+	require.NoError(t, err)
+	require.Len(t, attachments, 2)
+
+	response, err = ts.Service.CleanupAttachments(hostCtx, &v1pb.CleanupAttachmentsRequest{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"attachments/stale"}, response.Attachments)
+	attachments, err = ts.Store.ListAttachments(ctx, &store.FindAttachment{})
+	require.NoError(t, err)
+	require.Len(t, attachments, 1)
+	require.Equal(t, "recent", attachments[0].UID)
+}
@@ -0,0 +1,152 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"io"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	"github.com/usememos/memos/plugin/exif"
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	"github.com/usememos/memos/store"
+)
+
+// newTestPhoto returns a JPEG image of 8x4 pixels with EXIF metadata of the camera make Apple and
+// the GPS location 10N 20E.
+func newTestPhoto(t *testing.T) []byte {
+	tiff := []byte("MM\x00\x2a\x00\x00\x00\x08")
+	entry := func(tag, typ uint16, count, value uint32) {
+		tiff = binary.BigEndian.AppendUint16(tiff, tag)
+		tiff = binary.BigEndian.AppendUint16(tiff, typ)
+		tiff = binary.BigEndian.AppendUint32(tiff, count)
+		tiff = binary.BigEndian.AppendUint32(tiff, value)
+	}
+	// The first directory at 8 with the make at 38 and the GPS directory at 44.
+	tiff = binary.BigEndian.AppendUint16(tiff, 2)
+	entry(0x010F, 2, 6, 38)
+	entry(0x8825, 4, 1, 44)
+	tiff = append(tiff, 0, 0, 0, 0)
+	tiff = append(tiff, "Apple\x00"...)
+	// The GPS directory with the coordinates at 98 and 122.
+	tiff = binary.BigEndian.AppendUint16(tiff, 4)
+	entry(0x0001, 2, 2, 'N'<<24)
+	entry(0x0002, 5, 3, 98)
+	entry(0x0003, 2, 2, 'E'<<24)
+	entry(0x0004, 5, 3, 122)
+	tiff = append(tiff, 0, 0, 0, 0)
+	for _, degrees := range []uint32{10, 20} {
+		for _, value := range []uint32{degrees, 0, 0} {
+			tiff = binary.BigEndian.AppendUint32(tiff, value)
+			tiff = binary.BigEndian.AppendUint32(tiff, 1)
+		}
+	}
+
+	buffer := &bytes.Buffer{}
+	require.NoError(t, jpeg.Encode(buffer, image.NewGray(image.Rect(0, 0, 8, 4)), nil))
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+	header := binary.BigEndian.AppendUint16([]byte{0xFF, 0xE1}, uint16(len(segment)+2))
+	return append(append(append([]byte{0xFF, 0xD8}, header...), segment...), buffer.Bytes()[2:]...)
+}
+
+func TestAttachmentImageMetadata(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	hostUser, err := ts.CreateHostUser(ctx, "admin")
+	require.NoError(t, err)
+	user, err := ts.CreateRegularUser(ctx, "user")
+	require.NoError(t, err)
+	hostCtx := ts.CreateUserContext(ctx, hostUser.ID)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+
+	_, err = ts.Service.UpdateWorkspaceSetting(hostCtx, &v1pb.UpdateWorkspaceSettingRequest{
+		Setting: &v1pb.WorkspaceSetting{
+			Name: "workspace/settings/STORAGE",
+			Value: &v1pb.WorkspaceSetting_StorageSetting_{StorageSetting: &v1pb.WorkspaceSetting_StorageSetting{
+				StorageType:            v1pb.WorkspaceSetting_StorageSetting_DATABASE,
+				ImageMetadataStripping: v1pb.WorkspaceSetting_StorageSetting_STRIP_GPS,
+			}},
+		},
+	})
+	require.NoError(t, err)
+
+	createPhoto := func(ctx context.Context) *store.Attachment {
+		attachment, err := ts.Service.CreateAttachment(ctx, &v1pb.CreateAttachmentRequest{
+			Attachment: &v1pb.Attachment{Filename: "photo.jpg", Type: "image/jpeg", Content: newTestPhoto(t)},
+		})
+		require.NoError(t, err)
+		require.NotNil(t, attachment.ImageMetadata)
+		require.Equal(t, int32(8), attachment.ImageMetadata.Width)
+		require.Equal(t, int32(4), attachment.ImageMetadata.Height)
+		require.Equal(t, "Apple", attachment.ImageMetadata.CameraMake)
+		uid := attachment.Name[len("attachments/"):]
+		stored, err := ts.Store.GetAttachment(ctx, &store.FindAttachment{UID: &uid, GetBlob: true})
+		require.NoError(t, err)
+		return stored
+	}
+
+	// The GPS location is stripped from the content, and is not kept without opt-in.
+	stored := createPhoto(hostCtx)
+	reader, metadata, err := exif.Process(bytes.NewReader(stored.Blob), stored.Type, exif.StripNone, nil)
+	require.NoError(t, err)
+	_, err = io.ReadAll(reader)
+	require.NoError(t, err)
+	require.Nil(t, metadata.Location)
+	require.Equal(t, "Apple", metadata.CameraMake)
+	require.Nil(t, stored.Payload.GetImageMetadata().GetLocation())
+
+	// Images whose metadata cannot be stripped are rejected instead of being stored unchanged.
+	_, err = ts.Service.CreateAttachment(hostCtx, &v1pb.CreateAttachmentRequest{
+		Attachment: &v1pb.Attachment{Filename: "photo.heic", Type: "image/heic", Content: []byte("\x00\x00\x00\x18ftypheic\x00\x00\x00\x00")},
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = ts.Service.CreateAttachment(hostCtx, &v1pb.CreateAttachmentRequest{
+		Attachment: &v1pb.Attachment{Filename: "photo.jpg", Type: "image/jpeg", Content: newTestPhoto(t)[:100]},
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = ts.Service.UpdateUserSetting(userCtx, &v1pb.UpdateUserSettingRequest{
+		Setting: &v1pb.UserSetting{
+			Name: fmt.Sprintf("users/%d/settings/GENERAL", user.ID),
+			Value: &v1pb.UserSetting_GeneralSetting_{GeneralSetting: &v1pb.UserSetting_GeneralSetting{
+				LocationFromPhotos: true,
+			}},
+		},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"locationFromPhotos"}},
+	})
+	require.NoError(t, err)
+	stored = createPhoto(userCtx)
+	require.NotNil(t, stored.Payload.GetImageMetadata().GetLocation())
+
+	// The location of the memo is filled from its photo.
+	memo, err := ts.Service.CreateMemo(userCtx, &v1pb.CreateMemoRequest{
+		Memo: &v1pb.Memo{
+			Content:     "photo",
+			Visibility:  v1pb.Visibility_PRIVATE,
+			Attachments: []*v1pb.Attachment{{Name: "attachments/" + stored.UID}},
+		},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, memo.Location)
+	require.InDelta(t, 10, memo.Location.Latitude, 0.0001)
+	require.InDelta(t, 20, memo.Location.Longitude, 0.0001)
+
+	// Wait for the thumbnails that are generated in the background before the data is removed.
+	require.Eventually(t, func() bool {
+		thumbnails, err := filepath.Glob(filepath.Join(ts.Profile.Data, ".thumbnail_cache", "*.webp"))
+		return err == nil && len(thumbnails) == 4
+	}, 10*time.Second, 10*time.Millisecond)
+}
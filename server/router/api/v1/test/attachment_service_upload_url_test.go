@@ -0,0 +1,188 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/usememos/memos/plugin/exif"
+	"github.com/usememos/memos/plugin/storage/storagetest"
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+func TestAttachmentUploadURL(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateRegularUser(ctx, "user")
+	require.NoError(t, err)
+	other, err := ts.CreateRegularUser(ctx, "other")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+	otherCtx := ts.CreateUserContext(ctx, other.ID)
+
+	request := &v1pb.CreateAttachmentUploadURLRequest{Filename: "hello.txt", Type: "text/plain", Size: 5}
+	// Direct uploads require the S3 storage.
+	_, err = ts.Service.CreateAttachmentUploadURL(userCtx, request)
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	fakeS3 := storagetest.NewFakeS3()
+	server := httptest.NewServer(fakeS3)
+	defer server.Close()
+	_, err = ts.Store.UpsertWorkspaceSetting(ctx, &storepb.WorkspaceSetting{
+		Key: storepb.WorkspaceSettingKey_STORAGE,
+		Value: &storepb.WorkspaceSetting_StorageSetting{StorageSetting: &storepb.WorkspaceStorageSetting{
+			StorageType:       storepb.WorkspaceStorageSetting_S3,
+			FilepathTemplate:  "assets/{filename}",
+			UploadSizeLimitMb: 1,
+			S3Config: &storepb.StorageS3Config{
+				AccessKeyId:     "access-key",
+				AccessKeySecret: "secret-key",
+				Endpoint:        server.URL,
+				Region:          "us-east-1",
+				Bucket:          "memos",
+				UsePathStyle:    true,
+			},
+		}},
+	})
+	require.NoError(t, err)
+
+	_, err = ts.Service.CreateAttachmentUploadURL(ctx, request)
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+	_, err = ts.Service.CreateAttachmentUploadURL(userCtx, &v1pb.CreateAttachmentUploadURLRequest{Filename: "large.bin", Type: "application/octet-stream", Size: 2 << 20})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	upload := func(response *v1pb.CreateAttachmentUploadURLResponse, content string) {
+		httpRequest, err := http.NewRequest(response.Method, response.UploadUrl, strings.NewReader(content))
+		require.NoError(t, err)
+		for name, value := range response.Headers {
+			httpRequest.Header.Set(name, value)
+		}
+		httpResponse, err := http.DefaultClient.Do(httpRequest)
+		require.NoError(t, err)
+		httpResponse.Body.Close()
+		require.Equal(t, http.StatusOK, httpResponse.StatusCode)
+	}
+
+	response, err := ts.Service.CreateAttachmentUploadURL(userCtx, request)
+	require.NoError(t, err)
+	require.Equal(t, http.MethodPut, response.Method)
+	// The key holds the UID of the attachment, so uploads never overwrite existing content.
+	require.True(t, strings.HasPrefix(response.UploadUrl, server.URL+"/memos/assets/"))
+	require.Contains(t, response.UploadUrl, "_hello.txt?")
+	require.Equal(t, "text/plain", response.Headers["Content-Type"])
+	require.NotEmpty(t, response.UploadToken)
+
+	// The content must be uploaded before the attachment is finalized.
+	_, err = ts.Service.FinalizeAttachment(userCtx, &v1pb.FinalizeAttachmentRequest{UploadToken: response.UploadToken})
+	require.Equal(t, codes.NotFound, status.Code(err))
+	upload(response, "hello")
+
+	// The token is only valid for its uploader.
+	_, err = ts.Service.FinalizeAttachment(otherCtx, &v1pb.FinalizeAttachmentRequest{UploadToken: response.UploadToken})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = ts.Service.FinalizeAttachment(userCtx, &v1pb.FinalizeAttachmentRequest{UploadToken: response.UploadToken + "x"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	attachment, err := ts.Service.FinalizeAttachment(userCtx, &v1pb.FinalizeAttachmentRequest{UploadToken: response.UploadToken})
+	require.NoError(t, err)
+	require.Equal(t, "hello.txt", attachment.Filename)
+	require.Equal(t, "text/plain", attachment.Type)
+	require.Equal(t, int64(5), attachment.Size)
+	attachmentUID := strings.TrimPrefix(attachment.Name, "attachments/")
+	stored, err := ts.Store.GetAttachment(ctx, &store.FindAttachment{UID: &attachmentUID})
+	require.NoError(t, err)
+	require.Equal(t, user.ID, stored.CreatorID)
+	require.Equal(t, storepb.AttachmentStorageType_S3, stored.StorageType)
+	key := fmt.Sprintf("assets/%s_hello.txt", attachmentUID)
+	require.Equal(t, key, stored.Payload.GetS3Object().GetKey())
+	require.Contains(t, stored.Reference, "X-Amz-Signature")
+	checksum := sha256.Sum256([]byte("hello"))
+	require.Equal(t, hex.EncodeToString(checksum[:]), stored.Sha256)
+	content, ok := fakeS3.Object("/memos/" + key)
+	require.True(t, ok)
+	require.Equal(t, "hello", string(content))
+
+	// The token cannot be replayed.
+	_, err = ts.Service.FinalizeAttachment(userCtx, &v1pb.FinalizeAttachmentRequest{UploadToken: response.UploadToken})
+	require.Equal(t, codes.AlreadyExists, status.Code(err))
+
+	// Content of another size than the requested one is rejected and deleted.
+	response, err = ts.Service.CreateAttachmentUploadURL(userCtx, &v1pb.CreateAttachmentUploadURLRequest{Filename: "short.txt", Type: "text/plain", Size: 3})
+	require.NoError(t, err)
+	objectURL, _, _ := strings.Cut(response.UploadUrl, "?")
+	_, err = server.Client().Do(func() *http.Request {
+		httpRequest, err := http.NewRequest(http.MethodPut, objectURL, strings.NewReader("hello"))
+		require.NoError(t, err)
+		return httpRequest
+	}())
+	require.NoError(t, err)
+	_, err = ts.Service.FinalizeAttachment(userCtx, &v1pb.FinalizeAttachmentRequest{UploadToken: response.UploadToken})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	_, ok = fakeS3.Object(strings.TrimPrefix(objectURL, server.URL))
+	require.False(t, ok)
+
+	// Direct uploads are stripped and deduplicated like the content uploaded to the server.
+	storageSetting, err := ts.Store.GetWorkspaceStorageSetting(ctx)
+	require.NoError(t, err)
+	storageSetting.ImageMetadataStripping = storepb.WorkspaceStorageSetting_STRIP_GPS
+	storageSetting.Deduplicate = true
+	_, err = ts.Store.UpsertWorkspaceSetting(ctx, &storepb.WorkspaceSetting{
+		Key:   storepb.WorkspaceSettingKey_STORAGE,
+		Value: &storepb.WorkspaceSetting_StorageSetting{StorageSetting: storageSetting},
+	})
+	require.NoError(t, err)
+	finalize := func(filename, contentType string, content []byte) (*v1pb.Attachment, string, error) {
+		response, err := ts.Service.CreateAttachmentUploadURL(userCtx, &v1pb.CreateAttachmentUploadURLRequest{Filename: filename, Type: contentType, Size: int64(len(content))})
+		require.NoError(t, err)
+		upload(response, string(content))
+		objectURL, _, _ := strings.Cut(response.UploadUrl, "?")
+		attachment, err := ts.Service.FinalizeAttachment(userCtx, &v1pb.FinalizeAttachmentRequest{UploadToken: response.UploadToken})
+		return attachment, strings.TrimPrefix(objectURL, server.URL), err
+	}
+
+	photo := newTestPhoto(t)
+	attachment, objectPath, err := finalize("photo.jpg", "image/jpeg", photo)
+	require.NoError(t, err)
+	require.Equal(t, "Apple", attachment.ImageMetadata.GetCameraMake())
+	content, ok = fakeS3.Object(objectPath)
+	require.True(t, ok)
+	require.Equal(t, int64(len(content)), attachment.Size)
+	reader, metadata, err := exif.Process(bytes.NewReader(content), "image/jpeg", exif.StripNone, nil)
+	require.NoError(t, err)
+	_, err = io.ReadAll(reader)
+	require.NoError(t, err)
+	require.Nil(t, metadata.Location)
+	require.Equal(t, "Apple", metadata.CameraMake)
+
+	// The same content is shared with the first attachment, and the duplicate upload is deleted.
+	attachment, objectPath, err = finalize("copy.txt", "text/plain", []byte("hello"))
+	require.NoError(t, err)
+	attachmentUID = strings.TrimPrefix(attachment.Name, "attachments/")
+	stored, err = ts.Store.GetAttachment(ctx, &store.FindAttachment{UID: &attachmentUID})
+	require.NoError(t, err)
+	require.Equal(t, key, stored.Payload.GetS3Object().GetKey())
+	_, ok = fakeS3.Object(objectPath)
+	require.False(t, ok)
+
+	// Images whose metadata cannot be stripped are rejected and deleted.
+	_, objectPath, err = finalize("photo.heic", "image/heic", []byte("\x00\x00\x00\x18ftypheic\x00\x00\x00\x00"))
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, ok = fakeS3.Object(objectPath)
+	require.False(t, ok)
+}
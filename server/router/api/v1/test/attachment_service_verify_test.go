@@ -0,0 +1,57 @@
+package v1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	"github.com/usememos/memos/store"
+)
+
+func TestVerifyAttachments(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	hostUser, err := ts.CreateHostUser(ctx, "admin")
+	require.NoError(t, err)
+	user, err := ts.CreateRegularUser(ctx, "user")
+	require.NoError(t, err)
+	hostCtx := ts.CreateUserContext(ctx, hostUser.ID)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+
+	attachment, err := ts.Service.CreateAttachment(userCtx, &v1pb.CreateAttachmentRequest{
+		Attachment: &v1pb.Attachment{
+			Filename: "file.txt",
+			Type:     "text/plain",
+			Content:  []byte("content"),
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "ed7002b439e9ac845f22357d822bac1444730fbdb6016d3ec9432297b9ec9f73", attachment.Sha256)
+
+	// The blob of a database attachment is corrupted.
+	_, err = ts.Store.CreateAttachment(ctx, &store.Attachment{
+		UID:       "corrupted",
+		CreatorID: user.ID,
+		Filename:  "corrupted.txt",
+		Type:      "text/plain",
+		Size:      7,
+		Blob:      []byte("CONTENT"),
+		Sha256:    attachment.Sha256,
+	})
+	require.NoError(t, err)
+
+	_, err = ts.Service.VerifyAttachments(userCtx, &v1pb.VerifyAttachmentsRequest{})
+	require.Error(t, err)
+	response, err := ts.Service.VerifyAttachments(hostCtx, &v1pb.VerifyAttachmentsRequest{})
+	require.NoError(t, err)
+	require.Equal(t, int32(2), response.Total)
+	require.Equal(t, int32(0), response.Recorded)
+	require.Len(t, response.Issues, 1)
+	require.Equal(t, "attachments/corrupted", response.Issues[0].Attachment)
+	require.Equal(t, v1pb.VerifyAttachmentsResponse_Issue_MISMATCHED, response.Issues[0].Problem)
+}
@@ -0,0 +1,157 @@
+package v1
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	"github.com/usememos/memos/store"
+)
+
+func newImportZip(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+	for name, content := range files {
+		fileWriter, err := writer.Create(name)
+		require.NoError(t, err)
+		_, err = fileWriter.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+	return buf.Bytes()
+}
+
+func TestImportMemos(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateRegularUser(ctx, "user")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+
+	data := newImportZip(t, map[string]string{
+		"vault/.obsidian/app.json": "{}",
+		"vault/Ideas.md": `---
+tags: [project]
+created: 2023-05-02T10:00:00Z
+---
+Ask [[Alice]] about the plan.
+
+![[chart.png]]`,
+		"vault/Alice.md":         "---\ncreated: 2023-05-01T10:00:00Z\n---\nAlice's notes",
+		"vault/assets/chart.png": "png",
+	})
+
+	_, err = ts.Service.ImportMemos(ctx, &v1pb.ImportMemosRequest{Data: data})
+	require.Error(t, err)
+	_, err = ts.Service.ImportMemos(userCtx, &v1pb.ImportMemosRequest{Data: []byte("not an archive")})
+	require.Error(t, err)
+
+	// A dry run reports the notes without creating memos.
+	response, err := ts.Service.ImportMemos(userCtx, &v1pb.ImportMemosRequest{Data: data, DryRun: true})
+	require.NoError(t, err)
+	require.Equal(t, v1pb.ImportMemosRequest_OBSIDIAN, response.Format)
+	require.Len(t, response.Items, 2)
+	require.Equal(t, "vault/Alice.md", response.Items[0].Source)
+	require.Empty(t, response.Items[0].Memo)
+	require.Equal(t, "vault/Ideas.md", response.Items[1].Source)
+	require.Equal(t, int32(1), response.Items[1].AttachmentCount)
+	require.Equal(t, int32(1), response.Items[1].RelationCount)
+	memos, err := ts.Store.ListMemos(ctx, &store.FindMemo{CreatorID: &user.ID})
+	require.NoError(t, err)
+	require.Empty(t, memos)
+
+	response, err = ts.Service.ImportMemos(userCtx, &v1pb.ImportMemosRequest{Data: data})
+	require.NoError(t, err)
+	require.Len(t, response.Items, 2)
+	require.Empty(t, response.Warnings)
+
+	ideas, err := ts.Service.GetMemo(userCtx, &v1pb.GetMemoRequest{Name: response.Items[1].Memo})
+	require.NoError(t, err)
+	require.Equal(t, "# Ideas\n\nAsk Alice about the plan.\n\n#project", ideas.Content)
+	require.Equal(t, []string{"project"}, ideas.Tags)
+	require.Equal(t, v1pb.Visibility_PRIVATE, ideas.Visibility)
+	require.Equal(t, time.Date(2023, 5, 2, 10, 0, 0, 0, time.UTC), ideas.CreateTime.AsTime())
+	require.Len(t, ideas.Attachments, 1)
+	require.Equal(t, "chart.png", ideas.Attachments[0].Filename)
+	require.Equal(t, "image/png", ideas.Attachments[0].Type)
+	require.Len(t, ideas.Relations, 1)
+	require.Equal(t, response.Items[0].Memo, ideas.Relations[0].RelatedMemo.Name)
+	require.Equal(t, v1pb.MemoRelation_REFERENCE, ideas.Relations[0].Type)
+
+	alice, err := ts.Service.GetMemo(userCtx, &v1pb.GetMemoRequest{Name: response.Items[0].Memo})
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC), alice.CreateTime.AsTime())
+}
+
+func TestImportExportedUserData(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateRegularUser(ctx, "user")
+	require.NoError(t, err)
+	otherUser, err := ts.CreateRegularUser(ctx, "other")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+	otherUserCtx := ts.CreateUserContext(ctx, otherUser.ID)
+
+	referenced, err := ts.Service.CreateMemo(userCtx, &v1pb.CreateMemoRequest{
+		Memo: &v1pb.Memo{Content: "Referenced memo", Visibility: v1pb.Visibility_PROTECTED},
+	})
+	require.NoError(t, err)
+	referencedUID := strings.TrimPrefix(referenced.Name, "memos/")
+	referencedMemo, err := ts.Store.GetMemo(ctx, &store.FindMemo{UID: &referencedUID})
+	require.NoError(t, err)
+	// Keep the order of the imported memos deterministic.
+	createdTs := time.Now().Add(-time.Hour).Unix()
+	require.NoError(t, ts.Store.UpdateMemo(ctx, &store.UpdateMemo{ID: referencedMemo.ID, CreatedTs: &createdTs}))
+	attachment, err := ts.Service.CreateAttachment(userCtx, &v1pb.CreateAttachmentRequest{
+		Attachment: &v1pb.Attachment{Filename: "report.pdf", Type: "application/pdf", Content: []byte("report")},
+	})
+	require.NoError(t, err)
+	memo, err := ts.Service.CreateMemo(userCtx, &v1pb.CreateMemoRequest{
+		Memo: &v1pb.Memo{
+			Content:     "Hello #work",
+			Visibility:  v1pb.Visibility_PUBLIC,
+			Attachments: []*v1pb.Attachment{{Name: attachment.Name}},
+			Relations: []*v1pb.MemoRelation{{
+				RelatedMemo: &v1pb.MemoRelation_Memo{Name: referenced.Name},
+				Type:        v1pb.MemoRelation_REFERENCE,
+			}},
+		},
+	})
+	require.NoError(t, err)
+
+	stream := &httpBodyStream{ctx: userCtx}
+	require.NoError(t, ts.Service.ExportUserData(&v1pb.ExportUserDataRequest{Name: fmt.Sprintf("users/%d", user.ID)}, stream))
+
+	// The uids of the exported memos are taken, so the imported memos get new ones.
+	response, err := ts.Service.ImportMemos(otherUserCtx, &v1pb.ImportMemosRequest{Data: stream.data.Bytes()})
+	require.NoError(t, err)
+	require.Equal(t, v1pb.ImportMemosRequest_MARKDOWN, response.Format)
+	require.Len(t, response.Items, 2)
+	require.Len(t, response.Warnings, 2)
+
+	imported, err := ts.Service.GetMemo(otherUserCtx, &v1pb.GetMemoRequest{Name: response.Items[1].Memo})
+	require.NoError(t, err)
+	require.NotEqual(t, memo.Name, imported.Name)
+	require.Equal(t, memo.Content, imported.Content)
+	require.Equal(t, v1pb.Visibility_PUBLIC, imported.Visibility)
+	require.Equal(t, memo.CreateTime.AsTime(), imported.CreateTime.AsTime())
+	require.Len(t, imported.Attachments, 1)
+	require.Equal(t, "report.pdf", imported.Attachments[0].Filename)
+	require.Len(t, imported.Relations, 1)
+	require.Equal(t, response.Items[0].Memo, imported.Relations[0].RelatedMemo.Name)
+}
@@ -7,6 +7,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	apiv1 "github.com/usememos/memos/proto/gen/api/v1"
 )
@@ -250,3 +252,142 @@ func TestListMemos(t *testing.T) {
 	require.NotNil(t, userTwoReaction)
 	require.Equal(t, "👍", userTwoReaction.ReactionType)
 }
+
+func TestListMemoLocations(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateRegularUser(ctx, "test-user")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+
+	newYork, err := ts.Service.CreateMemo(userCtx, &apiv1.CreateMemoRequest{
+		Memo: &apiv1.Memo{
+			Content:    "New York",
+			Visibility: apiv1.Visibility_PUBLIC,
+			Location:   &apiv1.Location{Placeholder: "New York", Latitude: 40.7128, Longitude: -74.006},
+		},
+	})
+	require.NoError(t, err)
+	london, err := ts.Service.CreateMemo(userCtx, &apiv1.CreateMemoRequest{
+		Memo: &apiv1.Memo{
+			Content:    "London",
+			Visibility: apiv1.Visibility_PRIVATE,
+			Location:   &apiv1.Location{Placeholder: "London", Latitude: 51.5074, Longitude: -0.1278},
+		},
+	})
+	require.NoError(t, err)
+	_, err = ts.Service.CreateMemo(userCtx, &apiv1.CreateMemoRequest{
+		Memo: &apiv1.Memo{
+			Content:    "Nowhere",
+			Visibility: apiv1.Visibility_PUBLIC,
+		},
+	})
+	require.NoError(t, err)
+
+	// The creator sees all memos with a location.
+	resp, err := ts.Service.ListMemoLocations(userCtx, &apiv1.ListMemoLocationsRequest{})
+	require.NoError(t, err)
+	require.Equal(t, "FeatureCollection", resp.Type)
+	require.Len(t, resp.Features, 2)
+	names := []string{resp.Features[0].Properties.Name, resp.Features[1].Properties.Name}
+	require.ElementsMatch(t, []string{newYork.Name, london.Name}, names)
+	for _, feature := range resp.Features {
+		require.Equal(t, "Feature", feature.Type)
+		require.Equal(t, "Point", feature.Geometry.Type)
+		require.Len(t, feature.Geometry.Coordinates, 2)
+	}
+
+	// Anonymous users only see public memos.
+	resp, err = ts.Service.ListMemoLocations(ctx, &apiv1.ListMemoLocationsRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.Features, 1)
+	require.Equal(t, newYork.Name, resp.Features[0].Properties.Name)
+	require.Equal(t, []float64{-74.006, 40.7128}, resp.Features[0].Geometry.Coordinates)
+
+	// Geo filters narrow down the features.
+	resp, err = ts.Service.ListMemoLocations(userCtx, &apiv1.ListMemoLocationsRequest{
+		Filter: "within_radius(51.5, -0.12, 10)",
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Features, 1)
+	require.Equal(t, london.Name, resp.Features[0].Properties.Name)
+
+	resp, err = ts.Service.ListMemoLocations(userCtx, &apiv1.ListMemoLocationsRequest{
+		Filter: "within_bbox(40.0, -75.0, 41.0, -73.0)",
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Features, 1)
+	require.Equal(t, newYork.Name, resp.Features[0].Properties.Name)
+
+	resp, err = ts.Service.ListMemoLocations(userCtx, &apiv1.ListMemoLocationsRequest{
+		Filter: "within_radius(48.8566, 2.3522, 100)",
+	})
+	require.NoError(t, err)
+	require.Empty(t, resp.Features)
+}
+
+func TestValidateFilter(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateRegularUser(ctx, "test-user")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+
+	resp, err := ts.Service.ValidateFilter(userCtx, &apiv1.ValidateFilterRequest{
+		Filter: `pinned && tag in ["work"]`,
+	})
+	require.NoError(t, err)
+	require.True(t, resp.Valid)
+	require.Empty(t, resp.Errors)
+
+	// Unknown names are reported at their position with suggestions.
+	resp, err = ts.Service.ValidateFilter(userCtx, &apiv1.ValidateFilterRequest{
+		Filter: `pinned && has_tsk_list`,
+	})
+	require.NoError(t, err)
+	require.False(t, resp.Valid)
+	require.Len(t, resp.Errors, 1)
+	require.Equal(t, int32(1), resp.Errors[0].Line)
+	require.Equal(t, int32(10), resp.Errors[0].Column)
+	require.Equal(t, int32(10), resp.Errors[0].Offset)
+	require.Equal(t, []string{"has_task_list"}, resp.Errors[0].Suggestions)
+
+	// Unsupported usages are reported at the start of the failing expression.
+	resp, err = ts.Service.ValidateFilter(userCtx, &apiv1.ValidateFilterRequest{
+		Filter: "pinned &&\n  visibility > \"PUBLIC\"",
+	})
+	require.NoError(t, err)
+	require.False(t, resp.Valid)
+	require.Len(t, resp.Errors, 1)
+	require.Equal(t, int32(2), resp.Errors[0].Line)
+	require.Equal(t, int32(2), resp.Errors[0].Column)
+	require.Equal(t, int32(12), resp.Errors[0].Offset)
+
+	// Filters are checked against the attributes of their kind.
+	resp, err = ts.Service.ValidateFilter(userCtx, &apiv1.ValidateFilterRequest{
+		Filter: `username == "steven"`,
+		Kind:   apiv1.ValidateFilterRequest_USER,
+	})
+	require.NoError(t, err)
+	require.True(t, resp.Valid)
+	resp, err = ts.Service.ValidateFilter(userCtx, &apiv1.ValidateFilterRequest{
+		Filter: `username == "steven"`,
+	})
+	require.NoError(t, err)
+	require.False(t, resp.Valid)
+
+	// The user filters of ListUsers report the same errors.
+	hostUser, err := ts.CreateHostUser(ctx, "admin")
+	require.NoError(t, err)
+	_, err = ts.Service.ListUsers(ts.CreateUserContext(ctx, hostUser.ID), &apiv1.ListUsersRequest{
+		Filter: `usernme == "steven"`,
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	require.Contains(t, err.Error(), "(line 1, column 1), did you mean username?")
+}
@@ -4,11 +4,14 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/types/known/fieldmaskpb"
 
 	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
 )
 
 func TestListShortcuts(t *testing.T) {
@@ -817,3 +820,265 @@ func TestShortcutCRUDComplete(t *testing.T) {
 		require.Contains(t, err.Error(), "not found")
 	})
 }
+
+func TestShortcutSharing(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	owner, err := ts.CreateRegularUser(ctx, "owner")
+	require.NoError(t, err)
+	member, err := ts.CreateRegularUser(ctx, "member")
+	require.NoError(t, err)
+	guest, err := ts.CreateRegularUser(ctx, "guest")
+	require.NoError(t, err)
+	ownerCtx := ts.CreateUserContext(ctx, owner.ID)
+	memberCtx := ts.CreateUserContext(ctx, member.ID)
+	guestCtx := ts.CreateUserContext(ctx, guest.ID)
+
+	workspaceShortcut, err := ts.Service.CreateShortcut(ownerCtx, &v1pb.CreateShortcutRequest{
+		Parent: fmt.Sprintf("users/%d", owner.ID),
+		Shortcut: &v1pb.Shortcut{
+			Title:      "Team",
+			Filter:     `content.contains("team")`,
+			Visibility: v1pb.Shortcut_WORKSPACE,
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, fmt.Sprintf("users/%d", owner.ID), workspaceShortcut.Owner)
+	require.Equal(t, v1pb.Shortcut_WORKSPACE, workspaceShortcut.Visibility)
+
+	usersShortcut, err := ts.Service.CreateShortcut(ownerCtx, &v1pb.CreateShortcutRequest{
+		Parent: fmt.Sprintf("users/%d", owner.ID),
+		Shortcut: &v1pb.Shortcut{
+			Title:       "Guests",
+			Filter:      `tag in ["guest"]`,
+			Visibility:  v1pb.Shortcut_USERS,
+			SharedUsers: []string{fmt.Sprintf("users/%d", guest.ID)},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{fmt.Sprintf("users/%d", guest.ID)}, usersShortcut.SharedUsers)
+
+	privateShortcut, err := ts.Service.CreateShortcut(ownerCtx, &v1pb.CreateShortcutRequest{
+		Parent: fmt.Sprintf("users/%d", owner.ID),
+		Shortcut: &v1pb.Shortcut{
+			Title:  "Private",
+			Filter: `pinned`,
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, v1pb.Shortcut_PRIVATE, privateShortcut.Visibility)
+
+	// Sharing with users requires the users to be set.
+	_, err = ts.Service.CreateShortcut(ownerCtx, &v1pb.CreateShortcutRequest{
+		Parent: fmt.Sprintf("users/%d", owner.ID),
+		Shortcut: &v1pb.Shortcut{
+			Title:      "Nobody",
+			Filter:     `pinned`,
+			Visibility: v1pb.Shortcut_USERS,
+		},
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "shared users are required")
+
+	// Shared shortcuts are only listed after subscribing unless requested.
+	resp, err := ts.Service.ListShortcuts(memberCtx, &v1pb.ListShortcutsRequest{Parent: fmt.Sprintf("users/%d", member.ID)})
+	require.NoError(t, err)
+	require.Empty(t, resp.Shortcuts)
+	resp, err = ts.Service.ListShortcuts(memberCtx, &v1pb.ListShortcutsRequest{Parent: fmt.Sprintf("users/%d", member.ID), ShowShared: true})
+	require.NoError(t, err)
+	require.Len(t, resp.Shortcuts, 1)
+	require.Equal(t, workspaceShortcut.Name, resp.Shortcuts[0].Name)
+	require.False(t, resp.Shortcuts[0].Subscribed)
+	resp, err = ts.Service.ListShortcuts(guestCtx, &v1pb.ListShortcutsRequest{Parent: fmt.Sprintf("users/%d", guest.ID), ShowShared: true})
+	require.NoError(t, err)
+	require.Len(t, resp.Shortcuts, 2)
+
+	// Private shortcuts and shortcuts shared with other users cannot be subscribed to.
+	_, err = ts.Service.SubscribeShortcut(memberCtx, &v1pb.SubscribeShortcutRequest{Name: privateShortcut.Name})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "shortcut not found")
+	_, err = ts.Service.SubscribeShortcut(memberCtx, &v1pb.SubscribeShortcutRequest{Name: usersShortcut.Name})
+	require.Error(t, err)
+	_, err = ts.Service.GetShortcut(memberCtx, &v1pb.GetShortcutRequest{Name: usersShortcut.Name})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "permission denied")
+	_, err = ts.Service.SubscribeShortcut(ownerCtx, &v1pb.SubscribeShortcutRequest{Name: workspaceShortcut.Name})
+	require.Error(t, err)
+
+	subscribed, err := ts.Service.SubscribeShortcut(memberCtx, &v1pb.SubscribeShortcutRequest{Name: workspaceShortcut.Name})
+	require.NoError(t, err)
+	require.True(t, subscribed.Subscribed)
+	resp, err = ts.Service.ListShortcuts(memberCtx, &v1pb.ListShortcutsRequest{Parent: fmt.Sprintf("users/%d", member.ID)})
+	require.NoError(t, err)
+	require.Len(t, resp.Shortcuts, 1)
+	require.Equal(t, workspaceShortcut.Name, resp.Shortcuts[0].Name)
+	require.Equal(t, fmt.Sprintf("users/%d", owner.ID), resp.Shortcuts[0].Owner)
+	require.True(t, resp.Shortcuts[0].Subscribed)
+	shortcut, err := ts.Service.GetShortcut(memberCtx, &v1pb.GetShortcutRequest{Name: workspaceShortcut.Name})
+	require.NoError(t, err)
+	require.True(t, shortcut.Subscribed)
+
+	// Running the shared filter still applies the memo visibility checks.
+	_, err = ts.Service.CreateMemo(ownerCtx, &v1pb.CreateMemoRequest{
+		Memo: &v1pb.Memo{Content: "private team notes", Visibility: v1pb.Visibility_PRIVATE},
+	})
+	require.NoError(t, err)
+	_, err = ts.Service.CreateMemo(ownerCtx, &v1pb.CreateMemoRequest{
+		Memo: &v1pb.Memo{Content: "team announcement", Visibility: v1pb.Visibility_PROTECTED},
+	})
+	require.NoError(t, err)
+	memos, err := ts.Service.ListMemos(memberCtx, &v1pb.ListMemosRequest{Filter: shortcut.Filter})
+	require.NoError(t, err)
+	require.Len(t, memos.Memos, 1)
+	require.Equal(t, "team announcement", memos.Memos[0].Content)
+
+	// Making the shortcut private hides it from subscribers.
+	_, err = ts.Service.UpdateShortcut(ownerCtx, &v1pb.UpdateShortcutRequest{
+		Shortcut:   &v1pb.Shortcut{Name: workspaceShortcut.Name, Visibility: v1pb.Shortcut_PRIVATE},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"visibility"}},
+	})
+	require.NoError(t, err)
+	resp, err = ts.Service.ListShortcuts(memberCtx, &v1pb.ListShortcutsRequest{Parent: fmt.Sprintf("users/%d", member.ID)})
+	require.NoError(t, err)
+	require.Empty(t, resp.Shortcuts)
+
+	_, err = ts.Service.UnsubscribeShortcut(memberCtx, &v1pb.UnsubscribeShortcutRequest{Name: workspaceShortcut.Name})
+	require.NoError(t, err)
+	_, err = ts.Service.UnsubscribeShortcut(memberCtx, &v1pb.UnsubscribeShortcutRequest{Name: workspaceShortcut.Name})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "subscription not found")
+}
+
+func TestShortcutCountsAndNotifications(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	owner, err := ts.CreateRegularUser(ctx, "owner")
+	require.NoError(t, err)
+	member, err := ts.CreateRegularUser(ctx, "member")
+	require.NoError(t, err)
+	ownerCtx := ts.CreateUserContext(ctx, owner.ID)
+	memberCtx := ts.CreateUserContext(ctx, member.ID)
+
+	shortcut, err := ts.Service.CreateShortcut(ownerCtx, &v1pb.CreateShortcutRequest{
+		Parent: fmt.Sprintf("users/%d", owner.ID),
+		Shortcut: &v1pb.Shortcut{
+			Title:      "Releases",
+			Filter:     `content.contains("release")`,
+			Visibility: v1pb.Shortcut_WORKSPACE,
+		},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, shortcut.LastOpenTime)
+	_, err = ts.Service.SubscribeShortcut(memberCtx, &v1pb.SubscribeShortcutRequest{Name: shortcut.Name})
+	require.NoError(t, err)
+
+	// Subscribers can only update their own notification settings.
+	_, err = ts.Service.UpdateShortcut(memberCtx, &v1pb.UpdateShortcutRequest{
+		Shortcut:   &v1pb.Shortcut{Name: shortcut.Name, Title: "Mine", NotifyInbox: true},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"title", "notify_inbox"}},
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "permission denied")
+	updated, err := ts.Service.UpdateShortcut(memberCtx, &v1pb.UpdateShortcutRequest{
+		Shortcut:   &v1pb.Shortcut{Name: shortcut.Name, NotifyInbox: true},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"notify_inbox"}},
+	})
+	require.NoError(t, err)
+	require.True(t, updated.NotifyInbox)
+	require.Equal(t, "Releases", updated.Title)
+	_, err = ts.Service.UpdateShortcut(memberCtx, &v1pb.UpdateShortcutRequest{
+		Shortcut:   &v1pb.Shortcut{Name: shortcut.Name, Webhook: fmt.Sprintf("users/%d/webhooks/missing", member.ID)},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"webhook"}},
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "webhook not found")
+
+	listInboxes := func() []*v1pb.Inbox {
+		resp, err := ts.Service.ListInboxes(memberCtx, &v1pb.ListInboxesRequest{Parent: fmt.Sprintf("users/%d", member.ID)})
+		require.NoError(t, err)
+		return resp.Inboxes
+	}
+
+	// New visible memos matching the filter notify the subscriber.
+	releaseMemo, err := ts.Service.CreateMemo(ownerCtx, &v1pb.CreateMemoRequest{
+		Memo: &v1pb.Memo{Content: "release notes", Visibility: v1pb.Visibility_PROTECTED},
+	})
+	require.NoError(t, err)
+	_, err = ts.Service.CreateMemo(ownerCtx, &v1pb.CreateMemoRequest{
+		Memo: &v1pb.Memo{Content: "private release plan", Visibility: v1pb.Visibility_PRIVATE},
+	})
+	require.NoError(t, err)
+	otherMemo, err := ts.Service.CreateMemo(ownerCtx, &v1pb.CreateMemoRequest{
+		Memo: &v1pb.Memo{Content: "hello", Visibility: v1pb.Visibility_PUBLIC},
+	})
+	require.NoError(t, err)
+	// The notifications are dispatched in the background.
+	waitInboxes := func(count int) []*v1pb.Inbox {
+		require.Eventually(t, func() bool { return len(listInboxes()) >= count }, 5*time.Second, 10*time.Millisecond)
+		inboxes := listInboxes()
+		require.Len(t, inboxes, count)
+		return inboxes
+	}
+	inboxes := waitInboxes(1)
+	require.Equal(t, v1pb.Inbox_SHORTCUT_MATCH, inboxes[0].Type)
+	activity, err := ts.Service.GetActivity(memberCtx, &v1pb.GetActivityRequest{Name: fmt.Sprintf("activities/%d", inboxes[0].GetActivityId())})
+	require.NoError(t, err)
+	require.Equal(t, v1pb.Activity_SHORTCUT_MATCH, activity.Type)
+	require.Equal(t, releaseMemo.Name, activity.Payload.GetShortcutMatch().Memo)
+	require.Equal(t, shortcut.Name, activity.Payload.GetShortcutMatch().Shortcut)
+
+	// Only updates that make a memo start matching notify the subscriber.
+	_, err = ts.Service.UpdateMemo(ownerCtx, &v1pb.UpdateMemoRequest{
+		Memo:       &v1pb.Memo{Name: releaseMemo.Name, Content: "release notes v2"},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"content"}},
+	})
+	require.NoError(t, err)
+	// Comments do not notify the subscriber.
+	_, err = ts.Service.CreateMemoComment(ownerCtx, &v1pb.CreateMemoCommentRequest{
+		Name:    releaseMemo.Name,
+		Comment: &v1pb.Memo{Content: "release comment", Visibility: v1pb.Visibility_PROTECTED},
+	})
+	require.NoError(t, err)
+	_, err = ts.Service.UpdateMemo(ownerCtx, &v1pb.UpdateMemoRequest{
+		Memo:       &v1pb.Memo{Name: otherMemo.Name, Content: "hello release"},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"content"}},
+	})
+	require.NoError(t, err)
+	waitInboxes(2)
+	// Give the other dispatches time to complete before checking that they do not notify.
+	time.Sleep(100 * time.Millisecond)
+	require.Len(t, listInboxes(), 2)
+
+	// The owner does not get notified about their own memos.
+	resp, err := ts.Service.ListInboxes(ownerCtx, &v1pb.ListInboxesRequest{Parent: fmt.Sprintf("users/%d", owner.ID)})
+	require.NoError(t, err)
+	require.Empty(t, resp.Inboxes)
+
+	// Move the last open time of the subscriber back to count the memos as new.
+	userSetting, err := ts.Store.GetUserSetting(ctx, &store.FindUserSetting{UserID: &member.ID, Key: storepb.UserSetting_SHORTCUTS})
+	require.NoError(t, err)
+	for _, state := range userSetting.GetShortcuts().GetStates() {
+		state.LastOpenedTs -= 60
+	}
+	_, err = ts.Store.UpsertUserSetting(ctx, userSetting)
+	require.NoError(t, err)
+
+	got, err := ts.Service.GetShortcut(memberCtx, &v1pb.GetShortcutRequest{Name: shortcut.Name})
+	require.NoError(t, err)
+	require.Equal(t, int32(2), got.MemoCount)
+	require.Equal(t, int32(2), got.NewMemoCount)
+	got, err = ts.Service.GetShortcut(ownerCtx, &v1pb.GetShortcutRequest{Name: shortcut.Name})
+	require.NoError(t, err)
+	require.Equal(t, int32(3), got.MemoCount)
+
+	opened, err := ts.Service.OpenShortcut(memberCtx, &v1pb.OpenShortcutRequest{Name: shortcut.Name})
+	require.NoError(t, err)
+	require.Equal(t, int32(2), opened.MemoCount)
+	require.Equal(t, int32(0), opened.NewMemoCount)
+}
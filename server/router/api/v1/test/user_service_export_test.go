@@ -0,0 +1,130 @@
+package v1
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/grpc"
+
+	"github.com/usememos/memos/plugin/archive"
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+// httpBodyStream collects the chunks sent to a server stream of HttpBody.
+type httpBodyStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	data bytes.Buffer
+}
+
+func (s *httpBodyStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *httpBodyStream) Send(body *httpbody.HttpBody) error {
+	s.data.Write(body.Data)
+	return nil
+}
+
+func TestExportUserData(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateRegularUser(ctx, "user")
+	require.NoError(t, err)
+	otherUser, err := ts.CreateRegularUser(ctx, "other")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+
+	referenced, err := ts.Store.CreateMemo(ctx, &store.Memo{
+		UID:        "referenced",
+		CreatorID:  user.ID,
+		Content:    "Referenced memo",
+		Visibility: store.Private,
+	})
+	require.NoError(t, err)
+	memo, err := ts.Store.CreateMemo(ctx, &store.Memo{
+		UID:        "exported",
+		CreatorID:  user.ID,
+		Content:    "Hello #work\n\n![inline](http://localhost:8080/file/attachments/inline/inline.png)",
+		Visibility: store.Public,
+		Payload: &storepb.MemoPayload{
+			Tags:     []string{"work"},
+			Location: &storepb.MemoPayload_Location{Placeholder: "Paris", Latitude: 48.8566, Longitude: 2.3522},
+		},
+	})
+	require.NoError(t, err)
+	_, err = ts.Store.UpsertMemoRelation(ctx, &store.MemoRelation{
+		MemoID:        memo.ID,
+		RelatedMemoID: referenced.ID,
+		Type:          store.MemoRelationReference,
+	})
+	require.NoError(t, err)
+	for _, attachment := range []*store.Attachment{
+		{UID: "inline", CreatorID: user.ID, Filename: "inline.png", Type: "image/png", Blob: []byte("inline"), MemoID: &memo.ID},
+		{UID: "report", CreatorID: user.ID, Filename: "my report.pdf", Type: "application/pdf", Blob: []byte("report"), MemoID: &memo.ID},
+		{UID: "traversal", CreatorID: user.ID, Filename: "../../escaped.txt", Type: "text/plain", Blob: []byte("traversal"), MemoID: &referenced.ID},
+		{UID: "dots", CreatorID: user.ID, Filename: "..", Type: "text/plain", Blob: []byte("dots"), MemoID: &referenced.ID},
+		{UID: "backslash", CreatorID: user.ID, Filename: "..\\..\\windows.txt", Type: "text/plain", Blob: []byte("backslash")},
+	} {
+		attachment.Size = int64(len(attachment.Blob))
+		_, err := ts.Store.CreateAttachment(ctx, attachment)
+		require.NoError(t, err)
+	}
+
+	// Users can only export their own data.
+	err = ts.Service.ExportUserData(&v1pb.ExportUserDataRequest{Name: fmt.Sprintf("users/%d", otherUser.ID)}, &httpBodyStream{ctx: userCtx})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "permission denied")
+
+	stream := &httpBodyStream{ctx: userCtx}
+	err = ts.Service.ExportUserData(&v1pb.ExportUserDataRequest{Name: fmt.Sprintf("users/%d", user.ID)}, stream)
+	require.NoError(t, err)
+
+	reader, err := zip.NewReader(bytes.NewReader(stream.data.Bytes()), int64(stream.data.Len()))
+	require.NoError(t, err)
+	files := map[string][]byte{}
+	for _, file := range reader.File {
+		rc, err := file.Open()
+		require.NoError(t, err)
+		data, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		rc.Close()
+		files[file.Name] = data
+	}
+	require.Len(t, files, 7)
+	require.Contains(t, files, "referenced.md")
+	// The names of attachments cannot escape the attachment folder.
+	for name := range files {
+		require.Equal(t, path.Clean(name), name)
+		require.False(t, strings.HasPrefix(name, "../"))
+	}
+	require.Equal(t, []byte("traversal"), files["attachments/traversal/escaped.txt"])
+	require.Equal(t, []byte("backslash"), files["attachments/backslash/windows.txt"])
+	require.Equal(t, []byte("dots"), files["attachments/dots/attachment"])
+	require.Equal(t, []byte("inline"), files["attachments/inline/inline.png"])
+	require.Equal(t, []byte("report"), files["attachments/report/my report.pdf"])
+
+	frontMatter, content, err := archive.UnmarshalMarkdown(files["exported.md"])
+	require.NoError(t, err)
+	require.Equal(t, "exported", frontMatter.UID)
+	require.Equal(t, "PUBLIC", frontMatter.Visibility)
+	require.Equal(t, []string{"work"}, frontMatter.Tags)
+	require.Equal(t, memo.CreatedTs, frontMatter.Created.Unix())
+	require.Equal(t, &archive.Location{Placeholder: "Paris", Latitude: 48.8566, Longitude: 2.3522}, frontMatter.Location)
+	require.Equal(t, []*archive.Relation{{Type: "REFERENCE", Memo: "referenced"}}, frontMatter.Relations)
+	require.ElementsMatch(t, []string{"attachments/inline/inline.png", "attachments/report/my report.pdf"}, frontMatter.Attachments)
+	require.Equal(t, "Hello #work\n\n![inline](attachments/inline/inline.png)\n\n[my report.pdf](attachments/report/my%20report.pdf)", content)
+}
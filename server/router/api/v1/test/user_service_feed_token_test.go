@@ -0,0 +1,89 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+)
+
+func TestUserFeedTokens(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	user, err := ts.CreateRegularUser(ctx, "user")
+	require.NoError(t, err)
+	other, err := ts.CreateRegularUser(ctx, "other")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+	parent := fmt.Sprintf("users/%d", user.ID)
+
+	t.Run("Create and list feed tokens", func(t *testing.T) {
+		feedToken, err := ts.Service.CreateUserFeedToken(userCtx, &v1pb.CreateUserFeedTokenRequest{
+			Parent:    parent,
+			FeedToken: &v1pb.UserFeedToken{Description: "reader", IncludePrivate: true},
+		})
+		require.NoError(t, err)
+		require.Len(t, feedToken.FeedToken, len(fmt.Sprintf("%d.", user.ID))+32)
+		require.True(t, strings.HasPrefix(feedToken.FeedToken, fmt.Sprintf("%d.", user.ID)))
+		require.Equal(t, fmt.Sprintf("%s/feedTokens/%s", parent, feedToken.FeedToken), feedToken.Name)
+		require.True(t, feedToken.IncludePrivate)
+		require.NotNil(t, feedToken.CreateTime)
+
+		ownerID, storeFeedToken, err := ts.Store.GetFeedTokenOwner(ctx, feedToken.FeedToken)
+		require.NoError(t, err)
+		require.Equal(t, user.ID, ownerID)
+		require.Equal(t, "reader", storeFeedToken.Description)
+
+		// Tokens are only valid for their own user.
+		_, secret, _ := strings.Cut(feedToken.FeedToken, ".")
+		_, storeFeedToken, err = ts.Store.GetFeedTokenOwner(ctx, fmt.Sprintf("%d.%s", other.ID, secret))
+		require.NoError(t, err)
+		require.Nil(t, storeFeedToken)
+		_, storeFeedToken, err = ts.Store.GetFeedTokenOwner(ctx, secret)
+		require.NoError(t, err)
+		require.Nil(t, storeFeedToken)
+
+		response, err := ts.Service.ListUserFeedTokens(userCtx, &v1pb.ListUserFeedTokensRequest{Parent: parent})
+		require.NoError(t, err)
+		require.Len(t, response.FeedTokens, 1)
+		require.Equal(t, feedToken.Name, response.FeedTokens[0].Name)
+	})
+
+	t.Run("Delete feed token", func(t *testing.T) {
+		response, err := ts.Service.ListUserFeedTokens(userCtx, &v1pb.ListUserFeedTokensRequest{Parent: parent})
+		require.NoError(t, err)
+		require.Len(t, response.FeedTokens, 1)
+		feedToken := response.FeedTokens[0]
+
+		_, err = ts.Service.DeleteUserFeedToken(userCtx, &v1pb.DeleteUserFeedTokenRequest{Name: feedToken.Name})
+		require.NoError(t, err)
+		_, storeFeedToken, err := ts.Store.GetFeedTokenOwner(ctx, feedToken.FeedToken)
+		require.NoError(t, err)
+		require.Nil(t, storeFeedToken)
+
+		_, err = ts.Service.DeleteUserFeedToken(userCtx, &v1pb.DeleteUserFeedTokenRequest{Name: feedToken.Name})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "not found")
+	})
+
+	t.Run("Permission denied for other users", func(t *testing.T) {
+		otherCtx := ts.CreateUserContext(ctx, other.ID)
+		_, err := ts.Service.CreateUserFeedToken(otherCtx, &v1pb.CreateUserFeedTokenRequest{
+			Parent:    parent,
+			FeedToken: &v1pb.UserFeedToken{},
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "permission denied")
+
+		_, err = ts.Service.ListUserFeedTokens(ctx, &v1pb.ListUserFeedTokensRequest{Parent: parent})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "not authenticated")
+	})
+}
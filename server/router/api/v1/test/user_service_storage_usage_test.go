@@ -0,0 +1,95 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+)
+
+func TestUserStorageQuota(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	hostUser, err := ts.CreateHostUser(ctx, "admin")
+	require.NoError(t, err)
+	user, err := ts.CreateRegularUser(ctx, "user")
+	require.NoError(t, err)
+	otherUser, err := ts.CreateRegularUser(ctx, "other")
+	require.NoError(t, err)
+	hostCtx := ts.CreateUserContext(ctx, hostUser.ID)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+	otherUserCtx := ts.CreateUserContext(ctx, otherUser.ID)
+	userName := fmt.Sprintf("users/%d", user.ID)
+	otherUserName := fmt.Sprintf("users/%d", otherUser.ID)
+
+	updateStorageSetting := func(setting *v1pb.WorkspaceSetting_StorageSetting) error {
+		_, err := ts.Service.UpdateWorkspaceSetting(hostCtx, &v1pb.UpdateWorkspaceSettingRequest{
+			Setting: &v1pb.WorkspaceSetting{
+				Name:  "workspace/settings/STORAGE",
+				Value: &v1pb.WorkspaceSetting_StorageSetting_{StorageSetting: setting},
+			},
+		})
+		return err
+	}
+	require.ErrorContains(t, updateStorageSetting(&v1pb.WorkspaceSetting_StorageSetting{
+		StorageType: v1pb.WorkspaceSetting_StorageSetting_DATABASE,
+		RoleQuotaMb: map[string]int64{"GUEST": 1},
+	}), "invalid role")
+	require.NoError(t, updateStorageSetting(&v1pb.WorkspaceSetting_StorageSetting{
+		StorageType: v1pb.WorkspaceSetting_StorageSetting_DATABASE,
+		RoleQuotaMb: map[string]int64{"USER": 1},
+		UserQuotaMb: map[string]int64{otherUserName: 0},
+	}))
+	setting, err := ts.Service.GetWorkspaceSetting(hostCtx, &v1pb.GetWorkspaceSettingRequest{Name: "workspace/settings/STORAGE"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]int64{otherUserName: 0}, setting.GetStorageSetting().UserQuotaMb)
+
+	createAttachment := func(ctx context.Context, filename string, size int) error {
+		_, err := ts.Service.CreateAttachment(ctx, &v1pb.CreateAttachmentRequest{
+			Attachment: &v1pb.Attachment{
+				Filename: filename,
+				Type:     "application/octet-stream",
+				Content:  bytes.Repeat([]byte{0}, size),
+			},
+		})
+		return err
+	}
+	require.NoError(t, createAttachment(userCtx, "first.bin", 600<<10))
+	err = createAttachment(userCtx, "second.bin", 600<<10)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	require.NoError(t, createAttachment(userCtx, "small.bin", 100<<10))
+	// The quota of the user is used over the quota of its role.
+	require.NoError(t, createAttachment(otherUserCtx, "first.bin", 600<<10))
+	require.NoError(t, createAttachment(otherUserCtx, "second.bin", 600<<10))
+
+	usage, err := ts.Service.GetUserStorageUsage(userCtx, &v1pb.GetUserStorageUsageRequest{Name: userName})
+	require.NoError(t, err)
+	require.Equal(t, int64(700<<10), usage.Size)
+	require.Equal(t, int32(2), usage.Count)
+	require.Equal(t, int64(1<<20), usage.Quota)
+	require.Len(t, usage.TypeUsages, 1)
+	require.Equal(t, "application/octet-stream", usage.TypeUsages[0].Type)
+
+	// Users see only their own usage, while the host sees the usage of everyone.
+	_, err = ts.Service.GetUserStorageUsage(otherUserCtx, &v1pb.GetUserStorageUsageRequest{Name: userName})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = ts.Service.GetUserStorageUsage(hostCtx, &v1pb.GetUserStorageUsageRequest{Name: userName})
+	require.NoError(t, err)
+	_, err = ts.Service.ListStorageUsage(userCtx, &v1pb.ListStorageUsageRequest{})
+	require.Error(t, err)
+	response, err := ts.Service.ListStorageUsage(hostCtx, &v1pb.ListStorageUsageRequest{})
+	require.NoError(t, err)
+	require.Len(t, response.Usages, 2)
+	require.Equal(t, otherUserName, response.Usages[0].Name)
+	require.Equal(t, int64(0), response.Usages[0].Quota)
+	require.Equal(t, userName, response.Usages[1].Name)
+}
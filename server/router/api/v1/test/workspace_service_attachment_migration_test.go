@@ -0,0 +1,74 @@
+package v1
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+func TestMigrateAttachments(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	hostUser, err := ts.CreateHostUser(ctx, "admin")
+	require.NoError(t, err)
+	user, err := ts.CreateRegularUser(ctx, "user")
+	require.NoError(t, err)
+	hostCtx := ts.CreateUserContext(ctx, hostUser.ID)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+
+	_, err = ts.Store.CreateAttachment(ctx, &store.Attachment{
+		UID:       "attachment",
+		CreatorID: user.ID,
+		Filename:  "file.txt",
+		Type:      "text/plain",
+		Size:      7,
+		Blob:      []byte("content"),
+	})
+	require.NoError(t, err)
+	_, err = ts.Store.UpsertWorkspaceSetting(ctx, &storepb.WorkspaceSetting{
+		Key: storepb.WorkspaceSettingKey_STORAGE,
+		Value: &storepb.WorkspaceSetting_StorageSetting{StorageSetting: &storepb.WorkspaceStorageSetting{
+			StorageType:      storepb.WorkspaceStorageSetting_LOCAL,
+			FilepathTemplate: filepath.Join(t.TempDir(), "{filename}"),
+		}},
+	})
+	require.NoError(t, err)
+
+	// Only the host can migrate attachments.
+	_, err = ts.Service.MigrateAttachments(userCtx, &v1pb.MigrateAttachmentsRequest{})
+	require.Error(t, err)
+	_, err = ts.Service.GetAttachmentMigration(hostCtx, &v1pb.GetAttachmentMigrationRequest{})
+	require.ErrorContains(t, err, "not found")
+
+	migration, err := ts.Service.MigrateAttachments(hostCtx, &v1pb.MigrateAttachmentsRequest{BatchSize: 10})
+	require.NoError(t, err)
+	require.Equal(t, v1pb.AttachmentMigration_RUNNING, migration.State)
+	require.Eventually(t, func() bool {
+		migration, err = ts.Service.GetAttachmentMigration(hostCtx, &v1pb.GetAttachmentMigrationRequest{})
+		require.NoError(t, err)
+		return migration.State != v1pb.AttachmentMigration_RUNNING
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, v1pb.AttachmentMigration_SUCCEEDED, migration.State)
+	require.Equal(t, int32(1), migration.Total)
+	require.Equal(t, int32(1), migration.Migrated)
+	require.Equal(t, int32(0), migration.Failed)
+	require.NotNil(t, migration.EndTime)
+
+	uid := "attachment"
+	attachment, err := ts.Store.GetAttachment(ctx, &store.FindAttachment{UID: &uid})
+	require.NoError(t, err)
+	require.Equal(t, storepb.AttachmentStorageType_LOCAL, attachment.StorageType)
+
+	_, err = ts.Service.GetAttachmentMigration(userCtx, &v1pb.GetAttachmentMigrationRequest{})
+	require.Error(t, err)
+}
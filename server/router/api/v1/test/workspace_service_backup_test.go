@@ -0,0 +1,76 @@
+package v1
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	"github.com/usememos/memos/store/backup"
+)
+
+func TestListBackups(t *testing.T) {
+	ctx := context.Background()
+
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	hostUser, err := ts.CreateHostUser(ctx, "admin")
+	require.NoError(t, err)
+	user, err := ts.CreateRegularUser(ctx, "user")
+	require.NoError(t, err)
+	hostCtx := ts.CreateUserContext(ctx, hostUser.ID)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+
+	_, err = ts.Service.UpdateWorkspaceSetting(hostCtx, &v1pb.UpdateWorkspaceSettingRequest{
+		Setting: &v1pb.WorkspaceSetting{
+			Name: "workspace/settings/BACKUP",
+			Value: &v1pb.WorkspaceSetting_BackupSetting_{BackupSetting: &v1pb.WorkspaceSetting_BackupSetting{
+				Enabled:  true,
+				CronSpec: "every day",
+			}},
+		},
+	})
+	require.ErrorContains(t, err, "invalid cron spec")
+	directory := t.TempDir()
+	_, err = ts.Service.UpdateWorkspaceSetting(hostCtx, &v1pb.UpdateWorkspaceSettingRequest{
+		Setting: &v1pb.WorkspaceSetting{
+			Name: "workspace/settings/BACKUP",
+			Value: &v1pb.WorkspaceSetting_BackupSetting_{BackupSetting: &v1pb.WorkspaceSetting_BackupSetting{
+				Enabled:     true,
+				CronSpec:    "0 3 * * *",
+				Destination: v1pb.WorkspaceSetting_BackupSetting_LOCAL,
+				Directory:   directory,
+				KeepLast:    3,
+			}},
+		},
+	})
+	require.NoError(t, err)
+
+	// The backup setting is only visible to the host.
+	_, err = ts.Service.GetWorkspaceSetting(userCtx, &v1pb.GetWorkspaceSettingRequest{Name: "workspace/settings/BACKUP"})
+	require.Error(t, err)
+	setting, err := ts.Service.GetWorkspaceSetting(hostCtx, &v1pb.GetWorkspaceSettingRequest{Name: "workspace/settings/BACKUP"})
+	require.NoError(t, err)
+	require.Equal(t, directory, setting.GetBackupSetting().Directory)
+	require.Equal(t, "backups/", setting.GetBackupSetting().S3Prefix)
+
+	response, err := ts.Service.ListBackups(hostCtx, &v1pb.ListBackupsRequest{})
+	require.NoError(t, err)
+	require.Empty(t, response.Backups)
+
+	archive, err := backup.CreateArchive(ctx, ts.Store, ts.Profile)
+	require.NoError(t, err)
+	response, err = ts.Service.ListBackups(hostCtx, &v1pb.ListBackupsRequest{})
+	require.NoError(t, err)
+	require.Len(t, response.Backups, 1)
+	require.Equal(t, "workspace/backups/"+archive.Name, response.Backups[0].Name)
+	require.True(t, strings.HasSuffix(archive.Name, ".zip"))
+	require.Equal(t, archive.Size, response.Backups[0].Size)
+	require.Equal(t, archive.CreateTime, response.Backups[0].CreateTime.AsTime())
+
+	_, err = ts.Service.ListBackups(userCtx, &v1pb.ListBackupsRequest{})
+	require.Error(t, err)
+}
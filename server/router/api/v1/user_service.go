@@ -3,6 +3,7 @@ package v1
 import (
 	"context"
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/base64"
 	"encoding/hex"
 	"fmt"
@@ -46,7 +47,7 @@ func (s *APIV1Service) ListUsers(ctx context.Context, request *v1pb.ListUsersReq
 	userFind := &store.FindUser{}
 
 	if request.Filter != "" {
-		if err := s.validateUserFilter(ctx, request.Filter); err != nil {
+		if err := s.validateFilter(ctx, request.Filter, filter.UserFilter); err != nil {
 			return nil, status.Errorf(codes.InvalidArgument, "invalid filter: %v", err)
 		}
 		userFind.Filters = append(userFind.Filters, request.Filter)
@@ -104,6 +105,16 @@ func (s *APIV1Service) GetUserAvatar(ctx context.Context, request *v1pb.GetUserA
 		return nil, status.Errorf(codes.NotFound, "avatar not found")
 	}
 
+	// The avatar is revalidated on every request since it changes in place.
+	avatarHash := sha256.Sum256([]byte(user.AvatarURL))
+	if setCacheHeaders(ctx, cacheValidators{
+		etag:         fmt.Sprintf(`"%s"`, hex.EncodeToString(avatarHash[:16])),
+		lastModified: time.Unix(user.UpdatedTs, 0),
+		cacheControl: "public, no-cache",
+	}) {
+		return notModifiedResponse(), nil
+	}
+
 	imageType, base64Data, err := extractImageInfo(user.AvatarURL)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to extract image info: %v", err)
@@ -399,9 +410,11 @@ func (s *APIV1Service) UpdateUserSetting(ctx context.Context, request *v1pb.Upda
 	}
 
 	updatedGeneral := &v1pb.UserSetting_GeneralSetting{
-		MemoVisibility: generalSetting.GetMemoVisibility(),
-		Locale:         generalSetting.GetLocale(),
-		Theme:          generalSetting.GetTheme(),
+		MemoVisibility:     generalSetting.GetMemoVisibility(),
+		Locale:             generalSetting.GetLocale(),
+		Theme:              generalSetting.GetTheme(),
+		Timezone:           generalSetting.GetTimezone(),
+		LocationFromPhotos: generalSetting.GetLocationFromPhotos(),
 	}
 
 	// Apply updates for fields specified in the update mask
@@ -414,6 +427,13 @@ func (s *APIV1Service) UpdateUserSetting(ctx context.Context, request *v1pb.Upda
 			updatedGeneral.Theme = incomingGeneral.Theme
 		case "locale":
 			updatedGeneral.Locale = incomingGeneral.Locale
+		case "timezone":
+			if !isValidTimezone(incomingGeneral.Timezone) {
+				return nil, status.Errorf(codes.InvalidArgument, "invalid timezone: %s", incomingGeneral.Timezone)
+			}
+			updatedGeneral.Timezone = incomingGeneral.Timezone
+		case "locationFromPhotos":
+			updatedGeneral.LocationFromPhotos = incomingGeneral.LocationFromPhotos
 		default:
 			// Ignore unsupported fields
 		}
@@ -674,6 +694,129 @@ func (s *APIV1Service) DeleteUserAccessToken(ctx context.Context, request *v1pb.
 	return &emptypb.Empty{}, nil
 }
 
+func (s *APIV1Service) ListUserFeedTokens(ctx context.Context, request *v1pb.ListUserFeedTokensRequest) (*v1pb.ListUserFeedTokensResponse, error) {
+	userID, err := ExtractUserIDFromName(request.Parent)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user name: %v", err)
+	}
+	currentUser, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get current user: %v", err)
+	}
+	if currentUser == nil {
+		return nil, status.Errorf(codes.Unauthenticated, "user not authenticated")
+	}
+	if currentUser.ID != userID {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+
+	userFeedTokens, err := s.Store.GetUserFeedTokens(ctx, userID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list feed tokens: %v", err)
+	}
+	feedTokens := []*v1pb.UserFeedToken{}
+	for _, userFeedToken := range userFeedTokens {
+		feedTokens = append(feedTokens, convertUserFeedTokenFromStore(userID, userFeedToken))
+	}
+	// Sort by creation time in descending order.
+	slices.SortFunc(feedTokens, func(i, j *v1pb.UserFeedToken) int {
+		return j.CreateTime.AsTime().Compare(i.CreateTime.AsTime())
+	})
+	return &v1pb.ListUserFeedTokensResponse{
+		FeedTokens: feedTokens,
+	}, nil
+}
+
+func (s *APIV1Service) CreateUserFeedToken(ctx context.Context, request *v1pb.CreateUserFeedTokenRequest) (*v1pb.UserFeedToken, error) {
+	userID, err := ExtractUserIDFromName(request.Parent)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user name: %v", err)
+	}
+	currentUser, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get current user: %v", err)
+	}
+	if currentUser == nil {
+		return nil, status.Errorf(codes.Unauthenticated, "user not authenticated")
+	}
+	if currentUser.ID != userID {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+	if request.FeedToken == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "feed token is required")
+	}
+
+	secret, err := util.RandomString(32)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to generate feed token: %v", err)
+	}
+	userFeedToken := &storepb.FeedTokensUserSetting_FeedToken{
+		Token:          store.FormatFeedToken(userID, secret),
+		Description:    request.FeedToken.Description,
+		IncludePrivate: request.FeedToken.IncludePrivate,
+		CreateTime:     timestamppb.Now(),
+	}
+	userFeedTokens, err := s.Store.GetUserFeedTokens(ctx, userID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list feed tokens: %v", err)
+	}
+	if err := s.Store.UpsertUserFeedTokens(ctx, userID, append(userFeedTokens, userFeedToken)); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to upsert feed tokens: %v", err)
+	}
+	return convertUserFeedTokenFromStore(userID, userFeedToken), nil
+}
+
+func (s *APIV1Service) DeleteUserFeedToken(ctx context.Context, request *v1pb.DeleteUserFeedTokenRequest) (*emptypb.Empty, error) {
+	// Format: users/{user}/feedTokens/{feed_token}
+	parts := strings.Split(request.Name, "/")
+	if len(parts) != 4 || parts[0] != "users" || parts[2] != "feedTokens" {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid feed token name format: %s", request.Name)
+	}
+	userID, err := ExtractUserIDFromName(fmt.Sprintf("users/%s", parts[1]))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user name: %v", err)
+	}
+	currentUser, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get current user: %v", err)
+	}
+	if currentUser == nil {
+		return nil, status.Errorf(codes.Unauthenticated, "user not authenticated")
+	}
+	if currentUser.ID != userID {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+
+	userFeedTokens, err := s.Store.GetUserFeedTokens(ctx, userID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list feed tokens: %v", err)
+	}
+	updatedUserFeedTokens := []*storepb.FeedTokensUserSetting_FeedToken{}
+	for _, userFeedToken := range userFeedTokens {
+		if userFeedToken.Token == parts[3] {
+			continue
+		}
+		updatedUserFeedTokens = append(updatedUserFeedTokens, userFeedToken)
+	}
+	if len(updatedUserFeedTokens) == len(userFeedTokens) {
+		return nil, status.Errorf(codes.NotFound, "feed token not found")
+	}
+	if err := s.Store.UpsertUserFeedTokens(ctx, userID, updatedUserFeedTokens); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to upsert feed tokens: %v", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func convertUserFeedTokenFromStore(userID int32, feedToken *storepb.FeedTokensUserSetting_FeedToken) *v1pb.UserFeedToken {
+	return &v1pb.UserFeedToken{
+		Name:           fmt.Sprintf("users/%d/feedTokens/%s", userID, feedToken.Token),
+		FeedToken:      feedToken.Token,
+		Description:    feedToken.Description,
+		IncludePrivate: feedToken.IncludePrivate,
+		CreateTime:     feedToken.CreateTime,
+	}
+}
+
 func (s *APIV1Service) ListUserSessions(ctx context.Context, request *v1pb.ListUserSessionsRequest) (*v1pb.ListUserSessionsResponse, error) {
 	userID, err := ExtractUserIDFromName(request.Parent)
 	if err != nil {
@@ -1190,9 +1333,11 @@ func convertUserSettingFromStore(storeSetting *storepb.UserSetting, userID int32
 		if general := storeSetting.GetGeneral(); general != nil {
 			setting.Value = &v1pb.UserSetting_GeneralSetting_{
 				GeneralSetting: &v1pb.UserSetting_GeneralSetting{
-					Locale:         general.Locale,
-					MemoVisibility: general.MemoVisibility,
-					Theme:          general.Theme,
+					Locale:             general.Locale,
+					MemoVisibility:     general.MemoVisibility,
+					Theme:              general.Theme,
+					Timezone:           general.Timezone,
+					LocationFromPhotos: general.LocationFromPhotos,
 				},
 			}
 		} else {
@@ -1278,9 +1423,11 @@ func convertUserSettingToStore(apiSetting *v1pb.UserSetting, userID int32, key s
 		if general := apiSetting.GetGeneralSetting(); general != nil {
 			storeSetting.Value = &storepb.UserSetting_General{
 				General: &storepb.GeneralUserSetting{
-					Locale:         general.Locale,
-					MemoVisibility: general.MemoVisibility,
-					Theme:          general.Theme,
+					Locale:             general.Locale,
+					MemoVisibility:     general.MemoVisibility,
+					Theme:              general.Theme,
+					Timezone:           general.Timezone,
+					LocationFromPhotos: general.LocationFromPhotos,
 				},
 			}
 		} else {
@@ -1356,6 +1503,15 @@ func convertUserSettingToStore(apiSetting *v1pb.UserSetting, userID int32, key s
 	return storeSetting, nil
 }
 
+// isValidTimezone returns whether the timezone is empty or a known IANA time zone name.
+func isValidTimezone(timezone string) bool {
+	if timezone == "" {
+		return true
+	}
+	_, err := time.LoadLocation(timezone)
+	return err == nil
+}
+
 // extractWebhookIDFromName extracts webhook ID from resource name.
 // e.g., "users/123/webhooks/webhook-id" -> "webhook-id".
 func extractWebhookIDFromName(name string) string {
@@ -1365,37 +1521,3 @@ func extractWebhookIDFromName(name string) string {
 	}
 	return ""
 }
-
-// validateUserFilter validates the user filter string.
-func (s *APIV1Service) validateUserFilter(_ context.Context, filterStr string) error {
-	if filterStr == "" {
-		return errors.New("filter cannot be empty")
-	}
-	// Validate the filter.
-	parsedExpr, err := filter.Parse(filterStr, filter.UserFilterCELAttributes...)
-	if err != nil {
-		return errors.Wrap(err, "failed to parse filter")
-	}
-	convertCtx := filter.NewConvertContext()
-
-	// Determine the dialect based on the actual database driver
-	var dialect filter.SQLDialect
-	switch s.Profile.Driver {
-	case "sqlite":
-		dialect = &filter.SQLiteDialect{}
-	case "mysql":
-		dialect = &filter.MySQLDialect{}
-	case "postgres":
-		dialect = &filter.PostgreSQLDialect{}
-	default:
-		// Default to SQLite for unknown drivers
-		dialect = &filter.SQLiteDialect{}
-	}
-
-	converter := filter.NewUserSQLConverter(dialect)
-	err = converter.ConvertExprToSQL(convertCtx, parsedExpr.GetExpr())
-	if err != nil {
-		return errors.Wrap(err, "failed to convert filter to SQL")
-	}
-	return nil
-}
@@ -0,0 +1,270 @@
+package v1
+
+import (
+	"archive/zip"
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/usememos/memos/plugin/archive"
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+const (
+	// exportChunkSize is the size of the chunks sent while streaming an export.
+	exportChunkSize = 256 * 1024
+	// exportAttachmentFolder is the folder of the attachment files in an export.
+	exportAttachmentFolder = "attachments"
+)
+
+// attachmentLinkRegex matches the links to attachment binaries, e.g. /file/attachments/{attachment}/{filename}.
+var attachmentLinkRegex = regexp.MustCompile(`(?:https?://[^\s()<>"']+)?/file/attachments/([^/\s()<>"']+)/[^\s()<>"']*`)
+
+func (s *APIV1Service) ExportUserData(request *v1pb.ExportUserDataRequest, stream grpc.ServerStreamingServer[httpbody.HttpBody]) error {
+	ctx := stream.Context()
+	userID, err := ExtractUserIDFromName(request.Name)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid user name: %v", err)
+	}
+	currentUser, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to get current user: %v", err)
+	}
+	if currentUser == nil {
+		return status.Errorf(codes.Unauthenticated, "user not authenticated")
+	}
+	if currentUser.ID != userID {
+		return status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+
+	memos, err := s.Store.ListMemos(ctx, &store.FindMemo{
+		CreatorID: &userID,
+	})
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to list memos: %v", err)
+	}
+	attachments, err := s.Store.ListAttachments(ctx, &store.FindAttachment{
+		CreatorID: &userID,
+	})
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to list attachments: %v", err)
+	}
+
+	writer := bufio.NewWriterSize(&httpBodyStreamWriter{stream: stream, contentType: "application/zip"}, exportChunkSize)
+	zipWriter := zip.NewWriter(writer)
+	exporter := &userDataExporter{
+		service:         s,
+		zipWriter:       zipWriter,
+		memoUIDs:        map[int32]string{},
+		attachmentPaths: map[string]string{},
+		memoAttachments: map[int32][]*store.Attachment{},
+	}
+	for _, memo := range memos {
+		exporter.memoUIDs[memo.ID] = memo.UID
+	}
+	for _, attachment := range attachments {
+		if attachment.StorageType == storepb.AttachmentStorageType_EXTERNAL {
+			continue
+		}
+		exporter.attachmentPaths[attachment.UID] = getExportAttachmentPath(attachment)
+		if attachment.MemoID != nil {
+			exporter.memoAttachments[*attachment.MemoID] = append(exporter.memoAttachments[*attachment.MemoID], attachment)
+		}
+	}
+
+	for _, memo := range memos {
+		if err := exporter.writeMemo(ctx, memo); err != nil {
+			return status.Errorf(codes.Internal, "failed to export memo %s: %v", memo.UID, err)
+		}
+	}
+	for _, attachment := range attachments {
+		if attachment.StorageType == storepb.AttachmentStorageType_EXTERNAL {
+			continue
+		}
+		if err := exporter.writeAttachment(ctx, attachment); err != nil {
+			return status.Errorf(codes.Internal, "failed to export attachment %s: %v", attachment.UID, err)
+		}
+	}
+	if err := zipWriter.Close(); err != nil {
+		return status.Errorf(codes.Internal, "failed to close archive: %v", err)
+	}
+	if err := writer.Flush(); err != nil {
+		return status.Errorf(codes.Internal, "failed to send archive: %v", err)
+	}
+	return nil
+}
+
+// getExportAttachmentPath returns the path of an attachment in an export. The filename comes from
+// the user, so it is reduced to its last element to keep the entry inside the attachment folder
+// when the archive is extracted.
+func getExportAttachmentPath(attachment *store.Attachment) string {
+	filename := path.Base(strings.ReplaceAll(attachment.Filename, "\\", "/"))
+	if filename == "" || filename == "." || filename == ".." || filename == "/" {
+		filename = "attachment"
+	}
+	return path.Join(exportAttachmentFolder, attachment.UID, filename)
+}
+
+// userDataExporter writes the memos and the attachments of a user to a ZIP archive.
+type userDataExporter struct {
+	service   *APIV1Service
+	zipWriter *zip.Writer
+	// memoUIDs maps the IDs of the exported memos to their UIDs.
+	memoUIDs map[int32]string
+	// attachmentPaths maps the UIDs of the exported attachments to their paths in the archive.
+	attachmentPaths map[string]string
+	memoAttachments map[int32][]*store.Attachment
+}
+
+func (e *userDataExporter) writeMemo(ctx context.Context, memo *store.Memo) error {
+	frontMatter := &archive.FrontMatter{
+		UID:        memo.UID,
+		Visibility: memo.Visibility.String(),
+		State:      memo.RowStatus.String(),
+		Pinned:     memo.Pinned,
+		Created:    time.Unix(memo.CreatedTs, 0).UTC(),
+		Updated:    time.Unix(memo.UpdatedTs, 0).UTC(),
+	}
+	if payload := memo.Payload; payload != nil {
+		frontMatter.Tags = payload.Tags
+		if location := payload.Location; location != nil {
+			frontMatter.Location = &archive.Location{
+				Placeholder: location.Placeholder,
+				Latitude:    location.Latitude,
+				Longitude:   location.Longitude,
+			}
+		}
+	}
+
+	relations, err := e.service.Store.ListMemoRelations(ctx, &store.FindMemoRelation{
+		MemoID: &memo.ID,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to list memo relations")
+	}
+	for _, relation := range relations {
+		relatedMemoUID, ok := e.memoUIDs[relation.RelatedMemoID]
+		if !ok {
+			relatedMemo, err := e.service.Store.GetMemo(ctx, &store.FindMemo{ID: &relation.RelatedMemoID, ExcludeContent: true})
+			if err != nil {
+				return errors.Wrap(err, "failed to get related memo")
+			}
+			if relatedMemo == nil {
+				continue
+			}
+			relatedMemoUID = relatedMemo.UID
+		}
+		frontMatter.Relations = append(frontMatter.Relations, &archive.Relation{
+			Type: string(relation.Type),
+			Memo: relatedMemoUID,
+		})
+	}
+
+	// Point the links of the attachments to their files in the archive.
+	content := attachmentLinkRegex.ReplaceAllStringFunc(memo.Content, func(link string) string {
+		attachmentPath, ok := e.attachmentPaths[attachmentLinkRegex.FindStringSubmatch(link)[1]]
+		if !ok {
+			return link
+		}
+		return escapeArchivePath(attachmentPath)
+	})
+	links := []string{}
+	for _, attachment := range e.memoAttachments[memo.ID] {
+		attachmentPath := e.attachmentPaths[attachment.UID]
+		frontMatter.Attachments = append(frontMatter.Attachments, attachmentPath)
+		if strings.Contains(content, escapeArchivePath(attachmentPath)) {
+			continue
+		}
+		link := fmt.Sprintf("[%s](%s)", attachment.Filename, escapeArchivePath(attachmentPath))
+		if strings.HasPrefix(attachment.Type, "image/") {
+			link = "!" + link
+		}
+		links = append(links, link)
+	}
+	if len(links) > 0 {
+		content = strings.TrimRight(content, "\n") + "\n\n" + strings.Join(links, "\n")
+	}
+
+	data, err := archive.MarshalMarkdown(frontMatter, content)
+	if err != nil {
+		return err
+	}
+	fileWriter, err := e.zipWriter.CreateHeader(&zip.FileHeader{
+		Name:     memo.UID + ".md",
+		Method:   zip.Deflate,
+		Modified: frontMatter.Updated,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to create archive file")
+	}
+	_, err = fileWriter.Write(data)
+	return err
+}
+
+func (e *userDataExporter) writeAttachment(ctx context.Context, attachment *store.Attachment) error {
+	// Blobs stored in the database are loaded one by one to keep the memory usage low.
+	if attachment.StorageType == storepb.AttachmentStorageType_ATTACHMENT_STORAGE_TYPE_UNSPECIFIED {
+		var err error
+		attachment, err = e.service.Store.GetAttachment(ctx, &store.FindAttachment{ID: &attachment.ID, GetBlob: true})
+		if err != nil {
+			return errors.Wrap(err, "failed to get attachment")
+		}
+		if attachment == nil {
+			return errors.New("attachment not found")
+		}
+	}
+	content, err := e.service.OpenAttachmentContent(ctx, attachment)
+	if err != nil {
+		return errors.Wrap(err, "failed to open attachment content")
+	}
+	defer content.Close()
+	fileWriter, err := e.zipWriter.CreateHeader(&zip.FileHeader{
+		Name:     e.attachmentPaths[attachment.UID],
+		Method:   zip.Deflate,
+		Modified: time.Unix(attachment.UpdatedTs, 0).UTC(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to create archive file")
+	}
+	_, err = io.Copy(fileWriter, content)
+	return err
+}
+
+// escapeArchivePath escapes the segments of a path in the archive to be used as a Markdown link.
+func escapeArchivePath(p string) string {
+	segments := strings.Split(p, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// httpBodyStreamWriter sends the written data as HttpBody chunks of a server stream.
+type httpBodyStreamWriter struct {
+	stream      grpc.ServerStreamingServer[httpbody.HttpBody]
+	contentType string
+}
+
+func (w *httpBodyStreamWriter) Write(p []byte) (int, error) {
+	if err := w.stream.Send(&httpbody.HttpBody{
+		ContentType: w.contentType,
+		Data:        p,
+	}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
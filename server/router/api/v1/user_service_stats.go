@@ -48,15 +48,30 @@ func (s *APIV1Service) ListAllUserStats(ctx context.Context, _ *v1pb.ListAllUser
 	}
 
 	userMemoStatMap := make(map[int32]*v1pb.UserStats)
+	userLocationMap := make(map[int32]*time.Location)
 	for _, memo := range memos {
 		displayTs := memo.CreatedTs
 		if workspaceMemoRelatedSetting.DisplayWithUpdateTime {
 			displayTs = memo.UpdatedTs
 		}
-		userMemoStatMap[memo.CreatorID] = &v1pb.UserStats{
-			Name: fmt.Sprintf("users/%d/stats", memo.CreatorID),
+		userMemoStat, ok := userMemoStatMap[memo.CreatorID]
+		if !ok {
+			userMemoStat = &v1pb.UserStats{
+				Name:            fmt.Sprintf("users/%d/stats", memo.CreatorID),
+				MemoCountByDate: map[string]int32{},
+			}
+			userMemoStatMap[memo.CreatorID] = userMemoStat
+		}
+		location, ok := userLocationMap[memo.CreatorID]
+		if !ok {
+			location, err = s.Store.GetUserLocation(ctx, memo.CreatorID)
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to get user location: %v", err)
+			}
+			userLocationMap[memo.CreatorID] = location
 		}
-		userMemoStatMap[memo.CreatorID].MemoDisplayTimestamps = append(userMemoStatMap[memo.CreatorID].MemoDisplayTimestamps, timestamppb.New(time.Unix(displayTs, 0)))
+		userMemoStat.MemoDisplayTimestamps = append(userMemoStat.MemoDisplayTimestamps, timestamppb.New(time.Unix(displayTs, 0)))
+		userMemoStat.MemoCountByDate[formatStatsDate(displayTs, location)]++
 	}
 
 	userMemoStats := []*v1pb.UserStats{}
@@ -106,7 +121,14 @@ func (s *APIV1Service) GetUserStats(ctx context.Context, request *v1pb.GetUserSt
 		return nil, errors.Wrap(err, "failed to get workspace memo related setting")
 	}
 
+	// Memos are bucketed into days in the user's timezone, so every viewer sees the same days.
+	location, err := s.Store.GetUserLocation(ctx, userID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get user location: %v", err)
+	}
+
 	displayTimestamps := []*timestamppb.Timestamp{}
+	memoCountByDate := make(map[string]int32)
 	tagCount := make(map[string]int32)
 	linkCount := int32(0)
 	codeCount := int32(0)
@@ -120,6 +142,7 @@ func (s *APIV1Service) GetUserStats(ctx context.Context, request *v1pb.GetUserSt
 			displayTs = memo.UpdatedTs
 		}
 		displayTimestamps = append(displayTimestamps, timestamppb.New(time.Unix(displayTs, 0)))
+		memoCountByDate[formatStatsDate(displayTs, location)]++
 		// Count different memo types based on content.
 		if memo.Payload != nil {
 			for _, tag := range memo.Payload.Tags {
@@ -151,6 +174,7 @@ func (s *APIV1Service) GetUserStats(ctx context.Context, request *v1pb.GetUserSt
 		TagCount:              tagCount,
 		PinnedMemos:           pinnedMemos,
 		TotalMemoCount:        int32(len(memos)),
+		MemoCountByDate:       memoCountByDate,
 		MemoTypeStats: &v1pb.UserStats_MemoTypeStats{
 			LinkCount: linkCount,
 			CodeCount: codeCount,
@@ -161,3 +185,8 @@ func (s *APIV1Service) GetUserStats(ctx context.Context, request *v1pb.GetUserSt
 
 	return userStats, nil
 }
+
+// formatStatsDate formats the unix timestamp as the date key used in memo stats.
+func formatStatsDate(ts int64, location *time.Location) string {
+	return time.Unix(ts, 0).In(location).Format(time.DateOnly)
+}
@@ -0,0 +1,154 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	"github.com/usememos/memos/store"
+)
+
+// ListStorageUsage returns the storage usage of all users with attachments.
+func (s *APIV1Service) ListStorageUsage(ctx context.Context, _ *v1pb.ListStorageUsageRequest) (*v1pb.ListStorageUsageResponse, error) {
+	user, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get current user: %v", err)
+	}
+	if user == nil {
+		return nil, status.Errorf(codes.Unauthenticated, "user not authenticated")
+	}
+	if !isSuperUser(user) {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+
+	usages, err := s.Store.ListUserStorageUsages(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list storage usages: %v", err)
+	}
+	users, err := s.Store.ListUsers(ctx, &store.FindUser{})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list users: %v", err)
+	}
+	userMap := map[int32]*store.User{}
+	for _, user := range users {
+		userMap[user.ID] = user
+	}
+	response := &v1pb.ListStorageUsageResponse{Usages: []*v1pb.UserStorageUsage{}}
+	for _, usage := range usages {
+		userStorageUsage := convertUserStorageUsageFromStore(usage)
+		// Attachments of deleted users have no quota.
+		if user, ok := userMap[usage.UserID]; ok {
+			quota, err := s.Store.GetUserStorageQuota(ctx, user)
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to get storage quota: %v", err)
+			}
+			userStorageUsage.Quota = quota
+		}
+		response.Usages = append(response.Usages, userStorageUsage)
+	}
+	return response, nil
+}
+
+// GetUserStorageUsage returns the storage usage and the storage quota of a user.
+func (s *APIV1Service) GetUserStorageUsage(ctx context.Context, request *v1pb.GetUserStorageUsageRequest) (*v1pb.UserStorageUsage, error) {
+	userID, err := ExtractUserIDFromName(request.Name)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user name: %v", err)
+	}
+	currentUser, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get current user: %v", err)
+	}
+	if currentUser == nil {
+		return nil, status.Errorf(codes.Unauthenticated, "user not authenticated")
+	}
+	if currentUser.ID != userID && !isSuperUser(currentUser) {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+	user, err := s.Store.GetUser(ctx, &store.FindUser{ID: &userID})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get user: %v", err)
+	}
+	if user == nil {
+		return nil, status.Errorf(codes.NotFound, "user not found")
+	}
+
+	usage, err := s.Store.GetUserStorageUsage(ctx, userID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get storage usage: %v", err)
+	}
+	quota, err := s.Store.GetUserStorageQuota(ctx, user)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get storage quota: %v", err)
+	}
+	userStorageUsage := convertUserStorageUsageFromStore(usage)
+	userStorageUsage.Quota = quota
+	return userStorageUsage, nil
+}
+
+// getRemainingStorageQuota returns the number of bytes the user can still upload within its
+// storage quota, or -1 if the user has no storage quota.
+func (s *APIV1Service) getRemainingStorageQuota(ctx context.Context, user *store.User) (int64, error) {
+	quota, err := s.Store.GetUserStorageQuota(ctx, user)
+	if err != nil {
+		return 0, err
+	}
+	if quota == 0 {
+		return -1, nil
+	}
+	usage, err := s.Store.GetUserStorageUsage(ctx, user.ID)
+	if err != nil {
+		return 0, err
+	}
+	return max(quota-usage.Size, 0), nil
+}
+
+func convertUserStorageUsageFromStore(usage *store.UserStorageUsage) *v1pb.UserStorageUsage {
+	userStorageUsage := &v1pb.UserStorageUsage{
+		Name:       fmt.Sprintf("%s%d", UserNamePrefix, usage.UserID),
+		Size:       usage.Size,
+		Count:      int32(usage.Count),
+		TypeUsages: []*v1pb.UserStorageUsage_TypeUsage{},
+	}
+	for mimeType, typeUsage := range usage.Types {
+		userStorageUsage.TypeUsages = append(userStorageUsage.TypeUsages, &v1pb.UserStorageUsage_TypeUsage{
+			Type:  mimeType,
+			Size:  typeUsage.Size,
+			Count: int32(typeUsage.Count),
+		})
+	}
+	sort.Slice(userStorageUsage.TypeUsages, func(i, j int) bool {
+		a, b := userStorageUsage.TypeUsages[i], userStorageUsage.TypeUsages[j]
+		if a.Size != b.Size {
+			return a.Size > b.Size
+		}
+		return a.Type < b.Type
+	})
+	return userStorageUsage
+}
+
+// validateStorageQuotas checks the roles, the user names and the values of the storage quotas.
+func validateStorageQuotas(setting *v1pb.WorkspaceSetting_StorageSetting) error {
+	for role, quota := range setting.RoleQuotaMb {
+		if role != string(store.RoleHost) && role != string(store.RoleAdmin) && role != string(store.RoleUser) {
+			return errors.Errorf("invalid role %q", role)
+		}
+		if quota < 0 {
+			return errors.Errorf("invalid quota of role %s", role)
+		}
+	}
+	for name, quota := range setting.UserQuotaMb {
+		if _, err := ExtractUserIDFromName(name); err != nil {
+			return errors.Errorf("invalid user name %q", name)
+		}
+		if quota < 0 {
+			return errors.Errorf("invalid quota of user %s", name)
+		}
+	}
+	return nil
+}
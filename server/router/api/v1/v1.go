@@ -41,6 +41,9 @@ type APIV1Service struct {
 	Notification *notification.Service
 
 	grpcServer *grpc.Server
+
+	attachmentMigration attachmentMigrationJob
+	thumbnails          thumbnailGenerator
 }
 
 func NewAPIV1Service(secret string, profile *profile.Profile, store *store.Store, grpcServer *grpc.Server) *APIV1Service {
@@ -88,7 +91,10 @@ func (s *APIV1Service) RegisterGateway(ctx context.Context, echoServer *echo.Ech
 		return err
 	}
 
-	gwMux := runtime.NewServeMux()
+	gwMux := runtime.NewServeMux(
+		runtime.WithOutgoingHeaderMatcher(gatewayOutgoingHeaderMatcher),
+		runtime.WithForwardResponseOption(gatewayResponseModifier),
+	)
 	if err := v1pb.RegisterWorkspaceServiceHandler(ctx, gwMux, conn); err != nil {
 		return err
 	}
@@ -123,6 +129,7 @@ func (s *APIV1Service) RegisterGateway(ctx context.Context, echoServer *echo.Ech
 	gwGroup.Use(middleware.CORS())
 	handler := echo.WrapHandler(gwMux)
 
+	s.registerFileRoutes(gwGroup)
 	gwGroup.Any("/api/v1/*", handler)
 	gwGroup.Any("/file/*", handler)
 
@@ -7,10 +7,13 @@ import (
 	"github.com/pkg/errors"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/usememos/memos/plugin/cron"
 	v1pb "github.com/usememos/memos/proto/gen/api/v1"
 	storepb "github.com/usememos/memos/proto/gen/store"
 	"github.com/usememos/memos/store"
+	"github.com/usememos/memos/store/backup"
 )
 
 // GetWorkspaceProfile returns the workspace profile.
@@ -47,6 +50,8 @@ func (s *APIV1Service) GetWorkspaceSetting(ctx context.Context, request *v1pb.Ge
 		_, err = s.Store.GetWorkspaceMemoRelatedSetting(ctx)
 	case storepb.WorkspaceSettingKey_STORAGE:
 		_, err = s.Store.GetWorkspaceStorageSetting(ctx)
+	case storepb.WorkspaceSettingKey_BACKUP:
+		_, err = s.Store.GetWorkspaceBackupSetting(ctx)
 	default:
 		return nil, status.Errorf(codes.InvalidArgument, "unsupported workspace setting key: %v", workspaceSettingKey)
 	}
@@ -64,8 +69,8 @@ func (s *APIV1Service) GetWorkspaceSetting(ctx context.Context, request *v1pb.Ge
 		return nil, status.Errorf(codes.NotFound, "workspace setting not found")
 	}
 
-	// For storage setting, only host can get it.
-	if workspaceSetting.Key == storepb.WorkspaceSettingKey_STORAGE {
+	// For storage and backup settings, only host can get them.
+	if workspaceSetting.Key == storepb.WorkspaceSettingKey_STORAGE || workspaceSetting.Key == storepb.WorkspaceSettingKey_BACKUP {
 		user, err := s.GetCurrentUser(ctx)
 		if err != nil {
 			return nil, status.Errorf(codes.Internal, "failed to get current user: %v", err)
@@ -93,6 +98,21 @@ func (s *APIV1Service) UpdateWorkspaceSetting(ctx context.Context, request *v1pb
 	// TODO: Apply update_mask if specified
 	_ = request.UpdateMask
 
+	if generalSetting := request.Setting.GetGeneralSetting(); generalSetting != nil && !isValidTimezone(generalSetting.Timezone) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid timezone: %s", generalSetting.Timezone)
+	}
+	if backupSetting := request.Setting.GetBackupSetting(); backupSetting != nil && backupSetting.CronSpec != "" {
+		if _, err := cron.ParseStandard(backupSetting.CronSpec); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid cron spec: %v", err)
+		}
+	}
+
+	if storageSetting := request.Setting.GetStorageSetting(); storageSetting != nil {
+		if err := validateStorageQuotas(storageSetting); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid storage quotas: %v", err)
+		}
+	}
+
 	updateSetting := convertWorkspaceSettingToStore(request.Setting)
 	workspaceSetting, err := s.Store.UpsertWorkspaceSetting(ctx, updateSetting)
 	if err != nil {
@@ -119,6 +139,10 @@ func convertWorkspaceSettingFromStore(setting *storepb.WorkspaceSetting) *v1pb.W
 		workspaceSetting.Value = &v1pb.WorkspaceSetting_MemoRelatedSetting_{
 			MemoRelatedSetting: convertWorkspaceMemoRelatedSettingFromStore(setting.GetMemoRelatedSetting()),
 		}
+	case *storepb.WorkspaceSetting_BackupSetting:
+		workspaceSetting.Value = &v1pb.WorkspaceSetting_BackupSetting_{
+			BackupSetting: convertWorkspaceBackupSettingFromStore(setting.GetBackupSetting()),
+		}
 	}
 	return workspaceSetting
 }
@@ -144,6 +168,10 @@ func convertWorkspaceSettingToStore(setting *v1pb.WorkspaceSetting) *storepb.Wor
 		workspaceSetting.Value = &storepb.WorkspaceSetting_MemoRelatedSetting{
 			MemoRelatedSetting: convertWorkspaceMemoRelatedSettingToStore(setting.GetMemoRelatedSetting()),
 		}
+	case storepb.WorkspaceSettingKey_BACKUP:
+		workspaceSetting.Value = &storepb.WorkspaceSetting_BackupSetting{
+			BackupSetting: convertWorkspaceBackupSettingToStore(setting.GetBackupSetting()),
+		}
 	default:
 		// Keep the default GeneralSetting value
 	}
@@ -169,6 +197,7 @@ func convertWorkspaceGeneralSettingFromStore(setting *storepb.WorkspaceGeneralSe
 		WeekStartDayOffset:       setting.WeekStartDayOffset,
 		DisallowChangeUsername:   setting.DisallowChangeUsername,
 		DisallowChangeNickname:   setting.DisallowChangeNickname,
+		Timezone:                 setting.Timezone,
 	}
 	if setting.CustomProfile != nil {
 		generalSetting.CustomProfile = &v1pb.WorkspaceSetting_GeneralSetting_CustomProfile{
@@ -194,6 +223,7 @@ func convertWorkspaceGeneralSettingToStore(setting *v1pb.WorkspaceSetting_Genera
 		WeekStartDayOffset:       setting.WeekStartDayOffset,
 		DisallowChangeUsername:   setting.DisallowChangeUsername,
 		DisallowChangeNickname:   setting.DisallowChangeNickname,
+		Timezone:                 setting.Timezone,
 	}
 	if setting.CustomProfile != nil {
 		generalSetting.CustomProfile = &storepb.WorkspaceCustomProfile{
@@ -211,9 +241,17 @@ func convertWorkspaceStorageSettingFromStore(settingpb *storepb.WorkspaceStorage
 		return nil
 	}
 	setting := &v1pb.WorkspaceSetting_StorageSetting{
-		StorageType:       v1pb.WorkspaceSetting_StorageSetting_StorageType(settingpb.StorageType),
-		FilepathTemplate:  settingpb.FilepathTemplate,
-		UploadSizeLimitMb: settingpb.UploadSizeLimitMb,
+		StorageType:            v1pb.WorkspaceSetting_StorageSetting_StorageType(settingpb.StorageType),
+		FilepathTemplate:       settingpb.FilepathTemplate,
+		UploadSizeLimitMb:      settingpb.UploadSizeLimitMb,
+		Deduplicate:            settingpb.Deduplicate,
+		RoleQuotaMb:            settingpb.RoleQuotaMb,
+		UserQuotaMb:            map[string]int64{},
+		OrphanRetentionDays:    settingpb.OrphanRetentionDays,
+		ImageMetadataStripping: v1pb.WorkspaceSetting_StorageSetting_ImageMetadataStripping(settingpb.ImageMetadataStripping),
+	}
+	for userID, quota := range settingpb.UserQuotaMb {
+		setting.UserQuotaMb[fmt.Sprintf("%s%d", UserNamePrefix, userID)] = quota
 	}
 	if settingpb.S3Config != nil {
 		setting.S3Config = &v1pb.WorkspaceSetting_StorageSetting_S3Config{
@@ -225,6 +263,22 @@ func convertWorkspaceStorageSettingFromStore(settingpb *storepb.WorkspaceStorage
 			UsePathStyle:    settingpb.S3Config.UsePathStyle,
 		}
 	}
+	if settingpb.WebdavConfig != nil {
+		setting.WebdavConfig = &v1pb.WorkspaceSetting_StorageSetting_WebDAVConfig{
+			Endpoint: settingpb.WebdavConfig.Endpoint,
+			Username: settingpb.WebdavConfig.Username,
+			Password: settingpb.WebdavConfig.Password,
+		}
+	}
+	if settingpb.SftpConfig != nil {
+		setting.SftpConfig = &v1pb.WorkspaceSetting_StorageSetting_SFTPConfig{
+			Address:       settingpb.SftpConfig.Address,
+			Username:      settingpb.SftpConfig.Username,
+			Password:      settingpb.SftpConfig.Password,
+			PrivateKey:    settingpb.SftpConfig.PrivateKey,
+			HostPublicKey: settingpb.SftpConfig.HostPublicKey,
+		}
+	}
 	return setting
 }
 
@@ -233,9 +287,20 @@ func convertWorkspaceStorageSettingToStore(setting *v1pb.WorkspaceSetting_Storag
 		return nil
 	}
 	settingpb := &storepb.WorkspaceStorageSetting{
-		StorageType:       storepb.WorkspaceStorageSetting_StorageType(setting.StorageType),
-		FilepathTemplate:  setting.FilepathTemplate,
-		UploadSizeLimitMb: setting.UploadSizeLimitMb,
+		StorageType:            storepb.WorkspaceStorageSetting_StorageType(setting.StorageType),
+		FilepathTemplate:       setting.FilepathTemplate,
+		UploadSizeLimitMb:      setting.UploadSizeLimitMb,
+		Deduplicate:            setting.Deduplicate,
+		RoleQuotaMb:            setting.RoleQuotaMb,
+		UserQuotaMb:            map[int32]int64{},
+		OrphanRetentionDays:    setting.OrphanRetentionDays,
+		ImageMetadataStripping: storepb.WorkspaceStorageSetting_ImageMetadataStripping(setting.ImageMetadataStripping),
+	}
+	for name, quota := range setting.UserQuotaMb {
+		// The user names are validated before the setting is converted.
+		if userID, err := ExtractUserIDFromName(name); err == nil {
+			settingpb.UserQuotaMb[userID] = quota
+		}
 	}
 	if setting.S3Config != nil {
 		settingpb.S3Config = &storepb.StorageS3Config{
@@ -247,6 +312,22 @@ func convertWorkspaceStorageSettingToStore(setting *v1pb.WorkspaceSetting_Storag
 			UsePathStyle:    setting.S3Config.UsePathStyle,
 		}
 	}
+	if setting.WebdavConfig != nil {
+		settingpb.WebdavConfig = &storepb.StorageWebDAVConfig{
+			Endpoint: setting.WebdavConfig.Endpoint,
+			Username: setting.WebdavConfig.Username,
+			Password: setting.WebdavConfig.Password,
+		}
+	}
+	if setting.SftpConfig != nil {
+		settingpb.SftpConfig = &storepb.StorageSFTPConfig{
+			Address:       setting.SftpConfig.Address,
+			Username:      setting.SftpConfig.Username,
+			Password:      setting.SftpConfig.Password,
+			PrivateKey:    setting.SftpConfig.PrivateKey,
+			HostPublicKey: setting.SftpConfig.HostPublicKey,
+		}
+	}
 	return settingpb
 }
 
@@ -284,6 +365,74 @@ func convertWorkspaceMemoRelatedSettingToStore(setting *v1pb.WorkspaceSetting_Me
 	}
 }
 
+func convertWorkspaceBackupSettingFromStore(setting *storepb.WorkspaceBackupSetting) *v1pb.WorkspaceSetting_BackupSetting {
+	if setting == nil {
+		return nil
+	}
+	return &v1pb.WorkspaceSetting_BackupSetting{
+		Enabled:     setting.Enabled,
+		CronSpec:    setting.CronSpec,
+		Destination: v1pb.WorkspaceSetting_BackupSetting_Destination(setting.Destination),
+		Directory:   setting.Directory,
+		S3Prefix:    setting.S3Prefix,
+		KeepLast:    setting.KeepLast,
+		KeepDaily:   setting.KeepDaily,
+		KeepWeekly:  setting.KeepWeekly,
+	}
+}
+
+func convertWorkspaceBackupSettingToStore(setting *v1pb.WorkspaceSetting_BackupSetting) *storepb.WorkspaceBackupSetting {
+	if setting == nil {
+		return nil
+	}
+	return &storepb.WorkspaceBackupSetting{
+		Enabled:     setting.Enabled,
+		CronSpec:    setting.CronSpec,
+		Destination: storepb.WorkspaceBackupSetting_Destination(setting.Destination),
+		Directory:   setting.Directory,
+		S3Prefix:    setting.S3Prefix,
+		KeepLast:    setting.KeepLast,
+		KeepDaily:   setting.KeepDaily,
+		KeepWeekly:  setting.KeepWeekly,
+	}
+}
+
+// ListBackups lists the scheduled backups at the destination of the backup setting.
+func (s *APIV1Service) ListBackups(ctx context.Context, _ *v1pb.ListBackupsRequest) (*v1pb.ListBackupsResponse, error) {
+	user, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get current user: %v", err)
+	}
+	if user == nil {
+		return nil, status.Errorf(codes.Unauthenticated, "user not authenticated")
+	}
+	if !isSuperUser(user) {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+
+	workspaceBackupSetting, err := s.Store.GetWorkspaceBackupSetting(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get workspace backup setting: %v", err)
+	}
+	destination, err := backup.NewDestination(ctx, s.Store, s.Profile, workspaceBackupSetting)
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "invalid backup destination: %v", err)
+	}
+	archives, err := destination.List(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list backups: %v", err)
+	}
+	response := &v1pb.ListBackupsResponse{Backups: []*v1pb.Backup{}}
+	for _, archive := range archives {
+		response.Backups = append(response.Backups, &v1pb.Backup{
+			Name:       fmt.Sprintf("%s%s", BackupNamePrefix, archive.Name),
+			Size:       archive.Size,
+			CreateTime: timestamppb.New(archive.CreateTime),
+		})
+	}
+	return response, nil
+}
+
 var ownerCache *v1pb.User
 
 func (s *APIV1Service) GetInstanceOwner(ctx context.Context) (*v1pb.User, error) {
@@ -0,0 +1,149 @@
+package rss
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"strconv"
+
+	"github.com/gorilla/feeds"
+)
+
+// Feed formats by the file name of their routes.
+const (
+	feedFormatRSS  = "rss.xml"
+	feedFormatAtom = "atom.xml"
+	feedFormatJSON = "feed.json"
+)
+
+var feedFormats = []string{feedFormatRSS, feedFormatAtom, feedFormatJSON}
+
+var feedContentTypes = map[string]string{
+	feedFormatRSS:  "application/rss+xml; charset=UTF-8",
+	feedFormatAtom: "application/atom+xml; charset=UTF-8",
+	feedFormatJSON: "application/feed+json; charset=UTF-8",
+}
+
+// feedItem is a feed item with the categories and attachments that gorilla/feeds
+// only supports once per item.
+type feedItem struct {
+	*feeds.Item
+	Categories  []string
+	Attachments []*feedAttachment
+}
+
+type feedAttachment struct {
+	URL      string
+	Type     string
+	Filename string
+	Size     int64
+}
+
+// rssItem extends an RSS item with a category per tag and an enclosure per attachment.
+type rssItem struct {
+	*feeds.RssItem
+	Categories []string              `xml:"category"`
+	Enclosures []*feeds.RssEnclosure `xml:"enclosure"`
+}
+
+type rssChannel struct {
+	*feeds.RssFeed
+	Items []*rssItem `xml:"item"`
+}
+
+type rssFeedXML struct {
+	XMLName          xml.Name `xml:"rss"`
+	Version          string   `xml:"version,attr"`
+	ContentNamespace string   `xml:"xmlns:content,attr"`
+	Channel          *rssChannel
+}
+
+type atomCategory struct {
+	XMLName xml.Name `xml:"category"`
+	Term    string   `xml:"term,attr"`
+}
+
+// atomEntry extends an Atom entry with a category per tag.
+type atomEntry struct {
+	*feeds.AtomEntry
+	Categories []atomCategory `xml:"category"`
+}
+
+type atomFeed struct {
+	*feeds.AtomFeed
+	Entries []*atomEntry `xml:"entry"`
+}
+
+// renderFeed encodes the feed in the format.
+func renderFeed(feed *feeds.Feed, items []*feedItem, format string) (string, error) {
+	feed.Items = make([]*feeds.Item, len(items))
+	for i, item := range items {
+		feed.Items[i] = item.Item
+	}
+
+	switch format {
+	case feedFormatAtom:
+		atom := (&feeds.Atom{Feed: feed}).AtomFeed()
+		result := &atomFeed{AtomFeed: atom}
+		for i, entry := range atom.Entries {
+			item := items[i]
+			entry.Summary = nil
+			entry.Content = &feeds.AtomContent{Content: item.Description, Type: "html"}
+			// Replace the enclosure of the first attachment with the enclosures of all of them.
+			entry.Links = entry.Links[:1]
+			for _, attachment := range item.Attachments {
+				entry.Links = append(entry.Links, feeds.AtomLink{Href: attachment.URL, Rel: "enclosure", Type: attachment.Type, Length: strconv.FormatInt(attachment.Size, 10)})
+			}
+			categories := make([]atomCategory, len(item.Categories))
+			for j, category := range item.Categories {
+				categories[j] = atomCategory{Term: category}
+			}
+			result.Entries = append(result.Entries, &atomEntry{AtomEntry: entry, Categories: categories})
+		}
+		return marshalXML(result)
+	case feedFormatJSON:
+		jsonFeed := (&feeds.JSON{Feed: feed}).JSONFeed()
+		for i, jsonItem := range jsonFeed.Items {
+			item := items[i]
+			jsonItem.Summary = ""
+			jsonItem.ContentHTML = item.Description
+			jsonItem.Tags = item.Categories
+			for _, attachment := range item.Attachments {
+				jsonItem.Attachments = append(jsonItem.Attachments, feeds.JSONAttachment{
+					Url:      attachment.URL,
+					MIMEType: attachment.Type,
+					Title:    attachment.Filename,
+					Size:     int32(attachment.Size),
+				})
+			}
+		}
+		data, err := json.MarshalIndent(jsonFeed, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	default:
+		rss := (&feeds.Rss{Feed: feed}).RssFeed()
+		channel := &rssChannel{RssFeed: rss}
+		for i, rssFeedItem := range rss.Items {
+			item := items[i]
+			enclosures := make([]*feeds.RssEnclosure, len(item.Attachments))
+			for j, attachment := range item.Attachments {
+				enclosures[j] = &feeds.RssEnclosure{Url: attachment.URL, Type: attachment.Type, Length: strconv.FormatInt(attachment.Size, 10)}
+			}
+			channel.Items = append(channel.Items, &rssItem{RssItem: rssFeedItem, Categories: item.Categories, Enclosures: enclosures})
+		}
+		return marshalXML(&rssFeedXML{
+			Version:          "2.0",
+			ContentNamespace: "http://purl.org/rss/1.0/modules/content/",
+			Channel:          channel,
+		})
+	}
+}
+
+func marshalXML(v any) (string, error) {
+	data, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return xml.Header + string(data), nil
+}
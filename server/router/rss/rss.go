@@ -2,10 +2,16 @@ package rss
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"net/http"
+	"net/url"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/gorilla/feeds"
 	"github.com/labstack/echo/v4"
@@ -13,17 +19,30 @@ import (
 	"github.com/usememos/gomark/renderer"
 
 	"github.com/usememos/memos/internal/profile"
+	"github.com/usememos/memos/internal/util"
 	storepb "github.com/usememos/memos/proto/gen/store"
 	"github.com/usememos/memos/store"
+	"github.com/usememos/memos/store/cache"
 )
 
 const (
-	maxRSSItemCount = 100
+	// defaultFeedItemCount is the number of items of a feed without a limit parameter.
+	defaultFeedItemCount = 100
+	maxFeedItemCount     = 1000
+	// maxFeedAttachmentCount is the max number of attachments of the memos of a feed.
+	maxFeedAttachmentCount = 10 * maxFeedItemCount
+	// maxItemTitleLength is the max length of item titles in runes.
+	maxItemTitleLength = 64
+	// maxCachedFeedCount is the max number of rendered feeds in the feed cache.
+	maxCachedFeedCount = 100
 )
 
 type RSSService struct {
 	Profile *profile.Profile
 	Store   *store.Store
+
+	// feedCache caches the rendered feeds by their URL.
+	feedCache *cache.Cache
 }
 
 type RSSHeading struct {
@@ -35,129 +54,405 @@ func NewRSSService(profile *profile.Profile, store *store.Store) *RSSService {
 	return &RSSService{
 		Profile: profile,
 		Store:   store,
+		feedCache: cache.New(cache.Config{
+			DefaultTTL:      10 * time.Minute,
+			CleanupInterval: 5 * time.Minute,
+			MaxItems:        maxCachedFeedCount,
+		}),
 	}
 }
 
+// RegisterRoutes registers the feeds in every format, e.g. /explore/rss.xml, /explore/atom.xml and /explore/feed.json.
 func (s *RSSService) RegisterRoutes(g *echo.Group) {
-	g.GET("/explore/rss.xml", s.GetExploreRSS)
-	g.GET("/u/:username/rss.xml", s.GetUserRSS)
+	for _, format := range feedFormats {
+		g.GET("/explore/"+format, s.newFeedHandler(format, s.getExploreFeed))
+		g.GET("/u/:username/"+format, s.newFeedHandler(format, s.getUserFeed))
+		g.GET("/u/:username/tags/:tag/"+format, s.newFeedHandler(format, s.getUserFeed))
+		g.GET("/u/:username/shortcuts/:shortcut/"+format, s.newFeedHandler(format, s.getShortcutFeed))
+	}
+}
+
+// feedViewer is the user of the feed token of a private feed.
+type feedViewer struct {
+	userID         int32
+	includePrivate bool
+}
+
+// feedSource is the content of a feed.
+type feedSource struct {
+	heading  RSSHeading
+	link     string
+	memoFind *store.FindMemo
+	location *time.Location
+}
+
+func (s *RSSService) newFeedHandler(format string, getSource func(c echo.Context, baseURL string, viewer *feedViewer) (*feedSource, error)) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		limit := defaultFeedItemCount
+		if value := c.QueryParam("limit"); value != "" {
+			var err error
+			if limit, err = strconv.Atoi(value); err != nil || limit <= 0 {
+				return echo.NewHTTPError(http.StatusBadRequest, "Invalid limit")
+			}
+			limit = min(limit, maxFeedItemCount)
+		}
+
+		viewer, err := s.getFeedViewer(ctx, c.QueryParam("token"))
+		if err != nil {
+			return err
+		}
+		baseURL := c.Scheme() + "://" + c.Request().Host
+		source, err := getSource(c, baseURL, viewer)
+		if err != nil {
+			return err
+		}
+		normalStatus := store.Normal
+		source.memoFind.RowStatus = &normalStatus
+		switch {
+		case viewer == nil:
+			source.memoFind.VisibilityList = []store.Visibility{store.Public}
+		case viewer.includePrivate:
+			source.memoFind.Filters = append(source.memoFind.Filters, fmt.Sprintf(`creator_id == %d || visibility in ["PUBLIC", "PROTECTED"]`, viewer.userID))
+		default:
+			source.memoFind.VisibilityList = []store.Visibility{store.Public, store.Protected}
+		}
+		source.memoFind.Limit = &limit
+
+		// Find the memos without their content first to answer conditional requests and
+		// serve the rendered feed from the cache while its memos are unchanged.
+		source.memoFind.ExcludeContent = true
+		memoList, err := s.Store.ListMemos(ctx, source.memoFind)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to find memo list").SetInternal(err)
+		}
+		attachmentMap, err := s.listFeedAttachments(ctx, memoList)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to find attachment list").SetInternal(err)
+		}
+		version, lastModified := getFeedVersion(format, baseURL, source, memoList, attachmentMap)
+		header := c.Response().Header()
+		header.Set("ETag", `"`+version+`"`)
+		if !lastModified.IsZero() {
+			header.Set(echo.HeaderLastModified, lastModified.UTC().Format(http.TimeFormat))
+		}
+		if viewer != nil {
+			header.Set(echo.HeaderCacheControl, "private, no-cache")
+		} else {
+			header.Set(echo.HeaderCacheControl, "public, no-cache")
+		}
+		if util.IsNotModified(c.Request().Header.Get("If-None-Match"), c.Request().Header.Get(echo.HeaderIfModifiedSince), header.Get("ETag"), lastModified) {
+			return c.NoContent(http.StatusNotModified)
+		}
+
+		cacheKey := baseURL + c.Request().URL.RequestURI()
+		if cached, ok := s.feedCache.Get(ctx, cacheKey); ok {
+			if rendered, ok := cached.(*renderedFeed); ok && rendered.version == version {
+				header.Set(echo.HeaderContentType, feedContentTypes[format])
+				return c.String(http.StatusOK, rendered.content)
+			}
+		}
+
+		source.memoFind.ExcludeContent = false
+		memoList, err = s.Store.ListMemos(ctx, source.memoFind)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to find memo list").SetInternal(err)
+		}
+		feed := &feeds.Feed{
+			Title:       source.heading.Title,
+			Link:        &feeds.Link{Href: source.link},
+			Description: source.heading.Description,
+			Created:     time.Now().In(source.location),
+		}
+		items, err := getFeedItems(memoList, attachmentMap, baseURL, source.location)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to generate feed").SetInternal(err)
+		}
+		result, err := renderFeed(feed, items, format)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to generate feed").SetInternal(err)
+		}
+		s.feedCache.Set(ctx, cacheKey, &renderedFeed{version: version, content: result})
+		header.Set(echo.HeaderContentType, feedContentTypes[format])
+		return c.String(http.StatusOK, result)
+	}
+}
+
+// renderedFeed is a rendered feed in the feed cache.
+type renderedFeed struct {
+	version string
+	content string
+}
+
+// getFeedVersion returns the version of a feed, which changes with its heading, memos and
+// attachments, and the newest update time of its memos and attachments.
+func getFeedVersion(format, baseURL string, source *feedSource, memoList []*store.Memo, attachmentMap map[int32][]*store.Attachment) (string, time.Time) {
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s\n%s\n%s\n%s\n%s\n%s\n", format, baseURL, source.link, source.heading.Title, source.heading.Description, source.location)
+	var lastModified time.Time
+	updated := func(updatedTs int64) {
+		if updateTime := time.Unix(updatedTs, 0); updateTime.After(lastModified) {
+			lastModified = updateTime
+		}
+	}
+	for _, memo := range memoList {
+		fmt.Fprintf(hash, "%d:%d\n", memo.ID, memo.UpdatedTs)
+		updated(memo.UpdatedTs)
+		// Attachments are added and removed without updating their memo.
+		for _, attachment := range attachmentMap[memo.ID] {
+			fmt.Fprintf(hash, "attachment %d:%d\n", attachment.ID, attachment.UpdatedTs)
+			updated(attachment.UpdatedTs)
+		}
+	}
+	return hex.EncodeToString(hash.Sum(nil)[:16]), lastModified
+}
+
+// listFeedAttachments returns the attachments of the memos by memo ID.
+func (s *RSSService) listFeedAttachments(ctx context.Context, memoList []*store.Memo) (map[int32][]*store.Attachment, error) {
+	attachmentMap := map[int32][]*store.Attachment{}
+	if len(memoList) == 0 {
+		return attachmentMap, nil
+	}
+	memoIDs := make([]string, 0, len(memoList))
+	for _, memo := range memoList {
+		memoIDs = append(memoIDs, fmt.Sprintf("'%d'", memo.ID))
+	}
+	// The store lists few attachments by default, but the memos of a feed may have many more.
+	limit := maxFeedAttachmentCount
+	attachments, err := s.Store.ListAttachments(ctx, &store.FindAttachment{
+		Filters: []string{fmt.Sprintf("memo_id in [%s]", strings.Join(memoIDs, ", "))},
+		Limit:   &limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, attachment := range attachments {
+		attachmentMap[*attachment.MemoID] = append(attachmentMap[*attachment.MemoID], attachment)
+	}
+	return attachmentMap, nil
+}
+
+// getFeedViewer returns the viewer of a private feed from its feed token, or nil for public feeds.
+func (s *RSSService) getFeedViewer(ctx context.Context, token string) (*feedViewer, error) {
+	if token == "" {
+		return nil, nil
+	}
+	userID, feedToken, err := s.Store.GetFeedTokenOwner(ctx, token)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to find feed token").SetInternal(err)
+	}
+	if feedToken == nil {
+		return nil, echo.NewHTTPError(http.StatusUnauthorized, "Invalid feed token")
+	}
+	user, err := s.Store.GetUser(ctx, &store.FindUser{ID: &userID})
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to find user").SetInternal(err)
+	}
+	if user == nil || user.RowStatus == store.Archived {
+		return nil, echo.NewHTTPError(http.StatusUnauthorized, "Invalid feed token")
+	}
+	return &feedViewer{userID: user.ID, includePrivate: feedToken.IncludePrivate}, nil
 }
 
-func (s *RSSService) GetExploreRSS(c echo.Context) error {
+func (s *RSSService) getExploreFeed(c echo.Context, baseURL string, _ *feedViewer) (*feedSource, error) {
 	ctx := c.Request().Context()
-	normalStatus := store.Normal
-	memoFind := store.FindMemo{
-		RowStatus:      &normalStatus,
-		VisibilityList: []store.Visibility{store.Public},
+	heading, err := getRSSHeading(ctx, s.Store)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to get workspace setting").SetInternal(err)
 	}
-	memoList, err := s.Store.ListMemos(ctx, &memoFind)
+	location, err := s.Store.GetWorkspaceLocation(ctx)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to find memo list").SetInternal(err)
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to get workspace location").SetInternal(err)
 	}
+	return &feedSource{
+		heading:  heading,
+		link:     baseURL,
+		memoFind: &store.FindMemo{},
+		location: location,
+	}, nil
+}
 
-	baseURL := c.Scheme() + "://" + c.Request().Host
-	rss, err := s.generateRSSFromMemoList(ctx, memoList, baseURL)
+// getUserFeed returns the memos of a user, with a tag when the route has one.
+func (s *RSSService) getUserFeed(c echo.Context, baseURL string, _ *feedViewer) (*feedSource, error) {
+	ctx := c.Request().Context()
+	user, err := s.getUser(c)
+	if err != nil {
+		return nil, err
+	}
+	heading, err := getRSSHeading(ctx, s.Store)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to get workspace setting").SetInternal(err)
+	}
+	location, err := s.Store.GetUserLocation(ctx, user.ID)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to generate rss").SetInternal(err)
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to get user location").SetInternal(err)
 	}
-	c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationXMLCharsetUTF8)
-	return c.String(http.StatusOK, rss)
+	source := &feedSource{
+		heading:  heading,
+		link:     baseURL + "/u/" + url.PathEscape(user.Username),
+		memoFind: &store.FindMemo{CreatorID: &user.ID},
+		location: location,
+	}
+	if c.Param("tag") != "" {
+		tag, err := url.PathUnescape(c.Param("tag"))
+		if err != nil {
+			return nil, echo.NewHTTPError(http.StatusBadRequest, "Invalid tag")
+		}
+		source.heading.Title = fmt.Sprintf("#%s - %s", tag, heading.Title)
+		source.memoFind.Filters = []string{fmt.Sprintf("tag in [%s]", strconv.Quote(tag))}
+	}
+	return source, nil
 }
 
-func (s *RSSService) GetUserRSS(c echo.Context) error {
+// getShortcutFeed returns the memos matching the filter of a shortcut shared with the workspace,
+// or with the viewer of a private feed.
+func (s *RSSService) getShortcutFeed(c echo.Context, baseURL string, viewer *feedViewer) (*feedSource, error) {
 	ctx := c.Request().Context()
-	username := c.Param("username")
-	user, err := s.Store.GetUser(ctx, &store.FindUser{
-		Username: &username,
+	user, err := s.getUser(c)
+	if err != nil {
+		return nil, err
+	}
+	userSetting, err := s.Store.GetUserSetting(ctx, &store.FindUserSetting{
+		UserID: &user.ID,
+		Key:    storepb.UserSetting_SHORTCUTS,
 	})
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to find user").SetInternal(err)
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to find shortcuts").SetInternal(err)
 	}
-	if user == nil {
-		return echo.NewHTTPError(http.StatusNotFound, "User not found")
+	var shortcut *storepb.ShortcutsUserSetting_Shortcut
+	for _, item := range userSetting.GetShortcuts().GetShortcuts() {
+		if item.Id == c.Param("shortcut") {
+			shortcut = item
+		}
+	}
+	if shortcut == nil || !canViewShortcut(shortcut, user.ID, viewer) {
+		return nil, echo.NewHTTPError(http.StatusNotFound, "Shortcut not found")
 	}
 
-	normalStatus := store.Normal
-	memoFind := store.FindMemo{
-		CreatorID:      &user.ID,
-		RowStatus:      &normalStatus,
-		VisibilityList: []store.Visibility{store.Public},
+	heading, err := getRSSHeading(ctx, s.Store)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to get workspace setting").SetInternal(err)
 	}
-	memoList, err := s.Store.ListMemos(ctx, &memoFind)
+	location, err := s.Store.GetUserLocation(ctx, user.ID)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to find memo list").SetInternal(err)
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to get user location").SetInternal(err)
 	}
-
-	baseURL := c.Scheme() + "://" + c.Request().Host
-	rss, err := s.generateRSSFromMemoList(ctx, memoList, baseURL)
+	workspaceGeneralSetting, err := s.Store.GetWorkspaceGeneralSetting(ctx)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to generate rss").SetInternal(err)
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to get workspace setting").SetInternal(err)
+	}
+	source := &feedSource{
+		heading:  heading,
+		link:     baseURL,
+		memoFind: &store.FindMemo{Location: location, WeekStart: time.Weekday(workspaceGeneralSetting.WeekStartDayOffset % 7)},
+		location: location,
 	}
-	c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationXMLCharsetUTF8)
-	return c.String(http.StatusOK, rss)
+	source.heading.Title = fmt.Sprintf("%s - %s", shortcut.Title, heading.Title)
+	if shortcut.Filter != "" {
+		source.memoFind.Filters = []string{shortcut.Filter}
+	}
+	return source, nil
 }
 
-func (s *RSSService) generateRSSFromMemoList(ctx context.Context, memoList []*store.Memo, baseURL string) (string, error) {
-	rssHeading, err := getRSSHeading(ctx, s.Store)
+// canViewShortcut returns whether the feed of the shortcut is visible. Only the shortcuts shared
+// with the workspace have public feeds.
+func canViewShortcut(shortcut *storepb.ShortcutsUserSetting_Shortcut, ownerID int32, viewer *feedViewer) bool {
+	if shortcut.Visibility == storepb.ShortcutsUserSetting_Shortcut_WORKSPACE {
+		return true
+	}
+	if viewer == nil {
+		return false
+	}
+	if viewer.userID == ownerID {
+		return true
+	}
+	return shortcut.Visibility == storepb.ShortcutsUserSetting_Shortcut_USERS && slices.Contains(shortcut.SharedUserIds, viewer.userID)
+}
+
+func (s *RSSService) getUser(c echo.Context) (*store.User, error) {
+	username := c.Param("username")
+	user, err := s.Store.GetUser(c.Request().Context(), &store.FindUser{
+		Username: &username,
+	})
 	if err != nil {
-		return "", err
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to find user").SetInternal(err)
 	}
-	feed := &feeds.Feed{
-		Title:       rssHeading.Title,
-		Link:        &feeds.Link{Href: baseURL},
-		Description: rssHeading.Description,
-		Created:     time.Now(),
+	if user == nil {
+		return nil, echo.NewHTTPError(http.StatusNotFound, "User not found")
 	}
+	return user, nil
+}
 
-	var itemCountLimit = min(len(memoList), maxRSSItemCount)
-	feed.Items = make([]*feeds.Item, itemCountLimit)
-	for i := 0; i < itemCountLimit; i++ {
-		memo := memoList[i]
+func getFeedItems(memoList []*store.Memo, attachmentMap map[int32][]*store.Attachment, baseURL string, location *time.Location) ([]*feedItem, error) {
+	items := make([]*feedItem, len(memoList))
+	for i, memo := range memoList {
 		description, err := getRSSItemDescription(memo.Content)
 		if err != nil {
-			return "", err
+			return nil, err
 		}
-		link := &feeds.Link{Href: baseURL + "/memos/" + memo.UID}
-		feed.Items[i] = &feeds.Item{
-			Link:        link,
-			Description: description,
-			Created:     time.Unix(memo.CreatedTs, 0),
-			Id:          link.Href,
-		}
-		attachments, err := s.Store.ListAttachments(ctx, &store.FindAttachment{
-			MemoID: &memo.ID,
-		})
+		title, err := getRSSItemTitle(memo.Content)
 		if err != nil {
-			return "", err
+			return nil, err
 		}
-		if len(attachments) > 0 {
-			attachment := attachments[0]
-			enclosure := feeds.Enclosure{}
+		link := &feeds.Link{Href: baseURL + "/memos/" + memo.UID}
+		item := &feedItem{
+			Item: &feeds.Item{
+				Title:       title,
+				Link:        link,
+				Description: description,
+				Created:     time.Unix(memo.CreatedTs, 0).In(location),
+				Updated:     time.Unix(memo.UpdatedTs, 0).In(location),
+				Id:          link.Href,
+			},
+			Categories:  memo.Payload.GetTags(),
+			Attachments: []*feedAttachment{},
+		}
+		for _, attachment := range attachmentMap[memo.ID] {
+			attachmentURL := fmt.Sprintf("%s/file/attachments/%s/%s", baseURL, attachment.UID, url.PathEscape(attachment.Filename))
 			if attachment.StorageType == storepb.AttachmentStorageType_EXTERNAL || attachment.StorageType == storepb.AttachmentStorageType_S3 {
-				enclosure.Url = attachment.Reference
-			} else {
-				enclosure.Url = fmt.Sprintf("%s/file/attachments/%s/%s", baseURL, attachment.UID, attachment.Filename)
+				attachmentURL = attachment.Reference
 			}
-			enclosure.Length = strconv.Itoa(int(attachment.Size))
-			enclosure.Type = attachment.Type
-			feed.Items[i].Enclosure = &enclosure
+			item.Attachments = append(item.Attachments, &feedAttachment{
+				URL:      attachmentURL,
+				Type:     attachment.Type,
+				Filename: attachment.Filename,
+				Size:     attachment.Size,
+			})
+		}
+		if len(item.Attachments) > 0 {
+			first := item.Attachments[0]
+			item.Enclosure = &feeds.Enclosure{Url: first.URL, Type: first.Type, Length: strconv.FormatInt(first.Size, 10)}
 		}
+		items[i] = item
 	}
+	return items, nil
+}
 
-	rss, err := feed.ToRss()
+func getRSSItemDescription(content string) (string, error) {
+	doc, err := gomark.Parse(content)
 	if err != nil {
 		return "", err
 	}
-	return rss, nil
+	result := renderer.NewHTMLRenderer().RenderDocument(doc)
+	return result, nil
 }
 
-func getRSSItemDescription(content string) (string, error) {
+// getRSSItemTitle returns the first line of the plain text of the content.
+func getRSSItemTitle(content string) (string, error) {
 	doc, err := gomark.Parse(content)
 	if err != nil {
 		return "", err
 	}
-	result := renderer.NewHTMLRenderer().RenderDocument(doc)
-	return result, nil
+	title := ""
+	for _, line := range strings.Split(renderer.NewStringRenderer().RenderDocument(doc), "\n") {
+		if title = strings.TrimSpace(line); title != "" {
+			break
+		}
+	}
+	if utf8.RuneCountInString(title) > maxItemTitleLength {
+		title = string([]rune(title)[:maxItemTitleLength]) + "..."
+	}
+	return title, nil
 }
 
 func getRSSHeading(ctx context.Context, stores *store.Store) (RSSHeading, error) {
@@ -0,0 +1,278 @@
+package rss
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/internal/profile"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+	teststore "github.com/usememos/memos/store/test"
+)
+
+func newTestingServer(ctx context.Context, t *testing.T) (*echo.Echo, *store.Store) {
+	stores := teststore.NewTestingStore(ctx, t)
+	t.Cleanup(func() { stores.Close() })
+	e := echo.New()
+	NewRSSService(&profile.Profile{Mode: "dev"}, stores).RegisterRoutes(e.Group(""))
+	return e, stores
+}
+
+func getFeed(e *echo.Echo, target string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func createTestingMemo(ctx context.Context, t *testing.T, stores *store.Store, creatorID int32, uid, content string, visibility store.Visibility, tags ...string) *store.Memo {
+	memo, err := stores.CreateMemo(ctx, &store.Memo{
+		UID:        uid,
+		CreatorID:  creatorID,
+		Content:    content,
+		Visibility: visibility,
+		Payload:    &storepb.MemoPayload{Tags: tags},
+	})
+	require.NoError(t, err)
+	return memo
+}
+
+func TestFeedFormats(t *testing.T) {
+	ctx := context.Background()
+	e, stores := newTestingServer(ctx, t)
+	user, err := stores.CreateUser(ctx, &store.User{Username: "test", Role: store.RoleHost, Email: "test@example.com"})
+	require.NoError(t, err)
+	memo := createTestingMemo(ctx, t, stores, user.ID, "memo1", "**Hello** world\n\nsecond line #work #life", store.Public, "work", "life")
+	createTestingMemo(ctx, t, stores, user.ID, "memo2", "private", store.Private)
+	for _, filename := range []string{"a.png", "b.pdf"} {
+		_, err := stores.CreateAttachment(ctx, &store.Attachment{
+			UID:       strings.TrimSuffix(filename, path.Ext(filename)),
+			CreatorID: user.ID,
+			Filename:  filename,
+			Type:      "application/octet-stream",
+			Size:      10,
+			MemoID:    &memo.ID,
+		})
+		require.NoError(t, err)
+	}
+
+	rec := getFeed(e, "/u/test/rss.xml")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, feedContentTypes[feedFormatRSS], rec.Header().Get(echo.HeaderContentType))
+	body := rec.Body.String()
+	require.Contains(t, body, "<title>Hello world</title>")
+	require.Contains(t, body, "<category>work</category>")
+	require.Contains(t, body, "<category>life</category>")
+	require.Equal(t, 2, strings.Count(body, "<enclosure "))
+	require.NotContains(t, body, "memo2")
+
+	rec = getFeed(e, "/u/test/atom.xml")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, feedContentTypes[feedFormatAtom], rec.Header().Get(echo.HeaderContentType))
+	body = rec.Body.String()
+	require.Contains(t, body, `<category term="work">`)
+	require.Equal(t, 2, strings.Count(body, `rel="enclosure"`))
+
+	rec = getFeed(e, "/explore/feed.json")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, feedContentTypes[feedFormatJSON], rec.Header().Get(echo.HeaderContentType))
+	jsonFeed := struct {
+		Version string `json:"version"`
+		Items   []struct {
+			ID          string   `json:"id"`
+			Title       string   `json:"title"`
+			Tags        []string `json:"tags"`
+			Attachments []struct {
+				URL string `json:"url"`
+			} `json:"attachments"`
+		} `json:"items"`
+	}{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &jsonFeed))
+	require.Len(t, jsonFeed.Items, 1)
+	require.Equal(t, "http://example.com/memos/memo1", jsonFeed.Items[0].ID)
+	require.Equal(t, "Hello world", jsonFeed.Items[0].Title)
+	require.Equal(t, []string{"work", "life"}, jsonFeed.Items[0].Tags)
+	require.Len(t, jsonFeed.Items[0].Attachments, 2)
+	require.Equal(t, "http://example.com/file/attachments/a/a.png", jsonFeed.Items[0].Attachments[0].URL)
+
+	require.Equal(t, http.StatusBadRequest, getFeed(e, "/explore/rss.xml?limit=0").Code)
+	require.Equal(t, http.StatusNotFound, getFeed(e, "/u/unknown/atom.xml").Code)
+}
+
+func TestTagAndShortcutFeeds(t *testing.T) {
+	ctx := context.Background()
+	e, stores := newTestingServer(ctx, t)
+	user, err := stores.CreateUser(ctx, &store.User{Username: "test", Role: store.RoleHost, Email: "test@example.com"})
+	require.NoError(t, err)
+	other, err := stores.CreateUser(ctx, &store.User{Username: "other", Role: store.RoleUser, Email: "other@example.com"})
+	require.NoError(t, err)
+	createTestingMemo(ctx, t, stores, user.ID, "work1", "work memo #work", store.Public, "work")
+	createTestingMemo(ctx, t, stores, user.ID, "life1", "life memo #life", store.Public, "life")
+	createTestingMemo(ctx, t, stores, other.ID, "work2", "other work memo #work", store.Public, "work")
+	createTestingMemo(ctx, t, stores, other.ID, "work3", "protected work memo #work", store.Protected, "work")
+	_, err = stores.UpsertUserSetting(ctx, &storepb.UserSetting{
+		UserId: user.ID,
+		Key:    storepb.UserSetting_SHORTCUTS,
+		Value: &storepb.UserSetting_Shortcuts{
+			Shortcuts: &storepb.ShortcutsUserSetting{
+				Shortcuts: []*storepb.ShortcutsUserSetting_Shortcut{
+					{Id: "shared", Title: "Work", Filter: `tag in ["work"]`, Visibility: storepb.ShortcutsUserSetting_Shortcut_WORKSPACE},
+					{Id: "private", Title: "Life", Filter: `tag in ["life"]`},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	rec := getFeed(e, "/u/test/tags/work/rss.xml")
+	require.Equal(t, http.StatusOK, rec.Code)
+	body := rec.Body.String()
+	require.Contains(t, body, "<title>#work - Memos</title>")
+	require.Contains(t, body, "/memos/work1")
+	require.NotContains(t, body, "/memos/life1")
+	require.NotContains(t, body, "/memos/work2")
+
+	rec = getFeed(e, "/u/test/shortcuts/shared/atom.xml")
+	require.Equal(t, http.StatusOK, rec.Code)
+	body = rec.Body.String()
+	require.Contains(t, body, "Work - Memos")
+	require.Contains(t, body, "/memos/work1")
+	require.Contains(t, body, "/memos/work2")
+	require.NotContains(t, body, "/memos/work3")
+	require.NotContains(t, body, "/memos/life1")
+
+	require.Equal(t, http.StatusNotFound, getFeed(e, "/u/test/shortcuts/private/atom.xml").Code)
+	require.Equal(t, http.StatusNotFound, getFeed(e, "/u/test/shortcuts/unknown/atom.xml").Code)
+}
+
+func TestPrivateFeeds(t *testing.T) {
+	ctx := context.Background()
+	e, stores := newTestingServer(ctx, t)
+	user, err := stores.CreateUser(ctx, &store.User{Username: "test", Role: store.RoleHost, Email: "test@example.com"})
+	require.NoError(t, err)
+	other, err := stores.CreateUser(ctx, &store.User{Username: "other", Role: store.RoleUser, Email: "other@example.com"})
+	require.NoError(t, err)
+	createTestingMemo(ctx, t, stores, user.ID, "public1", "public memo", store.Public)
+	createTestingMemo(ctx, t, stores, user.ID, "protected1", "protected memo", store.Protected)
+	createTestingMemo(ctx, t, stores, user.ID, "private1", "private memo", store.Private)
+	createTestingMemo(ctx, t, stores, other.ID, "private2", "private memo of other", store.Private)
+	protectedToken, privateToken := store.FormatFeedToken(user.ID, "protected"), store.FormatFeedToken(user.ID, "private")
+	require.NoError(t, stores.UpsertUserFeedTokens(ctx, user.ID, []*storepb.FeedTokensUserSetting_FeedToken{
+		{Token: protectedToken},
+		{Token: privateToken, IncludePrivate: true},
+	}))
+	_, err = stores.UpsertUserSetting(ctx, &storepb.UserSetting{
+		UserId: user.ID,
+		Key:    storepb.UserSetting_SHORTCUTS,
+		Value: &storepb.UserSetting_Shortcuts{
+			Shortcuts: &storepb.ShortcutsUserSetting{
+				Shortcuts: []*storepb.ShortcutsUserSetting_Shortcut{
+					{Id: "mine", Title: "Mine", Visibility: storepb.ShortcutsUserSetting_Shortcut_PRIVATE},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	body := getFeed(e, "/explore/rss.xml").Body.String()
+	require.Contains(t, body, "/memos/public1")
+	require.NotContains(t, body, "/memos/protected1")
+
+	body = getFeed(e, "/explore/rss.xml?token="+protectedToken).Body.String()
+	require.Contains(t, body, "/memos/public1")
+	require.Contains(t, body, "/memos/protected1")
+	require.NotContains(t, body, "/memos/private1")
+
+	body = getFeed(e, "/explore/rss.xml?token="+privateToken).Body.String()
+	require.Contains(t, body, "/memos/protected1")
+	require.Contains(t, body, "/memos/private1")
+	require.NotContains(t, body, "/memos/private2")
+
+	require.Equal(t, http.StatusNotFound, getFeed(e, "/u/test/shortcuts/mine/rss.xml").Code)
+	rec := getFeed(e, "/u/test/shortcuts/mine/rss.xml?token="+privateToken)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), "/memos/private1")
+
+	// Revoked tokens no longer give access to the feeds.
+	require.NoError(t, stores.UpsertUserFeedTokens(ctx, user.ID, []*storepb.FeedTokensUserSetting_FeedToken{}))
+	require.Equal(t, http.StatusUnauthorized, getFeed(e, "/explore/rss.xml?token="+privateToken).Code)
+}
+
+func TestFeedConditionalGet(t *testing.T) {
+	ctx := context.Background()
+	e, stores := newTestingServer(ctx, t)
+	user, err := stores.CreateUser(ctx, &store.User{Username: "test", Role: store.RoleHost, Email: "test@example.com"})
+	require.NoError(t, err)
+	memo := createTestingMemo(ctx, t, stores, user.ID, "memo1", "first", store.Public)
+
+	rec := getFeed(e, "/u/test/rss.xml")
+	require.Equal(t, http.StatusOK, rec.Code)
+	etag := rec.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+	lastModified := rec.Header().Get(echo.HeaderLastModified)
+	require.NotEmpty(t, lastModified)
+	require.Equal(t, "public, no-cache", rec.Header().Get(echo.HeaderCacheControl))
+
+	req := httptest.NewRequest(http.MethodGet, "/u/test/rss.xml", nil)
+	req.Header.Set("If-None-Match", etag)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusNotModified, rec.Code)
+	require.Empty(t, rec.Body.String())
+
+	req = httptest.NewRequest(http.MethodGet, "/u/test/rss.xml", nil)
+	req.Header.Set(echo.HeaderIfModifiedSince, lastModified)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusNotModified, rec.Code)
+
+	// The feed changes with its memos, and the cached feed is rendered again.
+	content, updatedTs := "second", memo.UpdatedTs+10
+	require.NoError(t, stores.UpdateMemo(ctx, &store.UpdateMemo{ID: memo.ID, Content: &content, UpdatedTs: &updatedTs}))
+	req = httptest.NewRequest(http.MethodGet, "/u/test/rss.xml", nil)
+	req.Header.Set("If-None-Match", etag)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NotEqual(t, etag, rec.Header().Get("ETag"))
+	require.Contains(t, rec.Body.String(), "second")
+
+	// Other formats of the same memos have their own version.
+	require.NotEqual(t, rec.Header().Get("ETag"), getFeed(e, "/u/test/atom.xml").Header().Get("ETag"))
+
+	// The feed changes with the attachments of its memos, which do not update the memos.
+	etag = rec.Header().Get("ETag")
+	attachment, err := stores.CreateAttachment(ctx, &store.Attachment{
+		UID:       "attachment1",
+		CreatorID: user.ID,
+		Filename:  "photo.png",
+		Type:      "image/png",
+		Blob:      []byte("photo"),
+		Size:      5,
+		MemoID:    &memo.ID,
+	})
+	require.NoError(t, err)
+	req = httptest.NewRequest(http.MethodGet, "/u/test/rss.xml", nil)
+	req.Header.Set("If-None-Match", etag)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), "/file/attachments/attachment1/photo.png")
+
+	etag = rec.Header().Get("ETag")
+	require.NoError(t, stores.DeleteAttachment(ctx, &store.DeleteAttachment{ID: attachment.ID}))
+	req = httptest.NewRequest(http.MethodGet, "/u/test/rss.xml", nil)
+	req.Header.Set("If-None-Match", etag)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NotContains(t, rec.Body.String(), "attachment1")
+}
@@ -0,0 +1,55 @@
+package attachmentgc
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/usememos/memos/store"
+)
+
+// Runner deletes the orphaned attachments, files and thumbnails once the orphan retention
+// of the workspace storage setting has passed.
+type Runner struct {
+	Store *store.Store
+}
+
+func NewRunner(store *store.Store) *Runner {
+	return &Runner{
+		Store: store,
+	}
+}
+
+// Schedule runner every day.
+const runnerInterval = time.Hour * 24
+
+func (r *Runner) Run(ctx context.Context) {
+	ticker := time.NewTicker(runnerInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			r.RunOnce(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (r *Runner) RunOnce(ctx context.Context) {
+	workspaceStorageSetting, err := r.Store.GetWorkspaceStorageSetting(ctx)
+	if err != nil {
+		slog.Error("failed to get workspace storage setting", "error", err)
+		return
+	}
+	// The garbage collection is disabled without orphan retention.
+	if workspaceStorageSetting.OrphanRetentionDays <= 0 {
+		return
+	}
+	if _, err := r.Store.CollectAttachmentGarbage(ctx, store.CollectAttachmentGarbageOptions{
+		Retention: time.Duration(workspaceStorageSetting.OrphanRetentionDays) * 24 * time.Hour,
+	}); err != nil {
+		slog.Error("failed to collect attachment garbage", "error", err)
+	}
+}
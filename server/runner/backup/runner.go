@@ -0,0 +1,109 @@
+package backup
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/usememos/memos/internal/profile"
+	"github.com/usememos/memos/plugin/cron"
+	"github.com/usememos/memos/store"
+	storebackup "github.com/usememos/memos/store/backup"
+)
+
+// Runner takes scheduled backups on the cron spec of the workspace backup setting.
+type Runner struct {
+	Store   *store.Store
+	Profile *profile.Profile
+
+	cron    *cron.Cron
+	entryID cron.EntryID
+	spec    string
+}
+
+func NewRunner(store *store.Store, profile *profile.Profile) *Runner {
+	return &Runner{
+		Store:   store,
+		Profile: profile,
+	}
+}
+
+// Reload the backup setting every minute to pick up its changes.
+const reloadInterval = time.Minute
+
+func (r *Runner) Run(ctx context.Context) {
+	r.cron = cron.New(cron.WithChain(cron.SkipIfStillRunning(cron.DiscardLogger)))
+	r.cron.Start()
+	defer r.cron.Stop()
+
+	ticker := time.NewTicker(reloadInterval)
+	defer ticker.Stop()
+
+	r.reload(ctx)
+	for {
+		select {
+		case <-ticker.C:
+			r.reload(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// reload schedules the backups on the current cron spec, or stops them when they are disabled.
+func (r *Runner) reload(ctx context.Context) {
+	spec, err := r.getSpec(ctx)
+	if err != nil {
+		slog.Error("failed to get backup schedule", "error", err)
+		return
+	}
+	if spec == r.spec {
+		return
+	}
+	if r.entryID != 0 {
+		r.cron.Remove(r.entryID)
+		r.entryID = 0
+	}
+	r.spec = spec
+	if spec == "" {
+		return
+	}
+	entryID, err := r.cron.AddFunc(spec, func() {
+		r.RunOnce(ctx)
+	})
+	if err != nil {
+		slog.Error("invalid backup schedule", "spec", spec, "error", err)
+		return
+	}
+	r.entryID = entryID
+}
+
+// getSpec returns the cron spec of the backups in the workspace timezone, or empty when they are disabled.
+func (r *Runner) getSpec(ctx context.Context) (string, error) {
+	workspaceBackupSetting, err := r.Store.GetWorkspaceBackupSetting(ctx)
+	if err != nil {
+		return "", err
+	}
+	if !workspaceBackupSetting.Enabled {
+		return "", nil
+	}
+	spec := workspaceBackupSetting.CronSpec
+	if strings.HasPrefix(spec, "TZ=") || strings.HasPrefix(spec, "CRON_TZ=") {
+		return spec, nil
+	}
+	location, err := r.Store.GetWorkspaceLocation(ctx)
+	if err != nil {
+		return "", err
+	}
+	return "CRON_TZ=" + location.String() + " " + spec, nil
+}
+
+func (r *Runner) RunOnce(ctx context.Context) {
+	archive, err := storebackup.CreateArchive(ctx, r.Store, r.Profile)
+	if err != nil {
+		slog.Error("failed to take scheduled backup", "error", err)
+		return
+	}
+	slog.Info("scheduled backup taken", "name", archive.Name, "size", archive.Size)
+}
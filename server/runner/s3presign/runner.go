@@ -118,6 +118,7 @@ func (r *Runner) CheckAndPresign(ctx context.Context) {
 					Payload: &storepb.AttachmentPayload_S3Object_{
 						S3Object: s3ObjectPayload,
 					},
+					ImageMetadata: attachment.Payload.GetImageMetadata(),
 				},
 			}); err != nil {
 				slog.Error("Failed to update attachment", "error", err, "attachmentID", attachment.ID)
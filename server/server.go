@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
-	"math"
 	"net"
 	"net/http"
 	"runtime"
@@ -25,6 +24,8 @@ import (
 	apiv1 "github.com/usememos/memos/server/router/api/v1"
 	"github.com/usememos/memos/server/router/frontend"
 	"github.com/usememos/memos/server/router/rss"
+	"github.com/usememos/memos/server/runner/attachmentgc"
+	"github.com/usememos/memos/server/runner/backup"
 	"github.com/usememos/memos/server/runner/s3presign"
 	"github.com/usememos/memos/store"
 )
@@ -87,13 +88,21 @@ func NewServer(ctx context.Context, profile *profile.Profile, store *store.Store
 	// Log full stacktraces if we're in dev
 	logStacktraces := profile.IsDev()
 
+	loggerInterceptor := apiv1.NewLoggerInterceptor(logStacktraces)
+	recoveryOptions := newRecoveryOptions(logStacktraces)
+	authInterceptor := apiv1.NewGRPCAuthInterceptor(store, secret)
 	grpcServer := grpc.NewServer(
-		// Override the maximum receiving message size to math.MaxInt32 for uploading large attachments.
-		grpc.MaxRecvMsgSize(math.MaxInt32),
+		// Messages are buffered in memory, so large attachments are streamed to /file/attachments instead.
+		grpc.MaxRecvMsgSize(apiv1.MaxMessageSizeBytes),
 		grpc.ChainUnaryInterceptor(
-			apiv1.NewLoggerInterceptor(logStacktraces).LoggerInterceptor,
-			newRecoveryInterceptor(logStacktraces),
-			apiv1.NewGRPCAuthInterceptor(store, secret).AuthenticationInterceptor,
+			loggerInterceptor.LoggerInterceptor,
+			grpcrecovery.UnaryServerInterceptor(recoveryOptions...),
+			authInterceptor.AuthenticationInterceptor,
+		),
+		grpc.ChainStreamInterceptor(
+			loggerInterceptor.StreamLoggerInterceptor,
+			grpcrecovery.StreamServerInterceptor(recoveryOptions...),
+			authInterceptor.StreamAuthenticationInterceptor,
 		))
 	s.grpcServer = grpcServer
 
@@ -106,7 +115,9 @@ func NewServer(ctx context.Context, profile *profile.Profile, store *store.Store
 	return s, nil
 }
 
-func newRecoveryInterceptor(logStacktraces bool) grpc.UnaryServerInterceptor {
+// newRecoveryOptions returns the options of the interceptors that recover from panics in unary and
+// stream handlers.
+func newRecoveryOptions(logStacktraces bool) []grpcrecovery.Option {
 	var recoveryOptions []grpcrecovery.Option
 	if logStacktraces {
 		recoveryOptions = append(recoveryOptions, grpcrecovery.WithRecoveryHandler(func(p any) error {
@@ -123,7 +134,7 @@ func newRecoveryInterceptor(logStacktraces bool) grpc.UnaryServerInterceptor {
 		}))
 	}
 
-	return grpcrecovery.UnaryServerInterceptor(recoveryOptions...)
+	return recoveryOptions
 }
 
 func (s *Server) Start(ctx context.Context) error {
@@ -225,6 +236,24 @@ func (s *Server) StartBackgroundRunners(ctx context.Context) {
 		slog.Info("s3presign runner stopped")
 	}()
 
+	// Start scheduled backup runner
+	backupContext, backupCancel := context.WithCancel(ctx)
+	s.runnerCancelFuncs = append(s.runnerCancelFuncs, backupCancel)
+	backupRunner := backup.NewRunner(s.Store, s.Profile)
+	go func() {
+		backupRunner.Run(backupContext)
+		slog.Info("backup runner stopped")
+	}()
+
+	// Start attachment garbage collection runner
+	attachmentGCContext, attachmentGCCancel := context.WithCancel(ctx)
+	s.runnerCancelFuncs = append(s.runnerCancelFuncs, attachmentGCCancel)
+	attachmentGCRunner := attachmentgc.NewRunner(s.Store)
+	go func() {
+		attachmentGCRunner.Run(attachmentGCContext)
+		slog.Info("attachmentgc runner stopped")
+	}()
+
 	// Log the number of goroutines running
 	slog.Info("background runners started", "goroutines", runtime.NumGoroutine())
 }
@@ -9,7 +9,8 @@ import (
 type ActivityType string
 
 const (
-	ActivityTypeMemoComment ActivityType = "MEMO_COMMENT"
+	ActivityTypeMemoComment   ActivityType = "MEMO_COMMENT"
+	ActivityTypeShortcutMatch ActivityType = "SHORTCUT_MATCH"
 )
 
 func (t ActivityType) String() string {
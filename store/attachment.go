@@ -3,13 +3,11 @@ package store
 import (
 	"context"
 	"log/slog"
-	"os"
-	"path/filepath"
 
 	"github.com/pkg/errors"
 
 	"github.com/usememos/memos/internal/base"
-	"github.com/usememos/memos/plugin/storage/s3"
+	"github.com/usememos/memos/plugin/storage"
 	storepb "github.com/usememos/memos/proto/gen/store"
 )
 
@@ -32,6 +30,12 @@ type Attachment struct {
 	StorageType storepb.AttachmentStorageType
 	Reference   string
 	Payload     *storepb.AttachmentPayload
+	// Sha256 is the hex encoded SHA-256 checksum of the content, empty for external attachments
+	// and for attachments uploaded before checksums were recorded.
+	Sha256 string
+	// deduplicate is set by PutAttachmentContent when the content may be shared with another
+	// attachment once the attachment is saved.
+	deduplicate bool
 
 	// The related memo ID.
 	MemoID *int32
@@ -50,6 +54,7 @@ type FindAttachment struct {
 	MemoID         *int32
 	HasRelatedMemo bool
 	StorageType    *storepb.AttachmentStorageType
+	Sha256         *string
 	Limit          *int
 	Offset         *int
 	Filters        []string
@@ -63,6 +68,10 @@ type UpdateAttachment struct {
 	MemoID    *int32
 	Reference *string
 	Payload   *storepb.AttachmentPayload
+	// StorageType and Blob move the content of the attachment to another storage.
+	StorageType *storepb.AttachmentStorageType
+	Blob        *[]byte
+	Sha256      *string
 }
 
 type DeleteAttachment struct {
@@ -74,7 +83,15 @@ func (s *Store) CreateAttachment(ctx context.Context, create *Attachment) (*Atta
 	if !base.UIDMatcher.MatchString(create.UID) {
 		return nil, errors.New("invalid uid")
 	}
-	return s.driver.CreateAttachment(ctx, create)
+	var attachment *Attachment
+	if err := s.saveSharedAttachmentContent(ctx, create, func() error {
+		var err error
+		attachment, err = s.driver.CreateAttachment(ctx, create)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return attachment, nil
 }
 
 func (s *Store) ListAttachments(ctx context.Context, find *FindAttachment) ([]*Attachment, error) {
@@ -121,50 +138,37 @@ func (s *Store) DeleteAttachment(ctx context.Context, delete *DeleteAttachment)
 		return errors.New("attachment not found")
 	}
 
-	if attachment.StorageType == storepb.AttachmentStorageType_LOCAL {
-		if err := func() error {
-			p := filepath.FromSlash(attachment.Reference)
-			if !filepath.IsAbs(p) {
-				p = filepath.Join(s.profile.Data, p)
-			}
-			err := os.Remove(p)
-			if err != nil {
+	if attachment.Sha256 != "" {
+		// The content and the row are deleted together, so that the content is not adopted meanwhile.
+		unlock := s.lockAttachmentContent(attachment.Sha256)
+		defer unlock()
+	}
+	if attachment.StorageType != storepb.AttachmentStorageType_ATTACHMENT_STORAGE_TYPE_UNSPECIFIED && attachment.StorageType != storepb.AttachmentStorageType_EXTERNAL {
+		if err := s.deleteAttachmentContent(ctx, attachment); err != nil && !errors.Is(err, storage.ErrNotFound) {
+			// Local files must be deleted, while remote objects are left behind when the storage is unreachable.
+			if attachment.StorageType == storepb.AttachmentStorageType_LOCAL {
 				return errors.Wrap(err, "failed to delete local file")
 			}
-			return nil
-		}(); err != nil {
-			return errors.Wrap(err, "failed to delete local file")
-		}
-	} else if attachment.StorageType == storepb.AttachmentStorageType_S3 {
-		if err := func() error {
-			s3ObjectPayload := attachment.Payload.GetS3Object()
-			if s3ObjectPayload == nil {
-				return errors.Errorf("No s3 object found")
-			}
-			workspaceStorageSetting, err := s.GetWorkspaceStorageSetting(ctx)
-			if err != nil {
-				return errors.Wrap(err, "failed to get workspace storage setting")
-			}
-			s3Config := s3ObjectPayload.S3Config
-			if s3Config == nil {
-				if workspaceStorageSetting.S3Config == nil {
-					return errors.Errorf("S3 config is not found")
-				}
-				s3Config = workspaceStorageSetting.S3Config
-			}
-
-			s3Client, err := s3.NewClient(ctx, s3Config)
-			if err != nil {
-				return errors.Wrap(err, "Failed to create s3 client")
-			}
-			if err := s3Client.DeleteObject(ctx, s3ObjectPayload.Key); err != nil {
-				return errors.Wrap(err, "Failed to delete s3 object")
-			}
-			return nil
-		}(); err != nil {
-			slog.Warn("Failed to delete s3 object", slog.Any("err", err))
+			slog.Warn("Failed to delete attachment content", slog.Any("err", err))
 		}
 	}
 
 	return s.driver.DeleteAttachment(ctx, delete)
 }
+
+// deleteAttachmentContent deletes the content of an attachment from its storage, unless the content
+// is shared with other attachments.
+func (s *Store) deleteAttachmentContent(ctx context.Context, attachment *Attachment) error {
+	shared, err := s.isAttachmentContentShared(ctx, attachment)
+	if err != nil {
+		return err
+	}
+	if shared {
+		return nil
+	}
+	backend, key, err := s.GetAttachmentStorage(ctx, attachment)
+	if err != nil {
+		return err
+	}
+	return backend.Delete(ctx, key)
+}
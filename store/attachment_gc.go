@@ -0,0 +1,193 @@
+package store
+
+import (
+	"context"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+)
+
+// thumbnailCacheFolder is the folder of the attachment thumbnails in the data directory.
+const thumbnailCacheFolder = ".thumbnail_cache"
+
+// CollectAttachmentGarbageOptions are the options of a garbage collection of attachments.
+type CollectAttachmentGarbageOptions struct {
+	// Retention is the age after which attachments without memo and files without attachment are garbage.
+	Retention time.Duration
+	// DryRun reports the garbage without deleting it.
+	DryRun bool
+}
+
+// AttachmentGarbage is the garbage found by a garbage collection of attachments.
+type AttachmentGarbage struct {
+	// Attachments are the attachments that are not related to a memo.
+	Attachments []*Attachment
+	// Files are the paths of the local files without attachment, relative to the data directory.
+	Files []string
+	// Thumbnails are the paths of the thumbnails of deleted attachments, relative to the data directory.
+	Thumbnails []string
+	// Size is the total size of the garbage in bytes.
+	Size int64
+}
+
+// CollectAttachmentGarbage deletes the attachments without memo that have not been updated within
+// the retention, the files in the local storage directories that belong to no attachment, e.g. after
+// failed writes, and the cached thumbnails of deleted attachments.
+func (s *Store) CollectAttachmentGarbage(ctx context.Context, options CollectAttachmentGarbageOptions) (*AttachmentGarbage, error) {
+	cutoff := time.Now().Add(-options.Retention)
+	garbage := &AttachmentGarbage{Attachments: []*Attachment{}, Files: []string{}, Thumbnails: []string{}}
+	attachments, err := s.ListAttachments(ctx, &FindAttachment{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list attachments")
+	}
+
+	// The attachments are listed before the files, so that files of attachments created meanwhile are kept by the retention.
+	attachmentIDs := map[int32]bool{}
+	localFiles := map[string]bool{}
+	for _, attachment := range attachments {
+		attachmentIDs[attachment.ID] = true
+		if attachment.StorageType == storepb.AttachmentStorageType_LOCAL {
+			localFiles[s.getLocalFilePath(attachment.Reference)] = true
+		}
+		if attachment.MemoID == nil && time.Unix(attachment.UpdatedTs, 0).Before(cutoff) {
+			garbage.Attachments = append(garbage.Attachments, attachment)
+			garbage.Size += attachment.Size
+		}
+	}
+	for _, attachment := range garbage.Attachments {
+		// The thumbnails of the collected attachments are collected too.
+		delete(attachmentIDs, attachment.ID)
+		if options.DryRun {
+			continue
+		}
+		if err := s.DeleteAttachment(ctx, &DeleteAttachment{ID: attachment.ID}); err != nil {
+			return nil, errors.Wrapf(err, "failed to delete attachment %s", attachment.UID)
+		}
+	}
+
+	setting, err := s.GetWorkspaceStorageSetting(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get workspace storage setting")
+	}
+	for _, dir := range s.getLocalStorageDirs(setting, attachments) {
+		err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				if errors.Is(err, fs.ErrNotExist) {
+					return nil
+				}
+				return err
+			}
+			if entry.IsDir() || localFiles[path] {
+				return nil
+			}
+			info, err := entry.Info()
+			if err != nil {
+				return err
+			}
+			if !info.Mode().IsRegular() || !info.ModTime().Before(cutoff) {
+				return nil
+			}
+			return s.collectFile(path, info, options.DryRun, &garbage.Files, &garbage.Size)
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to walk local storage")
+		}
+	}
+
+	entries, err := os.ReadDir(filepath.Join(s.profile.Data, thumbnailCacheFolder))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, errors.Wrap(err, "failed to read thumbnail cache")
+	}
+	for _, entry := range entries {
+		// The thumbnails are named after the ID of their attachment, e.g. 1.png or 1_small.webp,
+		// and the hidden files are thumbnails being written.
+		prefix, _, _ := strings.Cut(strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name())), "_")
+		id, err := strconv.ParseInt(prefix, 10, 32)
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") || (err == nil && attachmentIDs[int32(id)]) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to stat thumbnail")
+		}
+		if err := s.collectFile(filepath.Join(s.profile.Data, thumbnailCacheFolder, entry.Name()), info, options.DryRun, &garbage.Thumbnails, &garbage.Size); err != nil {
+			return nil, err
+		}
+	}
+
+	if !options.DryRun {
+		slog.Info("collected attachment garbage",
+			slog.Int("attachments", len(garbage.Attachments)),
+			slog.Int("files", len(garbage.Files)),
+			slog.Int("thumbnails", len(garbage.Thumbnails)),
+			slog.Int64("size", garbage.Size))
+	}
+	return garbage, nil
+}
+
+// collectFile adds a file to the garbage, and deletes it unless it is a dry run.
+func (s *Store) collectFile(path string, info fs.FileInfo, dryRun bool, paths *[]string, size *int64) error {
+	if !dryRun {
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return errors.Wrap(err, "failed to delete file")
+		}
+	}
+	rel, err := filepath.Rel(s.profile.Data, path)
+	if err != nil {
+		rel = path
+	}
+	*paths = append(*paths, filepath.ToSlash(rel))
+	*size += info.Size()
+	return nil
+}
+
+// getLocalFilePath returns the clean absolute path of the reference of a local attachment.
+func (s *Store) getLocalFilePath(reference string) string {
+	if filepath.IsAbs(reference) {
+		return filepath.Clean(reference)
+	}
+	return filepath.Join(s.profile.Data, filepath.FromSlash(reference))
+}
+
+// getLocalStorageDirs returns the top-level directories of the data directory that hold local
+// attachments: the one of the filepath template, and the ones of the existing local attachments.
+// The data directory itself is never returned, since it holds the database and other files.
+func (s *Store) getLocalStorageDirs(setting *storepb.WorkspaceStorageSetting, attachments []*Attachment) []string {
+	dirs := []string{}
+	addDir := func(path string) {
+		rel, err := filepath.Rel(s.profile.Data, path)
+		if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return
+		}
+		top := strings.Split(rel, string(filepath.Separator))[0]
+		// Files right in the data directory and the thumbnail cache are not attachment files.
+		if top == rel || top == thumbnailCacheFolder {
+			return
+		}
+		dir := filepath.Join(s.profile.Data, top)
+		for _, existing := range dirs {
+			if existing == dir {
+				return
+			}
+		}
+		dirs = append(dirs, dir)
+	}
+	// The static part of the filepath template, e.g. assets/ of assets/{timestamp}_{filename}.
+	if prefix, _, ok := strings.Cut(setting.FilepathTemplate, "{"); ok && strings.ContainsAny(prefix, "/\\") {
+		addDir(s.getLocalFilePath(prefix + "_"))
+	}
+	for _, attachment := range attachments {
+		if attachment.StorageType == storepb.AttachmentStorageType_LOCAL {
+			addDir(s.getLocalFilePath(attachment.Reference))
+		}
+	}
+	return dirs
+}
@@ -0,0 +1,250 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/pkg/errors"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+)
+
+const (
+	defaultAttachmentMigrationBatchSize = 100
+	// maxAttachmentMigrationFailures is the max number of failures kept in the progress.
+	maxAttachmentMigrationFailures = 100
+)
+
+// MigrateAttachmentsOptions are the options of a migration of attachments.
+type MigrateAttachmentsOptions struct {
+	// BatchSize is the number of attachments listed at once, 100 by default.
+	BatchSize int
+	// OnProgress is called after every migrated or failed attachment.
+	OnProgress func(*AttachmentMigrationProgress)
+}
+
+// AttachmentMigrationProgress is the progress of a migration of attachments.
+type AttachmentMigrationProgress struct {
+	// Total is the number of attachments to migrate when the migration started.
+	Total    int
+	Migrated int
+	Failed   int
+	// Failures are the errors of the first failed attachments.
+	Failures []string
+}
+
+// MigrateAttachments moves the content of the attachments stored outside of the storage of the
+// workspace storage setting into it, e.g. from the database to S3 after the storage type changed.
+// The content is copied and verified with its SHA-256 checksum before the attachment is updated,
+// and the old content is deleted afterwards. Attachments are migrated one at a time, so that an
+// interrupted migration resumes with the remaining attachments when it runs again. The attachments
+// that fail are left where they are and reported in the progress.
+func (s *Store) MigrateAttachments(ctx context.Context, options MigrateAttachmentsOptions) (*AttachmentMigrationProgress, error) {
+	batchSize := options.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultAttachmentMigrationBatchSize
+	}
+	setting, err := s.GetWorkspaceStorageSetting(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get workspace storage setting")
+	}
+	targetStorageType := getAttachmentStorageType(setting.StorageType)
+
+	attachments, err := s.ListAttachments(ctx, &FindAttachment{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list attachments")
+	}
+	sourceStorageTypes := []storepb.AttachmentStorageType{}
+	progress := &AttachmentMigrationProgress{Failures: []string{}}
+	for _, attachment := range attachments {
+		if attachment.StorageType == targetStorageType || attachment.StorageType == storepb.AttachmentStorageType_EXTERNAL {
+			continue
+		}
+		progress.Total++
+		if !containsStorageType(sourceStorageTypes, attachment.StorageType) {
+			sourceStorageTypes = append(sourceStorageTypes, attachment.StorageType)
+		}
+	}
+
+	for _, sourceStorageType := range sourceStorageTypes {
+		// Migrated attachments leave the storage type, so the offset only skips the failed ones.
+		offset := 0
+		for {
+			if err := ctx.Err(); err != nil {
+				return progress, err
+			}
+			limit := batchSize
+			batch, err := s.ListAttachments(ctx, &FindAttachment{
+				StorageType: &sourceStorageType,
+				Limit:       &limit,
+				Offset:      &offset,
+			})
+			if err != nil {
+				return progress, errors.Wrap(err, "failed to list attachments")
+			}
+			if len(batch) == 0 {
+				break
+			}
+			for _, attachment := range batch {
+				if err := s.migrateAttachment(ctx, setting, attachment); err != nil {
+					if ctx.Err() != nil {
+						return progress, ctx.Err()
+					}
+					slog.Warn("failed to migrate attachment", slog.Int("id", int(attachment.ID)), slog.Any("error", err))
+					progress.Failed++
+					offset++
+					if len(progress.Failures) < maxAttachmentMigrationFailures {
+						progress.Failures = append(progress.Failures, fmt.Sprintf("attachments/%s: %v", attachment.UID, err))
+					}
+				} else {
+					progress.Migrated++
+				}
+				if options.OnProgress != nil {
+					options.OnProgress(progress)
+				}
+			}
+		}
+	}
+	return progress, nil
+}
+
+// migrateAttachment moves the content of an attachment to the storage of the setting.
+func (s *Store) migrateAttachment(ctx context.Context, setting *storepb.WorkspaceStorageSetting, attachment *Attachment) error {
+	source, err := s.openAttachmentContent(ctx, attachment)
+	if err != nil {
+		return errors.Wrap(err, "failed to open content")
+	}
+	defer source.Close()
+
+	location, err := s.GetUserLocation(ctx, attachment.CreatorID)
+	if err != nil {
+		return errors.Wrap(err, "failed to get user location")
+	}
+	migrated := &Attachment{
+		ID:        attachment.ID,
+		UID:       attachment.UID,
+		CreatorID: attachment.CreatorID,
+		Filename:  attachment.Filename,
+		Type:      attachment.Type,
+	}
+	key := NewAttachmentKey(setting, attachment.Filename, time.Unix(attachment.CreatedTs, 0).In(location))
+	if backend, err := s.newStorageBackend(ctx, setting); err == nil && backend != nil {
+		// Another attachment may have the same key, e.g. with the same filename and creation time.
+		if _, err := backend.Stat(ctx, key); err == nil {
+			key = NewAttachmentKey(setting, attachment.UID+"_"+attachment.Filename, time.Unix(attachment.CreatedTs, 0).In(location))
+		}
+	}
+	if err := s.PutAttachmentContent(ctx, setting, migrated, key, source); err != nil {
+		return errors.Wrap(err, "failed to copy content")
+	}
+	err = s.verifyAttachmentContent(ctx, migrated, migrated.Sha256)
+	if err == nil && attachment.Sha256 != "" && attachment.Sha256 != migrated.Sha256 {
+		err = errors.New("checksum mismatch of content")
+	}
+	if err != nil {
+		if migrated.StorageType != storepb.AttachmentStorageType_ATTACHMENT_STORAGE_TYPE_UNSPECIFIED {
+			if err := s.deleteAttachmentContent(ctx, migrated); err != nil {
+				slog.Warn("failed to delete unverified attachment content", slog.Any("error", err))
+			}
+		}
+		return err
+	}
+
+	if migrated.Payload == nil {
+		migrated.Payload = &storepb.AttachmentPayload{}
+	}
+	migrated.Payload.ImageMetadata = attachment.Payload.GetImageMetadata()
+	if err := s.saveSharedAttachmentContent(ctx, migrated, func() error {
+		blob := migrated.Blob
+		return s.UpdateAttachment(ctx, &UpdateAttachment{
+			ID:          attachment.ID,
+			Reference:   &migrated.Reference,
+			Payload:     migrated.Payload,
+			StorageType: &migrated.StorageType,
+			Blob:        &blob,
+			Sha256:      &migrated.Sha256,
+		})
+	}); err != nil {
+		return errors.Wrap(err, "failed to update attachment")
+	}
+	if attachment.StorageType != storepb.AttachmentStorageType_ATTACHMENT_STORAGE_TYPE_UNSPECIFIED {
+		if attachment.Sha256 != "" {
+			unlock := s.lockAttachmentContent(attachment.Sha256)
+			defer unlock()
+		}
+		if err := s.deleteAttachmentContent(ctx, attachment); err != nil {
+			slog.Warn("failed to delete migrated attachment content", slog.Int("id", int(attachment.ID)), slog.Any("error", err))
+		}
+	}
+	return nil
+}
+
+// openAttachmentContent opens the content of an attachment in the database or in a storage.
+func (s *Store) openAttachmentContent(ctx context.Context, attachment *Attachment) (io.ReadCloser, error) {
+	if attachment.StorageType == storepb.AttachmentStorageType_ATTACHMENT_STORAGE_TYPE_UNSPECIFIED {
+		if attachment.Blob == nil {
+			withBlob, err := s.GetAttachment(ctx, &FindAttachment{ID: &attachment.ID, GetBlob: true})
+			if err != nil {
+				return nil, err
+			}
+			if withBlob == nil {
+				return nil, errors.New("attachment not found")
+			}
+			attachment = withBlob
+		}
+		return io.NopCloser(bytes.NewReader(attachment.Blob)), nil
+	}
+	backend, key, err := s.GetAttachmentStorage(ctx, attachment)
+	if err != nil {
+		return nil, err
+	}
+	return backend.Get(ctx, key)
+}
+
+// verifyAttachmentContent checks the hex encoded SHA-256 checksum of the content of an attachment.
+func (s *Store) verifyAttachmentContent(ctx context.Context, attachment *Attachment, checksum string) error {
+	content, err := s.openAttachmentContent(ctx, attachment)
+	if err != nil {
+		return errors.Wrap(err, "failed to open copied content")
+	}
+	defer content.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, content); err != nil {
+		return errors.Wrap(err, "failed to read copied content")
+	}
+	if hex.EncodeToString(hash.Sum(nil)) != checksum {
+		return errors.New("checksum mismatch of copied content")
+	}
+	return nil
+}
+
+// getAttachmentStorageType returns the storage type of the attachments stored in the storage of a workspace storage type.
+func getAttachmentStorageType(storageType storepb.WorkspaceStorageSetting_StorageType) storepb.AttachmentStorageType {
+	switch storageType {
+	case storepb.WorkspaceStorageSetting_LOCAL:
+		return storepb.AttachmentStorageType_LOCAL
+	case storepb.WorkspaceStorageSetting_S3:
+		return storepb.AttachmentStorageType_S3
+	case storepb.WorkspaceStorageSetting_WEBDAV:
+		return storepb.AttachmentStorageType_WEBDAV
+	case storepb.WorkspaceStorageSetting_SFTP:
+		return storepb.AttachmentStorageType_SFTP
+	default:
+		return storepb.AttachmentStorageType_ATTACHMENT_STORAGE_TYPE_UNSPECIFIED
+	}
+}
+
+func containsStorageType(storageTypes []storepb.AttachmentStorageType, storageType storepb.AttachmentStorageType) bool {
+	for _, t := range storageTypes {
+		if t == storageType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,410 @@
+package store
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/usememos/memos/internal/util"
+	"github.com/usememos/memos/plugin/storage"
+	"github.com/usememos/memos/plugin/storage/local"
+	"github.com/usememos/memos/plugin/storage/s3"
+	"github.com/usememos/memos/plugin/storage/sftp"
+	"github.com/usememos/memos/plugin/storage/webdav"
+	storepb "github.com/usememos/memos/proto/gen/store"
+)
+
+// PutAttachmentContent writes the content of a new attachment at the key of the storage of the
+// workspace storage setting, and sets the size, the checksum, the storage type, the reference and
+// the payload of the attachment. The database storage keeps the content in the blob of the attachment.
+// With deduplication, the content of LOCAL and S3 attachments that is already stored for another
+// attachment is shared with it instead when the attachment is created.
+func (s *Store) PutAttachmentContent(ctx context.Context, setting *storepb.WorkspaceStorageSetting, create *Attachment, key string, content io.Reader) error {
+	backend, err := s.newStorageBackend(ctx, setting)
+	if err != nil {
+		return err
+	}
+	hash := sha256.New()
+	content = io.TeeReader(content, hash)
+	if backend == nil {
+		blob, err := io.ReadAll(content)
+		if err != nil {
+			return errors.Wrap(err, "failed to read content")
+		}
+		create.Size = int64(len(blob))
+		create.Blob = blob
+		create.Sha256 = hex.EncodeToString(hash.Sum(nil))
+		return nil
+	}
+
+	size, err := backend.Put(ctx, key, create.Type, content)
+	if err != nil {
+		return errors.Wrap(err, "failed to put content")
+	}
+	create.Size = size
+	create.Sha256 = hex.EncodeToString(hash.Sum(nil))
+	return s.adoptAttachmentContent(ctx, backend, setting, create, key)
+}
+
+// AdoptAttachmentContent sets the storage type, the reference and the payload of a new attachment
+// whose content is already stored at the key of the storage of the workspace storage setting, e.g.
+// content uploaded directly to S3. The size and the checksum of the attachment must be set.
+// With deduplication, the content is shared with another attachment of the same content instead
+// when the attachment is created.
+func (s *Store) AdoptAttachmentContent(ctx context.Context, setting *storepb.WorkspaceStorageSetting, create *Attachment, key string) error {
+	backend, err := s.newStorageBackend(ctx, setting)
+	if err != nil {
+		return err
+	}
+	if backend == nil {
+		return errors.New("the database storage has no stored content")
+	}
+	return s.adoptAttachmentContent(ctx, backend, setting, create, key)
+}
+
+func (s *Store) adoptAttachmentContent(ctx context.Context, backend storage.Backend, setting *storepb.WorkspaceStorageSetting, create *Attachment, key string) error {
+	create.Blob = nil
+	create.Reference = key
+	// The content is shared with another attachment when the attachment is saved.
+	create.deduplicate = setting.Deduplicate && (setting.StorageType == storepb.WorkspaceStorageSetting_LOCAL || setting.StorageType == storepb.WorkspaceStorageSetting_S3)
+	switch setting.StorageType {
+	case storepb.WorkspaceStorageSetting_LOCAL:
+		create.StorageType = storepb.AttachmentStorageType_LOCAL
+	case storepb.WorkspaceStorageSetting_S3:
+		// The reference of S3 attachments is a presigned URL that is renewed by the s3presign runner.
+		presignURL, err := backend.Presign(ctx, key, s3.PresignExpiration)
+		if err != nil {
+			return errors.Wrap(err, "failed to presign content")
+		}
+		create.StorageType = storepb.AttachmentStorageType_S3
+		create.Reference = presignURL
+		create.Payload = &storepb.AttachmentPayload{
+			Payload: &storepb.AttachmentPayload_S3Object_{
+				S3Object: &storepb.AttachmentPayload_S3Object{
+					S3Config:          setting.S3Config,
+					Key:               key,
+					LastPresignedTime: timestamppb.New(time.Now()),
+				},
+			},
+		}
+	case storepb.WorkspaceStorageSetting_WEBDAV:
+		create.StorageType = storepb.AttachmentStorageType_WEBDAV
+		create.Payload = &storepb.AttachmentPayload{
+			Payload: &storepb.AttachmentPayload_WebdavObject{
+				WebdavObject: &storepb.AttachmentPayload_WebDAVObject{
+					WebdavConfig: setting.WebdavConfig,
+					Path:         key,
+				},
+			},
+		}
+	case storepb.WorkspaceStorageSetting_SFTP:
+		create.StorageType = storepb.AttachmentStorageType_SFTP
+		create.Payload = &storepb.AttachmentPayload{
+			Payload: &storepb.AttachmentPayload_SftpObject{
+				SftpObject: &storepb.AttachmentPayload_SFTPObject{
+					SftpConfig: setting.SftpConfig,
+					Path:       key,
+				},
+			},
+		}
+	}
+	return nil
+}
+
+// saveSharedAttachmentContent saves the row of an attachment with save. With deduplication, the
+// attachment shares the content of another attachment of the same content, and its own copy is
+// deleted once the row is saved. Contents are looked up and adopted under the lock of their checksum,
+// which is also held while contents are deleted, so that a shared content is never deleted between
+// its lookup and the save of the attachment that adopts it.
+func (s *Store) saveSharedAttachmentContent(ctx context.Context, attachment *Attachment, save func() error) error {
+	if !attachment.deduplicate || attachment.Sha256 == "" {
+		return save()
+	}
+	unlock := s.lockAttachmentContent(attachment.Sha256)
+	defer unlock()
+
+	backend, key, err := s.GetAttachmentStorage(ctx, attachment)
+	if err != nil {
+		return err
+	}
+	shared, err := s.findDuplicateAttachment(ctx, backend, attachment)
+	if err != nil {
+		return err
+	}
+	if shared == nil {
+		return save()
+	}
+	imageMetadata := attachment.Payload.GetImageMetadata()
+	attachment.StorageType = shared.StorageType
+	attachment.Reference = shared.Reference
+	attachment.Payload = shared.Payload
+	if imageMetadata != nil {
+		if attachment.Payload == nil {
+			attachment.Payload = &storepb.AttachmentPayload{}
+		}
+		attachment.Payload.ImageMetadata = imageMetadata
+	}
+	if err := save(); err != nil {
+		return err
+	}
+	if sharedKey := getAttachmentContentKey(shared); sharedKey != key {
+		if err := backend.Delete(ctx, key); err != nil {
+			slog.Warn("failed to delete duplicate attachment content", slog.String("key", key), slog.Any("error", err))
+		}
+	}
+	return nil
+}
+
+// findDuplicateAttachment returns another attachment with the content of the attachment in the same
+// storage, or nil if there is none.
+func (s *Store) findDuplicateAttachment(ctx context.Context, backend storage.Backend, attachment *Attachment) (*Attachment, error) {
+	attachments, err := s.ListAttachments(ctx, &FindAttachment{
+		Sha256:      &attachment.Sha256,
+		StorageType: &attachment.StorageType,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list attachments")
+	}
+	for _, other := range attachments {
+		if other.ID == attachment.ID || other.Size != attachment.Size {
+			continue
+		}
+		// Objects in another bucket are not shared, since the bucket of the setting may differ.
+		if !sameAttachmentStorage(other, attachment) {
+			continue
+		}
+		if _, err := backend.Stat(ctx, getAttachmentContentKey(other)); err != nil {
+			continue
+		}
+		return other, nil
+	}
+	return nil, nil
+}
+
+// attachmentContentLock is the lock of the attachment contents of a checksum, with the number of
+// its holders and waiters.
+type attachmentContentLock struct {
+	sync.Mutex
+	refs int
+}
+
+// lockAttachmentContent locks the attachment contents of the checksum, and returns the function
+// that unlocks them.
+func (s *Store) lockAttachmentContent(sha256 string) func() {
+	s.attachmentContentLocksMutex.Lock()
+	lock, ok := s.attachmentContentLocks[sha256]
+	if !ok {
+		lock = &attachmentContentLock{}
+		s.attachmentContentLocks[sha256] = lock
+	}
+	lock.refs++
+	s.attachmentContentLocksMutex.Unlock()
+
+	lock.Lock()
+	return func() {
+		lock.Unlock()
+		s.attachmentContentLocksMutex.Lock()
+		lock.refs--
+		if lock.refs == 0 {
+			delete(s.attachmentContentLocks, sha256)
+		}
+		s.attachmentContentLocksMutex.Unlock()
+	}
+}
+
+// isAttachmentContentShared returns true if the content of the attachment is shared with other attachments.
+func (s *Store) isAttachmentContentShared(ctx context.Context, attachment *Attachment) (bool, error) {
+	if attachment.Sha256 == "" {
+		return false, nil
+	}
+	attachments, err := s.ListAttachments(ctx, &FindAttachment{
+		Sha256:      &attachment.Sha256,
+		StorageType: &attachment.StorageType,
+	})
+	if err != nil {
+		return false, errors.Wrap(err, "failed to list attachments")
+	}
+	key := getAttachmentContentKey(attachment)
+	for _, other := range attachments {
+		if other.ID != attachment.ID && getAttachmentContentKey(other) == key && sameAttachmentStorage(other, attachment) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// getAttachmentContentKey returns the key of the content of an attachment in its storage.
+func getAttachmentContentKey(attachment *Attachment) string {
+	switch attachment.StorageType {
+	case storepb.AttachmentStorageType_S3:
+		return attachment.Payload.GetS3Object().GetKey()
+	case storepb.AttachmentStorageType_WEBDAV:
+		return attachment.Payload.GetWebdavObject().GetPath()
+	case storepb.AttachmentStorageType_SFTP:
+		return attachment.Payload.GetSftpObject().GetPath()
+	default:
+		return attachment.Reference
+	}
+}
+
+// sameAttachmentStorage returns true if the contents of both attachments are in the same storage.
+func sameAttachmentStorage(a, b *Attachment) bool {
+	switch a.StorageType {
+	case storepb.AttachmentStorageType_S3:
+		return proto.Equal(a.Payload.GetS3Object().GetS3Config(), b.Payload.GetS3Object().GetS3Config())
+	case storepb.AttachmentStorageType_WEBDAV:
+		return proto.Equal(a.Payload.GetWebdavObject().GetWebdavConfig(), b.Payload.GetWebdavObject().GetWebdavConfig())
+	case storepb.AttachmentStorageType_SFTP:
+		return proto.Equal(a.Payload.GetSftpObject().GetSftpConfig(), b.Payload.GetSftpObject().GetSftpConfig())
+	default:
+		return true
+	}
+}
+
+// newStorageBackend returns the backend of the storage type of the workspace storage setting,
+// or nil for the database storage.
+func (s *Store) newStorageBackend(ctx context.Context, setting *storepb.WorkspaceStorageSetting) (storage.Backend, error) {
+	switch setting.StorageType {
+	case storepb.WorkspaceStorageSetting_LOCAL:
+		return local.New(s.profile.Data), nil
+	case storepb.WorkspaceStorageSetting_S3:
+		if setting.S3Config == nil {
+			return nil, errors.New("S3 config is not found")
+		}
+		client, err := s3.NewClient(ctx, setting.S3Config)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create s3 client")
+		}
+		return client, nil
+	case storepb.WorkspaceStorageSetting_WEBDAV:
+		if setting.WebdavConfig == nil {
+			return nil, errors.New("WebDAV config is not found")
+		}
+		client, err := webdav.NewClient(setting.WebdavConfig)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create webdav client")
+		}
+		return client, nil
+	case storepb.WorkspaceStorageSetting_SFTP:
+		if setting.SftpConfig == nil {
+			return nil, errors.New("SFTP config is not found")
+		}
+		client, err := sftp.NewClient(setting.SftpConfig)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create sftp client")
+		}
+		return client, nil
+	default:
+		return nil, nil
+	}
+}
+
+// GetAttachmentStorage returns the backend and the key of the content of an attachment that is
+// stored neither in the database nor at an external link. The configs of the payload are used
+// over the ones of the workspace storage setting, so that attachments stay readable after the
+// storage setting changes.
+func (s *Store) GetAttachmentStorage(ctx context.Context, attachment *Attachment) (storage.Backend, string, error) {
+	switch attachment.StorageType {
+	case storepb.AttachmentStorageType_LOCAL:
+		return local.New(s.profile.Data), attachment.Reference, nil
+	case storepb.AttachmentStorageType_S3, storepb.AttachmentStorageType_WEBDAV, storepb.AttachmentStorageType_SFTP:
+	default:
+		return nil, "", errors.Errorf("attachments of storage type %s have no storage backend", attachment.StorageType)
+	}
+
+	workspaceStorageSetting, err := s.GetWorkspaceStorageSetting(ctx)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "failed to get workspace storage setting")
+	}
+	setting := &storepb.WorkspaceStorageSetting{}
+	var key string
+	switch attachment.StorageType {
+	case storepb.AttachmentStorageType_S3:
+		object := attachment.Payload.GetS3Object()
+		if object == nil {
+			return nil, "", errors.New("invalid s3 object payload")
+		}
+		setting.StorageType = storepb.WorkspaceStorageSetting_S3
+		setting.S3Config, key = object.S3Config, object.Key
+		if setting.S3Config == nil {
+			setting.S3Config = workspaceStorageSetting.S3Config
+		}
+	case storepb.AttachmentStorageType_WEBDAV:
+		object := attachment.Payload.GetWebdavObject()
+		if object == nil {
+			return nil, "", errors.New("invalid webdav object payload")
+		}
+		setting.StorageType = storepb.WorkspaceStorageSetting_WEBDAV
+		setting.WebdavConfig, key = object.WebdavConfig, object.Path
+		if setting.WebdavConfig == nil {
+			setting.WebdavConfig = workspaceStorageSetting.WebdavConfig
+		}
+	case storepb.AttachmentStorageType_SFTP:
+		object := attachment.Payload.GetSftpObject()
+		if object == nil {
+			return nil, "", errors.New("invalid sftp object payload")
+		}
+		setting.StorageType = storepb.WorkspaceStorageSetting_SFTP
+		setting.SftpConfig, key = object.SftpConfig, object.Path
+		if setting.SftpConfig == nil {
+			setting.SftpConfig = workspaceStorageSetting.SftpConfig
+		}
+	}
+	backend, err := s.newStorageBackend(ctx, setting)
+	if err != nil {
+		return nil, "", err
+	}
+	return backend, key, nil
+}
+
+// NewAttachmentKey returns the key of the content of a new attachment from the filepath template
+// of the workspace storage setting, with the date placeholders resolved at the time.
+func NewAttachmentKey(setting *storepb.WorkspaceStorageSetting, filename string, t time.Time) string {
+	filepathTemplate := setting.FilepathTemplate
+	if !strings.Contains(filepathTemplate, "{filename}") {
+		filepathTemplate = filepath.Join(filepathTemplate, "{filename}")
+	}
+	return filepath.ToSlash(replaceFilenameWithPathTemplate(filepathTemplate, filename, t))
+}
+
+var fileKeyPattern = regexp.MustCompile(`\{[a-z]{1,9}\}`)
+
+func replaceFilenameWithPathTemplate(path, filename string, t time.Time) string {
+	path = fileKeyPattern.ReplaceAllStringFunc(path, func(s string) string {
+		switch s {
+		case "{filename}":
+			return filename
+		case "{timestamp}":
+			return fmt.Sprintf("%d", t.Unix())
+		case "{year}":
+			return fmt.Sprintf("%d", t.Year())
+		case "{month}":
+			return fmt.Sprintf("%02d", t.Month())
+		case "{day}":
+			return fmt.Sprintf("%02d", t.Day())
+		case "{hour}":
+			return fmt.Sprintf("%02d", t.Hour())
+		case "{minute}":
+			return fmt.Sprintf("%02d", t.Minute())
+		case "{second}":
+			return fmt.Sprintf("%02d", t.Second())
+		case "{uuid}":
+			return util.GenUUID()
+		default:
+			return s
+		}
+	})
+	return path
+}
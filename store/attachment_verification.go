@@ -0,0 +1,122 @@
+package store
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log/slog"
+
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/plugin/storage"
+	storepb "github.com/usememos/memos/proto/gen/store"
+)
+
+// AttachmentProblem is a problem of the content of an attachment.
+type AttachmentProblem string
+
+const (
+	// AttachmentProblemMissing is the problem of content that is not found in its storage.
+	AttachmentProblemMissing AttachmentProblem = "MISSING"
+	// AttachmentProblemMismatched is the problem of content whose checksum or size differs from the recorded one.
+	AttachmentProblemMismatched AttachmentProblem = "MISMATCHED"
+	// AttachmentProblemUnreadable is the problem of content that fails to be read, e.g. from an unreachable storage.
+	AttachmentProblemUnreadable AttachmentProblem = "UNREADABLE"
+)
+
+// AttachmentIssue is a problem found by the verification of an attachment.
+type AttachmentIssue struct {
+	AttachmentUID string
+	Problem       AttachmentProblem
+	Detail        string
+}
+
+// AttachmentVerification is the result of a verification of attachments.
+type AttachmentVerification struct {
+	// Total is the number of verified attachments, external attachments excluded.
+	Total int
+	// Recorded is the number of attachments whose checksum was recorded by the verification.
+	Recorded int
+	Issues   []*AttachmentIssue
+}
+
+// VerifyAttachments reads the content of every attachment and checks its SHA-256 checksum and
+// its size. The checksums of attachments uploaded before checksums were recorded are computed
+// and recorded, so that their later changes are detected.
+func (s *Store) VerifyAttachments(ctx context.Context) (*AttachmentVerification, error) {
+	attachments, err := s.ListAttachments(ctx, &FindAttachment{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list attachments")
+	}
+	verification := &AttachmentVerification{Issues: []*AttachmentIssue{}}
+	for _, attachment := range attachments {
+		if attachment.StorageType == storepb.AttachmentStorageType_EXTERNAL {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		verification.Total++
+		checksum, size, err := s.readAttachmentChecksum(ctx, attachment)
+		if err != nil {
+			problem := AttachmentProblemUnreadable
+			if errors.Is(err, storage.ErrNotFound) {
+				problem = AttachmentProblemMissing
+			}
+			verification.Issues = append(verification.Issues, &AttachmentIssue{AttachmentUID: attachment.UID, Problem: problem, Detail: err.Error()})
+			continue
+		}
+		if size != attachment.Size {
+			verification.Issues = append(verification.Issues, &AttachmentIssue{
+				AttachmentUID: attachment.UID,
+				Problem:       AttachmentProblemMismatched,
+				Detail:        "size mismatch of content",
+			})
+			continue
+		}
+		if attachment.Sha256 == "" {
+			if err := s.UpdateAttachment(ctx, &UpdateAttachment{ID: attachment.ID, Sha256: &checksum}); err != nil {
+				return nil, errors.Wrap(err, "failed to record checksum")
+			}
+			verification.Recorded++
+			continue
+		}
+		if checksum != attachment.Sha256 {
+			verification.Issues = append(verification.Issues, &AttachmentIssue{
+				AttachmentUID: attachment.UID,
+				Problem:       AttachmentProblemMismatched,
+				Detail:        "checksum mismatch of content",
+			})
+		}
+	}
+	if len(verification.Issues) > 0 {
+		slog.Warn("found attachments with invalid content", slog.Int("count", len(verification.Issues)))
+	}
+	return verification, nil
+}
+
+// readAttachmentChecksum returns the hex encoded SHA-256 checksum and the size of the content of an attachment.
+func (s *Store) readAttachmentChecksum(ctx context.Context, attachment *Attachment) (string, int64, error) {
+	if attachment.StorageType == storepb.AttachmentStorageType_ATTACHMENT_STORAGE_TYPE_UNSPECIFIED {
+		withBlob, err := s.GetAttachment(ctx, &FindAttachment{ID: &attachment.ID, GetBlob: true})
+		if err != nil {
+			return "", 0, err
+		}
+		if withBlob == nil || (withBlob.Blob == nil && attachment.Size > 0) {
+			return "", 0, errors.Wrap(storage.ErrNotFound, "blob not found")
+		}
+		attachment = withBlob
+	}
+	content, err := s.openAttachmentContent(ctx, attachment)
+	if err != nil {
+		return "", 0, err
+	}
+	defer content.Close()
+	hash := sha256.New()
+	size, err := io.Copy(hash, content)
+	if err != nil {
+		return "", 0, errors.Wrap(err, "failed to read content")
+	}
+	return hex.EncodeToString(hash.Sum(nil)), size, nil
+}
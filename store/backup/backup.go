@@ -0,0 +1,376 @@
+// Package backup creates and restores snapshots of a memos instance: the rows of every
+// table of the database and the local files under the data directory.
+// It also copies the rows of a database to a database of another driver.
+package backup
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/internal/profile"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+const (
+	// ManifestName is the name of the manifest in a backup archive.
+	ManifestName = "manifest.json"
+	// databaseFolder holds one file of JSON lines per table.
+	databaseFolder = "database"
+	// filesFolder holds the files of the data directory.
+	filesFolder = "files"
+	// thumbnailCacheFolder is the folder of the attachment thumbnails in the data directory.
+	thumbnailCacheFolder = ".thumbnail_cache"
+)
+
+// ErrDatabaseNotEmpty is returned when restoring to a database with data without forcing it.
+var ErrDatabaseNotEmpty = errors.New("the database is not empty")
+
+// Manifest describes the content of a backup.
+type Manifest struct {
+	// Version is the version of memos that created the backup.
+	Version       string    `json:"version"`
+	SchemaVersion string    `json:"schemaVersion"`
+	Driver        string    `json:"driver"`
+	CreateTime    time.Time `json:"createTime"`
+	// Tables maps the tables of the database to their row counts.
+	Tables map[string]int `json:"tables"`
+	// Entries lists the files of the backup with their checksums.
+	Entries []*Entry `json:"entries"`
+}
+
+// Entry is a file of a backup.
+type Entry struct {
+	Path   string `json:"path"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// Create writes a backup of the database and the local files of an instance.
+// The rows of the database are read in one transaction so the backup is consistent
+// while the instance keeps running.
+func Create(ctx context.Context, stores *store.Store, profile *profile.Profile, w io.Writer) (*Manifest, error) {
+	schemaVersion, err := stores.GetCurrentSchemaVersion()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get current schema version")
+	}
+	manifest := &Manifest{
+		Version:       profile.Version,
+		SchemaVersion: schemaVersion,
+		Driver:        profile.Driver,
+		CreateTime:    time.Now().UTC(),
+		Tables:        map[string]int{},
+	}
+	zipWriter := zip.NewWriter(w)
+
+	tx, err := stores.GetDriver().GetDB().BeginTx(ctx, snapshotTxOptions(profile.Driver))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to start transaction")
+	}
+	defer tx.Rollback()
+	localFiles := []string{}
+	for _, table := range Tables {
+		var onRow func(map[string]any)
+		if table == "resource" {
+			onRow = func(row map[string]any) {
+				if row["storage_type"] == storepb.AttachmentStorageType_LOCAL.String() {
+					if reference, ok := row["reference"].(string); ok {
+						localFiles = append(localFiles, reference)
+					}
+				}
+			}
+		}
+		err := writeEntry(zipWriter, manifest, path.Join(databaseFolder, table+".jsonl"), func(w io.Writer) error {
+			count, err := dumpTable(ctx, tx, profile.Driver, table, w, onRow)
+			manifest.Tables[table] = count
+			return err
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to back up table %s", table)
+		}
+	}
+	if err := tx.Rollback(); err != nil {
+		return nil, errors.Wrap(err, "failed to end transaction")
+	}
+
+	// The references of local attachments are relative to the data directory unless they are absolute.
+	for _, reference := range localFiles {
+		filePath := filepath.FromSlash(reference)
+		if !filepath.IsAbs(filePath) {
+			filePath = filepath.Join(profile.Data, filePath)
+		}
+		if err := writeDataFile(zipWriter, manifest, profile.Data, filePath); err != nil {
+			slog.Warn("failed to back up attachment file", slog.String("reference", reference), slog.Any("error", err))
+		}
+	}
+	thumbnailDir := filepath.Join(profile.Data, thumbnailCacheFolder)
+	err = filepath.WalkDir(thumbnailDir, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		return writeDataFile(zipWriter, manifest, profile.Data, filePath)
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to back up thumbnails")
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal manifest")
+	}
+	fileWriter, err := zipWriter.Create(ManifestName)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := fileWriter.Write(data); err != nil {
+		return nil, err
+	}
+	if err := zipWriter.Close(); err != nil {
+		return nil, errors.Wrap(err, "failed to close archive")
+	}
+	return manifest, nil
+}
+
+// writeEntry writes a file to the archive and records its size and checksum in the manifest.
+func writeEntry(zipWriter *zip.Writer, manifest *Manifest, name string, write func(io.Writer) error) error {
+	fileWriter, err := zipWriter.CreateHeader(&zip.FileHeader{
+		Name:     name,
+		Method:   zip.Deflate,
+		Modified: manifest.CreateTime,
+	})
+	if err != nil {
+		return err
+	}
+	hash := sha256.New()
+	counter := &countingWriter{writer: io.MultiWriter(fileWriter, hash)}
+	if err := write(counter); err != nil {
+		return err
+	}
+	manifest.Entries = append(manifest.Entries, &Entry{
+		Path:   name,
+		Size:   counter.count,
+		SHA256: hex.EncodeToString(hash.Sum(nil)),
+	})
+	return nil
+}
+
+// writeDataFile writes a file of the data directory to the archive.
+func writeDataFile(zipWriter *zip.Writer, manifest *Manifest, dataDir, filePath string) error {
+	relativePath, err := filepath.Rel(dataDir, filePath)
+	if err != nil || !filepath.IsLocal(relativePath) {
+		return errors.Errorf("file %s is outside of the data directory", filePath)
+	}
+	name := path.Join(filesFolder, filepath.ToSlash(relativePath))
+	for _, entry := range manifest.Entries {
+		if entry.Path == name {
+			return nil
+		}
+	}
+	file, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return writeEntry(zipWriter, manifest, name, func(w io.Writer) error {
+		_, err := io.Copy(w, file)
+		return err
+	})
+}
+
+type countingWriter struct {
+	writer io.Writer
+	count  int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.writer.Write(p)
+	w.count += int64(n)
+	return n, err
+}
+
+// Verify reads the manifest of a backup and checks the checksums of its files.
+func Verify(reader *zip.Reader) (*Manifest, error) {
+	files := map[string]*zip.File{}
+	for _, file := range reader.File {
+		files[file.Name] = file
+	}
+	manifestFile, ok := files[ManifestName]
+	if !ok {
+		return nil, errors.New("missing manifest")
+	}
+	manifest := &Manifest{}
+	if err := readJSON(manifestFile, manifest); err != nil {
+		return nil, errors.Wrap(err, "invalid manifest")
+	}
+
+	names := []string{ManifestName}
+	for _, entry := range manifest.Entries {
+		file, ok := files[entry.Path]
+		if !ok {
+			return nil, errors.Errorf("missing file %s", entry.Path)
+		}
+		reader, err := file.Open()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to open %s", entry.Path)
+		}
+		hash := sha256.New()
+		size, err := io.Copy(hash, reader)
+		reader.Close()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read %s", entry.Path)
+		}
+		if size != entry.Size || hex.EncodeToString(hash.Sum(nil)) != entry.SHA256 {
+			return nil, errors.Errorf("checksum mismatch of %s", entry.Path)
+		}
+		names = append(names, entry.Path)
+	}
+	for name := range files {
+		if !slices.Contains(names, name) {
+			return nil, errors.Errorf("unexpected file %s", name)
+		}
+	}
+	for _, table := range Tables {
+		if !slices.ContainsFunc(manifest.Entries, func(entry *Entry) bool {
+			return entry.Path == path.Join(databaseFolder, table+".jsonl")
+		}) {
+			return nil, errors.Errorf("missing table %s", table)
+		}
+	}
+	return manifest, nil
+}
+
+func readJSON(file *zip.File, v any) error {
+	reader, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	return json.NewDecoder(reader).Decode(v)
+}
+
+// RestoreOptions are the options of a restore.
+type RestoreOptions struct {
+	// Force replaces the data of a database that is not empty.
+	Force bool
+}
+
+// Restore replaces the database and the local files of an instance with a backup.
+// The backup must come from the same database driver and schema version.
+// The instance should be stopped while restoring.
+func Restore(ctx context.Context, stores *store.Store, profile *profile.Profile, reader *zip.Reader, options RestoreOptions) (*Manifest, error) {
+	manifest, err := Verify(reader)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid backup")
+	}
+	if manifest.Driver != profile.Driver {
+		return nil, errors.Errorf("backup of a %s database cannot be restored to a %s database", manifest.Driver, profile.Driver)
+	}
+	schemaVersion, err := stores.GetCurrentSchemaVersion()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get current schema version")
+	}
+	if manifest.SchemaVersion != schemaVersion {
+		return nil, errors.Errorf("backup schema version %s is not compatible with the current schema version %s, restore it with memos %s", manifest.SchemaVersion, schemaVersion, manifest.Version)
+	}
+	if !options.Force {
+		users, err := stores.ListUsers(ctx, &store.FindUser{})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list users")
+		}
+		if len(users) > 0 {
+			return nil, ErrDatabaseNotEmpty
+		}
+	}
+
+	files := map[string]*zip.File{}
+	for _, file := range reader.File {
+		files[file.Name] = file
+	}
+	tx, err := stores.GetDriver().GetDB().BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to start transaction")
+	}
+	defer tx.Rollback()
+	for i := len(Tables) - 1; i >= 0; i-- {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM "+quoteIdentifier(profile.Driver, Tables[i])); err != nil {
+			return nil, errors.Wrapf(err, "failed to clear table %s", Tables[i])
+		}
+	}
+	for _, table := range Tables {
+		file := files[path.Join(databaseFolder, table+".jsonl")]
+		fileReader, err := file.Open()
+		if err != nil {
+			return nil, err
+		}
+		count, err := restoreTable(ctx, tx, profile.Driver, table, fileReader)
+		fileReader.Close()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to restore table %s", table)
+		}
+		if count != manifest.Tables[table] {
+			return nil, errors.Errorf("restored %d rows of table %s, expected %d", count, table, manifest.Tables[table])
+		}
+	}
+	if err := resetSequences(ctx, tx, profile.Driver); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "failed to commit transaction")
+	}
+
+	for _, entry := range manifest.Entries {
+		relativePath, ok := strings.CutPrefix(entry.Path, filesFolder+"/")
+		if !ok {
+			continue
+		}
+		if err := restoreDataFile(files[entry.Path], profile.Data, relativePath); err != nil {
+			return nil, errors.Wrapf(err, "failed to restore %s", relativePath)
+		}
+	}
+	return manifest, nil
+}
+
+func restoreDataFile(file *zip.File, dataDir, relativePath string) error {
+	localPath := filepath.FromSlash(relativePath)
+	if !filepath.IsLocal(localPath) {
+		return errors.New("path is outside of the data directory")
+	}
+	filePath := filepath.Join(dataDir, localPath)
+	if err := os.MkdirAll(filepath.Dir(filePath), os.ModePerm); err != nil {
+		return err
+	}
+	reader, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	dst, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(dst, reader); err != nil {
+		dst.Close()
+		return err
+	}
+	return dst.Close()
+}
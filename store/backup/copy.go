@@ -0,0 +1,174 @@
+package backup
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/internal/profile"
+	"github.com/usememos/memos/store"
+)
+
+// CopyOptions are the options of a copy between databases.
+type CopyOptions struct {
+	// Force replaces the data of a target database that is not empty.
+	Force bool
+}
+
+// Copy copies the rows of every table from the database of source to the database of target,
+// which may use another driver. The ids of the rows are kept and the id sequences of the target
+// continue after them. The row counts of the target are checked before committing the copy.
+// It returns the number of rows copied by table.
+func Copy(ctx context.Context, source *store.Store, sourceProfile *profile.Profile, target *store.Store, targetProfile *profile.Profile, options CopyOptions) (map[string]int, error) {
+	sourceSchemaVersion, err := source.GetCurrentSchemaVersion()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get source schema version")
+	}
+	targetSchemaVersion, err := target.GetCurrentSchemaVersion()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get target schema version")
+	}
+	if sourceSchemaVersion != targetSchemaVersion {
+		return nil, errors.Errorf("source schema version %s is not compatible with the target schema version %s", sourceSchemaVersion, targetSchemaVersion)
+	}
+	if !options.Force {
+		users, err := target.ListUsers(ctx, &store.FindUser{})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list users")
+		}
+		if len(users) > 0 {
+			return nil, ErrDatabaseNotEmpty
+		}
+	}
+
+	sourceTx, err := source.GetDriver().GetDB().BeginTx(ctx, snapshotTxOptions(sourceProfile.Driver))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to start source transaction")
+	}
+	defer sourceTx.Rollback()
+	targetTx, err := target.GetDriver().GetDB().BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to start target transaction")
+	}
+	defer targetTx.Rollback()
+
+	for i := len(Tables) - 1; i >= 0; i-- {
+		if _, err := targetTx.ExecContext(ctx, "DELETE FROM "+quoteIdentifier(targetProfile.Driver, Tables[i])); err != nil {
+			return nil, errors.Wrapf(err, "failed to clear table %s", Tables[i])
+		}
+	}
+	counts := map[string]int{}
+	for _, table := range Tables {
+		count, err := copyTable(ctx, sourceTx, sourceProfile.Driver, targetTx, targetProfile.Driver, table)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to copy table %s", table)
+		}
+		var targetCount int
+		if err := targetTx.QueryRowContext(ctx, "SELECT COUNT(*) FROM "+quoteIdentifier(targetProfile.Driver, table)).Scan(&targetCount); err != nil {
+			return nil, errors.Wrapf(err, "failed to count rows of table %s", table)
+		}
+		if targetCount != count {
+			return nil, errors.Errorf("table %s has %d rows after the copy, expected %d", table, targetCount, count)
+		}
+		counts[table] = count
+	}
+	if err := resetSequences(ctx, targetTx, targetProfile.Driver); err != nil {
+		return nil, err
+	}
+	if err := targetTx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "failed to commit target transaction")
+	}
+	return counts, nil
+}
+
+// copyTable inserts the rows of a source table into the same table of the target.
+func copyTable(ctx context.Context, sourceTx *sql.Tx, sourceDriver string, targetTx *sql.Tx, targetDriver, table string) (int, error) {
+	columns, err := tableColumns(ctx, sourceTx, sourceDriver, table)
+	if err != nil {
+		return 0, err
+	}
+	targetColumns, err := tableColumns(ctx, targetTx, targetDriver, table)
+	if err != nil {
+		return 0, err
+	}
+	slices.Sort(targetColumns)
+	if sortedColumns := slices.Sorted(slices.Values(columns)); !slices.Equal(sortedColumns, targetColumns) {
+		return 0, errors.Errorf("columns %v do not match the target columns %v", sortedColumns, targetColumns)
+	}
+
+	selections := make([]string, len(columns))
+	quotedColumns := make([]string, len(columns))
+	placeholders := make([]string, len(columns))
+	for i, column := range columns {
+		quoted := quoteIdentifier(sourceDriver, column)
+		selections[i] = quoted
+		placeholders[i] = placeholder(targetDriver, i+1)
+		// MySQL stores times as TIMESTAMP while the other drivers store unix seconds.
+		if isTimestampColumn(column) && sourceDriver == "mysql" {
+			selections[i] = fmt.Sprintf("UNIX_TIMESTAMP(%s) AS %s", quoted, quoted)
+		}
+		if isTimestampColumn(column) && targetDriver == "mysql" {
+			placeholders[i] = fmt.Sprintf("FROM_UNIXTIME(%s)", placeholders[i])
+		}
+		quotedColumns[i] = quoteIdentifier(targetDriver, column)
+	}
+	insertStmt, err := targetTx.PrepareContext(ctx, fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)",
+		quoteIdentifier(targetDriver, table), strings.Join(quotedColumns, ", "), strings.Join(placeholders, ", ")))
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to prepare insert")
+	}
+	defer insertStmt.Close()
+	// A prepared query makes MySQL return typed values instead of text.
+	selectStmt, err := sourceTx.PrepareContext(ctx, fmt.Sprintf("SELECT %s FROM %s", strings.Join(selections, ", "), quoteIdentifier(sourceDriver, table)))
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to prepare query")
+	}
+	defer selectStmt.Close()
+	rows, err := selectStmt.QueryContext(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to query rows")
+	}
+	defer rows.Close()
+
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	values := make([]any, len(columns))
+	pointers := make([]any, len(columns))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+	for rows.Next() {
+		if err := rows.Scan(pointers...); err != nil {
+			return 0, errors.Wrapf(err, "failed to scan row %d", count+1)
+		}
+		for i, value := range values {
+			values[i] = normalizeValue(columnTypes[i], value)
+		}
+		if _, err := insertStmt.ExecContext(ctx, values...); err != nil {
+			return 0, errors.Wrapf(err, "failed to insert row %d", count+1)
+		}
+		count++
+	}
+	return count, rows.Err()
+}
+
+// tableColumns returns the column names of a table.
+func tableColumns(ctx context.Context, tx *sql.Tx, driver, table string) ([]string, error) {
+	rows, err := tx.QueryContext(ctx, fmt.Sprintf("SELECT * FROM %s WHERE 1 = 0", quoteIdentifier(driver, table)))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query columns")
+	}
+	defer rows.Close()
+	return rows.Columns()
+}
+
+func isTimestampColumn(column string) bool {
+	return strings.HasSuffix(column, "_ts")
+}
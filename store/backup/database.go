@@ -0,0 +1,228 @@
+package backup
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+// Tables lists the tables of the database schema, parents first.
+var Tables = []string{
+	"migration_history",
+	"system_setting",
+	"user",
+	"user_setting",
+	"memo",
+	"memo_organizer",
+	"memo_relation",
+	"resource",
+	"activity",
+	"idp",
+	"inbox",
+	"reaction",
+}
+
+// serialTables lists the tables with an auto-incremented id column.
+var serialTables = []string{"user", "memo", "resource", "activity", "idp", "inbox", "reaction"}
+
+// snapshotTxOptions returns the options of a transaction that reads a consistent snapshot of the database.
+func snapshotTxOptions(driver string) *sql.TxOptions {
+	if driver == "sqlite" {
+		// A SQLite read transaction sees a snapshot of the WAL database from its first read.
+		return nil
+	}
+	return &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true}
+}
+
+func quoteIdentifier(driver, name string) string {
+	if driver == "mysql" {
+		return "`" + name + "`"
+	}
+	return `"` + name + `"`
+}
+
+func placeholder(driver string, n int) string {
+	if driver == "postgres" {
+		return fmt.Sprintf("$%d", n)
+	}
+	return "?"
+}
+
+// isBinaryColumn reports whether a column holds binary data rather than text.
+func isBinaryColumn(columnType *sql.ColumnType) bool {
+	name := strings.ToUpper(columnType.DatabaseTypeName())
+	return strings.Contains(name, "BLOB") || strings.Contains(name, "BINARY") || name == "BYTEA"
+}
+
+// normalizeValue returns text that some drivers scan as bytes as a string,
+// so it is not written as binary data.
+func normalizeValue(columnType *sql.ColumnType, value any) any {
+	if bytes, ok := value.([]byte); ok && !isBinaryColumn(columnType) && utf8.Valid(bytes) {
+		return string(bytes)
+	}
+	return value
+}
+
+// dumpTable writes the rows of a table as JSON lines: the column names, then one array of values per row.
+// The row callback receives the values of each row by column name.
+func dumpTable(ctx context.Context, tx *sql.Tx, driver, table string, w io.Writer, onRow func(map[string]any)) (int, error) {
+	rows, err := tx.QueryContext(ctx, "SELECT * FROM "+quoteIdentifier(driver, table))
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to query table %s", table)
+	}
+	defer rows.Close()
+
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return 0, err
+	}
+	columns := make([]string, len(columnTypes))
+	for i, columnType := range columnTypes {
+		columns[i] = columnType.Name()
+	}
+	encoder := json.NewEncoder(w)
+	if err := encoder.Encode(columns); err != nil {
+		return 0, err
+	}
+
+	count := 0
+	values := make([]any, len(columns))
+	pointers := make([]any, len(columns))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+	for rows.Next() {
+		if err := rows.Scan(pointers...); err != nil {
+			return 0, errors.Wrapf(err, "failed to scan table %s", table)
+		}
+		cells := make([]any, len(values))
+		row := map[string]any{}
+		for i, value := range values {
+			value = normalizeValue(columnTypes[i], value)
+			cells[i] = encodeValue(value)
+			row[columns[i]] = value
+		}
+		if err := encoder.Encode(cells); err != nil {
+			return 0, err
+		}
+		if onRow != nil {
+			onRow(row)
+		}
+		count++
+	}
+	return count, rows.Err()
+}
+
+// restoreTable inserts the rows of a table dumped by dumpTable.
+func restoreTable(ctx context.Context, tx *sql.Tx, driver, table string, r io.Reader) (int, error) {
+	reader := bufio.NewReader(r)
+	line, err := reader.ReadBytes('\n')
+	if err != nil {
+		return 0, errors.Wrap(err, "missing columns")
+	}
+	columns := []string{}
+	if err := json.Unmarshal(line, &columns); err != nil {
+		return 0, errors.Wrap(err, "invalid columns")
+	}
+	quotedColumns := make([]string, len(columns))
+	placeholders := make([]string, len(columns))
+	for i, column := range columns {
+		quotedColumns[i] = quoteIdentifier(driver, column)
+		placeholders[i] = placeholder(driver, i+1)
+	}
+	stmt, err := tx.PrepareContext(ctx, fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)",
+		quoteIdentifier(driver, table), strings.Join(quotedColumns, ", "), strings.Join(placeholders, ", ")))
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to prepare insert into %s", table)
+	}
+	defer stmt.Close()
+
+	count := 0
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF && len(line) == 0 {
+			break
+		}
+		if err != nil && err != io.EOF {
+			return 0, err
+		}
+		decoder := json.NewDecoder(strings.NewReader(string(line)))
+		decoder.UseNumber()
+		cells := []any{}
+		if err := decoder.Decode(&cells); err != nil {
+			return 0, errors.Wrapf(err, "invalid row %d", count+1)
+		}
+		if len(cells) != len(columns) {
+			return 0, errors.Errorf("row %d has %d values, expected %d", count+1, len(cells), len(columns))
+		}
+		args := make([]any, len(cells))
+		for i, cell := range cells {
+			if args[i], err = decodeValue(cell); err != nil {
+				return 0, errors.Wrapf(err, "invalid value of %s in row %d", columns[i], count+1)
+			}
+		}
+		if _, err := stmt.ExecContext(ctx, args...); err != nil {
+			return 0, errors.Wrapf(err, "failed to insert row %d", count+1)
+		}
+		count++
+	}
+	return count, nil
+}
+
+// resetSequences moves the id sequences past the restored ids.
+// SQLite and MySQL do it on their own when rows are inserted with explicit ids.
+func resetSequences(ctx context.Context, tx *sql.Tx, driver string) error {
+	if driver != "postgres" {
+		return nil
+	}
+	for _, table := range serialTables {
+		quoted := quoteIdentifier(driver, table)
+		stmt := fmt.Sprintf("SELECT setval(pg_get_serial_sequence('%s', 'id'), COALESCE(MAX(id), 0) + 1, false) FROM %s", quoted, quoted)
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			return errors.Wrapf(err, "failed to reset the sequence of %s", table)
+		}
+	}
+	return nil
+}
+
+// encodeValue returns the JSON value of a database value.
+// Bytes and times are wrapped in objects to be restored with their type.
+func encodeValue(value any) any {
+	switch value := value.(type) {
+	case []byte:
+		return map[string]string{"bytes": base64.StdEncoding.EncodeToString(value)}
+	case time.Time:
+		return map[string]string{"time": value.Format(time.RFC3339Nano)}
+	default:
+		return value
+	}
+}
+
+func decodeValue(cell any) (any, error) {
+	switch cell := cell.(type) {
+	case json.Number:
+		if i, err := cell.Int64(); err == nil {
+			return i, nil
+		}
+		return cell.Float64()
+	case map[string]any:
+		if encoded, ok := cell["bytes"].(string); ok {
+			return base64.StdEncoding.DecodeString(encoded)
+		}
+		if encoded, ok := cell["time"].(string); ok {
+			return time.Parse(time.RFC3339Nano, encoded)
+		}
+		return nil, errors.New("unknown value type")
+	default:
+		return cell, nil
+	}
+}
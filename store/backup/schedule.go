@@ -0,0 +1,310 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/internal/profile"
+	"github.com/usememos/memos/plugin/storage"
+	"github.com/usememos/memos/plugin/storage/s3"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+const (
+	// archivePrefix and archiveTimeLayout make up the names of scheduled backups,
+	// e.g. memos-backup-20240102T030000.000Z.zip. The names of older backups have no milliseconds,
+	// which archiveTimeLayout also parses.
+	archivePrefix     = "memos-backup-"
+	archiveTimeLayout = "20060102T150405.000Z"
+	archiveExtension  = ".zip"
+)
+
+// archiveMutex serializes the backups, so that a manual and a scheduled backup never save
+// archives of the same name at once.
+var archiveMutex sync.Mutex
+
+// Archive is a scheduled backup at a destination.
+type Archive struct {
+	Name       string
+	Size       int64
+	CreateTime time.Time
+}
+
+// Destination stores the archives of scheduled backups.
+type Destination interface {
+	// List returns the archives, newest first.
+	List(ctx context.Context) ([]*Archive, error)
+	// Save saves the archive, and fails when an archive of the name exists.
+	Save(ctx context.Context, name string, r io.Reader) error
+	Delete(ctx context.Context, name string) error
+}
+
+// NewDestination returns the destination of the backup setting.
+func NewDestination(ctx context.Context, stores *store.Store, profile *profile.Profile, setting *storepb.WorkspaceBackupSetting) (Destination, error) {
+	switch setting.Destination {
+	case storepb.WorkspaceBackupSetting_LOCAL:
+		dir := setting.Directory
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(profile.Data, dir)
+		}
+		return &localDestination{dir: dir}, nil
+	case storepb.WorkspaceBackupSetting_S3:
+		workspaceStorageSetting, err := stores.GetWorkspaceStorageSetting(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if workspaceStorageSetting.S3Config == nil {
+			return nil, errors.New("S3 storage is not configured")
+		}
+		client, err := s3.NewClient(ctx, workspaceStorageSetting.S3Config)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create s3 client")
+		}
+		return &s3Destination{client: client, prefix: setting.S3Prefix}, nil
+	default:
+		return nil, errors.Errorf("unsupported backup destination %s", setting.Destination)
+	}
+}
+
+// parseArchiveName returns the creation time of a scheduled backup from its name.
+func parseArchiveName(name string) (time.Time, bool) {
+	timestamp, ok := strings.CutPrefix(name, archivePrefix)
+	if !ok {
+		return time.Time{}, false
+	}
+	timestamp, ok = strings.CutSuffix(timestamp, archiveExtension)
+	if !ok {
+		return time.Time{}, false
+	}
+	// The fractional seconds are optional when parsing a layout without them.
+	createTime, err := time.Parse("20060102T150405Z", timestamp)
+	return createTime, err == nil
+}
+
+func sortArchives(archives []*Archive) {
+	slices.SortFunc(archives, func(a, b *Archive) int {
+		return b.CreateTime.Compare(a.CreateTime)
+	})
+}
+
+type localDestination struct {
+	dir string
+}
+
+func (d *localDestination) List(_ context.Context) ([]*Archive, error) {
+	entries, err := os.ReadDir(d.dir)
+	if os.IsNotExist(err) {
+		return []*Archive{}, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read backup directory")
+	}
+	archives := []*Archive{}
+	for _, entry := range entries {
+		createTime, ok := parseArchiveName(entry.Name())
+		if !ok || !entry.Type().IsRegular() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil, err
+		}
+		archives = append(archives, &Archive{Name: entry.Name(), Size: info.Size(), CreateTime: createTime})
+	}
+	sortArchives(archives)
+	return archives, nil
+}
+
+func (d *localDestination) Save(_ context.Context, name string, r io.Reader) error {
+	if err := os.MkdirAll(d.dir, os.ModePerm); err != nil {
+		return errors.Wrap(err, "failed to create backup directory")
+	}
+	if _, err := os.Lstat(filepath.Join(d.dir, name)); err == nil {
+		return errors.Errorf("backup %s already exists", name)
+	} else if !os.IsNotExist(err) {
+		return errors.Wrap(err, "failed to check backup file")
+	}
+	// Write to a temporary file first so an interrupted backup is never listed.
+	file, err := os.CreateTemp(d.dir, name+".*.tmp")
+	if err != nil {
+		return errors.Wrap(err, "failed to create backup file")
+	}
+	defer os.Remove(file.Name())
+	if _, err := io.Copy(file, r); err != nil {
+		file.Close()
+		return errors.Wrap(err, "failed to write backup file")
+	}
+	if err := file.Close(); err != nil {
+		return errors.Wrap(err, "failed to write backup file")
+	}
+	return os.Rename(file.Name(), filepath.Join(d.dir, name))
+}
+
+func (d *localDestination) Delete(_ context.Context, name string) error {
+	return os.Remove(filepath.Join(d.dir, name))
+}
+
+type s3Destination struct {
+	client *s3.Client
+	prefix string
+}
+
+func (d *s3Destination) List(ctx context.Context) ([]*Archive, error) {
+	objects, err := d.client.ListObjects(ctx, d.prefix)
+	if err != nil {
+		return nil, err
+	}
+	archives := []*Archive{}
+	for _, object := range objects {
+		name := strings.TrimPrefix(aws.ToString(object.Key), d.prefix)
+		createTime, ok := parseArchiveName(name)
+		if !ok {
+			continue
+		}
+		archives = append(archives, &Archive{Name: name, Size: aws.ToInt64(object.Size), CreateTime: createTime})
+	}
+	sortArchives(archives)
+	return archives, nil
+}
+
+func (d *s3Destination) Save(ctx context.Context, name string, r io.Reader) error {
+	if _, err := d.client.Stat(ctx, d.key(name)); err == nil {
+		return errors.Errorf("backup %s already exists", name)
+	} else if !errors.Is(err, storage.ErrNotFound) {
+		return errors.Wrap(err, "failed to check backup object")
+	}
+	_, err := d.client.UploadObject(ctx, d.key(name), "application/zip", r)
+	return err
+}
+
+func (d *s3Destination) Delete(ctx context.Context, name string) error {
+	return d.client.DeleteObject(ctx, d.key(name))
+}
+
+func (d *s3Destination) key(name string) string {
+	if d.prefix == "" || strings.HasSuffix(d.prefix, "/") {
+		return d.prefix + name
+	}
+	return path.Join(d.prefix, name)
+}
+
+// RetentionPolicy selects the scheduled backups to keep.
+// A backup is kept when any of the rules keeps it. When no rule is set, every backup is kept.
+type RetentionPolicy struct {
+	// KeepLast keeps the most recent backups.
+	KeepLast int
+	// KeepDaily keeps the most recent backup of each of the most recent days.
+	KeepDaily int
+	// KeepWeekly keeps the most recent backup of each of the most recent ISO weeks.
+	KeepWeekly int
+	// Location is the location of the days and weeks.
+	Location *time.Location
+}
+
+// Expired returns the archives that the policy does not keep.
+// The archives must be sorted newest first.
+func (p RetentionPolicy) Expired(archives []*Archive) []*Archive {
+	if p.KeepLast <= 0 && p.KeepDaily <= 0 && p.KeepWeekly <= 0 {
+		return []*Archive{}
+	}
+	location := p.Location
+	if location == nil {
+		location = time.UTC
+	}
+	days := map[string]bool{}
+	weeks := map[string]bool{}
+	expired := []*Archive{}
+	for i, archive := range archives {
+		createTime := archive.CreateTime.In(location)
+		keep := i < p.KeepLast
+		day := createTime.Format(time.DateOnly)
+		if !days[day] && len(days) < p.KeepDaily {
+			days[day] = true
+			keep = true
+		}
+		year, week := createTime.ISOWeek()
+		weekKey := fmt.Sprintf("%d-W%02d", year, week)
+		if !weeks[weekKey] && len(weeks) < p.KeepWeekly {
+			weeks[weekKey] = true
+			keep = true
+		}
+		if !keep {
+			expired = append(expired, archive)
+		}
+	}
+	return expired
+}
+
+// CreateArchive takes a backup to the destination of the backup setting of the workspace
+// and removes the backups that the retention rules no longer keep.
+func CreateArchive(ctx context.Context, stores *store.Store, profile *profile.Profile) (*Archive, error) {
+	archiveMutex.Lock()
+	defer archiveMutex.Unlock()
+	setting, err := stores.GetWorkspaceBackupSetting(ctx)
+	if err != nil {
+		return nil, err
+	}
+	destination, err := NewDestination(ctx, stores, profile, setting)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.CreateTemp("", archivePrefix+"*"+archiveExtension)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create temporary backup file")
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+	manifest, err := Create(ctx, stores, profile, file)
+	if err != nil {
+		return nil, err
+	}
+	size, err := file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	archive := &Archive{
+		Name:       archivePrefix + manifest.CreateTime.Format(archiveTimeLayout) + archiveExtension,
+		Size:       size,
+		CreateTime: manifest.CreateTime.Truncate(time.Millisecond),
+	}
+	if err := destination.Save(ctx, archive.Name, file); err != nil {
+		return nil, errors.Wrap(err, "failed to save backup")
+	}
+
+	location, err := stores.GetWorkspaceLocation(ctx)
+	if err != nil {
+		return nil, err
+	}
+	archives, err := destination.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	policy := RetentionPolicy{
+		KeepLast:   int(setting.KeepLast),
+		KeepDaily:  int(setting.KeepDaily),
+		KeepWeekly: int(setting.KeepWeekly),
+		Location:   location,
+	}
+	for _, expired := range policy.Expired(archives) {
+		if err := destination.Delete(ctx, expired.Name); err != nil {
+			return nil, errors.Wrapf(err, "failed to delete backup %s", expired.Name)
+		}
+	}
+	return archive, nil
+}
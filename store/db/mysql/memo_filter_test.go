@@ -145,6 +145,56 @@ func TestConvertExprToSQL(t *testing.T) {
 			want:   "JSON_EXTRACT(`memo`.`payload`, '$.property.hasIncompleteTasks') = CAST('true' AS JSON)",
 			args:   []any{},
 		},
+		{
+			filter: `has_attachment`,
+			want:   "EXISTS (SELECT 1 FROM `resource` WHERE `resource`.`memo_id` = `memo`.`id`)",
+			args:   []any{},
+		},
+		{
+			filter: `!has_location`,
+			want:   "NOT (JSON_EXTRACT(`memo`.`payload`, '$.location') IS NOT NULL)",
+			args:   []any{},
+		},
+		{
+			filter: `is_comment == false`,
+			want:   "NOT (EXISTS (SELECT 1 FROM `memo_relation` AS `mr` WHERE `mr`.`memo_id` = `memo`.`id` AND `mr`.`type` = 'COMMENT'))",
+			args:   []any{},
+		},
+		{
+			filter: `comment_count > 2`,
+			want:   "(SELECT COUNT(*) FROM `memo_relation` AS `mr` WHERE `mr`.`related_memo_id` = `memo`.`id` AND `mr`.`type` = 'COMMENT') > ?",
+			args:   []any{int64(2)},
+		},
+		{
+			filter: `reaction_count >= 1`,
+			want:   "(SELECT COUNT(*) FROM `reaction` WHERE `reaction`.`content_id` = CONCAT('memos/', `memo`.`uid`)) >= ?",
+			args:   []any{int64(1)},
+		},
+		{
+			filter: `attachment_type == "image/png"`,
+			want:   "EXISTS (SELECT 1 FROM `resource` WHERE `resource`.`memo_id` = `memo`.`id` AND `resource`.`type` = ?)",
+			args:   []any{"image/png"},
+		},
+		{
+			filter: `attachment_type in ["image/png", "image/jpeg"]`,
+			want:   "EXISTS (SELECT 1 FROM `resource` WHERE `resource`.`memo_id` = `memo`.`id` AND `resource`.`type` IN (?,?))",
+			args:   []any{"image/png", "image/jpeg"},
+		},
+		{
+			filter: `attachment_type.startsWith("image/")`,
+			want:   "EXISTS (SELECT 1 FROM `resource` WHERE `resource`.`memo_id` = `memo`.`id` AND `resource`.`type` LIKE ? ESCAPE '!')",
+			args:   []any{"image/%"},
+		},
+		{
+			filter: `"memo-uid" in references`,
+			want:   "EXISTS (SELECT 1 FROM `memo_relation` AS `mr` JOIN `memo` AS `referenced_memo` ON `referenced_memo`.`id` = `mr`.`related_memo_id` WHERE `mr`.`memo_id` = `memo`.`id` AND `mr`.`type` = 'REFERENCE' AND `referenced_memo`.`uid` = ?)",
+			args:   []any{"memo-uid"},
+		},
+		{
+			filter: `size(references) > 0`,
+			want:   "(SELECT COUNT(*) FROM `memo_relation` AS `mr` WHERE `mr`.`memo_id` = `memo`.`id` AND `mr`.`type` = 'REFERENCE') > ?",
+			args:   []any{int64(0)},
+		},
 	}
 
 	for _, tt := range tests {
@@ -158,3 +208,72 @@ func TestConvertExprToSQL(t *testing.T) {
 		require.Equal(t, tt.args, convertCtx.Args)
 	}
 }
+
+func TestConvertDateFunctionsToSQL(t *testing.T) {
+	location := time.FixedZone("UTC+8", 8*60*60)
+	timeContext := filter.NewTimeContext(location, time.Monday)
+	timeContext.Now = func() time.Time {
+		return time.Date(2025, 1, 15, 10, 0, 0, 0, location)
+	}
+	day := func(year int, month time.Month, day int) int64 {
+		return time.Date(year, month, day, 0, 0, 0, 0, location).Unix()
+	}
+
+	tests := []struct {
+		filter string
+		want   string
+		args   []any
+	}{
+		{
+			filter: `created_ts > timestamp("2025-01-01")`,
+			want:   "UNIX_TIMESTAMP(`memo`.`created_ts`) > ?",
+			args:   []any{day(2025, 1, 1)},
+		},
+		{
+			filter: `created_ts < timestamp("2025-01-01T00:00:00Z")`,
+			want:   "UNIX_TIMESTAMP(`memo`.`created_ts`) < ?",
+			args:   []any{int64(1735689600)},
+		},
+		{
+			filter: `created_ts >= now() - duration("7d")`,
+			want:   "UNIX_TIMESTAMP(`memo`.`created_ts`) >= ?",
+			args:   []any{day(2025, 1, 8) + 10*60*60},
+		},
+		{
+			filter: `created_ts >= today() && updated_ts >= start_of_week()`,
+			want:   "(UNIX_TIMESTAMP(`memo`.`created_ts`) >= ? AND UNIX_TIMESTAMP(`memo`.`updated_ts`) >= ?)",
+			args:   []any{day(2025, 1, 15), day(2025, 1, 13)},
+		},
+		{
+			filter: `created_ts >= start_of_month()`,
+			want:   "UNIX_TIMESTAMP(`memo`.`created_ts`) >= ?",
+			args:   []any{day(2025, 1, 1)},
+		},
+		{
+			filter: `date(created_ts) == timestamp("2025-01-10")`,
+			want:   "(UNIX_TIMESTAMP(`memo`.`created_ts`) >= ? AND UNIX_TIMESTAMP(`memo`.`created_ts`) < ?)",
+			args:   []any{day(2025, 1, 10), day(2025, 1, 11)},
+		},
+		{
+			filter: `date(created_ts) != today()`,
+			want:   "(UNIX_TIMESTAMP(`memo`.`created_ts`) < ? OR UNIX_TIMESTAMP(`memo`.`created_ts`) >= ?)",
+			args:   []any{day(2025, 1, 15), day(2025, 1, 16)},
+		},
+		{
+			filter: `date(updated_ts) <= timestamp("2025-01-10") && date(created_ts) > timestamp("2025-01-01")`,
+			want:   "(UNIX_TIMESTAMP(`memo`.`updated_ts`) < ? AND UNIX_TIMESTAMP(`memo`.`created_ts`) >= ?)",
+			args:   []any{day(2025, 1, 11), day(2025, 1, 2)},
+		},
+	}
+
+	for _, tt := range tests {
+		parsedExpr, err := filter.Parse(tt.filter, filter.MemoFilterCELAttributes...)
+		require.NoError(t, err)
+		convertCtx := filter.NewConvertContext()
+		converter := filter.NewCommonSQLConverter(&filter.MySQLDialect{}).WithTimeContext(timeContext)
+		err = converter.ConvertExprToSQL(convertCtx, parsedExpr.GetExpr())
+		require.NoError(t, err)
+		require.Equal(t, tt.want, convertCtx.Buffer.String())
+		require.Equal(t, tt.args, convertCtx.Args)
+	}
+}
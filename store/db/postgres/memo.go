@@ -39,6 +39,85 @@ func (d *DB) CreateMemo(ctx context.Context, create *store.Memo) (*store.Memo, e
 }
 
 func (d *DB) ListMemos(ctx context.Context, find *store.FindMemo) ([]*store.Memo, error) {
+	query, args, err := buildListMemosQuery(find)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := make([]*store.Memo, 0)
+	for rows.Next() {
+		var memo store.Memo
+		var payloadBytes []byte
+		dests := []any{
+			&memo.ID,
+			&memo.UID,
+			&memo.CreatorID,
+			&memo.CreatedTs,
+			&memo.UpdatedTs,
+			&memo.RowStatus,
+			&memo.Visibility,
+			&memo.Pinned,
+			&payloadBytes,
+			&memo.ParentUID,
+		}
+		if !find.ExcludeContent {
+			dests = append(dests, &memo.Content)
+		}
+		if err := rows.Scan(dests...); err != nil {
+			return nil, err
+		}
+		payload := &storepb.MemoPayload{}
+		if err := protojsonUnmarshaler.Unmarshal(payloadBytes, payload); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal payload")
+		}
+		memo.Payload = payload
+		list = append(list, &memo)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (d *DB) GetMemo(ctx context.Context, find *store.FindMemo) (*store.Memo, error) {
+	list, err := d.ListMemos(ctx, find)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, nil
+	}
+
+	memo := list[0]
+	return memo, nil
+}
+
+// CountMemos returns the number of memos that ListMemos returns without pagination.
+func (d *DB) CountMemos(ctx context.Context, find *store.FindMemo) (int, error) {
+	countFind := *find
+	countFind.ExcludeContent = true
+	countFind.Limit, countFind.Offset = nil, nil
+	query, args, err := buildListMemosQuery(&countFind)
+	if err != nil {
+		return 0, err
+	}
+	var count int
+	if err := d.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM ("+query+") AS memo_list", args...).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// buildListMemosQuery builds the query of the memos of ListMemos.
+func buildListMemosQuery(find *store.FindMemo) (string, []any, error) {
 	where, args := []string{"1 = 1"}, []any{}
 
 	for _, filterStr := range find.Filters {
@@ -46,14 +125,14 @@ func (d *DB) ListMemos(ctx context.Context, find *store.FindMemo) ([]*store.Memo
 		// The filter string should be a CEL expression.
 		parsedExpr, err := filter.Parse(filterStr, filter.MemoFilterCELAttributes...)
 		if err != nil {
-			return nil, err
+			return "", nil, err
 		}
 		convertCtx := filter.NewConvertContext()
 		convertCtx.ArgsOffset = len(args)
 		// ConvertExprToSQL converts the parsed expression to a SQL condition string.
-		converter := filter.NewCommonSQLConverterWithOffset(&filter.PostgreSQLDialect{}, convertCtx.ArgsOffset+len(convertCtx.Args))
+		converter := filter.NewCommonSQLConverterWithOffset(&filter.PostgreSQLDialect{}, convertCtx.ArgsOffset+len(convertCtx.Args)).WithTimeContext(filter.NewTimeContext(find.Location, find.WeekStart))
 		if err := converter.ConvertExprToSQL(convertCtx, parsedExpr.GetExpr()); err != nil {
-			return nil, err
+			return "", nil, err
 		}
 		condition := convertCtx.Buffer.String()
 		if condition != "" {
@@ -123,61 +202,7 @@ func (d *DB) ListMemos(ctx context.Context, find *store.FindMemo) ([]*store.Memo
 			query = fmt.Sprintf("%s OFFSET %d", query, *find.Offset)
 		}
 	}
-
-	rows, err := d.db.QueryContext(ctx, query, args...)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	list := make([]*store.Memo, 0)
-	for rows.Next() {
-		var memo store.Memo
-		var payloadBytes []byte
-		dests := []any{
-			&memo.ID,
-			&memo.UID,
-			&memo.CreatorID,
-			&memo.CreatedTs,
-			&memo.UpdatedTs,
-			&memo.RowStatus,
-			&memo.Visibility,
-			&memo.Pinned,
-			&payloadBytes,
-			&memo.ParentUID,
-		}
-		if !find.ExcludeContent {
-			dests = append(dests, &memo.Content)
-		}
-		if err := rows.Scan(dests...); err != nil {
-			return nil, err
-		}
-		payload := &storepb.MemoPayload{}
-		if err := protojsonUnmarshaler.Unmarshal(payloadBytes, payload); err != nil {
-			return nil, errors.Wrap(err, "failed to unmarshal payload")
-		}
-		memo.Payload = payload
-		list = append(list, &memo)
-	}
-
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-
-	return list, nil
-}
-
-func (d *DB) GetMemo(ctx context.Context, find *store.FindMemo) (*store.Memo, error) {
-	list, err := d.ListMemos(ctx, find)
-	if err != nil {
-		return nil, err
-	}
-	if len(list) == 0 {
-		return nil, nil
-	}
-
-	memo := list[0]
-	return memo, nil
+	return query, args, nil
 }
 
 func (d *DB) UpdateMemo(ctx context.Context, update *store.UpdateMemo) error {
@@ -3,6 +3,7 @@ package store
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/usememos/memos/internal/base"
 
@@ -70,6 +71,11 @@ type FindMemo struct {
 	ExcludeComments bool
 	Filters         []string
 
+	// Location and WeekStart are used to evaluate date functions in filters,
+	// e.g. today() and start_of_week(). A nil location means the server local time zone.
+	Location  *time.Location
+	WeekStart time.Weekday
+
 	// Pagination
 	Limit  *int
 	Offset *int
@@ -115,6 +121,11 @@ func (s *Store) ListMemos(ctx context.Context, find *FindMemo) ([]*Memo, error)
 	return s.driver.ListMemos(ctx, find)
 }
 
+// CountMemos returns the number of memos matching the find, ignoring its pagination.
+func (s *Store) CountMemos(ctx context.Context, find *FindMemo) (int, error) {
+	return s.driver.CountMemos(ctx, find)
+}
+
 func (s *Store) GetMemo(ctx context.Context, find *FindMemo) (*Memo, error) {
 	list, err := s.ListMemos(ctx, find)
 	if err != nil {
@@ -0,0 +1,82 @@
+package store
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/plugin/filter"
+)
+
+// GetFilterMemo returns the in-memory view of the memo used to evaluate memo filters,
+// including the attributes that come from its attachments, relations and reactions.
+func (s *Store) GetFilterMemo(ctx context.Context, memo *Memo) (*filter.Memo, error) {
+	filterMemo := &filter.Memo{
+		Content:         memo.Content,
+		CreatorID:       memo.CreatorID,
+		CreatedTs:       memo.CreatedTs,
+		UpdatedTs:       memo.UpdatedTs,
+		Pinned:          memo.Pinned,
+		Visibility:      memo.Visibility.String(),
+		Tags:            []string{},
+		AttachmentTypes: []string{},
+		References:      []string{},
+	}
+	if payload := memo.Payload; payload != nil {
+		filterMemo.Tags = append(filterMemo.Tags, payload.Tags...)
+		if property := payload.Property; property != nil {
+			filterMemo.HasTaskList = property.HasTaskList
+			filterMemo.HasLink = property.HasLink
+			filterMemo.HasCode = property.HasCode
+			filterMemo.HasIncompleteTasks = property.HasIncompleteTasks
+		}
+		if location := payload.Location; location != nil {
+			filterMemo.Location = &filter.MemoLocation{
+				Latitude:  location.Latitude,
+				Longitude: location.Longitude,
+			}
+		}
+	}
+
+	attachments, err := s.ListAttachments(ctx, &FindAttachment{MemoID: &memo.ID})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list attachments")
+	}
+	for _, attachment := range attachments {
+		filterMemo.AttachmentTypes = append(filterMemo.AttachmentTypes, attachment.Type)
+	}
+
+	contentID := "memos/" + memo.UID
+	reactions, err := s.ListReactions(ctx, &FindReaction{ContentID: &contentID})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list reactions")
+	}
+	filterMemo.ReactionCount = int64(len(reactions))
+
+	commentType := MemoRelationComment
+	comments, err := s.ListMemoRelations(ctx, &FindMemoRelation{RelatedMemoID: &memo.ID, Type: &commentType})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list comment relations")
+	}
+	filterMemo.CommentCount = int64(len(comments))
+
+	relations, err := s.ListMemoRelations(ctx, &FindMemoRelation{MemoID: &memo.ID})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list memo relations")
+	}
+	for _, relation := range relations {
+		switch relation.Type {
+		case MemoRelationComment:
+			filterMemo.IsComment = true
+		case MemoRelationReference:
+			relatedMemo, err := s.GetMemo(ctx, &FindMemo{ID: &relation.RelatedMemoID, ExcludeContent: true})
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to get referenced memo")
+			}
+			if relatedMemo != nil {
+				filterMemo.References = append(filterMemo.References, relatedMemo.UID)
+			}
+		}
+	}
+	return filterMemo, nil
+}
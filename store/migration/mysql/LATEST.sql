@@ -78,7 +78,9 @@ CREATE TABLE `resource` (
   `memo_id` INT DEFAULT NULL,
   `storage_type` VARCHAR(256) NOT NULL DEFAULT '',
   `reference` TEXT NOT NULL DEFAULT (''),
-  `payload` TEXT NOT NULL
+  `payload` TEXT NOT NULL,
+  `sha256` VARCHAR(64) NOT NULL DEFAULT '',
+  INDEX `idx_resource_sha256` (`sha256`)
 );
 
 -- activity
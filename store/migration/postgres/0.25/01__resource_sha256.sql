@@ -0,0 +1,3 @@
+ALTER TABLE resource ADD COLUMN sha256 TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX idx_resource_sha256 ON resource (sha256);
@@ -78,9 +78,12 @@ CREATE TABLE resource (
   memo_id INTEGER DEFAULT NULL,
   storage_type TEXT NOT NULL DEFAULT '',
   reference TEXT NOT NULL DEFAULT '',
-  payload TEXT NOT NULL DEFAULT '{}'
+  payload TEXT NOT NULL DEFAULT '{}',
+  sha256 TEXT NOT NULL DEFAULT ''
 );
 
+CREATE INDEX idx_resource_sha256 ON resource (sha256);
+
 -- activity
 CREATE TABLE activity (
   id SERIAL PRIMARY KEY,
@@ -0,0 +1,90 @@
+package store
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// StorageUsage is the storage usage of attachments.
+type StorageUsage struct {
+	// Size is the total size of the attachments in bytes.
+	Size  int64
+	Count int
+}
+
+// UserStorageUsage is the storage usage of the attachments of a user.
+type UserStorageUsage struct {
+	StorageUsage
+	UserID int32
+	// Types is the storage usage by MIME type.
+	Types map[string]*StorageUsage
+}
+
+// GetUserStorageUsage returns the storage usage of the attachments of a user.
+func (s *Store) GetUserStorageUsage(ctx context.Context, userID int32) (*UserStorageUsage, error) {
+	attachments, err := s.ListAttachments(ctx, &FindAttachment{CreatorID: &userID})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list attachments")
+	}
+	usage := &UserStorageUsage{UserID: userID, Types: map[string]*StorageUsage{}}
+	for _, attachment := range attachments {
+		usage.add(attachment)
+	}
+	return usage, nil
+}
+
+// ListUserStorageUsages returns the storage usage of the users with attachments, largest first.
+func (s *Store) ListUserStorageUsages(ctx context.Context) ([]*UserStorageUsage, error) {
+	attachments, err := s.ListAttachments(ctx, &FindAttachment{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list attachments")
+	}
+	usageMap := map[int32]*UserStorageUsage{}
+	for _, attachment := range attachments {
+		usage, ok := usageMap[attachment.CreatorID]
+		if !ok {
+			usage = &UserStorageUsage{UserID: attachment.CreatorID, Types: map[string]*StorageUsage{}}
+			usageMap[attachment.CreatorID] = usage
+		}
+		usage.add(attachment)
+	}
+	usages := make([]*UserStorageUsage, 0, len(usageMap))
+	for _, usage := range usageMap {
+		usages = append(usages, usage)
+	}
+	sort.Slice(usages, func(i, j int) bool {
+		if usages[i].Size != usages[j].Size {
+			return usages[i].Size > usages[j].Size
+		}
+		return usages[i].UserID < usages[j].UserID
+	})
+	return usages, nil
+}
+
+func (u *UserStorageUsage) add(attachment *Attachment) {
+	u.Size += attachment.Size
+	u.Count++
+	typeUsage, ok := u.Types[attachment.Type]
+	if !ok {
+		typeUsage = &StorageUsage{}
+		u.Types[attachment.Type] = typeUsage
+	}
+	typeUsage.Size += attachment.Size
+	typeUsage.Count++
+}
+
+// GetUserStorageQuota returns the storage quota of a user in bytes, 0 for unlimited.
+// The quota of the user in the workspace storage setting is used over the quota of its role.
+func (s *Store) GetUserStorageQuota(ctx context.Context, user *User) (int64, error) {
+	setting, err := s.GetWorkspaceStorageSetting(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get workspace storage setting")
+	}
+	quotaMb, ok := setting.UserQuotaMb[user.ID]
+	if !ok {
+		quotaMb = setting.RoleQuotaMb[string(user.Role)]
+	}
+	return quotaMb * 1024 * 1024, nil
+}
@@ -1,6 +1,7 @@
 package store
 
 import (
+	"sync"
 	"time"
 
 	"github.com/usememos/memos/internal/profile"
@@ -19,6 +20,11 @@ type Store struct {
 	workspaceSettingCache *cache.Cache // cache for workspace settings
 	userCache             *cache.Cache // cache for users
 	userSettingCache      *cache.Cache // cache for user settings
+
+	// attachmentContentLocks serialize the deduplication and the deletion of attachment contents
+	// by checksum.
+	attachmentContentLocksMutex sync.Mutex
+	attachmentContentLocks      map[string]*attachmentContentLock
 }
 
 // New creates a new instance of Store.
@@ -32,12 +38,13 @@ func New(driver Driver, profile *profile.Profile) *Store {
 	}
 
 	store := &Store{
-		driver:                driver,
-		profile:               profile,
-		cacheConfig:           cacheConfig,
-		workspaceSettingCache: cache.New(cacheConfig),
-		userCache:             cache.New(cacheConfig),
-		userSettingCache:      cache.New(cacheConfig),
+		driver:                 driver,
+		profile:                profile,
+		cacheConfig:            cacheConfig,
+		workspaceSettingCache:  cache.New(cacheConfig),
+		userCache:              cache.New(cacheConfig),
+		userSettingCache:       cache.New(cacheConfig),
+		attachmentContentLocks: map[string]*attachmentContentLock{},
 	}
 
 	return store
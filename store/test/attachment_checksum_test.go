@@ -0,0 +1,195 @@
+package teststore
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+// contentSha256 is the hex encoded SHA-256 checksum of "content".
+const contentSha256 = "ed7002b439e9ac845f22357d822bac1444730fbdb6016d3ec9432297b9ec9f73"
+
+func TestAttachmentDeduplication(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	defer ts.Close()
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	setting := &storepb.WorkspaceStorageSetting{
+		StorageType: storepb.WorkspaceStorageSetting_LOCAL,
+		Deduplicate: true,
+	}
+	createAttachment := func(uid, content string) *store.Attachment {
+		create := &store.Attachment{UID: uid, CreatorID: user.ID, Filename: uid + ".txt", Type: "text/plain"}
+		require.NoError(t, ts.PutAttachmentContent(ctx, setting, create, filepath.Join(dir, uid+".txt"), bytes.NewReader([]byte(content))))
+		attachment, err := ts.CreateAttachment(ctx, create)
+		require.NoError(t, err)
+		return attachment
+	}
+
+	first := createAttachment("first", "content")
+	require.Equal(t, contentSha256, first.Sha256)
+	second := createAttachment("second", "content")
+	require.Equal(t, contentSha256, second.Sha256)
+	require.Equal(t, first.Reference, second.Reference)
+	other := createAttachment("other", "other content")
+	require.NotEqual(t, first.Reference, other.Reference)
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	// The shared file is deleted with the last attachment that refers to it.
+	require.NoError(t, ts.DeleteAttachment(ctx, &store.DeleteAttachment{ID: first.ID}))
+	_, err = os.Stat(second.Reference)
+	require.NoError(t, err)
+	require.NoError(t, ts.DeleteAttachment(ctx, &store.DeleteAttachment{ID: second.ID}))
+	_, err = os.Stat(second.Reference)
+	require.True(t, os.IsNotExist(err))
+
+	// Without deduplication, identical contents are stored separately.
+	setting.Deduplicate = false
+	third := createAttachment("third", "content")
+	fourth := createAttachment("fourth", "content")
+	require.NotEqual(t, third.Reference, fourth.Reference)
+	require.Equal(t, third.Sha256, fourth.Sha256)
+	require.NoError(t, ts.DeleteAttachment(ctx, &store.DeleteAttachment{ID: third.ID}))
+	_, err = os.Stat(third.Reference)
+	require.True(t, os.IsNotExist(err))
+	_, err = os.Stat(fourth.Reference)
+	require.NoError(t, err)
+}
+
+// pausingDriver pauses the creation of attachments until it is resumed.
+type pausingDriver struct {
+	store.Driver
+	paused  chan struct{}
+	resumed chan struct{}
+}
+
+func (d *pausingDriver) CreateAttachment(ctx context.Context, create *store.Attachment) (*store.Attachment, error) {
+	d.paused <- struct{}{}
+	<-d.resumed
+	return d.Driver.CreateAttachment(ctx, create)
+}
+
+func TestAttachmentDeduplicationConcurrency(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	defer ts.Close()
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	setting := &storepb.WorkspaceStorageSetting{
+		StorageType: storepb.WorkspaceStorageSetting_LOCAL,
+		Deduplicate: true,
+	}
+	putContent := func(uid string) *store.Attachment {
+		create := &store.Attachment{UID: uid, CreatorID: user.ID, Filename: uid + ".txt", Type: "text/plain"}
+		require.NoError(t, ts.PutAttachmentContent(ctx, setting, create, filepath.Join(dir, uid+".txt"), bytes.NewReader([]byte("content"))))
+		return create
+	}
+	shared, err := ts.CreateAttachment(ctx, putContent("shared"))
+	require.NoError(t, err)
+
+	// A store on the same database pauses the creation of an attachment after its content is found
+	// to be shared with the attachment that is deleted meanwhile.
+	driver := &pausingDriver{Driver: ts.GetDriver(), paused: make(chan struct{}), resumed: make(chan struct{})}
+	pausingStore := store.New(driver, getTestingProfile(t))
+	created := make(chan *store.Attachment, 1)
+	go func() {
+		attachment, err := pausingStore.CreateAttachment(ctx, putContent("copy"))
+		assert.NoError(t, err)
+		created <- attachment
+	}()
+	<-driver.paused
+	deleted := make(chan error, 1)
+	go func() {
+		deleted <- pausingStore.DeleteAttachment(ctx, &store.DeleteAttachment{ID: shared.ID})
+	}()
+
+	// The deletion waits for the attachment that adopts the content.
+	select {
+	case err := <-deleted:
+		t.Fatalf("attachment deleted while its content is adopted: %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+	close(driver.resumed)
+	copied := <-created
+	require.NoError(t, <-deleted)
+	require.Equal(t, shared.Reference, copied.Reference)
+	content, err := os.ReadFile(copied.Reference)
+	require.NoError(t, err)
+	require.Equal(t, "content", string(content))
+}
+
+func TestVerifyAttachments(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	defer ts.Close()
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+
+	// An attachment uploaded before checksums were recorded.
+	legacy, err := ts.CreateAttachment(ctx, &store.Attachment{
+		UID:       "legacy",
+		CreatorID: user.ID,
+		Filename:  "legacy.txt",
+		Type:      "text/plain",
+		Size:      7,
+		Blob:      []byte("content"),
+	})
+	require.NoError(t, err)
+	require.Empty(t, legacy.Sha256)
+
+	dir := t.TempDir()
+	setting := &storepb.WorkspaceStorageSetting{StorageType: storepb.WorkspaceStorageSetting_LOCAL}
+	for _, uid := range []string{"valid", "corrupted", "missing"} {
+		create := &store.Attachment{UID: uid, CreatorID: user.ID, Filename: uid + ".txt", Type: "text/plain"}
+		require.NoError(t, ts.PutAttachmentContent(ctx, setting, create, filepath.Join(dir, uid+".txt"), bytes.NewReader([]byte("content"))))
+		_, err := ts.CreateAttachment(ctx, create)
+		require.NoError(t, err)
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "corrupted.txt"), []byte("CONTENT"), 0644))
+	require.NoError(t, os.Remove(filepath.Join(dir, "missing.txt")))
+	_, err = ts.CreateAttachment(ctx, &store.Attachment{
+		UID:         "external",
+		CreatorID:   user.ID,
+		Filename:    "external.txt",
+		StorageType: storepb.AttachmentStorageType_EXTERNAL,
+		Reference:   "https://example.com/external.txt",
+	})
+	require.NoError(t, err)
+
+	verification, err := ts.VerifyAttachments(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 4, verification.Total)
+	require.Equal(t, 1, verification.Recorded)
+	problems := map[string]store.AttachmentProblem{}
+	for _, issue := range verification.Issues {
+		problems[issue.AttachmentUID] = issue.Problem
+	}
+	require.Equal(t, map[string]store.AttachmentProblem{
+		"corrupted": store.AttachmentProblemMismatched,
+		"missing":   store.AttachmentProblemMissing,
+	}, problems)
+	legacy, err = ts.GetAttachment(ctx, &store.FindAttachment{ID: &legacy.ID})
+	require.NoError(t, err)
+	require.Equal(t, contentSha256, legacy.Sha256)
+
+	verification, err = ts.VerifyAttachments(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 0, verification.Recorded)
+	require.Len(t, verification.Issues, 2)
+}
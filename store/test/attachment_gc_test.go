@@ -0,0 +1,107 @@
+package teststore
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+func TestCollectAttachmentGarbage(t *testing.T) {
+	ctx := context.Background()
+	profile := getTestingProfile(t)
+	ts := newTestingStoreWithProfile(ctx, profile)
+	defer ts.Close()
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	_, err = ts.UpsertWorkspaceSetting(ctx, &storepb.WorkspaceSetting{
+		Key: storepb.WorkspaceSettingKey_STORAGE,
+		Value: &storepb.WorkspaceSetting_StorageSetting{StorageSetting: &storepb.WorkspaceStorageSetting{
+			StorageType:      storepb.WorkspaceStorageSetting_LOCAL,
+			FilepathTemplate: "assets/{filename}",
+		}},
+	})
+	require.NoError(t, err)
+	memo, err := ts.CreateMemo(ctx, &store.Memo{UID: "memo", CreatorID: user.ID, Content: "memo", Visibility: store.Public})
+	require.NoError(t, err)
+
+	old := time.Now().Add(-48 * time.Hour)
+	writeFile := func(path string, content string, modTime time.Time) {
+		path = filepath.Join(profile.Data, filepath.FromSlash(path))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+		require.NoError(t, os.Chtimes(path, modTime, modTime))
+	}
+	createAttachment := func(uid string, memoID *int32, updatedTs int64) *store.Attachment {
+		writeFile("assets/"+uid, uid, old)
+		attachment, err := ts.CreateAttachment(ctx, &store.Attachment{
+			UID:         uid,
+			CreatorID:   user.ID,
+			MemoID:      memoID,
+			Filename:    uid,
+			Type:        "text/plain",
+			Size:        int64(len(uid)),
+			StorageType: storepb.AttachmentStorageType_LOCAL,
+			Reference:   "assets/" + uid,
+		})
+		require.NoError(t, err)
+		require.NoError(t, ts.UpdateAttachment(ctx, &store.UpdateAttachment{ID: attachment.ID, UpdatedTs: &updatedTs}))
+		return attachment
+	}
+	unlinked := createAttachment("unlinked", nil, old.Unix())
+	linked := createAttachment("linked", &memo.ID, old.Unix())
+	createAttachment("recent", nil, time.Now().Unix())
+	writeFile("assets/orphan", "orphan", old)
+	writeFile("assets/upload", "upload", time.Now())
+	writeFile("notes.txt", "notes", old)
+	writeFile(".thumbnail_cache/"+strconv.Itoa(int(linked.ID))+".png", "thumbnail", old)
+	writeFile(".thumbnail_cache/"+strconv.Itoa(int(unlinked.ID))+".png", "thumbnail", old)
+	writeFile(".thumbnail_cache/"+strconv.Itoa(int(linked.ID))+"_small.webp", "thumbnail", old)
+	writeFile(".thumbnail_cache/999.png", "thumbnail", old)
+	writeFile(".thumbnail_cache/.thumbnail-123", "thumbnail", old)
+
+	options := store.CollectAttachmentGarbageOptions{Retention: 24 * time.Hour, DryRun: true}
+	garbage, err := ts.CollectAttachmentGarbage(ctx, options)
+	require.NoError(t, err)
+	require.Len(t, garbage.Attachments, 1)
+	require.Equal(t, unlinked.UID, garbage.Attachments[0].UID)
+	require.Equal(t, []string{"assets/orphan"}, garbage.Files)
+	require.ElementsMatch(t, []string{
+		".thumbnail_cache/" + strconv.Itoa(int(unlinked.ID)) + ".png",
+		".thumbnail_cache/999.png",
+	}, garbage.Thumbnails)
+	require.Equal(t, int64(len("unlinked")+len("orphan")+2*len("thumbnail")), garbage.Size)
+	// A dry run deletes nothing.
+	attachments, err := ts.ListAttachments(ctx, &store.FindAttachment{})
+	require.NoError(t, err)
+	require.Len(t, attachments, 3)
+	require.FileExists(t, filepath.Join(profile.Data, "assets", "orphan"))
+
+	options.DryRun = false
+	deleted, err := ts.CollectAttachmentGarbage(ctx, options)
+	require.NoError(t, err)
+	require.Equal(t, garbage.Files, deleted.Files)
+	require.ElementsMatch(t, garbage.Thumbnails, deleted.Thumbnails)
+	attachments, err = ts.ListAttachments(ctx, &store.FindAttachment{})
+	require.NoError(t, err)
+	require.Len(t, attachments, 2)
+	for _, path := range []string{"assets/unlinked", "assets/orphan", ".thumbnail_cache/999.png"} {
+		require.NoFileExists(t, filepath.Join(profile.Data, filepath.FromSlash(path)))
+	}
+	for _, path := range []string{"assets/linked", "assets/recent", "assets/upload", "notes.txt", ".thumbnail_cache/" + strconv.Itoa(int(linked.ID)) + ".png", ".thumbnail_cache/" + strconv.Itoa(int(linked.ID)) + "_small.webp", ".thumbnail_cache/.thumbnail-123"} {
+		require.FileExists(t, filepath.Join(profile.Data, filepath.FromSlash(path)))
+	}
+
+	garbage, err = ts.CollectAttachmentGarbage(ctx, options)
+	require.NoError(t, err)
+	require.Empty(t, garbage.Attachments)
+	require.Empty(t, garbage.Files)
+	require.Empty(t, garbage.Thumbnails)
+}
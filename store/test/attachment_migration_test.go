@@ -0,0 +1,123 @@
+package teststore
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+func TestMigrateAttachments(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	defer ts.Close()
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+
+	contents := map[string]string{
+		"first":  "first content",
+		"second": "second content",
+		"third":  "third content",
+	}
+	for uid, content := range contents {
+		// All attachments have the same filename and creation time, so their keys collide.
+		_, err := ts.CreateAttachment(ctx, &store.Attachment{
+			UID:       uid,
+			CreatorID: user.ID,
+			Filename:  "file.txt",
+			Type:      "text/plain",
+			Size:      int64(len(content)),
+			Blob:      []byte(content),
+		})
+		require.NoError(t, err)
+	}
+	_, err = ts.CreateAttachment(ctx, &store.Attachment{
+		UID:         "external",
+		CreatorID:   user.ID,
+		Filename:    "external.txt",
+		Type:        "text/plain",
+		StorageType: storepb.AttachmentStorageType_EXTERNAL,
+		Reference:   "https://example.com/external.txt",
+	})
+	require.NoError(t, err)
+
+	// Migrate from the database to the local storage.
+	dir := t.TempDir()
+	setWorkspaceStorageSetting(ctx, t, ts, &storepb.WorkspaceStorageSetting{
+		StorageType:      storepb.WorkspaceStorageSetting_LOCAL,
+		FilepathTemplate: filepath.Join(dir, "{filename}"),
+	})
+	progressCount := 0
+	progress, err := ts.MigrateAttachments(ctx, store.MigrateAttachmentsOptions{
+		BatchSize:  2,
+		OnProgress: func(*store.AttachmentMigrationProgress) { progressCount++ },
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3, progress.Total)
+	require.Equal(t, 3, progress.Migrated)
+	require.Equal(t, 0, progress.Failed)
+	require.Equal(t, 3, progressCount)
+	for uid, content := range contents {
+		attachment, err := ts.GetAttachment(ctx, &store.FindAttachment{UID: &uid, GetBlob: true})
+		require.NoError(t, err)
+		require.Equal(t, storepb.AttachmentStorageType_LOCAL, attachment.StorageType)
+		require.Empty(t, attachment.Blob)
+		data, err := os.ReadFile(attachment.Reference)
+		require.NoError(t, err)
+		require.Equal(t, content, string(data))
+	}
+	external, err := ts.GetAttachment(ctx, &store.FindAttachment{UID: &[]string{"external"}[0]})
+	require.NoError(t, err)
+	require.Equal(t, storepb.AttachmentStorageType_EXTERNAL, external.StorageType)
+
+	// A migration that runs again has nothing left to migrate.
+	progress, err = ts.MigrateAttachments(ctx, store.MigrateAttachmentsOptions{})
+	require.NoError(t, err)
+	require.Equal(t, 0, progress.Total)
+
+	// An attachment whose content is missing fails and stays in the local storage.
+	uid := "first"
+	first, err := ts.GetAttachment(ctx, &store.FindAttachment{UID: &uid})
+	require.NoError(t, err)
+	require.NoError(t, os.Remove(first.Reference))
+
+	// Migrate back from the local storage to the database.
+	setWorkspaceStorageSetting(ctx, t, ts, &storepb.WorkspaceStorageSetting{
+		StorageType: storepb.WorkspaceStorageSetting_DATABASE,
+	})
+	progress, err = ts.MigrateAttachments(ctx, store.MigrateAttachmentsOptions{BatchSize: 1})
+	require.NoError(t, err)
+	require.Equal(t, 3, progress.Total)
+	require.Equal(t, 2, progress.Migrated)
+	require.Equal(t, 1, progress.Failed)
+	require.Len(t, progress.Failures, 1)
+	require.Contains(t, progress.Failures[0], "attachments/first")
+	for uid, content := range contents {
+		attachment, err := ts.GetAttachment(ctx, &store.FindAttachment{UID: &uid, GetBlob: true})
+		require.NoError(t, err)
+		if uid == "first" {
+			require.Equal(t, storepb.AttachmentStorageType_LOCAL, attachment.StorageType)
+			continue
+		}
+		require.Equal(t, storepb.AttachmentStorageType_ATTACHMENT_STORAGE_TYPE_UNSPECIFIED, attachment.StorageType)
+		require.Equal(t, content, string(attachment.Blob))
+		require.Empty(t, attachment.Reference)
+		require.Nil(t, attachment.Payload.GetPayload())
+	}
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+func setWorkspaceStorageSetting(ctx context.Context, t *testing.T, ts *store.Store, setting *storepb.WorkspaceStorageSetting) {
+	_, err := ts.UpsertWorkspaceSetting(ctx, &storepb.WorkspaceSetting{
+		Key:   storepb.WorkspaceSettingKey_STORAGE,
+		Value: &storepb.WorkspaceSetting_StorageSetting{StorageSetting: setting},
+	})
+	require.NoError(t, err)
+}
@@ -0,0 +1,81 @@
+package teststore
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/webdav"
+
+	"github.com/usememos/memos/plugin/storage"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+func TestAttachmentStorage(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	defer ts.Close()
+	server := httptest.NewServer(&webdav.Handler{
+		FileSystem: webdav.NewMemFS(),
+		LockSystem: webdav.NewMemLS(),
+	})
+	defer server.Close()
+
+	setting := &storepb.WorkspaceStorageSetting{
+		StorageType: storepb.WorkspaceStorageSetting_WEBDAV,
+		WebdavConfig: &storepb.StorageWebDAVConfig{
+			Endpoint: server.URL,
+		},
+	}
+	create := &store.Attachment{
+		UID:       "attachment",
+		CreatorID: 101,
+		Filename:  "file.txt",
+		Type:      "text/plain",
+	}
+	require.NoError(t, ts.PutAttachmentContent(ctx, setting, create, "assets/file.txt", bytes.NewReader([]byte("content"))))
+	require.Equal(t, storepb.AttachmentStorageType_WEBDAV, create.StorageType)
+	require.Equal(t, int64(7), create.Size)
+	require.Equal(t, "assets/file.txt", create.Payload.GetWebdavObject().Path)
+	attachment, err := ts.CreateAttachment(ctx, create)
+	require.NoError(t, err)
+
+	// The attachment is read with the config of its payload after the storage setting changes.
+	_, err = ts.UpsertWorkspaceSetting(ctx, &storepb.WorkspaceSetting{
+		Key:   storepb.WorkspaceSettingKey_STORAGE,
+		Value: &storepb.WorkspaceSetting_StorageSetting{StorageSetting: &storepb.WorkspaceStorageSetting{StorageType: storepb.WorkspaceStorageSetting_LOCAL}},
+	})
+	require.NoError(t, err)
+	backend, key, err := ts.GetAttachmentStorage(ctx, attachment)
+	require.NoError(t, err)
+	body, err := backend.Get(ctx, key)
+	require.NoError(t, err)
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+	require.NoError(t, body.Close())
+	require.Equal(t, "content", string(data))
+
+	require.NoError(t, ts.DeleteAttachment(ctx, &store.DeleteAttachment{ID: attachment.ID}))
+	_, err = backend.Stat(ctx, key)
+	require.True(t, errors.Is(err, storage.ErrNotFound))
+}
+
+func TestAttachmentStorageDatabase(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	defer ts.Close()
+
+	create := &store.Attachment{UID: "attachment", CreatorID: 101, Filename: "file.txt", Type: "text/plain"}
+	setting := &storepb.WorkspaceStorageSetting{StorageType: storepb.WorkspaceStorageSetting_DATABASE}
+	require.NoError(t, ts.PutAttachmentContent(ctx, setting, create, "assets/file.txt", bytes.NewReader([]byte("content"))))
+	require.Equal(t, storepb.AttachmentStorageType_ATTACHMENT_STORAGE_TYPE_UNSPECIFIED, create.StorageType)
+	require.Equal(t, []byte("content"), create.Blob)
+	require.Equal(t, int64(7), create.Size)
+	_, _, err := ts.GetAttachmentStorage(ctx, create)
+	require.Error(t, err)
+}
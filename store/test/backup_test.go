@@ -0,0 +1,333 @@
+package teststore
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+	"github.com/usememos/memos/store/backup"
+)
+
+func TestBackupRestore(t *testing.T) {
+	ctx := context.Background()
+	sourceProfile := getTestingProfile(t)
+	if sourceProfile.Driver != "sqlite" {
+		t.Skip("restoring to a second database is only tested with SQLite")
+	}
+	source := newTestingStoreWithProfile(ctx, sourceProfile)
+	defer source.Close()
+
+	user, err := createTestingHostUser(ctx, source)
+	require.NoError(t, err)
+	memo, err := source.CreateMemo(ctx, &store.Memo{
+		UID:        "backup-memo",
+		CreatorID:  user.ID,
+		Content:    "Hello #backup",
+		Visibility: store.Public,
+		Payload:    &storepb.MemoPayload{Tags: []string{"backup"}},
+	})
+	require.NoError(t, err)
+	_, err = source.CreateAttachment(ctx, &store.Attachment{
+		UID:       "database-attachment",
+		CreatorID: user.ID,
+		Filename:  "blob.bin",
+		Type:      "application/octet-stream",
+		Blob:      []byte{0x00, 0xff, 0x10},
+		Size:      3,
+		MemoID:    &memo.ID,
+	})
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Join(sourceProfile.Data, "assets"), os.ModePerm))
+	require.NoError(t, os.WriteFile(filepath.Join(sourceProfile.Data, "assets", "local.txt"), []byte("local"), 0644))
+	_, err = source.CreateAttachment(ctx, &store.Attachment{
+		UID:         "local-attachment",
+		CreatorID:   user.ID,
+		Filename:    "local.txt",
+		Type:        "text/plain",
+		Size:        5,
+		StorageType: storepb.AttachmentStorageType_LOCAL,
+		Reference:   "assets/local.txt",
+	})
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Join(sourceProfile.Data, ".thumbnail_cache"), os.ModePerm))
+	require.NoError(t, os.WriteFile(filepath.Join(sourceProfile.Data, ".thumbnail_cache", "1.png"), []byte("thumbnail"), 0644))
+
+	var buf bytes.Buffer
+	manifest, err := backup.Create(ctx, source, sourceProfile, &buf)
+	require.NoError(t, err)
+	require.Equal(t, "sqlite", manifest.Driver)
+	require.Equal(t, 1, manifest.Tables["user"])
+	require.Equal(t, 1, manifest.Tables["memo"])
+	require.Equal(t, 2, manifest.Tables["resource"])
+	paths := []string{}
+	for _, entry := range manifest.Entries {
+		paths = append(paths, entry.Path)
+	}
+	require.Contains(t, paths, "files/assets/local.txt")
+	require.Contains(t, paths, "files/.thumbnail_cache/1.png")
+	data := buf.Bytes()
+
+	targetProfile := getTestingProfile(t)
+	target := newTestingStoreWithProfile(ctx, targetProfile)
+	defer target.Close()
+	restored, err := backup.Restore(ctx, target, targetProfile, newZipReader(t, data), backup.RestoreOptions{})
+	require.NoError(t, err)
+	require.Equal(t, manifest.Tables, restored.Tables)
+
+	restoredUser, err := target.GetUser(ctx, &store.FindUser{ID: &user.ID})
+	require.NoError(t, err)
+	require.Equal(t, user.Username, restoredUser.Username)
+	restoredMemo, err := target.GetMemo(ctx, &store.FindMemo{ID: &memo.ID})
+	require.NoError(t, err)
+	require.Equal(t, memo.UID, restoredMemo.UID)
+	require.Equal(t, memo.Content, restoredMemo.Content)
+	require.Equal(t, []string{"backup"}, restoredMemo.Payload.Tags)
+	attachmentUID := "database-attachment"
+	restoredAttachment, err := target.GetAttachment(ctx, &store.FindAttachment{UID: &attachmentUID, GetBlob: true})
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x00, 0xff, 0x10}, restoredAttachment.Blob)
+	content, err := os.ReadFile(filepath.Join(targetProfile.Data, "assets", "local.txt"))
+	require.NoError(t, err)
+	require.Equal(t, "local", string(content))
+	content, err = os.ReadFile(filepath.Join(targetProfile.Data, ".thumbnail_cache", "1.png"))
+	require.NoError(t, err)
+	require.Equal(t, "thumbnail", string(content))
+
+	// New rows get ids after the restored ones.
+	newMemo, err := target.CreateMemo(ctx, &store.Memo{UID: "after-restore", CreatorID: user.ID, Content: "After", Visibility: store.Private})
+	require.NoError(t, err)
+	require.Greater(t, newMemo.ID, memo.ID)
+
+	// A database with data is only replaced when forced.
+	_, err = backup.Restore(ctx, target, targetProfile, newZipReader(t, data), backup.RestoreOptions{})
+	require.ErrorContains(t, err, "not empty")
+	_, err = backup.Restore(ctx, target, targetProfile, newZipReader(t, data), backup.RestoreOptions{Force: true})
+	require.NoError(t, err)
+	memos, err := target.ListMemos(ctx, &store.FindMemo{})
+	require.NoError(t, err)
+	require.Len(t, memos, 1)
+
+	// Corrupted files and incompatible schema versions are rejected.
+	corrupted := rewriteZip(t, data, func(name string, content []byte) []byte {
+		if name == "database/memo.jsonl" {
+			return bytes.ReplaceAll(content, []byte("Hello"), []byte("Jello"))
+		}
+		return content
+	})
+	_, err = backup.Restore(ctx, target, targetProfile, newZipReader(t, corrupted), backup.RestoreOptions{Force: true})
+	require.ErrorContains(t, err, "checksum mismatch")
+	incompatible := rewriteZip(t, data, func(name string, content []byte) []byte {
+		if name == backup.ManifestName {
+			manifest := &backup.Manifest{}
+			require.NoError(t, json.Unmarshal(content, manifest))
+			manifest.SchemaVersion = "0.1.0"
+			content, err := json.Marshal(manifest)
+			require.NoError(t, err)
+			return content
+		}
+		return content
+	})
+	_, err = backup.Restore(ctx, target, targetProfile, newZipReader(t, incompatible), backup.RestoreOptions{Force: true})
+	require.ErrorContains(t, err, "not compatible")
+}
+
+func newZipReader(t *testing.T, data []byte) *zip.Reader {
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+	return reader
+}
+
+// rewriteZip copies an archive with the files changed by rewrite.
+func rewriteZip(t *testing.T, data []byte, rewrite func(name string, content []byte) []byte) []byte {
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+	for _, file := range newZipReader(t, data).File {
+		reader, err := file.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		reader.Close()
+		fileWriter, err := writer.Create(file.Name)
+		require.NoError(t, err)
+		_, err = fileWriter.Write(rewrite(file.Name, content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+	return buf.Bytes()
+}
+
+func TestCopyDatabase(t *testing.T) {
+	ctx := context.Background()
+	// The source is always SQLite, so the copy is tested from SQLite to the driver under test,
+	// e.g. to MySQL and PostgreSQL with DRIVER=mysql and DRIVER=postgres.
+	sourceProfile := getTestingProfile(t)
+	sourceProfile.Driver = "sqlite"
+	sourceProfile.DSN = filepath.Join(sourceProfile.Data, "memos_source.db")
+	source := newTestingStoreWithProfile(ctx, sourceProfile)
+	defer source.Close()
+
+	user, err := createTestingHostUser(ctx, source)
+	require.NoError(t, err)
+	memo, err := source.CreateMemo(ctx, &store.Memo{UID: "copied-memo", CreatorID: user.ID, Content: "Hello", Visibility: store.Public})
+	require.NoError(t, err)
+	pinned := true
+	require.NoError(t, source.UpdateMemo(ctx, &store.UpdateMemo{ID: memo.ID, Pinned: &pinned}))
+	// Leave a gap in the memo ids.
+	deleted, err := source.CreateMemo(ctx, &store.Memo{UID: "deleted-memo", CreatorID: user.ID, Content: "Deleted", Visibility: store.Private})
+	require.NoError(t, err)
+	require.NoError(t, source.DeleteMemo(ctx, &store.DeleteMemo{ID: deleted.ID}))
+	last, err := source.CreateMemo(ctx, &store.Memo{UID: "last-memo", CreatorID: user.ID, Content: "Last", Visibility: store.Private})
+	require.NoError(t, err)
+	_, err = source.CreateAttachment(ctx, &store.Attachment{
+		UID:       "copied-attachment",
+		CreatorID: user.ID,
+		Filename:  "blob.bin",
+		Type:      "application/octet-stream",
+		Blob:      []byte{0x00, 0xff, 0x10},
+		Size:      3,
+		MemoID:    &memo.ID,
+	})
+	require.NoError(t, err)
+
+	targetProfile := getTestingProfile(t)
+	target := newTestingStoreWithProfile(ctx, targetProfile)
+	defer target.Close()
+	counts, err := backup.Copy(ctx, source, sourceProfile, target, targetProfile, backup.CopyOptions{})
+	require.NoError(t, err)
+	require.Equal(t, 1, counts["user"])
+	require.Equal(t, 2, counts["memo"])
+	require.Equal(t, 1, counts["resource"])
+
+	copiedMemo, err := target.GetMemo(ctx, &store.FindMemo{ID: &last.ID})
+	require.NoError(t, err)
+	require.Equal(t, last.UID, copiedMemo.UID)
+	require.Equal(t, last.CreatedTs, copiedMemo.CreatedTs)
+	copiedMemo, err = target.GetMemo(ctx, &store.FindMemo{ID: &memo.ID})
+	require.NoError(t, err)
+	require.True(t, copiedMemo.Pinned)
+	attachmentUID := "copied-attachment"
+	copiedAttachment, err := target.GetAttachment(ctx, &store.FindAttachment{UID: &attachmentUID, GetBlob: true})
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x00, 0xff, 0x10}, copiedAttachment.Blob)
+
+	// New rows get ids after the copied ones.
+	newMemo, err := target.CreateMemo(ctx, &store.Memo{UID: "after-copy", CreatorID: user.ID, Content: "After", Visibility: store.Private})
+	require.NoError(t, err)
+	require.Greater(t, newMemo.ID, last.ID)
+
+	// A database with data is only replaced when forced.
+	_, err = backup.Copy(ctx, source, sourceProfile, target, targetProfile, backup.CopyOptions{})
+	require.ErrorIs(t, err, backup.ErrDatabaseNotEmpty)
+	_, err = backup.Copy(ctx, source, sourceProfile, target, targetProfile, backup.CopyOptions{Force: true})
+	require.NoError(t, err)
+	memos, err := target.ListMemos(ctx, &store.FindMemo{})
+	require.NoError(t, err)
+	require.Len(t, memos, 2)
+}
+
+func TestRetentionPolicy(t *testing.T) {
+	archives := []*backup.Archive{}
+	newest := time.Date(2024, 3, 20, 3, 0, 0, 0, time.UTC)
+	// Two backups a day for four weeks, newest first.
+	for i := 0; i < 56; i++ {
+		createTime := newest.Add(-time.Duration(i) * 12 * time.Hour)
+		archives = append(archives, &backup.Archive{Name: createTime.Format(time.RFC3339), CreateTime: createTime})
+	}
+	names := func(archives []*backup.Archive) []string {
+		names := []string{}
+		for _, archive := range archives {
+			names = append(names, archive.Name)
+		}
+		return names
+	}
+
+	require.Empty(t, backup.RetentionPolicy{}.Expired(archives))
+	require.Len(t, backup.RetentionPolicy{KeepLast: 5}.Expired(archives), 51)
+
+	expired := backup.RetentionPolicy{KeepDaily: 3}.Expired(archives)
+	require.Len(t, expired, 53)
+	require.NotContains(t, names(expired), "2024-03-20T03:00:00Z")
+	require.NotContains(t, names(expired), "2024-03-19T15:00:00Z")
+	require.Contains(t, names(expired), "2024-03-19T03:00:00Z")
+	require.NotContains(t, names(expired), "2024-03-18T15:00:00Z")
+
+	// 2024-03-20 is a Wednesday, the weeks start on Mondays.
+	expired = backup.RetentionPolicy{KeepLast: 1, KeepWeekly: 2}.Expired(archives)
+	require.Len(t, expired, 54)
+	require.NotContains(t, names(expired), "2024-03-20T03:00:00Z")
+	require.NotContains(t, names(expired), "2024-03-17T15:00:00Z")
+
+	// Days follow the location of the policy: 2024-03-19T15:00:00Z is on 2024-03-20 in UTC+10.
+	location := time.FixedZone("UTC+10", 10*60*60)
+	expired = backup.RetentionPolicy{KeepDaily: 2, Location: location}.Expired(archives)
+	require.Len(t, expired, 54)
+	require.NotContains(t, names(expired), "2024-03-20T03:00:00Z")
+	require.Contains(t, names(expired), "2024-03-19T15:00:00Z")
+	require.NotContains(t, names(expired), "2024-03-19T03:00:00Z")
+}
+
+func TestCreateArchive(t *testing.T) {
+	ctx := context.Background()
+	profile := getTestingProfile(t)
+	ts := newTestingStoreWithProfile(ctx, profile)
+	defer ts.Close()
+
+	_, err := ts.UpsertWorkspaceSetting(ctx, &storepb.WorkspaceSetting{
+		Key: storepb.WorkspaceSettingKey_BACKUP,
+		Value: &storepb.WorkspaceSetting_BackupSetting{BackupSetting: &storepb.WorkspaceBackupSetting{
+			Enabled:  true,
+			KeepLast: 1,
+		}},
+	})
+	require.NoError(t, err)
+	directory := filepath.Join(profile.Data, "backups")
+	require.NoError(t, os.MkdirAll(directory, os.ModePerm))
+	// An older backup is removed by the retention and other files are left alone.
+	require.NoError(t, os.WriteFile(filepath.Join(directory, "memos-backup-20200101T000000Z.zip"), []byte("old"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(directory, "notes.txt"), []byte("notes"), 0644))
+
+	archive, err := backup.CreateArchive(ctx, ts, profile)
+	require.NoError(t, err)
+	entries, err := os.ReadDir(directory)
+	require.NoError(t, err)
+	entryNames := []string{}
+	for _, entry := range entries {
+		entryNames = append(entryNames, entry.Name())
+	}
+	require.ElementsMatch(t, []string{archive.Name, "notes.txt"}, entryNames)
+
+	reader, err := zip.OpenReader(filepath.Join(directory, archive.Name))
+	require.NoError(t, err)
+	defer reader.Close()
+	manifest, err := backup.Verify(&reader.Reader)
+	require.NoError(t, err)
+	require.Equal(t, archive.CreateTime, manifest.CreateTime.Truncate(time.Millisecond))
+
+	// Backups taken right after each other do not replace each other, and archives are never overwritten.
+	_, err = ts.UpsertWorkspaceSetting(ctx, &storepb.WorkspaceSetting{
+		Key:   storepb.WorkspaceSettingKey_BACKUP,
+		Value: &storepb.WorkspaceSetting_BackupSetting{BackupSetting: &storepb.WorkspaceBackupSetting{Enabled: true}},
+	})
+	require.NoError(t, err)
+	second, err := backup.CreateArchive(ctx, ts, profile)
+	require.NoError(t, err)
+	require.NotEqual(t, archive.Name, second.Name)
+	setting, err := ts.GetWorkspaceBackupSetting(ctx)
+	require.NoError(t, err)
+	destination, err := backup.NewDestination(ctx, ts, profile, setting)
+	require.NoError(t, err)
+	require.ErrorContains(t, destination.Save(ctx, second.Name, bytes.NewReader([]byte("other"))), "already exists")
+	archives, err := destination.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, archives, 2)
+}
@@ -0,0 +1,262 @@
+package teststore
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/plugin/filter"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+// TestMemoFilterEvaluatorMatchesSQL checks that the in-memory evaluator selects
+// the same memos as the SQL generated for the same filter.
+func TestMemoFilterEvaluatorMatchesSQL(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	defer ts.Close()
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	otherUser, err := ts.CreateUser(ctx, &store.User{
+		Username: "other",
+		Role:     store.RoleUser,
+		Email:    "other@test.com",
+	})
+	require.NoError(t, err)
+
+	createMemo := func(uid string, creatorID int32, content string, visibility store.Visibility, pinned bool, createdTs time.Time, payload *storepb.MemoPayload) *store.Memo {
+		memo, err := ts.CreateMemo(ctx, &store.Memo{
+			UID:        uid,
+			CreatorID:  creatorID,
+			Content:    content,
+			Visibility: visibility,
+			Payload:    payload,
+		})
+		require.NoError(t, err)
+		timestamp := createdTs.Unix()
+		update := &store.UpdateMemo{ID: memo.ID, CreatedTs: &timestamp, UpdatedTs: &timestamp}
+		if pinned {
+			update.Pinned = &pinned
+		}
+		require.NoError(t, ts.UpdateMemo(ctx, update))
+		return memo
+	}
+
+	now := time.Now()
+	memoA := createMemo("memo-a", user.ID, "Hello World meeting", store.Public, true, time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC), &storepb.MemoPayload{
+		Tags: []string{"work", "project"},
+		Property: &storepb.MemoPayload_Property{
+			HasLink:            true,
+			HasTaskList:        true,
+			HasIncompleteTasks: true,
+		},
+		Location: &storepb.MemoPayload_Location{Placeholder: "New York", Latitude: 40.7128, Longitude: -74.006},
+	})
+	memoB := createMemo("memo-b", user.ID, "grocery list", store.Private, false, time.Date(2025, 1, 15, 8, 0, 0, 0, time.UTC), &storepb.MemoPayload{
+		Tags: []string{"personal"},
+		Property: &storepb.MemoPayload_Property{
+			HasCode: true,
+		},
+		Location: &storepb.MemoPayload_Location{Placeholder: "London", Latitude: 51.5074, Longitude: -0.1278},
+	})
+	memoC := createMemo("memo-c", otherUser.ID, "Reply", store.Protected, false, time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), &storepb.MemoPayload{
+		Location: &storepb.MemoPayload_Location{Placeholder: "Fiji", Latitude: -17.7134, Longitude: 178.065},
+	})
+	memoD := createMemo("memo-d", otherUser.ID, "WORK notes", store.Public, false, now.Add(-time.Hour), &storepb.MemoPayload{
+		Tags: []string{"work"},
+	})
+	createMemo("memo-e", user.ID, "empty", store.Public, false, time.Date(2024, 12, 31, 23, 30, 0, 0, time.UTC), nil)
+
+	for _, attachment := range []*store.Attachment{
+		{UID: "attachment-a", CreatorID: user.ID, Filename: "image.png", Type: "image/png", MemoID: &memoA.ID},
+		{UID: "attachment-b", CreatorID: user.ID, Filename: "file.pdf", Type: "application/pdf", MemoID: &memoB.ID},
+		{UID: "attachment-c", CreatorID: user.ID, Filename: "photo.jpg", Type: "image/jpeg", MemoID: &memoB.ID},
+	} {
+		_, err := ts.CreateAttachment(ctx, attachment)
+		require.NoError(t, err)
+	}
+	for _, relation := range []*store.MemoRelation{
+		{MemoID: memoC.ID, RelatedMemoID: memoA.ID, Type: store.MemoRelationComment},
+		{MemoID: memoD.ID, RelatedMemoID: memoA.ID, Type: store.MemoRelationComment},
+		{MemoID: memoC.ID, RelatedMemoID: memoB.ID, Type: store.MemoRelationReference},
+		{MemoID: memoD.ID, RelatedMemoID: memoA.ID, Type: store.MemoRelationReference},
+	} {
+		_, err := ts.UpsertMemoRelation(ctx, relation)
+		require.NoError(t, err)
+	}
+	for _, reaction := range []*store.Reaction{
+		{CreatorID: user.ID, ContentID: "memos/" + memoA.UID, ReactionType: "👍"},
+		{CreatorID: otherUser.ID, ContentID: "memos/" + memoA.UID, ReactionType: "👍"},
+		{CreatorID: user.ID, ContentID: "memos/" + memoB.UID, ReactionType: "🎉"},
+	} {
+		_, err := ts.UpsertReaction(ctx, reaction)
+		require.NoError(t, err)
+	}
+
+	memos, err := ts.ListMemos(ctx, &store.FindMemo{})
+	require.NoError(t, err)
+	require.Len(t, memos, 5)
+	filterMemos := map[int32]*filter.Memo{}
+	for _, memo := range memos {
+		filterMemo, err := ts.GetFilterMemo(ctx, memo)
+		require.NoError(t, err)
+		filterMemos[memo.ID] = filterMemo
+	}
+
+	filters := []string{
+		// Strings.
+		`content == "grocery list"`,
+		`content != "grocery list"`,
+		`content.contains("work")`,
+		`content.contains("hello")`,
+		`visibility == "PUBLIC"`,
+		`visibility != "PUBLIC"`,
+		`visibility in ["PUBLIC", "PROTECTED"]`,
+		// Integers.
+		fmt.Sprintf(`creator_id == %d`, user.ID),
+		fmt.Sprintf(`creator_id != %d`, user.ID),
+		// Timestamps.
+		`created_ts > timestamp("2025-01-12")`,
+		`created_ts >= timestamp("2025-01-10T12:00:00Z")`,
+		`created_ts < timestamp("2025-01-10T12:00:00Z")`,
+		`created_ts <= timestamp("2025-01-10T12:00:00Z")`,
+		`created_ts == timestamp("2025-01-10T12:00:00Z")`,
+		`created_ts != timestamp("2025-01-10T12:00:00Z")`,
+		`updated_ts > now() - duration("1d")`,
+		`created_ts > now() - 60 * 60 * 24 * 7`,
+		`created_ts >= today() - duration("1d")`,
+		`created_ts >= start_of_week()`,
+		`created_ts >= start_of_month()`,
+		`date(created_ts) == timestamp("2025-01-10")`,
+		`date(created_ts) != timestamp("2025-01-10")`,
+		`date(created_ts) < timestamp("2025-01-10")`,
+		`date(created_ts) <= timestamp("2025-01-10")`,
+		`date(created_ts) > timestamp("2025-01-10")`,
+		`date(created_ts) >= timestamp("2025-01-10")`,
+		`date(updated_ts) == today()`,
+		// Booleans.
+		`pinned`,
+		`!pinned`,
+		`pinned == true`,
+		`pinned == false`,
+		`pinned != true`,
+		`has_task_list`,
+		`has_task_list == true`,
+		`has_task_list == false`,
+		`has_task_list != true`,
+		`!(has_task_list == true)`,
+		`has_link`,
+		`has_link == false`,
+		`has_code`,
+		`has_code != true`,
+		`has_incomplete_tasks`,
+		`has_incomplete_tasks != false`,
+		// Tags.
+		`tag in ["work"]`,
+		`tag in ["personal", "project"]`,
+		`"work" in tags`,
+		`!("work" in tags)`,
+		`!(tag in ["work"])`,
+		`!(tag in ["work"]) || pinned`,
+		`size(tags) == 0`,
+		`size(tags) != 0`,
+		`size(tags) > 1`,
+		`size(tags) >= 1`,
+		`size(tags) < 2`,
+		`size(tags) <= 1`,
+		// Attachments, relations and reactions.
+		`has_attachment`,
+		`!has_attachment`,
+		`has_attachment == false`,
+		`attachment_type == "image/png"`,
+		`attachment_type != "image/png"`,
+		`attachment_type in ["application/pdf", "image/png"]`,
+		`attachment_type.startsWith("image/")`,
+		`attachment_type.startsWith("image_")`,
+		`attachment_type.startsWith("%/p")`,
+		`comment_count == 2`,
+		`comment_count > 0`,
+		`comment_count < 1`,
+		`reaction_count >= 2`,
+		`reaction_count != 0`,
+		`is_comment`,
+		`is_comment == false`,
+		`"memo-b" in references`,
+		`size(references) > 0`,
+		// Locations.
+		`has_location`,
+		`!has_location`,
+		`has_location == true`,
+		`within_radius(40.7, -74.0, 50)`,
+		`within_radius(51.5, 0, 5)`,
+		`within_radius(-17.7, -179.5, 300)`,
+		`within_radius(-17.7, -179.9, 250)`,
+		`within_bbox(50, -1, 52, 1)`,
+		`within_bbox(-20, 170, -10, -170)`,
+		// Combinations.
+		`pinned && tag in ["work"] || is_comment`,
+		`!(visibility == "PRIVATE") && has_location`,
+		`(has_attachment || comment_count > 0) && !pinned`,
+	}
+
+	timeContext := filter.NewTimeContext(time.UTC, time.Monday)
+	for _, filterStr := range filters {
+		sqlMemos, err := ts.ListMemos(ctx, &store.FindMemo{
+			Filters:   []string{filterStr},
+			Location:  timeContext.Location,
+			WeekStart: timeContext.WeekStart,
+		})
+		require.NoError(t, err, filterStr)
+		want := []int32{}
+		for _, memo := range sqlMemos {
+			want = append(want, memo.ID)
+		}
+
+		evaluator, err := filter.NewMemoEvaluator(filterStr)
+		require.NoError(t, err, filterStr)
+		evaluator = evaluator.WithTimeContext(timeContext)
+		got := []int32{}
+		for _, memo := range memos {
+			matched, err := evaluator.Evaluate(filterMemos[memo.ID])
+			require.NoError(t, err, filterStr)
+			if matched {
+				got = append(got, memo.ID)
+			}
+		}
+
+		slices.Sort(want)
+		slices.Sort(got)
+		require.Equal(t, want, got, filterStr)
+	}
+
+	// Areas crossing the antimeridian match locations on its other side.
+	for _, filterStr := range []string{
+		`within_radius(-17.7, -179.5, 300)`,
+		`within_radius(-17.7, -179.9, 250)`,
+	} {
+		memos, err := ts.ListMemos(ctx, &store.FindMemo{Filters: []string{filterStr}})
+		require.NoError(t, err, filterStr)
+		require.Len(t, memos, 1, filterStr)
+		require.Equal(t, memoC.ID, memos[0].ID, filterStr)
+	}
+}
+
+func TestMemoFilterEvaluatorUnsupported(t *testing.T) {
+	for _, filterStr := range []string{
+		`visibility > "PUBLIC"`,
+		`content_id in ["memos/1"]`,
+		`size(content) > 1`,
+	} {
+		evaluator, err := filter.NewMemoEvaluator(filterStr)
+		if err != nil {
+			continue
+		}
+		_, err = evaluator.Evaluate(&filter.Memo{})
+		require.Error(t, err, filterStr)
+	}
+}
@@ -3,6 +3,7 @@ package teststore
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -94,6 +95,148 @@ func TestMemoListByTags(t *testing.T) {
 	ts.Close()
 }
 
+func TestMemoListByDateFilter(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	oldMemo, err := ts.CreateMemo(ctx, &store.Memo{
+		UID:        "old-memo",
+		CreatorID:  user.ID,
+		Content:    "old_content",
+		Visibility: store.Public,
+	})
+	require.NoError(t, err)
+	createdTs := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC).Unix()
+	err = ts.UpdateMemo(ctx, &store.UpdateMemo{
+		ID:        oldMemo.ID,
+		CreatedTs: &createdTs,
+	})
+	require.NoError(t, err)
+	newMemo, err := ts.CreateMemo(ctx, &store.Memo{
+		UID:        "new-memo",
+		CreatorID:  user.ID,
+		Content:    "new_content",
+		Visibility: store.Public,
+	})
+	require.NoError(t, err)
+
+	memoList, err := ts.ListMemos(ctx, &store.FindMemo{
+		Filters:  []string{`date(created_ts) == timestamp("2025-01-10")`},
+		Location: time.UTC,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(memoList))
+	require.Equal(t, oldMemo.ID, memoList[0].ID)
+
+	// The same day does not match in a time zone where the memo falls on the next day.
+	memoList, err = ts.ListMemos(ctx, &store.FindMemo{
+		Filters:  []string{`date(created_ts) == timestamp("2025-01-10")`},
+		Location: time.FixedZone("UTC+14", 14*60*60),
+	})
+	require.NoError(t, err)
+	require.Equal(t, 0, len(memoList))
+
+	memoList, err = ts.ListMemos(ctx, &store.FindMemo{
+		Filters: []string{`created_ts >= now() - duration("7d")`},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(memoList))
+	require.Equal(t, newMemo.ID, memoList[0].ID)
+	ts.Close()
+}
+
+func TestMemoListByRelationFilter(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	memo, err := ts.CreateMemo(ctx, &store.Memo{
+		UID:        "memo",
+		CreatorID:  user.ID,
+		Content:    "memo_content",
+		Visibility: store.Public,
+		Payload: &storepb.MemoPayload{
+			Location: &storepb.MemoPayload_Location{Placeholder: "home", Latitude: 1, Longitude: 2},
+		},
+	})
+	require.NoError(t, err)
+	comment, err := ts.CreateMemo(ctx, &store.Memo{
+		UID:        "comment",
+		CreatorID:  user.ID,
+		Content:    "comment_content",
+		Visibility: store.Public,
+	})
+	require.NoError(t, err)
+	_, err = ts.UpsertMemoRelation(ctx, &store.MemoRelation{
+		MemoID:        comment.ID,
+		RelatedMemoID: memo.ID,
+		Type:          store.MemoRelationComment,
+	})
+	require.NoError(t, err)
+	_, err = ts.UpsertMemoRelation(ctx, &store.MemoRelation{
+		MemoID:        comment.ID,
+		RelatedMemoID: memo.ID,
+		Type:          store.MemoRelationReference,
+	})
+	require.NoError(t, err)
+	_, err = ts.CreateAttachment(ctx, &store.Attachment{
+		UID:       "attachment",
+		CreatorID: user.ID,
+		Filename:  "image.png",
+		Type:      "image/png",
+		MemoID:    &memo.ID,
+	})
+	require.NoError(t, err)
+	_, err = ts.UpsertReaction(ctx, &store.Reaction{
+		CreatorID:    user.ID,
+		ContentID:    "memos/" + memo.UID,
+		ReactionType: "👍",
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		filter string
+		want   []int32
+	}{
+		{filter: `has_attachment`, want: []int32{memo.ID}},
+		{filter: `attachment_type.startsWith("image/")`, want: []int32{memo.ID}},
+		{filter: `attachment_type == "video/mp4"`, want: []int32{}},
+		{filter: `comment_count == 1`, want: []int32{memo.ID}},
+		{filter: `reaction_count > 0`, want: []int32{memo.ID}},
+		{filter: `has_location`, want: []int32{memo.ID}},
+		{filter: `is_comment`, want: []int32{comment.ID}},
+		{filter: `"memo" in references`, want: []int32{comment.ID}},
+		{filter: `size(references) == 0`, want: []int32{memo.ID}},
+	}
+	for _, tt := range tests {
+		memoList, err := ts.ListMemos(ctx, &store.FindMemo{
+			Filters: []string{tt.filter},
+		})
+		require.NoError(t, err, tt.filter)
+		ids := []int32{}
+		for _, m := range memoList {
+			ids = append(ids, m.ID)
+		}
+		require.Equal(t, tt.want, ids, tt.filter)
+		count, err := ts.CountMemos(ctx, &store.FindMemo{
+			Filters: []string{tt.filter},
+		})
+		require.NoError(t, err, tt.filter)
+		require.Equal(t, len(tt.want), count, tt.filter)
+	}
+
+	// Counts ignore the pagination.
+	limit := 1
+	count, err := ts.CountMemos(ctx, &store.FindMemo{Limit: &limit})
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+	count, err = ts.CountMemos(ctx, &store.FindMemo{ExcludeComments: true})
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+	ts.Close()
+}
+
 func TestDeleteMemoStore(t *testing.T) {
 	ctx := context.Background()
 	ts := NewTestingStore(ctx, t)
@@ -0,0 +1,68 @@
+package teststore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+func TestStorageUsage(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	defer ts.Close()
+	host, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	user, err := ts.CreateUser(ctx, &store.User{Username: "user", Role: store.RoleUser, Email: "user@test.com"})
+	require.NoError(t, err)
+
+	for i, attachment := range []*store.Attachment{
+		{UID: "image1", CreatorID: user.ID, Filename: "1.png", Type: "image/png", Size: 100},
+		{UID: "image2", CreatorID: user.ID, Filename: "2.png", Type: "image/png", Size: 200},
+		{UID: "text", CreatorID: user.ID, Filename: "1.txt", Type: "text/plain", Size: 50},
+		{UID: "host", CreatorID: host.ID, Filename: "host.txt", Type: "text/plain", Size: 10},
+	} {
+		attachment.Blob = make([]byte, attachment.Size)
+		_, err := ts.CreateAttachment(ctx, attachment)
+		require.NoError(t, err, i)
+	}
+
+	usage, err := ts.GetUserStorageUsage(ctx, user.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(350), usage.Size)
+	require.Equal(t, 3, usage.Count)
+	require.Equal(t, map[string]*store.StorageUsage{
+		"image/png":  {Size: 300, Count: 2},
+		"text/plain": {Size: 50, Count: 1},
+	}, usage.Types)
+
+	usages, err := ts.ListUserStorageUsages(ctx)
+	require.NoError(t, err)
+	require.Len(t, usages, 2)
+	require.Equal(t, user.ID, usages[0].UserID)
+	require.Equal(t, host.ID, usages[1].UserID)
+	require.Equal(t, int64(10), usages[1].Size)
+
+	// Without quotas, users are unlimited.
+	quota, err := ts.GetUserStorageQuota(ctx, user)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), quota)
+
+	_, err = ts.UpsertWorkspaceSetting(ctx, &storepb.WorkspaceSetting{
+		Key: storepb.WorkspaceSettingKey_STORAGE,
+		Value: &storepb.WorkspaceSetting_StorageSetting{StorageSetting: &storepb.WorkspaceStorageSetting{
+			RoleQuotaMb: map[string]int64{"USER": 10},
+			UserQuotaMb: map[int32]int64{host.ID: 0},
+		}},
+	})
+	require.NoError(t, err)
+	quota, err = ts.GetUserStorageQuota(ctx, user)
+	require.NoError(t, err)
+	require.Equal(t, int64(10<<20), quota)
+	quota, err = ts.GetUserStorageQuota(ctx, host)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), quota)
+}
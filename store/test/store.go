@@ -20,7 +20,10 @@ import (
 )
 
 func NewTestingStore(ctx context.Context, t *testing.T) *store.Store {
-	profile := getTestingProfile(t)
+	return newTestingStoreWithProfile(ctx, getTestingProfile(t))
+}
+
+func newTestingStoreWithProfile(ctx context.Context, profile *profile.Profile) *store.Store {
 	dbDriver, err := db.NewDBDriver(profile)
 	if err != nil {
 		slog.Error("failed to create db driver", slog.String("error", err.Error()))
@@ -3,6 +3,7 @@ package teststore
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -26,3 +27,39 @@ func TestUserSettingStore(t *testing.T) {
 	require.Equal(t, 1, len(list))
 	ts.Close()
 }
+
+func TestUserLocation(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+
+	// Without any timezone configured, the server local timezone is used.
+	location, err := ts.GetUserLocation(ctx, user.ID)
+	require.NoError(t, err)
+	require.Equal(t, time.Local, location)
+
+	// The workspace timezone is used when the user has no timezone.
+	_, err = ts.UpsertWorkspaceSetting(ctx, &storepb.WorkspaceSetting{
+		Key: storepb.WorkspaceSettingKey_GENERAL,
+		Value: &storepb.WorkspaceSetting_GeneralSetting{
+			GeneralSetting: &storepb.WorkspaceGeneralSetting{Timezone: "Europe/Berlin"},
+		},
+	})
+	require.NoError(t, err)
+	location, err = ts.GetUserLocation(ctx, user.ID)
+	require.NoError(t, err)
+	require.Equal(t, "Europe/Berlin", location.String())
+
+	// The user timezone takes precedence over the workspace timezone.
+	_, err = ts.UpsertUserSetting(ctx, &storepb.UserSetting{
+		UserId: user.ID,
+		Key:    storepb.UserSetting_GENERAL,
+		Value:  &storepb.UserSetting_General{General: &storepb.GeneralUserSetting{Timezone: "Asia/Shanghai"}},
+	})
+	require.NoError(t, err)
+	location, err = ts.GetUserLocation(ctx, user.ID)
+	require.NoError(t, err)
+	require.Equal(t, "Asia/Shanghai", location.String())
+	ts.Close()
+}
@@ -2,6 +2,11 @@ package store
 
 import (
 	"context"
+	"crypto/subtle"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"google.golang.org/protobuf/encoding/protojson"
@@ -89,6 +94,26 @@ func (s *Store) GetUserSetting(ctx context.Context, find *FindUserSetting) (*sto
 	return userSetting, nil
 }
 
+// GetUserLocation returns the time location used to interpret dates for the user.
+// It resolves the user's timezone first, then the workspace timezone, and finally the server local timezone.
+func (s *Store) GetUserLocation(ctx context.Context, userID int32) (*time.Location, error) {
+	userSetting, err := s.GetUserSetting(ctx, &FindUserSetting{
+		UserID: &userID,
+		Key:    storepb.UserSetting_GENERAL,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if timezone := userSetting.GetGeneral().GetTimezone(); timezone != "" {
+		location, err := time.LoadLocation(timezone)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid user timezone %s", timezone)
+		}
+		return location, nil
+	}
+	return s.GetWorkspaceLocation(ctx)
+}
+
 // GetUserAccessTokens returns the access tokens of the user.
 func (s *Store) GetUserAccessTokens(ctx context.Context, userID int32) ([]*storepb.AccessTokensUserSetting_AccessToken, error) {
 	userSetting, err := s.GetUserSetting(ctx, &FindUserSetting{
@@ -133,6 +158,65 @@ func (s *Store) RemoveUserAccessToken(ctx context.Context, userID int32, token s
 	return err
 }
 
+// GetUserFeedTokens returns the feed tokens of the user.
+func (s *Store) GetUserFeedTokens(ctx context.Context, userID int32) ([]*storepb.FeedTokensUserSetting_FeedToken, error) {
+	userSetting, err := s.GetUserSetting(ctx, &FindUserSetting{
+		UserID: &userID,
+		Key:    storepb.UserSetting_FEED_TOKENS,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if userSetting == nil {
+		return []*storepb.FeedTokensUserSetting_FeedToken{}, nil
+	}
+	return userSetting.GetFeedTokens().FeedTokens, nil
+}
+
+// UpsertUserFeedTokens replaces the feed tokens of the user.
+func (s *Store) UpsertUserFeedTokens(ctx context.Context, userID int32, feedTokens []*storepb.FeedTokensUserSetting_FeedToken) error {
+	_, err := s.UpsertUserSetting(ctx, &storepb.UserSetting{
+		UserId: userID,
+		Key:    storepb.UserSetting_FEED_TOKENS,
+		Value: &storepb.UserSetting_FeedTokens{
+			FeedTokens: &storepb.FeedTokensUserSetting{
+				FeedTokens: feedTokens,
+			},
+		},
+	})
+	return err
+}
+
+// FormatFeedToken returns the feed token of the user with the secret. The token starts with the
+// ID of the user, so that it is only looked up in the settings of that user.
+func FormatFeedToken(userID int32, secret string) string {
+	return fmt.Sprintf("%d.%s", userID, secret)
+}
+
+// GetFeedTokenOwner returns the ID of the user of the feed token and the feed token,
+// or nil when no user has the token.
+func (s *Store) GetFeedTokenOwner(ctx context.Context, token string) (int32, *storepb.FeedTokensUserSetting_FeedToken, error) {
+	prefix, _, ok := strings.Cut(token, ".")
+	if !ok {
+		return 0, nil, nil
+	}
+	userID, err := strconv.ParseInt(prefix, 10, 32)
+	if err != nil {
+		return 0, nil, nil
+	}
+	feedTokens, err := s.GetUserFeedTokens(ctx, int32(userID))
+	if err != nil {
+		return 0, nil, err
+	}
+	for _, feedToken := range feedTokens {
+		// Compare in constant time, so that the response time does not reveal the token.
+		if subtle.ConstantTimeCompare([]byte(feedToken.Token), []byte(token)) == 1 {
+			return int32(userID), feedToken, nil
+		}
+	}
+	return 0, nil, nil
+}
+
 // GetUserSessions returns the sessions of the user.
 func (s *Store) GetUserSessions(ctx context.Context, userID int32) ([]*storepb.SessionsUserSetting_Session, error) {
 	userSetting, err := s.GetUserSetting(ctx, &FindUserSetting{
@@ -386,6 +470,12 @@ func convertUserSettingFromRaw(raw *UserSetting) (*storepb.UserSetting, error) {
 			return nil, err
 		}
 		userSetting.Value = &storepb.UserSetting_Webhooks{Webhooks: webhooksUserSetting}
+	case storepb.UserSetting_FEED_TOKENS:
+		feedTokensUserSetting := &storepb.FeedTokensUserSetting{}
+		if err := protojsonUnmarshaler.Unmarshal([]byte(raw.Value), feedTokensUserSetting); err != nil {
+			return nil, err
+		}
+		userSetting.Value = &storepb.UserSetting_FeedTokens{FeedTokens: feedTokensUserSetting}
 	default:
 		return nil, nil
 	}
@@ -434,6 +524,13 @@ func convertUserSettingToRaw(userSetting *storepb.UserSetting) (*UserSetting, er
 			return nil, err
 		}
 		raw.Value = string(value)
+	case storepb.UserSetting_FEED_TOKENS:
+		feedTokensUserSetting := userSetting.GetFeedTokens()
+		value, err := protojson.Marshal(feedTokensUserSetting)
+		if err != nil {
+			return nil, err
+		}
+		raw.Value = string(value)
 	default:
 		return nil, errors.Errorf("unsupported user setting key: %v", userSetting.Key)
 	}
@@ -2,6 +2,7 @@ package store
 
 import (
 	"context"
+	"time"
 
 	"github.com/pkg/errors"
 	"google.golang.org/protobuf/encoding/protojson"
@@ -37,6 +38,8 @@ func (s *Store) UpsertWorkspaceSetting(ctx context.Context, upsert *storepb.Work
 		valueBytes, err = protojson.Marshal(upsert.GetStorageSetting())
 	} else if upsert.Key == storepb.WorkspaceSettingKey_MEMO_RELATED {
 		valueBytes, err = protojson.Marshal(upsert.GetMemoRelatedSetting())
+	} else if upsert.Key == storepb.WorkspaceSettingKey_BACKUP {
+		valueBytes, err = protojson.Marshal(upsert.GetBackupSetting())
 	} else {
 		return nil, errors.Errorf("unsupported workspace setting key: %v", upsert.Key)
 	}
@@ -137,6 +140,23 @@ func (s *Store) GetWorkspaceGeneralSetting(ctx context.Context) (*storepb.Worksp
 	return workspaceGeneralSetting, nil
 }
 
+// GetWorkspaceLocation returns the time location of the workspace.
+// It falls back to the server local timezone when no workspace timezone is configured.
+func (s *Store) GetWorkspaceLocation(ctx context.Context) (*time.Location, error) {
+	workspaceGeneralSetting, err := s.GetWorkspaceGeneralSetting(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if workspaceGeneralSetting.Timezone == "" {
+		return time.Local, nil
+	}
+	location, err := time.LoadLocation(workspaceGeneralSetting.Timezone)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid workspace timezone %s", workspaceGeneralSetting.Timezone)
+	}
+	return location, nil
+}
+
 // DefaultContentLengthLimit is the default limit of content length in bytes. 8KB.
 const DefaultContentLengthLimit = 8 * 1024
 
@@ -208,6 +228,44 @@ func (s *Store) GetWorkspaceStorageSetting(ctx context.Context) (*storepb.Worksp
 	return workspaceStorageSetting, nil
 }
 
+const (
+	defaultWorkspaceBackupCronSpec    = "0 3 * * *"
+	defaultWorkspaceBackupDestination = storepb.WorkspaceBackupSetting_LOCAL
+	defaultWorkspaceBackupDirectory   = "backups"
+	defaultWorkspaceBackupS3Prefix    = "backups/"
+)
+
+func (s *Store) GetWorkspaceBackupSetting(ctx context.Context) (*storepb.WorkspaceBackupSetting, error) {
+	workspaceSetting, err := s.GetWorkspaceSetting(ctx, &FindWorkspaceSetting{
+		Name: storepb.WorkspaceSettingKey_BACKUP.String(),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get workspace backup setting")
+	}
+
+	workspaceBackupSetting := &storepb.WorkspaceBackupSetting{}
+	if workspaceSetting != nil {
+		workspaceBackupSetting = workspaceSetting.GetBackupSetting()
+	}
+	if workspaceBackupSetting.CronSpec == "" {
+		workspaceBackupSetting.CronSpec = defaultWorkspaceBackupCronSpec
+	}
+	if workspaceBackupSetting.Destination == storepb.WorkspaceBackupSetting_DESTINATION_UNSPECIFIED {
+		workspaceBackupSetting.Destination = defaultWorkspaceBackupDestination
+	}
+	if workspaceBackupSetting.Directory == "" {
+		workspaceBackupSetting.Directory = defaultWorkspaceBackupDirectory
+	}
+	if workspaceBackupSetting.S3Prefix == "" {
+		workspaceBackupSetting.S3Prefix = defaultWorkspaceBackupS3Prefix
+	}
+	s.workspaceSettingCache.Set(ctx, storepb.WorkspaceSettingKey_BACKUP.String(), &storepb.WorkspaceSetting{
+		Key:   storepb.WorkspaceSettingKey_BACKUP,
+		Value: &storepb.WorkspaceSetting_BackupSetting{BackupSetting: workspaceBackupSetting},
+	})
+	return workspaceBackupSetting, nil
+}
+
 func convertWorkspaceSettingFromRaw(workspaceSettingRaw *WorkspaceSetting) (*storepb.WorkspaceSetting, error) {
 	workspaceSetting := &storepb.WorkspaceSetting{
 		Key: storepb.WorkspaceSettingKey(storepb.WorkspaceSettingKey_value[workspaceSettingRaw.Name]),
@@ -237,6 +295,12 @@ func convertWorkspaceSettingFromRaw(workspaceSettingRaw *WorkspaceSetting) (*sto
 			return nil, err
 		}
 		workspaceSetting.Value = &storepb.WorkspaceSetting_MemoRelatedSetting{MemoRelatedSetting: memoRelatedSetting}
+	case storepb.WorkspaceSettingKey_BACKUP.String():
+		backupSetting := &storepb.WorkspaceBackupSetting{}
+		if err := protojsonUnmarshaler.Unmarshal([]byte(workspaceSettingRaw.Value), backupSetting); err != nil {
+			return nil, err
+		}
+		workspaceSetting.Value = &storepb.WorkspaceSetting_BackupSetting{BackupSetting: backupSetting}
 	default:
 		// Skip unsupported workspace setting key.
 		return nil, nil
@@ -32,7 +32,27 @@ const attachmentStore = (() => {
   };
 
   const createAttachment = async (create: CreateAttachmentRequest): Promise<Attachment> => {
-    const attachment = await attachmentServiceClient.createAttachment(create);
+    // The content is streamed to the storage, since gRPC messages are limited in size.
+    const formData = new FormData();
+    if (create.attachmentId) {
+      formData.append("attachment_id", create.attachmentId);
+    }
+    if (create.attachment?.memo) {
+      formData.append("memo", create.attachment.memo);
+    }
+    const { filename = "", type = "", content = new Uint8Array() } = create.attachment ?? {};
+    formData.append("file", new File([content], filename, { type }));
+    const response = await fetch("/file/attachments", {
+      method: "POST",
+      body: formData,
+      credentials: "include",
+    });
+    if (!response.ok) {
+      const { message } = await response.json().catch(() => ({ message: response.statusText }));
+      throw Object.assign(new Error(message), { details: message });
+    }
+    const { name } = await response.json();
+    const attachment = await attachmentServiceClient.getAttachment({ name });
     const attachmentMap = { ...state.attachmentMapByName };
     attachmentMap[attachment.name] = attachment;
     state.setPartial({ attachmentMapByName: attachmentMap });
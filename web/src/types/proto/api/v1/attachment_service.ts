@@ -25,7 +25,10 @@ export interface Attachment {
     | undefined;
   /** The filename of the attachment. */
   filename: string;
-  /** Input only. The content of the attachment. */
+  /**
+   * Input only. The content of the attachment, up to 32 MiB. Larger files are uploaded to
+   * /file/attachments, which streams them to the storage, or with CreateAttachmentUploadURL.
+   */
   content: Uint8Array;
   /** Optional. The external link of the attachment. */
   externalLink: string;